@@ -8,6 +8,7 @@ require (
 	github.com/google/go-github/v66 v66.0.0
 	github.com/spf13/cobra v1.2.1
 	golang.org/x/oauth2 v0.0.0-20210819190943-2bc19b11175f
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,340 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+)
+
+// IssueLabel is the label of issues filed for violations. It is also used to find issues filed by earlier audits.
+const IssueLabel = "merge-gatekeeper-audit"
+
+// NOTE: https://docs.github.com/en/rest/reference/checks
+const (
+	checkRunCompletedStatus = "completed"
+
+	checkRunNeutralConclusion = "neutral"
+	checkRunSuccessConclusion = "success"
+	checkRunSkipConclusion    = "skipped"
+)
+
+const maxItemsPerPage = 100
+
+// Violation is a pull request which was merged without all of its checks succeeding.
+type Violation struct {
+	Number   int
+	Title    string
+	URL      string
+	SHA      string
+	MergedAt time.Time
+	Failed   []string // Checks which completed without success.
+	Missing  []string // Required checks which did not complete.
+}
+
+// IssueTitle is the title of the issue filed for the violation. It is stable, so that each violation
+// is only filed once.
+func (v *Violation) IssueTitle() string {
+	return fmt.Sprintf("Merge Gatekeeper audit: #%d was merged with failing or missing checks", v.Number)
+}
+
+// IssueBody describes the violation in markdown.
+func (v *Violation) IssueBody() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pull request #%d (%s) was merged at %s with commit `%s`, but not all of its checks succeeded.\n",
+		v.Number, v.Title, v.MergedAt.UTC().Format(time.RFC3339), v.SHA)
+	if len(v.Failed) != 0 {
+		b.WriteString("\n**Failed checks**\n\n")
+		for _, name := range v.Failed {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	if len(v.Missing) != 0 {
+		b.WriteString("\n**Missing required checks**\n\n")
+		for _, name := range v.Missing {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	if len(v.URL) != 0 {
+		fmt.Fprintf(&b, "\n%s\n", v.URL)
+	}
+	return b.String()
+}
+
+// Auditor looks for pull requests merged into the default branch without all of their checks succeeding.
+type Auditor struct {
+	owner        string
+	repo         string
+	selfJobName  string
+	ignoredJobs  []string
+	requiredJobs []string
+	window       time.Duration
+	clock        clock.Clock
+	client       github.Client
+}
+
+func New(c github.Client, opts ...Option) (*Auditor, error) {
+	a := &Auditor{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if err := a.validateFields(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Auditor) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(a.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(a.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if a.window <= 0 {
+		errs = append(errs, errors.New("audit window must be positive"))
+	}
+	if a.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (a *Auditor) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
+	}
+	return a.clock.Now()
+}
+
+// Audit returns the violations of pull requests merged into the default branch within the window, oldest first.
+func (a *Auditor) Audit(ctx context.Context) ([]*Violation, error) {
+	repository, _, err := a.client.GetRepository(ctx, a.owner, a.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	prs, err := a.listMergedPullRequests(ctx, repository.GetDefaultBranch(), a.now().Add(-a.window))
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []*Violation
+	for _, pr := range prs {
+		v, err := a.auditPullRequest(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			violations = append(violations, v)
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].MergedAt.Before(violations[j].MergedAt)
+	})
+	return violations, nil
+}
+
+// listMergedPullRequests returns the pull requests merged into the base branch since the time.
+func (a *Auditor) listMergedPullRequests(ctx context.Context, base string, since time.Time) ([]*github.PullRequest, error) {
+	var merged []*github.PullRequest
+	page := 1
+	for {
+		prs, _, err := a.client.ListPullRequests(ctx, a.owner, a.repo, &github.PullRequestListOptions{
+			State:       "closed",
+			Base:        base,
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: github.ListOptions{Page: page, PerPage: maxItemsPerPage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+		for _, pr := range prs {
+			if pr.MergedAt != nil && !pr.GetMergedAt().Time.Before(since) {
+				merged = append(merged, pr)
+			}
+		}
+		// Pull requests are sorted by the last update, which is never before the merge.
+		if len(prs) < maxItemsPerPage || prs[len(prs)-1].GetUpdatedAt().Time.Before(since) {
+			break
+		}
+		page++
+	}
+	return merged, nil
+}
+
+func (a *Auditor) auditPullRequest(ctx context.Context, pr *github.PullRequest) (*Violation, error) {
+	sha := pr.GetHead().GetSHA()
+	runs, err := a.listCheckRuns(ctx, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs of #%d: %w", pr.GetNumber(), err)
+	}
+
+	v := &Violation{
+		Number:   pr.GetNumber(),
+		Title:    pr.GetTitle(),
+		URL:      pr.GetHTMLURL(),
+		SHA:      sha,
+		MergedAt: pr.GetMergedAt().Time,
+	}
+
+	// Check runs are listed newest first, so only the latest run of each check is considered.
+	completed := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, run := range runs {
+		name := run.GetName()
+		if seen[name] || a.isIgnored(name) {
+			continue
+		}
+		seen[name] = true
+
+		if run.GetStatus() != checkRunCompletedStatus {
+			continue
+		}
+		switch run.GetConclusion() {
+		case checkRunNeutralConclusion, checkRunSuccessConclusion, checkRunSkipConclusion:
+			completed[name] = true
+		default:
+			v.Failed = append(v.Failed, name)
+		}
+	}
+	for _, name := range a.requiredJobs {
+		if !completed[name] && !contains(v.Failed, name) {
+			v.Missing = append(v.Missing, name)
+		}
+	}
+
+	if len(v.Failed) == 0 && len(v.Missing) == 0 {
+		return nil, nil
+	}
+	sort.Strings(v.Failed)
+	return v, nil
+}
+
+func (a *Auditor) isIgnored(name string) bool {
+	return name == a.selfJobName || contains(a.ignoredJobs, name)
+}
+
+func (a *Auditor) listCheckRuns(ctx context.Context, ref string) ([]*github.CheckRun, error) {
+	var runResults []*github.CheckRun
+	page := 1
+	for {
+		cr, _, err := a.client.ListCheckRunsForRef(ctx, a.owner, a.repo, ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{
+			Page:    page,
+			PerPage: maxItemsPerPage,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		runResults = append(runResults, cr.CheckRuns...)
+		if cr.GetTotal() <= len(runResults) || len(cr.CheckRuns) == 0 {
+			break
+		}
+		page++
+	}
+	return runResults, nil
+}
+
+// issue is a finding of the audit, filed as an issue.
+type issue interface {
+	IssueTitle() string
+	IssueBody() string
+}
+
+// FileIssues opens an issue for each violation which has not been filed yet, and returns the number of
+// opened issues. Issues filed by earlier audits are found by their label and title, including closed ones,
+// so that triaged violations are not reopened.
+func (a *Auditor) FileIssues(ctx context.Context, violations []*Violation) (int, error) {
+	issues := make([]issue, 0, len(violations))
+	for _, v := range violations {
+		issues = append(issues, v)
+	}
+	return a.fileIssues(ctx, issues)
+}
+
+// FileWorkflowIssues opens an issue for each workflow finding which has not been filed yet, like FileIssues.
+func (a *Auditor) FileWorkflowIssues(ctx context.Context, findings []*WorkflowFinding) (int, error) {
+	issues := make([]issue, 0, len(findings))
+	for _, f := range findings {
+		issues = append(issues, f)
+	}
+	return a.fileIssues(ctx, issues)
+}
+
+func (a *Auditor) fileIssues(ctx context.Context, issues []issue) (int, error) {
+	if len(issues) == 0 {
+		return 0, nil
+	}
+
+	filed, err := a.listFiledTitles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var opened int
+	for _, i := range issues {
+		title := i.IssueTitle()
+		if filed[title] {
+			continue
+		}
+		body := i.IssueBody()
+		if _, _, err := a.client.CreateIssue(ctx, a.owner, a.repo, &github.IssueRequest{
+			Title:  &title,
+			Body:   &body,
+			Labels: &[]string{IssueLabel},
+		}); err != nil {
+			return opened, fmt.Errorf("failed to open issue %q: %w", title, err)
+		}
+		filed[title] = true
+		opened++
+	}
+	return opened, nil
+}
+
+func (a *Auditor) listFiledTitles(ctx context.Context) (map[string]bool, error) {
+	titles := make(map[string]bool)
+	page := 1
+	for {
+		issues, _, err := a.client.ListIssues(ctx, a.owner, a.repo, &github.IssueListByRepoOptions{
+			State:       "all",
+			Labels:      []string{IssueLabel},
+			ListOptions: github.ListOptions{Page: page, PerPage: maxItemsPerPage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit issues: %w", err)
+		}
+		for _, issue := range issues {
+			titles[issue.GetTitle()] = true
+		}
+		if len(issues) < maxItemsPerPage {
+			break
+		}
+		page++
+	}
+	return titles, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func timestamp(t time.Time) *github.Timestamp {
+	return &github.Timestamp{Time: t}
+}
+
+var now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func pullRequest(number int, sha string, mergedAt time.Time) *github.PullRequest {
+	return &github.PullRequest{
+		Number:    &number,
+		Title:     stringPtr("title"),
+		Head:      &github.PullRequestBranch{SHA: &sha},
+		MergedAt:  timestamp(mergedAt),
+		UpdatedAt: timestamp(mergedAt),
+	}
+}
+
+func checkRun(name, status, conclusion string) *github.CheckRun {
+	return &github.CheckRun{Name: &name, Status: &status, Conclusion: &conclusion}
+}
+
+func TestAuditor_Audit(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		prs     []*github.PullRequest
+		runs    map[string][]*github.CheckRun
+		want    []*Violation
+		wantErr bool
+	}{
+		"returns no violations when every check succeeded": {
+			prs: []*github.PullRequest{pullRequest(1, "sha-1", now.Add(-time.Hour))},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "completed", "success"), checkRun("job-02", "completed", "skipped")},
+			},
+		},
+		"returns violations of failed and missing required checks": {
+			opts: []Option{WithRequiredJobs("job-01,job-03")},
+			prs: []*github.PullRequest{
+				pullRequest(2, "sha-2", now.Add(-time.Hour)),
+				pullRequest(1, "sha-1", now.Add(-2*time.Hour)),
+			},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "completed", "success"), checkRun("job-02", "completed", "failure")},
+				"sha-2": {checkRun("job-01", "in_progress", ""), checkRun("job-03", "completed", "success")},
+			},
+			want: []*Violation{
+				{Number: 1, Title: "title", SHA: "sha-1", MergedAt: now.Add(-2 * time.Hour), Failed: []string{"job-02"}, Missing: []string{"job-03"}},
+				{Number: 2, Title: "title", SHA: "sha-2", MergedAt: now.Add(-time.Hour), Missing: []string{"job-01"}},
+			},
+		},
+		"considers only the latest run of each check": {
+			prs: []*github.PullRequest{pullRequest(1, "sha-1", now.Add(-time.Hour))},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "completed", "success"), checkRun("job-01", "completed", "failure")},
+			},
+		},
+		"skips self and ignored jobs": {
+			opts: []Option{WithSelfJob("merge-gatekeeper"), WithIgnoredJobs("job-01")},
+			prs:  []*github.PullRequest{pullRequest(1, "sha-1", now.Add(-time.Hour))},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("merge-gatekeeper", "completed", "failure"), checkRun("job-01", "completed", "failure")},
+			},
+		},
+		"skips pull requests merged before the window": {
+			prs: []*github.PullRequest{pullRequest(1, "sha-1", now.Add(-48*time.Hour))},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "completed", "failure")},
+			},
+		},
+		"returns error when check runs can not be listed": {
+			prs:     []*github.PullRequest{pullRequest(1, "sha-unknown", now.Add(-time.Hour))},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetRepositoryFunc: func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+					return &github.Repository{DefaultBranch: stringPtr("main")}, nil, nil
+				},
+				ListPullRequestsFunc: func(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+					if opts.Base != "main" {
+						t.Errorf("base = %s, want main", opts.Base)
+					}
+					return tt.prs, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					runs, ok := tt.runs[ref]
+					if !ok {
+						return nil, nil, errors.New("not found")
+					}
+					total := len(runs)
+					return &github.ListCheckRunsResults{Total: &total, CheckRuns: runs}, nil, nil
+				},
+			}
+			opts := append([]Option{
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithWindow(24 * time.Hour),
+				WithClock(clockmock.NewClock(now)),
+			}, tt.opts...)
+			a, err := New(c, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := a.Audit(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Audit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Audit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditor_FileIssues(t *testing.T) {
+	violations := []*Violation{
+		{Number: 1, SHA: "sha-1", Failed: []string{"job-01"}},
+		{Number: 2, SHA: "sha-2", Missing: []string{"job-02"}},
+	}
+
+	var created []string
+	c := &mock.Client{
+		ListIssuesFunc: func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+			if opts.State != "all" || !reflect.DeepEqual(opts.Labels, []string{IssueLabel}) {
+				t.Errorf("unexpected options: %+v", opts)
+			}
+			return []*github.Issue{{Title: stringPtr(violations[0].IssueTitle())}}, nil, nil
+		},
+		CreateIssueFunc: func(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+			created = append(created, issue.GetTitle())
+			return &github.Issue{}, nil, nil
+		},
+	}
+	a, err := New(c, WithGitHubOwnerAndRepo("owner", "repo"), WithWindow(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := a.FileIssues(context.Background(), violations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened != 1 {
+		t.Errorf("opened = %d, want 1", opened)
+	}
+	if want := []string{violations[1].IssueTitle()}; !reflect.DeepEqual(created, want) {
+		t.Errorf("created = %v, want %v", created, want)
+	}
+}
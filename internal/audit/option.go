@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+)
+
+type Option func(a *Auditor)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(a *Auditor) {
+		if len(owner) != 0 {
+			a.owner = owner
+		}
+		if len(repo) != 0 {
+			a.repo = repo
+		}
+	}
+}
+
+// WithSelfJob sets the name of the Merge Gatekeeper job, which is never audited.
+func WithSelfJob(name string) Option {
+	return func(a *Auditor) {
+		if len(name) != 0 {
+			a.selfJobName = name
+		}
+	}
+}
+
+// WithIgnoredJobs sets the comma-separated list of jobs which are not audited.
+func WithIgnoredJobs(names string) Option {
+	return func(a *Auditor) {
+		a.ignoredJobs = splitNames(names)
+	}
+}
+
+// WithRequiredJobs sets the comma-separated list of jobs which must have succeeded on every merged pull request.
+func WithRequiredJobs(names string) Option {
+	return func(a *Auditor) {
+		a.requiredJobs = splitNames(names)
+	}
+}
+
+// WithWindow sets how far back merges are audited.
+func WithWindow(d time.Duration) Option {
+	return func(a *Auditor) {
+		a.window = d
+	}
+}
+
+// WithClock sets the clock used to resolve the start of the window.
+func WithClock(c clock.Clock) Option {
+	return func(a *Auditor) {
+		if c != nil {
+			a.clock = c
+		}
+	}
+}
+
+func splitNames(names string) []string {
+	var jobs []string
+	for _, s := range strings.Split(names, ",") {
+		if name := strings.TrimSpace(s); len(name) != 0 {
+			jobs = append(jobs, name)
+		}
+	}
+	return jobs
+}
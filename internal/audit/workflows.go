@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// Kinds of findings on workflows the gate does not cover.
+const (
+	// FindingUncovered is a workflow which never runs on pull requests, so that the gate never waits for its jobs.
+	FindingUncovered = "uncovered"
+	// FindingIgnored is a workflow whose jobs are all ignored by the gate.
+	FindingIgnored = "ignored"
+)
+
+// pullRequestTriggers are the events running workflows whose jobs the gate waits for. Workflows triggered by
+// workflow_run are waited for along with the workflows triggering them.
+var pullRequestTriggers = []string{"pull_request", "pull_request_target", "merge_group", "workflow_run"}
+
+// WorkflowFinding is a workflow added within the window which the gate does not cover.
+type WorkflowFinding struct {
+	Name      string
+	Path      string
+	CreatedAt time.Time
+	Kind      string
+	Rule      string // Rule of the gate ignoring the workflow, for FindingIgnored.
+}
+
+// IssueTitle is the title of the issue filed for the finding. It is stable, so that each workflow is only filed once.
+func (f *WorkflowFinding) IssueTitle() string {
+	return fmt.Sprintf("Merge Gatekeeper audit: workflow %s is not gated", f.Name)
+}
+
+// IssueBody describes the finding and suggests how to update the configuration in markdown.
+func (f *WorkflowFinding) IssueBody() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workflow %s (`%s`) was added at %s, ", f.Name, f.Path, f.CreatedAt.UTC().Format(time.RFC3339))
+	if f.Kind == FindingIgnored {
+		fmt.Fprintf(&b, "but its jobs are all ignored by the `%s` rule of Merge Gatekeeper, so they never gate merges.\n", f.Rule)
+		b.WriteString("\nIf its jobs must succeed before pull requests are merged, update the rule, or exempt the workflow with `require workflow:" + f.Name + "` in `rules`.\n")
+		return b.String()
+	}
+	b.WriteString("but it never runs on pull requests, so Merge Gatekeeper never waits for its jobs.\n")
+	b.WriteString("\nIf its jobs must succeed before pull requests are merged, trigger it on `pull_request`. Otherwise, close this issue.\n")
+	return b.String()
+}
+
+// AuditWorkflows returns the workflows added within the window which the gate does not cover, oldest first. The
+// ignores reports which rule of the gate ignores every job of a workflow, if any.
+func (a *Auditor) AuditWorkflows(ctx context.Context, ignores func(workflow string) (string, bool)) ([]*WorkflowFinding, error) {
+	workflows, err := a.listWorkflows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	since := a.now().Add(-a.window)
+	var findings []*WorkflowFinding
+	for _, wf := range workflows {
+		// Workflows of dynamic paths, such as those of Dependabot, are not defined in the repository.
+		if wf.GetCreatedAt().Time.Before(since) || !strings.HasPrefix(wf.GetPath(), ".github/workflows/") {
+			continue
+		}
+		f := &WorkflowFinding{Name: wf.GetName(), Path: wf.GetPath(), CreatedAt: wf.GetCreatedAt().Time}
+		if rule, ok := ignores(wf.GetName()); ok {
+			f.Kind, f.Rule = FindingIgnored, rule
+			findings = append(findings, f)
+			continue
+		}
+
+		content, _, _, err := a.client.GetContents(ctx, a.owner, a.repo, wf.GetPath(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow definition %s: %w", wf.GetPath(), err)
+		}
+		str, err := content.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode workflow definition %s: %w", wf.GetPath(), err)
+		}
+		covered, err := runsOnPullRequests([]byte(str))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workflow definition %s: %w", wf.GetPath(), err)
+		}
+		if !covered {
+			f.Kind = FindingUncovered
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].CreatedAt.Before(findings[j].CreatedAt)
+	})
+	return findings, nil
+}
+
+func (a *Auditor) listWorkflows(ctx context.Context) ([]*github.Workflow, error) {
+	var workflows []*github.Workflow
+	page := 1
+	for {
+		wfs, _, err := a.client.ListWorkflows(ctx, a.owner, a.repo, &github.ListOptions{
+			Page:    page,
+			PerPage: maxItemsPerPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, wfs.Workflows...)
+		if wfs.GetTotalCount() <= len(workflows) || len(wfs.Workflows) == 0 {
+			break
+		}
+		page++
+	}
+	return workflows, nil
+}
+
+// runsOnPullRequests reports whether the workflow definition is triggered by any of the pull request triggers.
+func runsOnPullRequests(definition []byte) (bool, error) {
+	var wf struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(definition, &wf); err != nil {
+		return false, err
+	}
+
+	var events []string
+	switch n := wf.On; n.Kind {
+	case yaml.ScalarNode:
+		events = []string{n.Value}
+	case yaml.SequenceNode:
+		if err := n.Decode(&events); err != nil {
+			return false, err
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(n.Content); i += 2 {
+			events = append(events, n.Content[i].Value)
+		}
+	}
+	for _, event := range events {
+		if contains(pullRequestTriggers, event) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
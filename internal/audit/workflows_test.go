@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func TestAuditor_AuditWorkflows(t *testing.T) {
+	workflow := func(name, path string, createdAt time.Time) *github.Workflow {
+		return &github.Workflow{Name: &name, Path: &path, CreatedAt: timestamp(createdAt)}
+	}
+	definitions := map[string]string{
+		".github/workflows/ci.yml":      "on: [push, pull_request]\n",
+		".github/workflows/deploy.yml":  "on:\n  push:\n    branches: [main]\n",
+		".github/workflows/nightly.yml": "on: schedule\n",
+		".github/workflows/release.yml": "on:\n  workflow_run:\n    workflows: [CI]\n",
+	}
+	c := &mock.Client{
+		ListWorkflowsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+			wfs := []*github.Workflow{
+				workflow("Deploy", ".github/workflows/deploy.yml", now.Add(-time.Hour)),
+				workflow("CI", ".github/workflows/ci.yml", now.Add(-2*time.Hour)),
+				workflow("Nightly", ".github/workflows/nightly.yml", now.Add(-3*time.Hour)),
+				workflow("Release", ".github/workflows/release.yml", now.Add(-4*time.Hour)),
+				workflow("Old", ".github/workflows/old.yml", now.Add(-48*time.Hour)),
+				workflow("Dependabot Updates", "dynamic/dependabot/dependabot-updates", now.Add(-time.Hour)),
+			}
+			total := len(wfs)
+			return &github.Workflows{TotalCount: &total, Workflows: wfs}, nil, nil
+		},
+		GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			content := base64.StdEncoding.EncodeToString([]byte(definitions[path]))
+			return &github.RepositoryContent{Content: &content, Encoding: stringPtr("base64")}, nil, nil, nil
+		},
+	}
+	a, err := New(c,
+		WithGitHubOwnerAndRepo("owner", "repo"),
+		WithWindow(24*time.Hour),
+		WithClock(clockmock.NewClock(now)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.AuditWorkflows(context.Background(), func(workflow string) (string, bool) {
+		return "ignored-workflows", workflow == "Nightly"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*WorkflowFinding{
+		{Name: "Nightly", Path: ".github/workflows/nightly.yml", CreatedAt: now.Add(-3 * time.Hour), Kind: FindingIgnored, Rule: "ignored-workflows"},
+		{Name: "Deploy", Path: ".github/workflows/deploy.yml", CreatedAt: now.Add(-time.Hour), Kind: FindingUncovered},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AuditWorkflows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRunsOnPullRequests(t *testing.T) {
+	tests := map[string]struct {
+		definition string
+		want       bool
+	}{
+		"string":        {definition: "on: pull_request\n", want: true},
+		"list":          {definition: "on: [push, merge_group]\n", want: true},
+		"mapping":       {definition: "on:\n  pull_request_target:\n    types: [opened]\n", want: true},
+		"push only":     {definition: "on:\n  push:\n", want: false},
+		"no triggers":   {definition: "name: Empty\n", want: false},
+		"dispatch only": {definition: "on: [workflow_dispatch]\n", want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := runsOnPullRequests([]byte(tt.definition))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("runsOnPullRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/audit"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+)
+
+// doAuditCmd audits the recent merges into the default branch. It runs instead of the validation on schedule
+// events, where there is no pull request to gate, and fails when any violation is found.
+func doAuditCmd(ctx context.Context, logger logger, c github.Client, owner, repo string) error {
+	defer debug(logger, "audit")()
+
+	a, err := audit.New(c,
+		audit.WithGitHubOwnerAndRepo(owner, repo),
+		audit.WithSelfJob(selfJob()),
+		audit.WithIgnoredJobs(ignoredJobs),
+		audit.WithRequiredJobs(auditRequired),
+		audit.WithWindow(auditWindow),
+		audit.WithClock(clk),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create auditor: %w", err)
+	}
+
+	if auditWorkflows {
+		auditWorkflowCoverage(ctx, logger, c, a, owner, repo)
+	}
+
+	violations, err := a.Audit(ctx)
+	if err != nil {
+		return fmt.Errorf("audit failed, err: %v", err)
+	}
+	if len(traceFile) != 0 {
+		if err := writeAuditRecord(traceFile, newAuditRecord(owner+"/"+repo, clk.Now(), auditWindow, violations)); err != nil {
+			logger.PrintErrf("failed to write audit record: %v\n", err)
+		}
+	}
+	if len(violations) == 0 {
+		logger.Printf("No violations in merges of the last %s\n", auditWindow)
+		return nil
+	}
+
+	for _, v := range violations {
+		logger.PrintErrf("#%d (%s) was merged with failed checks %v and missing checks %v\n", v.Number, v.SHA, v.Failed, v.Missing)
+	}
+	if auditIssues {
+		opened, err := a.FileIssues(ctx, violations)
+		if err != nil {
+			logger.PrintErrf("failed to file audit issues: %v\n", err)
+		}
+		logger.Printf("Opened %d issues for %d violations\n", opened, len(violations))
+	}
+	return fmt.Errorf("audit found %d merged pull requests with failing or missing checks", len(violations))
+}
+
+// auditWorkflowCoverage reports the workflows added within the window which the gate does not cover, so that its
+// configuration keeps up with new workflows. The findings are suggestions, so they never fail the audit.
+func auditWorkflowCoverage(ctx context.Context, logger logger, c github.Client, a *audit.Auditor, owner, repo string) {
+	classifier, err := gateClassifier(c, owner, repo, "HEAD")
+	if err != nil {
+		logger.PrintErrf("failed to audit workflows: %v\n", err)
+		return
+	}
+	findings, err := a.AuditWorkflows(ctx, classifier.IgnoresGroup)
+	if err != nil {
+		logger.PrintErrf("failed to audit workflows: %v\n", err)
+		return
+	}
+	for _, f := range findings {
+		if f.Kind == audit.FindingIgnored {
+			logger.PrintErrf("Workflow %s added at %s is ignored by %s\n", f.Name, f.CreatedAt.UTC().Format(time.RFC3339), f.Rule)
+		} else {
+			logger.PrintErrf("Workflow %s added at %s never runs on pull requests\n", f.Name, f.CreatedAt.UTC().Format(time.RFC3339))
+		}
+	}
+	if auditIssues && len(findings) != 0 {
+		opened, err := a.FileWorkflowIssues(ctx, findings)
+		if err != nil {
+			logger.PrintErrf("failed to file workflow issues: %v\n", err)
+		}
+		logger.Printf("Opened %d issues for %d workflows not gated\n", opened, len(findings))
+	}
+}
+
+// newAuditRecord converts the violations found by the audit into its versioned JSON output.
+func newAuditRecord(repository string, at time.Time, window time.Duration, violations []*audit.Violation) *schema.Audit {
+	record := &schema.Audit{
+		Header:        schema.NewHeader(schema.KindAudit),
+		Repository:    repository,
+		GeneratedAt:   at.UTC(),
+		WindowSeconds: window.Seconds(),
+		Violations:    make([]schema.Violation, 0, len(violations)),
+	}
+	for _, v := range violations {
+		record.Violations = append(record.Violations, schema.Violation{
+			Number:   v.Number,
+			Title:    v.Title,
+			URL:      v.URL,
+			SHA:      v.SHA,
+			MergedAt: v.MergedAt.UTC(),
+			Failed:   append([]string{}, v.Failed...),
+			Missing:  append([]string{}, v.Missing...),
+		})
+	}
+	return record
+}
+
+func writeAuditRecord(path string, record *schema.Audit) error {
+	b, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/audit"
+)
+
+func Test_newAuditRecord(t *testing.T) {
+	at := time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC)
+	violations := []*audit.Violation{
+		{Number: 1, Title: "Fix", SHA: "sha", MergedAt: at.Add(-time.Hour), Failed: []string{"CI / test"}},
+	}
+	b, err := json.Marshal(newAuditRecord("owner/repo", at, 24*time.Hour, violations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"kind":"audit","schema_version":"1","repository":"owner/repo","generated_at":"2021-09-02T00:00:00Z","window_seconds":86400,` +
+		`"violations":[{"number":1,"title":"Fix","sha":"sha","merged_at":"2021-09-01T23:00:00Z","failed":["CI / test"],"missing":[]}]}`
+	if string(b) != want {
+		t.Errorf("newAuditRecord() = %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pollFailureBudget is how many polls may fail with transient API errors before the validation fails.
+// It is set from --poll-error-budget.
+var pollFailureBudget int
+
+// parseErrorBudget parses the budget of failed polls, given either as a count, e.g) 3, or as a percentage of
+// the polls until the timeout, e.g) 5%, so that the budget of a long wait grows along with its polls.
+func parseErrorBudget(spec string, timeout, interval uint) (int, error) {
+	spec = strings.TrimSpace(spec)
+	if len(spec) == 0 {
+		return 0, nil
+	}
+
+	if percent, ok := strings.CutSuffix(spec, "%"); ok {
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil || ratio < 0 || ratio > 100 {
+			return 0, fmt.Errorf("invalid poll error budget %q: percentage must be between 0%% and 100%%", spec)
+		}
+		polls := timeout
+		if interval != 0 {
+			polls = timeout / interval
+		}
+		return int(float64(polls) * ratio / 100), nil
+	}
+
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid poll error budget %q: must be a non-negative count or a percentage", spec)
+	}
+	return n, nil
+}
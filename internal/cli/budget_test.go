@@ -0,0 +1,59 @@
+package cli
+
+import "testing"
+
+func Test_parseErrorBudget(t *testing.T) {
+	tests := map[string]struct {
+		spec     string
+		timeout  uint
+		interval uint
+		want     int
+		wantErr  bool
+	}{
+		"returns zero when empty": {
+			spec: "",
+			want: 0,
+		},
+		"returns the count": {
+			spec: "3",
+			want: 3,
+		},
+		"returns the percentage of the polls until the timeout": {
+			spec:     "5%",
+			timeout:  7200,
+			interval: 10,
+			want:     36,
+		},
+		"rounds the percentage down": {
+			spec:     "10%",
+			timeout:  600,
+			interval: 100,
+			want:     0,
+		},
+		"returns error when the count is negative": {
+			spec:    "-1",
+			wantErr: true,
+		},
+		"returns error when the percentage exceeds 100%": {
+			spec:     "120%",
+			timeout:  600,
+			interval: 10,
+			wantErr:  true,
+		},
+		"returns error when malformed": {
+			spec:    "a few",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseErrorBudget(tt.spec, tt.timeout, tt.interval)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseErrorBudget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseErrorBudget() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// changes are the changes of the checks of a validator since its previous poll.
+type changes struct {
+	name      string
+	summary   string
+	completed []string
+	failed    []string
+	appeared  []string
+}
+
+// pollChanges are the changes observed by a single poll.
+type pollChanges struct {
+	poll    int
+	elapsed time.Duration
+	changes []*changes
+}
+
+func (c *changes) empty() bool {
+	return len(c.completed) == 0 && len(c.failed) == 0 && len(c.appeared) == 0
+}
+
+// diffResults returns the changes of the result since the previous result of the same validator, or nil when they
+// can not be compared, e.g) on the first poll or for statuses which do not describe their checks as sections.
+func diffResults(prev, cur *result) *changes {
+	if prev == nil || prev.status == nil || cur.status == nil {
+		return nil
+	}
+	before, ok := prev.status.(validators.Reporter)
+	if !ok {
+		return nil
+	}
+	after, ok := cur.status.(validators.Reporter)
+	if !ok {
+		return nil
+	}
+
+	states := make(map[string]string)
+	for _, sec := range before.Sections() {
+		for _, item := range sec.Items {
+			states[item] = sec.State
+		}
+	}
+	c := &changes{name: cur.name, summary: after.Summary()}
+	for _, sec := range after.Sections() {
+		for _, item := range sec.Items {
+			was, ok := states[item]
+			if !ok {
+				c.appeared = append(c.appeared, item)
+			}
+			if ok && was == sec.State {
+				continue
+			}
+			switch sec.State {
+			case validators.StateSuccess:
+				c.completed = append(c.completed, item)
+			case validators.StateFailure:
+				c.failed = append(c.failed, item)
+			}
+		}
+	}
+	return c
+}
+
+// findResult returns the result of the validator, or nil when it was not run.
+func findResult(results []*result, name string) *result {
+	for _, r := range results {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// logStatus logs the status of the result, or only its changes since the previous poll with --log-changes,
+// so that the logs of long waits stay scannable.
+func logStatus(logger logger, r *result, c *changes) {
+	if !logChanges || c == nil {
+		logger.Println(r.status.Detail())
+		return
+	}
+	if c.empty() {
+		logger.Println(msgs.Sprintf(i18n.ValidationUnchanged, c.name, c.summary))
+		return
+	}
+	logger.Println(msgs.Sprintf(i18n.ValidationChanged, c.name, c.summary))
+	for _, line := range c.lines() {
+		logger.Println(line)
+	}
+}
+
+func (c *changes) lines() []string {
+	var lines []string
+	if len(c.completed) != 0 {
+		lines = append(lines, msgs.Sprintf(i18n.ValidationCompleted, strings.Join(c.completed, ", ")))
+	}
+	if len(c.failed) != 0 {
+		lines = append(lines, msgs.Sprintf(i18n.ValidationFailed, strings.Join(c.failed, ", ")))
+	}
+	if len(c.appeared) != 0 {
+		lines = append(lines, msgs.Sprintf(i18n.ValidationAppeared, strings.Join(c.appeared, ", ")))
+	}
+	return lines
+}
+
+// renderChanges renders the changes of every poll as markdown, in the order they were observed.
+func renderChanges(history []*pollChanges) string {
+	if len(history) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", msgs.Get(i18n.ValidationChanges))
+	for _, p := range history {
+		for _, c := range p.changes {
+			fmt.Fprintf(&b, "- **+%s** (#%d) %s\n", p.elapsed.Round(time.Second), p.poll, msgs.Sprintf(i18n.ValidationChanged, c.name, c.summary))
+			for _, line := range c.lines() {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
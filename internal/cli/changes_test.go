@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+// sectionStatus is a status describing its checks as sections.
+type sectionStatus struct {
+	sections  []validators.Section
+	succeeded bool
+}
+
+func (s *sectionStatus) Detail() string                 { return "detail" }
+func (s *sectionStatus) IsSuccess() bool                { return s.succeeded }
+func (s *sectionStatus) Summary() string                { return "summary" }
+func (s *sectionStatus) Sections() []validators.Section { return s.sections }
+
+func sections(succeeded, failed, pending []string) *sectionStatus {
+	return &sectionStatus{sections: []validators.Section{
+		{State: validators.StateFailure, Items: failed},
+		{State: validators.StateSuccess, Items: succeeded},
+		{State: validators.StatePending, Items: pending},
+	}}
+}
+
+func Test_diffResults(t *testing.T) {
+	tests := map[string]struct {
+		prev *result
+		cur  *result
+		want *changes
+	}{
+		"returns nil on the first poll": {
+			prev: nil,
+			cur:  &result{name: "status", status: sections(nil, nil, []string{"build"})},
+			want: nil,
+		},
+		"returns nil when the status does not describe its checks": {
+			prev: &result{name: "status", status: &validators.BasicStatus{}},
+			cur:  &result{name: "status", status: &validators.BasicStatus{}},
+			want: nil,
+		},
+		"returns newly completed, failed and appeared checks": {
+			prev: &result{name: "status", status: sections([]string{"lint"}, nil, []string{"build", "test"})},
+			cur:  &result{name: "status", status: sections([]string{"lint", "build"}, []string{"test"}, []string{"e2e"})},
+			want: &changes{
+				name:      "status",
+				summary:   "summary",
+				completed: []string{"build"},
+				failed:    []string{"test"},
+				appeared:  []string{"e2e"},
+			},
+		},
+		"returns checks appeared already completed as completed": {
+			prev: &result{name: "status", status: sections(nil, nil, []string{"build"})},
+			cur:  &result{name: "status", status: sections([]string{"lint"}, nil, []string{"build"})},
+			want: &changes{
+				name:      "status",
+				summary:   "summary",
+				completed: []string{"lint"},
+				appeared:  []string{"lint"},
+			},
+		},
+		"returns no changes when nothing changed": {
+			prev: &result{name: "status", status: sections([]string{"lint"}, nil, []string{"build"})},
+			cur:  &result{name: "status", status: sections([]string{"lint"}, nil, []string{"build"})},
+			want: &changes{name: "status", summary: "summary"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := diffResults(tt.prev, tt.cur); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffResults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_renderChanges(t *testing.T) {
+	msgs = i18n.Default()
+	history := []*pollChanges{
+		{poll: 3, elapsed: 20 * time.Second, changes: []*changes{
+			{name: "status", summary: "2 out of 3", completed: []string{"build"}, failed: []string{"test"}},
+		}},
+	}
+	got := renderChanges(history)
+	for _, want := range []string{
+		"### Changes between polls",
+		"- **+20s** (#3) status changed since the previous poll (2 out of 3):",
+		"  - newly completed: build",
+		"  - newly failed: test",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderChanges() = %s, want to contain %s", got, want)
+		}
+	}
+	if got := renderChanges(nil); len(got) != 0 {
+		t.Errorf("renderChanges() = %s, want empty", got)
+	}
+}
+
+func Test_doValidateCmd_logChanges(t *testing.T) {
+	msgs = i18n.Default()
+	logChanges = true
+	t.Cleanup(func() { logChanges = false })
+
+	polls := []*sectionStatus{
+		sections(nil, nil, []string{"build"}),
+		sections(nil, nil, []string{"build"}),
+		{sections: sections([]string{"build"}, nil, nil).sections, succeeded: true},
+	}
+	var i int
+	v := &mock.Validator{
+		NameFunc: func() string { return "status" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			st := polls[i]
+			i++
+			return st, nil
+		},
+	}
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	if err := doValidateCmd(context.Background(), cmd, v); err != nil {
+		t.Fatalf("doValidateCmd() error = %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"detail",
+		"status is unchanged since the previous poll (summary).",
+		"status changed since the previous poll (summary):\n- newly completed: build",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("doValidateCmd() logged %s, want to contain %s", got, want)
+		}
+	}
+	if n := strings.Count(got, "detail"); n != 1 {
+		t.Errorf("doValidateCmd() logged the whole status %d times, want once", n)
+	}
+}
@@ -2,18 +2,28 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
 )
 
 // These variables will be set by command line flags.
 var (
-	ghToken string
+	ghToken         string
+	userAgentSuffix string
 )
 
+// cliVersion is the version of the running binary, which identifies its requests to the API.
+var cliVersion string
+
 func Run(version string, args ...string) error {
+	cliVersion = version
 	cmd := &cobra.Command{
 		Use:     "merge-gatekeeper",
 		Short:   "Get more refined merge control",
@@ -21,8 +31,20 @@ func Run(version string, args ...string) error {
 	}
 	cmd.PersistentFlags().StringVarP(&ghToken, "token", "t", "", "set github token")
 	cmd.MarkPersistentFlagRequired("token")
+	cmd.PersistentFlags().StringVar(&userAgentSuffix, "user-agent-suffix", "", "set suffix of the User-Agent of API requests, e.g) the name of the deployment, so that administrators can attribute the requests")
 
 	cmd.AddCommand(validateCmd())
+	cmd.AddCommand(reportCmd())
+	cmd.AddCommand(pauseCmd())
+	cmd.AddCommand(resumeCmd())
+	cmd.AddCommand(replayWebhookCmd())
+	cmd.AddCommand(policyCmd())
+	cmd.AddCommand(waitForCmd())
+	cmd.AddCommand(explainCmd())
+	cmd.AddCommand(installCmd())
+	cmd.AddCommand(reconcileCmd())
+	cmd.AddCommand(versionCmd())
+	cmd.AddCommand(verifyTraceCmd())
 
 	ctx, cancel := signal.NotifyContext(context.Background(),
 		syscall.SIGINT,
@@ -35,3 +57,35 @@ func Run(version string, args ...string) error {
 	}
 	return nil
 }
+
+// newClient returns the client of the API authenticated by the token, identifying its requests by userAgent.
+func newClient(ctx context.Context) github.Client {
+	return github.NewClient(ctx, ghToken, github.WithUserAgent(userAgent(cliVersion, userAgentSuffix)))
+}
+
+// userAgent describes the requests of the gate by its version and, in actions, the workflow run making them, e.g)
+// merge-gatekeeper/v1.2.1 (owner/repo; run 123456789; attempt 1) deployment.
+func userAgent(version, suffix string) string {
+	if len(version) == 0 {
+		version = "dev"
+	}
+	ua := "merge-gatekeeper/" + version
+
+	var run []string
+	if repo := os.Getenv("GITHUB_REPOSITORY"); len(repo) != 0 {
+		run = append(run, repo)
+	}
+	if id := os.Getenv("GITHUB_RUN_ID"); len(id) != 0 {
+		run = append(run, "run "+id)
+	}
+	if attempt := os.Getenv("GITHUB_RUN_ATTEMPT"); len(attempt) != 0 {
+		run = append(run, "attempt "+attempt)
+	}
+	if len(run) != 0 {
+		ua += fmt.Sprintf(" (%s)", strings.Join(run, "; "))
+	}
+	if suffix = strings.TrimSpace(suffix); len(suffix) != 0 {
+		ua += " " + suffix
+	}
+	return ua
+}
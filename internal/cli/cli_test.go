@@ -0,0 +1,41 @@
+package cli
+
+import "testing"
+
+func Test_userAgent(t *testing.T) {
+	tests := map[string]struct {
+		version string
+		suffix  string
+		env     map[string]string
+		want    string
+	}{
+		"identifies version only outside actions": {
+			version: "v1.2.1",
+			want:    "merge-gatekeeper/v1.2.1",
+		},
+		"identifies workflow run in actions": {
+			version: "v1.2.1",
+			env:     map[string]string{"GITHUB_REPOSITORY": "owner/repo", "GITHUB_RUN_ID": "123", "GITHUB_RUN_ATTEMPT": "2"},
+			want:    "merge-gatekeeper/v1.2.1 (owner/repo; run 123; attempt 2)",
+		},
+		"appends suffix": {
+			version: "v1.2.1",
+			suffix:  " ghes-prod ",
+			env:     map[string]string{"GITHUB_REPOSITORY": "owner/repo"},
+			want:    "merge-gatekeeper/v1.2.1 (owner/repo) ghes-prod",
+		},
+		"uses dev without version": {
+			want: "merge-gatekeeper/dev",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"GITHUB_REPOSITORY", "GITHUB_RUN_ID", "GITHUB_RUN_ATTEMPT"} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := userAgent(tt.version, tt.suffix); got != tt.want {
+				t.Errorf("userAgent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+const (
+	eventPullRequest       = "pull_request"
+	eventPullRequestTarget = "pull_request_target"
+	eventWorkflowRun       = "workflow_run"
+	eventSchedule          = "schedule"
+	eventPush              = "push"
+)
+
+// event is the subset of the GitHub Actions event payload used to resolve the target of the validation.
+type event struct {
+	PullRequest *eventPullRequestPayload `json:"pull_request"`
+	WorkflowRun *struct {
+		HeadSHA      string                     `json:"head_sha"`
+		PullRequests []*eventPullRequestPayload `json:"pull_requests"`
+	} `json:"workflow_run"`
+
+	// Fields of push events.
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+}
+
+type eventPullRequestPayload struct {
+	Number int `json:"number"`
+	Head   struct {
+		SHA  string           `json:"sha"`
+		Repo *eventRepository `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Repo *eventRepository `json:"repo"`
+	} `json:"base"`
+}
+
+type eventRepository struct {
+	FullName string `json:"full_name"`
+}
+
+// isFork reports whether the head of the pull request lives in another repository.
+func (pr *eventPullRequestPayload) isFork() bool {
+	if pr.Head.Repo == nil || pr.Base.Repo == nil {
+		return false
+	}
+	return pr.Head.Repo.FullName != pr.Base.Repo.FullName
+}
+
+// loadEvent reads the event payload of the running workflow. It returns nil when not running in GitHub Actions.
+func loadEvent() (string, *event, error) {
+	name, path := os.Getenv("GITHUB_EVENT_NAME"), os.Getenv("GITHUB_EVENT_PATH")
+	if len(name) == 0 || len(path) == 0 {
+		return name, nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return name, nil, fmt.Errorf("failed to read event payload: %w", err)
+	}
+	ev := &event{}
+	if err := json.Unmarshal(b, ev); err != nil {
+		return name, nil, fmt.Errorf("failed to parse event payload: %w", err)
+	}
+	return name, ev, nil
+}
+
+// resolveTarget fills the ref and pull request number from the event when they are not given.
+// The ref input defaults to the head of the pull_request event, which is empty for workflow_run events,
+// so the head SHA and the originating pull request are taken from the workflow run instead.
+func resolveTarget(ctx context.Context, c github.Client, owner, repo string, logger logger) error {
+	name, ev, err := loadEvent()
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		return nil
+	}
+
+	switch {
+	case ev.PullRequest != nil:
+		// The head SHA is the commit in the fork for pull requests from forks, which is where the checks run.
+		if len(ghRef) == 0 {
+			ghRef = ev.PullRequest.Head.SHA
+		}
+		if prNumber == 0 {
+			prNumber = ev.PullRequest.Number
+		}
+		if ev.PullRequest.isFork() {
+			logger.Printf("Pull request is from fork %s\n", ev.PullRequest.Head.Repo.FullName)
+			// Unlike pull_request_target, the token of pull_request events from forks is always read-only.
+			if name == eventPullRequest {
+				readOnly = true
+			}
+		}
+	case name == eventPush:
+		// Direct pushes of protected branches and release tags have no pull request, so only the pushed commit is gated.
+		if ev.Deleted {
+			return fmt.Errorf("push event deletes %s, there is nothing to validate", ev.Ref)
+		}
+		if len(ghRef) == 0 {
+			ghRef = ev.After
+		}
+		logger.Printf("Validating %s pushed to %s\n", ghRef, ev.Ref)
+	case name == eventWorkflowRun && ev.WorkflowRun != nil:
+		if len(ghRef) == 0 {
+			ghRef = ev.WorkflowRun.HeadSHA
+		}
+		if prNumber != 0 {
+			break
+		}
+		for _, pr := range ev.WorkflowRun.PullRequests {
+			if pr.Head.SHA == ghRef {
+				prNumber = pr.Number
+				break
+			}
+		}
+		if prNumber != 0 || len(ghRef) == 0 {
+			break
+		}
+		// Pull requests from forks are not listed in the payload, so look them up by the commit.
+		prs, _, err := c.ListPullRequestsWithCommit(ctx, owner, repo, ghRef, nil)
+		if err != nil {
+			logger.PrintErrf("failed to look up pull request of %s: %v\n", ghRef, err)
+			break
+		}
+		for _, pr := range prs {
+			if pr.GetHead().GetSHA() == ghRef {
+				prNumber = pr.GetNumber()
+				break
+			}
+		}
+	}
+
+	if prNumber != 0 {
+		logger.Printf("Validating %s of pull request #%d\n", ghRef, prNumber)
+	}
+	return nil
+}
+
+// resolveRef resolves a branch or tag name given as the ref to the commit SHA, as workflow runs and check runs
+// are looked up by the SHA.
+func resolveRef(ctx context.Context, c github.Client, owner, repo string, logger logger) error {
+	if len(ghRef) == 0 || isCommitSHA(ghRef) {
+		return nil
+	}
+	sha, _, err := c.GetCommitSHA1(ctx, owner, repo, ghRef, "")
+	if err != nil {
+		return fmt.Errorf("failed to resolve ref %s: %w", ghRef, err)
+	}
+	logger.Printf("Resolved ref %s to %s\n", ghRef, sha)
+	ghRef = sha
+	return nil
+}
+
+func isCommitSHA(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	for _, r := range ref {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
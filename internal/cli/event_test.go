@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_resolveTarget(t *testing.T) {
+	tests := map[string]struct {
+		eventName    string
+		payload      string
+		ref          string
+		client       github.Client
+		wantRef      string
+		wantNumber   int
+		wantReadOnly bool
+		wantErr      bool
+	}{
+		"does nothing outside of GitHub Actions": {
+			ref:     "sha",
+			wantRef: "sha",
+		},
+		"resolves pull request number of pull_request event": {
+			eventName:  eventPullRequest,
+			payload:    `{"pull_request": {"number": 1, "head": {"sha": "head-sha"}}}`,
+			ref:        "head-sha",
+			wantRef:    "head-sha",
+			wantNumber: 1,
+		},
+		"resolves head of fork as read-only for pull_request event": {
+			eventName:    eventPullRequest,
+			payload:      `{"pull_request": {"number": 1, "head": {"sha": "fork-sha", "repo": {"full_name": "fork/repo"}}, "base": {"repo": {"full_name": "owner/repo"}}}}`,
+			wantRef:      "fork-sha",
+			wantNumber:   1,
+			wantReadOnly: true,
+		},
+		"resolves head of fork as writable for pull_request_target event": {
+			eventName:  eventPullRequestTarget,
+			payload:    `{"pull_request": {"number": 1, "head": {"sha": "fork-sha", "repo": {"full_name": "fork/repo"}}, "base": {"repo": {"full_name": "owner/repo"}}}}`,
+			wantRef:    "fork-sha",
+			wantNumber: 1,
+		},
+		"resolves ref and pull request from workflow_run event": {
+			eventName:  eventWorkflowRun,
+			payload:    `{"workflow_run": {"head_sha": "head-sha", "pull_requests": [{"number": 2, "head": {"sha": "head-sha"}}]}}`,
+			wantRef:    "head-sha",
+			wantNumber: 2,
+		},
+		"keeps the given ref of workflow_run event": {
+			eventName: eventWorkflowRun,
+			payload:   `{"workflow_run": {"head_sha": "head-sha"}}`,
+			ref:       "main",
+			client: &mock.Client{
+				ListPullRequestsWithCommitFunc: func(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+					return nil, nil, nil
+				},
+			},
+			wantRef: "main",
+		},
+		"looks up pull request of forks by commit": {
+			eventName: eventWorkflowRun,
+			payload:   `{"workflow_run": {"head_sha": "fork-sha", "pull_requests": []}}`,
+			client: &mock.Client{
+				ListPullRequestsWithCommitFunc: func(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+					number := 3
+					return []*github.PullRequest{
+						{Number: &number, Head: &github.PullRequestBranch{SHA: &sha}},
+					}, nil, nil
+				},
+			},
+			wantRef:    "fork-sha",
+			wantNumber: 3,
+		},
+		"continues without pull request when the look up fails": {
+			eventName: eventWorkflowRun,
+			payload:   `{"workflow_run": {"head_sha": "fork-sha"}}`,
+			client: &mock.Client{
+				ListPullRequestsWithCommitFunc: func(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantRef: "fork-sha",
+		},
+		"resolves ref from push event": {
+			eventName: eventPush,
+			payload:   `{"ref": "refs/tags/v1.0.0", "after": "tag-sha"}`,
+			wantRef:   "tag-sha",
+		},
+		"returns error when push event deletes the ref": {
+			eventName: eventPush,
+			payload:   `{"ref": "refs/heads/release", "after": "0000000000000000000000000000000000000000", "deleted": true}`,
+			wantErr:   true,
+		},
+		"returns error when the payload is malformed": {
+			eventName: eventWorkflowRun,
+			payload:   `{`,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ghRef, prNumber = tt.ref, 0
+			defer func() { ghRef, prNumber, readOnly = "", 0, false }()
+
+			t.Setenv("GITHUB_EVENT_NAME", tt.eventName)
+			t.Setenv("GITHUB_EVENT_PATH", "")
+			if len(tt.payload) != 0 {
+				path := filepath.Join(t.TempDir(), "event.json")
+				if err := os.WriteFile(path, []byte(tt.payload), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				t.Setenv("GITHUB_EVENT_PATH", path)
+			}
+
+			err := resolveTarget(context.Background(), tt.client, "owner", "repo", &cobra.Command{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ghRef != tt.wantRef {
+				t.Errorf("ref = %s, want %s", ghRef, tt.wantRef)
+			}
+			if prNumber != tt.wantNumber {
+				t.Errorf("pull request number = %d, want %d", prNumber, tt.wantNumber)
+			}
+			if readOnly != tt.wantReadOnly {
+				t.Errorf("read-only = %v, want %v", readOnly, tt.wantReadOnly)
+			}
+		})
+	}
+}
+
+func Test_resolveRef(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	tests := map[string]struct {
+		ref     string
+		client  github.Client
+		wantRef string
+		wantErr bool
+	}{
+		"keeps commit SHA": {
+			ref:     sha,
+			wantRef: sha,
+		},
+		"resolves tag name to commit SHA": {
+			ref: "v1.0.0",
+			client: &mock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return sha, nil, nil
+				},
+			},
+			wantRef: sha,
+		},
+		"returns error when the ref does not exist": {
+			ref: "unknown",
+			client: &mock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return "", nil, errors.New("not found")
+				},
+			},
+			wantRef: "unknown",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ghRef = tt.ref
+			defer func() { ghRef = "" }()
+
+			err := resolveRef(context.Background(), tt.client, "owner", "repo", &cobra.Command{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ghRef != tt.wantRef {
+				t.Errorf("ref = %s, want %s", ghRef, tt.wantRef)
+			}
+		})
+	}
+}
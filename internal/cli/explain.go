@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// explainCmd explains the decision of the gate on a single check. It takes every input of the validate command, so
+// that the check is evaluated against the same rules as the gate.
+func explainCmd() *cobra.Command {
+	cmd := validateCmd()
+	cmd.Use = "explain"
+	cmd.Short = "Explain the live state of a check, every rule evaluated against it, and why it is counted as it is"
+	// The check is given by --job, while --explain is kept for the action, which only runs the validate command.
+	cmd.PersistentFlags().MarkHidden("explain")
+	cmd.Flags().StringVar(&explainCheck, "job", "", "set name of the check to explain, optionally qualified by its workflow, e.g) CI / build")
+	cmd.MarkFlagRequired("job")
+	return cmd
+}
+
+// doExplainCmd evaluates the validators once and explains which rule captured the check, instead of gating.
+func doExplainCmd(ctx context.Context, logger logger, check string, vs ...validators.Validator) error {
+	results, err := evaluateOnce(ctx, logger, vs...)
+	for _, r := range results {
+		ex, ok := r.status.(validators.Explainer)
+		if !ok {
+			continue
+		}
+		if explanation, ok := ex.Explain(check); ok {
+			logger.Println(explanation)
+			return nil
+		}
+	}
+	// Failures of validators evaluated before the check was found may have prevented it from being explained.
+	if err != nil {
+		return err
+	}
+	logger.Println(msgs.Sprintf(i18n.ValidationNotExplained, check))
+	return nil
+}
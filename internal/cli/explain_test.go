@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+// explainedStatus is a status explaining the checks it knows.
+type explainedStatus struct {
+	explanations map[string]string
+}
+
+func (s *explainedStatus) Detail() string  { return "detail" }
+func (s *explainedStatus) IsSuccess() bool { return false }
+func (s *explainedStatus) Explain(check string) (string, bool) {
+	explanation, ok := s.explanations[check]
+	return explanation, ok
+}
+
+func Test_doExplainCmd(t *testing.T) {
+	explained := &mock.Validator{
+		NameFunc: func() string { return "validator-1" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			return &explainedStatus{explanations: map[string]string{"lint": "lint is captured by the rule rules[1]."}}, nil
+		},
+	}
+	failing := &mock.Validator{
+		NameFunc: func() string { return "validator-2" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			return nil, errors.New("err")
+		},
+	}
+	tests := map[string]struct {
+		check   string
+		vs      []validators.Validator
+		want    string
+		wantErr bool
+	}{
+		"explains the check": {
+			check: "lint",
+			vs:    []validators.Validator{explained},
+			want:  "lint is captured by the rule rules[1].",
+		},
+		"explains the check despite failures of later validators": {
+			check: "lint",
+			vs:    []validators.Validator{explained, failing},
+			want:  "lint is captured by the rule rules[1].",
+		},
+		"reports checks which were not observed": {
+			check: "build",
+			vs:    []validators.Validator{explained},
+			want:  "No check named build was observed.",
+		},
+		"returns error of failures hiding the check": {
+			check:   "build",
+			vs:      []validators.Validator{explained, failing},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+
+			err := doExplainCmd(context.Background(), cmd, tt.check, tt.vs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("doExplainCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !strings.HasSuffix(out.String(), tt.want+"\n") {
+				t.Errorf("doExplainCmd() output = %q, want %q at the end", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// doExportCmd evaluates the validators once and exports the structured status to the trace file and the step
+// outputs, instead of gating. It never fails on the result, so that workflows can build their own logic on it.
+func doExportCmd(ctx context.Context, logger logger, vs ...validators.Validator) error {
+	started := clk.Now()
+	results, err := evaluateOnce(ctx, logger, vs...)
+	u := &usage{Waited: clk.Now().Sub(started), Polls: 1}
+
+	t := newTrace(err, results)
+	if err == nil && !succeeded(results) {
+		t.Result = traceResultPending
+	}
+	t.Usage = u
+	logger.Println(msgs.Sprintf(i18n.ValidationExported, t.Result))
+
+	writeOutputs(logger, err, results, u, nil)
+	if werr := writeStatusOutputs(t); werr != nil {
+		logger.PrintErrf("failed to write step outputs: %v\n", werr)
+	}
+	return nil
+}
+
+// evaluateOnce runs every validator once, stopping at the first error like a single poll of doValidateCmd.
+// While gating is paused, only the status of the pause is returned.
+func evaluateOnce(ctx context.Context, logger logger, vs ...validators.Validator) ([]*result, error) {
+	if pauseGate != nil {
+		r := validate(ctx, pauseGate, logger)
+		if r.err != nil {
+			return []*result{r}, fmt.Errorf("validation failed, err: %v", r.err)
+		}
+		if !r.status.IsSuccess() {
+			return []*result{r}, nil
+		}
+	}
+	results := make([]*result, 0, len(vs))
+	for _, v := range vs {
+		r := validate(ctx, v, logger)
+		results = append(results, r)
+		if r.err != nil {
+			return results, fmt.Errorf("validation failed, err: %v", r.err)
+		}
+	}
+	return results, nil
+}
+
+func succeeded(results []*result) bool {
+	for _, r := range results {
+		if r.status == nil || !r.status.IsSuccess() {
+			return false
+		}
+	}
+	return true
+}
+
+// writeStatusOutputs writes the result and the whole trace as the result and status step outputs ($GITHUB_OUTPUT).
+func writeStatusOutputs(t *trace) error {
+	path := os.Getenv("GITHUB_OUTPUT")
+	if len(path) == 0 {
+		return errors.New("GITHUB_OUTPUT is not set")
+	}
+	b, err := json.Marshal(t.versioned())
+	if err != nil {
+		return err
+	}
+	delimiter, err := outputDelimiter()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "result=%s\nstatus<<%s\n%s\n%s\n", t.Result, delimiter, b, delimiter)
+	return err
+}
+
+// outputDelimiter returns a random delimiter of multiline outputs, which can never appear in the output itself.
+func outputDelimiter() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "EOF_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_doExportCmd(t *testing.T) {
+	validator := func(success bool, err error) validators.Validator {
+		return &mock.Validator{
+			NameFunc: func() string { return "validator-1" },
+			ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+				if err != nil {
+					return nil, err
+				}
+				return &mock.Status{
+					DetailFunc:    func() string { return "detail" },
+					IsSuccessFunc: func() bool { return success },
+				}, nil
+			},
+		}
+	}
+	tests := map[string]struct {
+		validator  validators.Validator
+		wantResult string
+	}{
+		"exports success": {
+			validator:  validator(true, nil),
+			wantResult: traceResultSuccess,
+		},
+		"exports pending without waiting": {
+			validator:  validator(false, nil),
+			wantResult: traceResultPending,
+		},
+		"exports failure without failing": {
+			validator:  validator(false, errors.New("err")),
+			wantResult: traceResultFailure,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "output")
+			t.Setenv("GITHUB_OUTPUT", output)
+
+			if err := doExportCmd(context.Background(), &cobra.Command{}, tt.validator); err != nil {
+				t.Fatalf("doExportCmd() error = %v", err)
+			}
+
+			b, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			if len(lines) != 4 {
+				t.Fatalf("outputs = %q, want result and multiline status", b)
+			}
+			if want := "result=" + tt.wantResult; lines[0] != want {
+				t.Errorf("result output = %q, want %q", lines[0], want)
+			}
+			delimiter := strings.TrimPrefix(lines[1], "status<<")
+			if lines[3] != delimiter {
+				t.Errorf("status output is not closed by %q: %q", delimiter, lines[3])
+			}
+			got := &schema.Trace{}
+			if err := json.Unmarshal([]byte(lines[2]), got); err != nil {
+				t.Fatalf("failed to unmarshal status: %v", err)
+			}
+			if got.Result != tt.wantResult || len(got.Validators) != 1 || got.Usage == nil || got.Usage.Polls != 1 {
+				t.Errorf("status = %+v, want %s result of 1 validator in 1 poll", got, tt.wantResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/incident"
+)
+
+// incidentCheckEvery bounds how often the status page is checked, which is updated far less often than polls.
+const incidentCheckEvery = time.Minute
+
+// incidentCheckTimeout bounds a check of the status page, so that an unresponsive page does not stall the poll.
+const incidentCheckTimeout = 10 * time.Second
+
+// incidentURL is the status page checked with --incident-check.
+var incidentURL = incident.DefaultURL
+
+// incidentWatch defers polls to --incident-interval while the status page of GitHub reports an incident affecting
+// Actions or the API, so that gates do not fail en masse during outages of the platform.
+type incidentWatch struct {
+	enabled   bool
+	incident  *incident.Incident
+	checkedAt time.Time
+	polledAt  time.Time
+}
+
+func newIncidentWatch() *incidentWatch {
+	return &incidentWatch{enabled: incidentCheck}
+}
+
+// ongoing reports whether an incident is ongoing, as of the last check.
+func (w *incidentWatch) ongoing() bool {
+	return w.enabled && w.incident != nil
+}
+
+// interval returns the seconds until the next poll.
+func (w *incidentWatch) interval() uint {
+	if w.ongoing() && incidentInterval > validateInvalSecond {
+		return incidentInterval
+	}
+	return validateInvalSecond
+}
+
+// deferPoll checks the status page, at most every incidentCheckEvery, and reports whether the poll is deferred as
+// it comes sooner than --incident-interval since the last poll during an incident. The status page is only
+// informational, so failing to check it keeps the last known state.
+func (w *incidentWatch) deferPoll(ctx context.Context, logger logger) bool {
+	if !w.enabled {
+		return false
+	}
+	now := clk.Now()
+	if w.checkedAt.IsZero() || now.Sub(w.checkedAt) >= incidentCheckEvery {
+		w.checkedAt = now
+		cctx, cancel := context.WithTimeout(ctx, incidentCheckTimeout)
+		inc, err := incident.Check(cctx, http.DefaultClient, incidentURL)
+		cancel()
+		switch {
+		case err != nil:
+			logger.PrintErrf("WARNING: failed to check the status of GitHub: %v\n", err)
+		case inc != nil && (w.incident == nil || w.incident.URL != inc.URL):
+			w.incident = inc
+			logger.Printf("::warning title=GitHub incident::%s\n", msgs.Sprintf(i18n.ValidationIncident,
+				strings.Join(inc.Components, ", "), inc.Name, inc.URL, w.interval()))
+		case inc == nil && w.incident != nil:
+			w.incident = nil
+			logger.Println(msgs.Sprintf(i18n.ValidationResolved, w.interval()))
+		}
+	}
+	// Half an interval of slack keeps ticks coming slightly early from deferring the poll by another interval.
+	slack := time.Duration(validateInvalSecond) * time.Second / 2
+	if w.ongoing() && !w.polledAt.IsZero() && now.Sub(w.polledAt)+slack < time.Duration(w.interval())*time.Second {
+		return true
+	}
+	w.polledAt = now
+	return false
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+)
+
+func Test_incidentWatch_deferPoll(t *testing.T) {
+	fake := clockmock.NewClock(time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC))
+	clk = fake
+	interval, check, url := incidentInterval, incidentCheck, incidentURL
+	defer func() {
+		clk = clock.New()
+		incidentInterval, incidentCheck, incidentURL = interval, check, url
+	}()
+
+	ongoing := true
+	var checks int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checks++
+		if ongoing {
+			w.Write([]byte(`{"incidents": [{"name": "Incident with Actions", "shortlink": "https://stspg.io/actions", "components": [{"name": "Actions"}]}]}`))
+			return
+		}
+		w.Write([]byte(`{"incidents": []}`))
+	}))
+	defer srv.Close()
+	incidentURL = srv.URL
+	incidentCheck = true
+	incidentInterval = 3
+
+	w := newIncidentWatch()
+	var polled []bool
+	poll := func() {
+		polled = append(polled, !w.deferPoll(context.Background(), &cobra.Command{}))
+		fake.Advance(time.Duration(validateInvalSecond) * time.Second)
+	}
+	// Polls are deferred to the incident interval during the incident.
+	for i := 0; i < 6; i++ {
+		poll()
+	}
+	if want := []bool{true, false, false, true, false, false}; !reflect.DeepEqual(polled, want) {
+		t.Errorf("polled = %v, want %v", polled, want)
+	}
+	if !w.ongoing() || w.interval() != 3 {
+		t.Errorf("ongoing = %v, interval = %d, want the incident interval", w.ongoing(), w.interval())
+	}
+
+	// Every tick is polled again once the incident is resolved, which is checked at most every minute.
+	ongoing = false
+	fake.Advance(incidentCheckEvery)
+	polled = nil
+	for i := 0; i < 3; i++ {
+		poll()
+	}
+	if want := []bool{true, true, true}; !reflect.DeepEqual(polled, want) {
+		t.Errorf("polled = %v, want %v", polled, want)
+	}
+	if w.ongoing() || w.interval() != validateInvalSecond {
+		t.Errorf("ongoing = %v, interval = %d, want the interval", w.ongoing(), w.interval())
+	}
+	if checks != 2 {
+		t.Errorf("checks = %d, want 2", checks)
+	}
+}
+
+func Test_incidentWatch_disabled(t *testing.T) {
+	check := incidentCheck
+	incidentCheck = false
+	defer func() { incidentCheck = check }()
+
+	w := newIncidentWatch()
+	if w.deferPoll(context.Background(), &cobra.Command{}) || w.ongoing() {
+		t.Error("deferPoll() = true, want polls never deferred without --incident-check")
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/install"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+)
+
+// These variables will be set by command line flags.
+var (
+	installOrg      string
+	installRepos    string
+	installWorkflow string
+	installPath     string
+	installBranch   string
+	installCheck    string
+	installDryRun   bool
+)
+
+// installCmd onboards repositories of an organization in bulk, so that platform teams can roll the gate out
+// programmatically. Repositories failing to install are reported at the end, without stopping the others.
+func installCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install [REPO...]",
+		Short: "Open pull requests adding the workflow and require its check by branch protection",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			repos := installRepositories(installRepos, args)
+			if len(installOrg) == 0 || len(repos) == 0 {
+				return errors.New("organization or repositories to install are empty")
+			}
+			opts := install.Options{
+				Path:   installPath,
+				Branch: installBranch,
+				Check:  installCheck,
+				DryRun: installDryRun,
+			}
+			if len(installWorkflow) != 0 {
+				b, err := os.ReadFile(installWorkflow)
+				if err != nil {
+					return fmt.Errorf("failed to read workflow: %w", err)
+				}
+				opts.Workflow = b
+			}
+
+			cmd.SilenceUsage = true
+			c := newClient(ctx)
+			errs := make(multierror.Errors, 0, len(repos))
+			for _, repo := range repos {
+				res, err := install.Install(ctx, c, installOrg, repo, opts)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s/%s: %w", installOrg, repo, err))
+					continue
+				}
+				cmd.Println(installSummary(res))
+			}
+			if len(errs) != 0 {
+				return errs
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&installOrg, "org", "", "set github organization owning the repositories")
+	cmd.MarkFlagRequired("org")
+	cmd.Flags().StringVar(&installRepos, "repos", "", "set comma-separated repositories to install, in addition to the arguments")
+	cmd.Flags().StringVar(&installWorkflow, "workflow-file", "", "set local workflow file to add. the standard workflow is added when empty")
+	cmd.Flags().StringVar(&installPath, "path", install.DefaultPath, "set path of the workflow in the repositories")
+	cmd.Flags().StringVar(&installBranch, "branch", install.DefaultBranch, "set branch the workflow is added on")
+	cmd.Flags().StringVar(&installCheck, "check", defaultSelfJobName, "set check required by branch protection, which is the job name of the workflow")
+	cmd.Flags().BoolVar(&installDryRun, "dry-run", false, "set to only print what would be done, without writing anything")
+
+	return cmd
+}
+
+func installRepositories(repos string, args []string) []string {
+	var rt []string
+	for _, repo := range append(strings.Split(repos, ","), args...) {
+		if repo = strings.TrimSpace(repo); len(repo) != 0 {
+			rt = append(rt, repo)
+		}
+	}
+	return rt
+}
+
+func installSummary(res *install.Result) string {
+	workflow := res.Workflow
+	if len(res.PullRequest) != 0 {
+		workflow = fmt.Sprintf("%s %s", workflow, res.PullRequest)
+	}
+	return fmt.Sprintf("%s: workflow %s, protection of %s %s", res.Repository, workflow, res.DefaultBranch, res.Protection)
+}
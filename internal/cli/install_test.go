@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/install"
+)
+
+func TestInstallRepositories(t *testing.T) {
+	tests := map[string]struct {
+		repos string
+		args  []string
+		want  []string
+	}{
+		"empty": {},
+		"flag and arguments": {
+			repos: "api, web,,",
+			args:  []string{"worker"},
+			want:  []string{"api", "web", "worker"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := installRepositories(tt.repos, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("installRepositories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallSummary(t *testing.T) {
+	got := installSummary(&install.Result{
+		Repository:    "owner/repo",
+		DefaultBranch: "main",
+		Workflow:      install.ActionOpened,
+		PullRequest:   "https://github.com/owner/repo/pull/1",
+		Protection:    install.ActionRequired,
+	})
+	want := "owner/repo: workflow opened https://github.com/owner/repo/pull/1, protection of main required"
+	if got != want {
+		t.Errorf("installSummary() = %q, want %q", got, want)
+	}
+}
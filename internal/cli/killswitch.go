@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+)
+
+// disabledEnv disables gating when true. The action sets it from the repository or organization variable
+// of the same name, so that admins can disable gating without editing every workflow.
+const disabledEnv = "MERGE_GATEKEEPER_DISABLED"
+
+// isDisabled reports whether gating is disabled by disabledEnv. Values which are not booleans are ignored,
+// as a typo must not silently disable gating.
+func isDisabled(logger logger) bool {
+	str := strings.TrimSpace(os.Getenv(disabledEnv))
+	if len(str) == 0 {
+		return false
+	}
+	disabled, err := strconv.ParseBool(str)
+	if err != nil {
+		logger.PrintErrf("WARNING: %s is ignored as it is not a boolean: %s\n", disabledEnv, str)
+		return false
+	}
+	return disabled
+}
+
+// doDisabledCmd exits without validating anything. The neutral result is labelled in the annotations and every
+// requested output, so that it is never mistaken for a successful validation.
+func doDisabledCmd(logger logger) error {
+	msg := msgs.Sprintf(i18n.ValidationDisabled, disabledEnv)
+	logger.Printf("::notice title=Merge Gatekeeper disabled::%s\n", msg)
+
+	if len(traceFile) != 0 {
+		if err := writeTrace(traceFile, &trace{Result: traceResultDisabled, Validators: []*validatorTrace{}}); err != nil {
+			logger.PrintErrf("failed to write decision trace: %v\n", err)
+		}
+	}
+	if stepSummary {
+		if err := appendStepSummary("> [!NOTE]\n> " + msg + "\n\n"); err != nil {
+			logger.PrintErrf("failed to write step summary: %v\n", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func Test_isDisabled(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  bool
+	}{
+		"returns false when not set":       {value: "", want: false},
+		"returns true when true":           {value: "true", want: true},
+		"returns true when padded":         {value: " TRUE ", want: true},
+		"returns false when false":         {value: "false", want: false},
+		"returns false when not a boolean": {value: "yes please", want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(disabledEnv, tt.value)
+			if got := isDisabled(&cobra.Command{}); got != tt.want {
+				t.Errorf("isDisabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_doDisabledCmd(t *testing.T) {
+	dir := t.TempDir()
+	traceFile = filepath.Join(dir, "trace.json")
+	stepSummary = true
+	defer func() {
+		traceFile = ""
+		stepSummary = false
+	}()
+	t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(dir, "summary.md"))
+
+	if err := doDisabledCmd(&cobra.Command{}); err != nil {
+		t.Fatalf("doDisabledCmd() error = %v", err)
+	}
+
+	b, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"result": "disabled"`) {
+		t.Errorf("trace = %s, want disabled result", b)
+	}
+	b, err = os.ReadFile(os.Getenv("GITHUB_STEP_SUMMARY"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "> [!NOTE]\n> Merge Gatekeeper is disabled by MERGE_GATEKEEPER_DISABLED. Nothing was validated.\n\n"; string(b) != want {
+		t.Errorf("step summary = %q, want %q", b, want)
+	}
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+	"github.com/aac228/merge-gatekeeper/internal/notify/automerge"
+	"github.com/aac228/merge-gatekeeper/internal/notify/deploy"
+	"github.com/aac228/merge-gatekeeper/internal/notify/email"
+	"github.com/aac228/merge-gatekeeper/internal/notify/escalation"
+	"github.com/aac228/merge-gatekeeper/internal/notify/flaky"
+	"github.com/aac228/merge-gatekeeper/internal/notify/jira"
+	"github.com/aac228/merge-gatekeeper/internal/notify/labels"
+)
+
+// notifyTimeout bounds each notifier, which runs after the validation has already finished or timed out.
+const notifyTimeout = 30 * time.Second
+
+// notifiers are told about the final decision of the validation.
+var notifiers []notify.Notifier
+
+// optionalNotifiers returns the notifiers enabled by flags.
+func optionalNotifiers(c github.Client, owner, repo string) ([]notify.Notifier, error) {
+	var ns []notify.Notifier
+	if len(jiraURL) != 0 {
+		n, err := jira.New(c,
+			jira.WithGitHubOwnerAndRepo(owner, repo),
+			jira.WithPullRequest(prNumber),
+			jira.WithURL(jiraURL),
+			jira.WithCredentials(jiraUser, jiraToken),
+			jira.WithTransition(jiraTransition),
+			jira.WithProjects(jiraProjects),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	if len(escalationService) != 0 {
+		n, err := escalation.New(c,
+			escalation.WithGitHubOwnerAndRepo(owner, repo),
+			escalation.WithPullRequest(prNumber),
+			escalation.WithService(escalationService, escalationKey, escalationURL),
+			escalation.WithLabels(escalationLabels),
+			escalation.WithSLA(escalationSLA),
+			escalation.WithClock(clk),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	if len(emailServer) != 0 {
+		n, err := email.New(
+			email.WithGitHubOwnerAndRepo(owner, repo),
+			email.WithPullRequest(prNumber),
+			email.WithServer(emailServer),
+			email.WithAuth(emailUser, emailPassword),
+			email.WithFrom(emailFrom),
+			email.WithTo(emailTo),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	if flakyIssues {
+		n, err := flaky.New(c,
+			flaky.WithGitHubOwnerAndRepo(owner, repo),
+			flaky.WithGitHubRef(ghRef),
+			flaky.WithPullRequest(prNumber),
+			flaky.WithSelfJob(selfJob()),
+			flaky.WithIgnoredJobs(ignoredJobs),
+			flaky.WithThreshold(flakyThreshold),
+			flaky.WithOwnersFile(ownersFile),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	if autoMerge {
+		n, err := automerge.New(c,
+			automerge.WithGitHubOwnerAndRepo(owner, repo),
+			automerge.WithPullRequest(prNumber),
+			automerge.WithAuthors(autoMergeAuthors),
+			automerge.WithUpdateTypes(autoMergeTypes),
+			automerge.WithMergeMethod(autoMergeMethod),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	if gateLabels {
+		n, err := labels.New(c,
+			labels.WithGitHubOwnerAndRepo(owner, repo),
+			labels.WithPullRequest(prNumber),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	if len(deployWebhook) != 0 {
+		n, err := deploy.New(
+			deploy.WithGitHubOwnerAndRepo(owner, repo),
+			deploy.WithPullRequest(prNumber),
+			deploy.WithURL(deployWebhook),
+			deploy.WithKey(os.Getenv(signingKeyEnv)),
+			deploy.WithPolicy(appliedPolicy, policySHA),
+			deploy.WithTraceFile(traceFile),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
+
+func newOutcome(err error, results []*result) *notify.Outcome {
+	o := &notify.Outcome{Ref: ghRef}
+	switch {
+	case err == nil:
+		o.Result = notify.ResultSuccess
+	case errors.Is(err, context.DeadlineExceeded):
+		o.Result = notify.ResultTimeout
+	default:
+		o.Result = notify.ResultFailure
+	}
+	for _, r := range results {
+		switch {
+		case r.err != nil:
+			o.Failures = append(o.Failures, notify.Failure{Validator: r.name, Detail: r.err.Error()})
+		case !r.status.IsSuccess():
+			o.Failures = append(o.Failures, notify.Failure{Validator: r.name, Detail: r.status.Detail()})
+		}
+	}
+	return o
+}
+
+// notifyOutcome tells every notifier about the final decision. Failures are only reported, as notifications must
+// never change the decision itself.
+func notifyOutcome(ctx context.Context, logger logger, err error, results []*result) {
+	if len(notifiers) == 0 {
+		return
+	}
+	o := newOutcome(err, results)
+	// The context may have been cancelled by the timeout of the validation, which must still be notified.
+	ctx = context.WithoutCancel(ctx)
+	for _, n := range notifiers {
+		nctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+		if nerr := n.Notify(nctx, o); nerr != nil {
+			logger.PrintErrf("failed to notify %s: %v\n", n.Name(), nerr)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_newOutcome(t *testing.T) {
+	succeeded := &result{name: "validator-1", status: &mock.Status{
+		DetailFunc:    func() string { return "success-1" },
+		IsSuccessFunc: func() bool { return true },
+	}}
+	pending := &result{name: "validator-2", status: &mock.Status{
+		DetailFunc:    func() string { return "pending-2" },
+		IsSuccessFunc: func() bool { return false },
+	}}
+	failed := &result{name: "validator-3", err: errors.New("fails-3")}
+
+	tests := map[string]struct {
+		err     error
+		results []*result
+		want    *notify.Outcome
+	}{
+		"returns success": {
+			results: []*result{succeeded},
+			want:    &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+		},
+		"returns failures": {
+			err:     errors.New("validation failed"),
+			results: []*result{succeeded, failed},
+			want:    &notify.Outcome{Ref: "sha", Result: notify.ResultFailure, Failures: []notify.Failure{{Validator: "validator-3", Detail: "fails-3"}}},
+		},
+		"returns pending validators on timeout": {
+			err:     fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+			results: []*result{pending},
+			want:    &notify.Outcome{Ref: "sha", Result: notify.ResultTimeout, Failures: []notify.Failure{{Validator: "validator-2", Detail: "pending-2"}}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ref := ghRef
+			ghRef = "sha"
+			defer func() { ghRef = ref }()
+
+			if got := newOutcome(tt.err, tt.results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newOutcome() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/report"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// result is the latest outcome of a single validator. The status is also set along with the error
+// when checks failed, as described by validators.FailedChecksError.
+type result struct {
+	name   string
+	status validators.Status
+	err    error
+}
+
+// writeOutputs writes the final decision, along with the usage of the wait, to every requested output.
+// Failures are only reported, as outputs must never change the decision itself.
+func writeOutputs(logger logger, err error, results []*result, u *usage, history []*pollChanges) {
+	if len(traceFile) != 0 {
+		t := newTrace(err, results)
+		t.Usage = u
+		if traceSigned {
+			t.Provenance = newProvenance()
+			if key := os.Getenv(signingKeyEnv); len(key) != 0 {
+				t.signingKey = []byte(key)
+			} else {
+				logger.PrintErrf("WARNING: %s is not set, the trace is not signed\n", signingKeyEnv)
+			}
+		}
+		if werr := writeTrace(traceFile, t); werr != nil {
+			logger.PrintErrf("failed to write decision trace: %v\n", werr)
+		}
+	}
+	if stepSummary {
+		if werr := writeStepSummary(results, u, summaryOptions()); werr != nil {
+			logger.PrintErrf("failed to write step summary: %v\n", werr)
+		}
+		if summaryChanges && len(history) != 0 {
+			if werr := appendStepSummary(renderChanges(history)); werr != nil {
+				logger.PrintErrf("failed to write step summary: %v\n", werr)
+			}
+		}
+	}
+}
+
+func summaryOptions() report.Options {
+	return report.Options{
+		Format:  summaryFormat,
+		Emoji:   summaryEmoji,
+		Details: summaryDetails,
+	}
+}
+
+func writeStepSummary(results []*result, u *usage, opts report.Options) error {
+	summary := renderSummary(results, opts)
+	if u != nil {
+		summary += msgs.Sprintf(i18n.ValidationUsage, u.Waited.Round(time.Second), u.Polls, u.RunnerMinutes()) + "\n"
+	}
+	return appendStepSummary(summary)
+}
+
+func appendStepSummary(summary string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if len(path) == 0 {
+		return errors.New("GITHUB_STEP_SUMMARY is not set")
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(summary)
+	return err
+}
+
+func renderSummary(results []*result, opts report.Options) string {
+	var b strings.Builder
+	for _, r := range results {
+		if r.err != nil {
+			// Failed checks are rendered like any other status, when they can be described as sections.
+			if rp, ok := r.status.(validators.Reporter); ok {
+				b.WriteString(report.Markdown(r.name, validators.StateFailure, rp, opts))
+				continue
+			}
+			b.WriteString(report.MarkdownError(r.name, r.err, opts))
+			continue
+		}
+
+		state := validators.StatePending
+		if r.status.IsSuccess() {
+			state = validators.StateSuccess
+		}
+		if rp, ok := r.status.(validators.Reporter); ok {
+			b.WriteString(report.Markdown(r.name, state, rp, opts))
+			continue
+		}
+		b.WriteString(report.Markdown(r.name, state, &detailReporter{detail: r.status.Detail()}, opts))
+	}
+	return b.String()
+}
+
+// detailReporter falls back to the plain detail for statuses which do not implement validators.Reporter.
+type detailReporter struct {
+	detail string
+}
+
+func (d *detailReporter) Summary() string {
+	return ""
+}
+
+func (d *detailReporter) Sections() []validators.Section {
+	return []validators.Section{{Title: "Detail", State: validators.StateIgnored, Items: []string{d.detail}}}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/report"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_writeStepSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	results := []*result{
+		{
+			name: "validator-1",
+			status: &mock.Status{
+				DetailFunc:    func() string { return "success-1" },
+				IsSuccessFunc: func() bool { return true },
+			},
+		},
+		{name: "validator-2", err: errors.New("fails-2")},
+	}
+	u := &usage{Waited: 90 * time.Second, Polls: 10}
+	if err := writeStepSummary(results, u, report.Options{Format: report.FormatList}); err != nil {
+		t.Fatalf("writeStepSummary() error = %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "### validator-1\n\n**Detail (1)**\n\n- success-1\n\n### validator-2\n\n```\nfails-2\n```\n\n" +
+		"Waited 1m30s over 10 polls, consuming about 2 runner minutes.\n"
+	if string(b) != want {
+		t.Errorf("step summary = %q, want %q", string(b), want)
+	}
+}
+
+func Test_writeStepSummary_withoutEnv(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	if err := writeStepSummary(nil, nil, report.Options{Format: report.FormatList}); err == nil {
+		t.Error("writeStepSummary() error = nil, want error")
+	}
+}
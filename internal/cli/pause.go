@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/pause"
+)
+
+// pauseGate keeps every gate pending while gating is paused. It is nil unless --pausable is set.
+var pauseGate validators.Validator
+
+// These variables will be set by command line flags.
+var (
+	pauseReason string
+)
+
+func pauseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause gating of the repository or a pull request, e.g. during an incident",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			cmd.SilenceUsage = true
+			if err := pause.Pause(ctx, newClient(ctx), owner, repo, prNumber, os.Getenv("GITHUB_ACTOR"), pauseReason); err != nil {
+				return err
+			}
+			cmd.Printf("Paused gating of %s\n", pauseTarget(owner, repo))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+	cmd.Flags().IntVar(&prNumber, "pr", 0, "set pull request number to pause. the whole repository is paused when zero")
+	cmd.Flags().StringVar(&pauseReason, "reason", "", "set why gating is paused, which is shown in the result of every paused gate")
+	cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+func resumeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume gating of the repository or a pull request",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			cmd.SilenceUsage = true
+			err := pause.Resume(ctx, newClient(ctx), owner, repo, prNumber, os.Getenv("GITHUB_ACTOR"))
+			if errors.Is(err, pause.ErrNotPaused) {
+				cmd.Printf("Gating of %s is not paused\n", pauseTarget(owner, repo))
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			cmd.Printf("Resumed gating of %s\n", pauseTarget(owner, repo))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+	cmd.Flags().IntVar(&prNumber, "pr", 0, "set pull request number to resume. the whole repository is resumed when zero")
+
+	return cmd
+}
+
+func pauseTarget(owner, repo string) string {
+	if prNumber != 0 {
+		return fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)
+	}
+	return owner + "/" + repo
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// readOnly is set when the token is known to have no write permission, such as for pull requests from forks.
+var readOnly bool
+
+// writeFeatures are the optional features which need write permission, keyed by their flag name.
+// Validators only read from the API, so they are fully supported with read-only tokens.
+var writeFeatures = map[string]*bool{
+	"audit-issues":  &auditIssues,
+	"auto-merge":    &autoMerge,
+	"flaky-issues":  &flakyIssues,
+	"gate-labels":   &gateLabels,
+	"stale-comment": &staleComment,
+}
+
+// degradeForReadOnly disables every enabled write feature when the token is read-only, and explains it once
+// instead of failing on every poll.
+func degradeForReadOnly(logger logger) {
+	if !readOnly {
+		return
+	}
+
+	var disabled []string
+	for name, enabled := range writeFeatures {
+		if *enabled {
+			*enabled = false
+			disabled = append(disabled, name)
+		}
+	}
+	if len(disabled) == 0 {
+		logger.Println("The token is read-only. All validations are supported without write permission.")
+		return
+	}
+	sort.Strings(disabled)
+	logger.PrintErrf("WARNING: The token is read-only, the following features are disabled: %s\n", strings.Join(disabled, ", "))
+}
+
+// detectReadOnly finds whether the token has write permission to the repository, so that write features are disabled
+// once at startup rather than failing on every poll. The API only tells the permissions of tokens of users, so
+// tokens of GitHub Apps, including GITHUB_TOKEN, are found read-only by the first write they are forbidden instead.
+func detectReadOnly(ctx context.Context, c github.Client, owner, repo string, logger logger) {
+	if readOnly || !writesEnabled() {
+		return
+	}
+	r, _, err := c.GetRepository(ctx, owner, repo)
+	if err != nil {
+		logger.PrintErrf("failed to get permissions of the token, write features are kept enabled: %v\n", err)
+		return
+	}
+	perms := r.GetPermissions()
+	if len(perms) == 0 {
+		return
+	}
+	if !perms["push"] && !perms["maintain"] && !perms["admin"] {
+		readOnly = true
+	}
+}
+
+// writesEnabled reports whether any feature needing write permission is enabled, including publishing statuses.
+func writesEnabled() bool {
+	for _, enabled := range writeFeatures {
+		if *enabled {
+			return true
+		}
+	}
+	return len(profileSpec) != 0 || optionalStatus
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	ghmock "github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_degradeForReadOnly(t *testing.T) {
+	tests := map[string]struct {
+		readOnly    bool
+		enabled     bool
+		wantEnabled bool
+	}{
+		"keeps write features with writable token": {
+			readOnly:    false,
+			enabled:     true,
+			wantEnabled: true,
+		},
+		"disables write features with read-only token": {
+			readOnly:    true,
+			enabled:     true,
+			wantEnabled: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			enabled := tt.enabled
+			features := writeFeatures
+			readOnly = tt.readOnly
+			writeFeatures = map[string]*bool{"feature": &enabled}
+			defer func() {
+				readOnly = false
+				writeFeatures = features
+			}()
+
+			degradeForReadOnly(&cobra.Command{})
+			if enabled != tt.wantEnabled {
+				t.Errorf("enabled = %v, want %v", enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func Test_detectReadOnly(t *testing.T) {
+	tests := map[string]struct {
+		permissions  map[string]bool
+		err          error
+		enabled      bool
+		wantReadOnly bool
+		wantCalled   bool
+	}{
+		"detects tokens without write permission": {
+			permissions:  map[string]bool{"pull": true},
+			enabled:      true,
+			wantReadOnly: true,
+			wantCalled:   true,
+		},
+		"keeps tokens with write permission": {
+			permissions: map[string]bool{"pull": true, "push": true},
+			enabled:     true,
+			wantCalled:  true,
+		},
+		"keeps tokens whose permissions are unknown": {
+			enabled:    true,
+			wantCalled: true,
+		},
+		"keeps tokens when the repository is unavailable": {
+			err:        errors.New("not found"),
+			enabled:    true,
+			wantCalled: true,
+		},
+		"does not detect without write features": {
+			permissions: map[string]bool{"pull": true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			enabled := tt.enabled
+			features := writeFeatures
+			writeFeatures = map[string]*bool{"feature": &enabled}
+			defer func() {
+				readOnly = false
+				writeFeatures = features
+			}()
+
+			var called bool
+			c := &ghmock.Client{
+				GetRepositoryFunc: func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+					called = true
+					return &github.Repository{Permissions: tt.permissions}, nil, tt.err
+				},
+			}
+			detectReadOnly(context.Background(), c, "owner", "repo", &cobra.Command{})
+			if readOnly != tt.wantReadOnly {
+				t.Errorf("readOnly = %v, want %v", readOnly, tt.wantReadOnly)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("called = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/policy"
+)
+
+// These variables will be set by command line flags.
+var (
+	policyFile string
+)
+
+// appliedPolicy and policySHA are the name of the policy applied and the blob SHA of the policy file, recorded in the
+// provenance of signed traces.
+var (
+	appliedPolicy string
+	policySHA     string
+)
+
+// pathChecks are the jobs required by the paths changed by the pull request, which are the only jobs gated when
+// pathChecksOnly is set.
+var (
+	pathChecks     []string
+	pathChecksOnly bool
+)
+
+// policyExcludedInputs can not be overridden by policies. They either select the target and the policy itself,
+// have already taken effect, or are secrets which must never be committed to the policy file.
+var policyExcludedInputs = map[string]bool{
+	"token":              true,
+	"repo":               true,
+	"ref":                true,
+	"pr":                 true,
+	"policy-file":        true,
+	"locale":             true,
+	"messages-file":      true,
+	"flag-service-token": true,
+	"jira-token":         true,
+	"escalation-key":     true,
+	"email-password":     true,
+}
+
+// applyPolicy overrides the inputs by the first policy of the policy file matching the target. The policy file
+// is read from the base branch, so that pull requests can not loosen the policy gating themselves.
+func applyPolicy(ctx context.Context, cmd *cobra.Command, c github.Client, owner, repo string) error {
+	if len(policyFile) == 0 {
+		return nil
+	}
+	target, err := resolvePolicyTarget(ctx, c, owner, repo)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return nil
+	}
+
+	cfg, err := policy.Load(ctx, c, owner, repo, target.Base, policyFile)
+	if err != nil {
+		return err
+	}
+	policySHA = cfg.SHA
+	if len(cfg.Policies) == 0 && len(cfg.RequiredChecks) == 0 {
+		return nil
+	}
+	if cfg.NeedsPaths() && prNumber != 0 {
+		if target.Paths, err = listChangedFiles(ctx, c, owner, repo, prNumber); err != nil {
+			return err
+		}
+	}
+	applyRequiredChecks(cmd, cfg, target.Paths)
+	if len(cfg.Policies) == 0 {
+		return nil
+	}
+	return applyPolicyInputs(cmd, cfg.Select(target))
+}
+
+// applyRequiredChecks selects the jobs required by the changed paths. Every job is gated as usual when the required
+// checks do not cover every changed path.
+func applyRequiredChecks(cmd *cobra.Command, cfg *policy.Config, paths []string) {
+	pathChecks, pathChecksOnly = cfg.Required(paths)
+	if len(cfg.RequiredChecks) == 0 {
+		return
+	}
+	if !pathChecksOnly {
+		cmd.Printf("Requiring every job, as the required checks of %s do not cover every changed path\n", policyFile)
+		return
+	}
+	cmd.Printf("Requiring the jobs of the changed paths by %s: %s\n", policyFile, strings.Join(pathChecks, ", "))
+}
+
+// applyPolicyInputs overrides the inputs by the policy, which is nil when no policy matches the target.
+func applyPolicyInputs(cmd *cobra.Command, p *policy.Policy) error {
+	if p == nil {
+		cmd.Printf("No policy of %s matches the target\n", policyFile)
+		return nil
+	}
+
+	names := make([]string, 0, len(p.Inputs))
+	for name := range p.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if policyExcludedInputs[name] || cmd.Flags().Lookup(name) == nil {
+			return fmt.Errorf("policy %s can not override input %s", p.Name, name)
+		}
+		if err := cmd.Flags().Set(name, p.Inputs[name]); err != nil {
+			return fmt.Errorf("policy %s sets invalid %s: %w", p.Name, name, err)
+		}
+	}
+	appliedPolicy = p.Name
+	cmd.Printf("Applying policy %s, overriding: %s\n", p.Name, strings.Join(names, ", "))
+	return nil
+}
+
+// resolvePolicyTarget returns the target of the validation, or nil when it is neither a pull request nor a push
+// of a branch.
+func resolvePolicyTarget(ctx context.Context, c github.Client, owner, repo string) (*policy.Target, error) {
+	if prNumber != 0 {
+		pr, _, err := c.GetPullRequest(ctx, owner, repo, prNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request #%d: %w", prNumber, err)
+		}
+		return &policy.Target{
+			Base:        pr.GetBase().GetRef(),
+			Author:      pr.GetUser().GetLogin(),
+			Association: pr.GetAuthorAssociation(),
+		}, nil
+	}
+	name, ev, err := loadEvent()
+	if err != nil {
+		return nil, err
+	}
+	if name == eventPush && ev != nil && strings.HasPrefix(ev.Ref, "refs/heads/") {
+		return &policy.Target{Base: strings.TrimPrefix(ev.Ref, "refs/heads/")}, nil
+	}
+	return nil, nil
+}
+
+// listChangedFiles returns the files changed by the pull request. Renamed files are listed by both names, so that
+// moving files out of a path is considered a change of the path.
+func listChangedFiles(ctx context.Context, c github.Client, owner, repo string, number int) ([]string, error) {
+	const perPage = 100
+	var paths []string
+	page := 1
+	for {
+		files, _, err := c.ListPullRequestFiles(ctx, owner, repo, number, &github.ListOptions{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of #%d: %w", number, err)
+		}
+		for _, f := range files {
+			paths = append(paths, f.GetFilename())
+			if prev := f.GetPreviousFilename(); len(prev) != 0 {
+				paths = append(paths, prev)
+			}
+		}
+		if len(files) < perPage {
+			break
+		}
+		page++
+	}
+	return paths, nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/policy"
+)
+
+func Test_applyPolicy(t *testing.T) {
+	tests := map[string]struct {
+		config      string
+		base        string
+		files       []string
+		wantErr     bool
+		wantIgnored string
+	}{
+		"applies the policy of the base branch": {
+			config:      "policies:\n  - name: release\n    branches: [release/*]\n    inputs:\n      ignored: lint\n",
+			base:        "release/1.0",
+			wantIgnored: "lint",
+		},
+		"applies the policy of the changed paths": {
+			config:      "policies:\n  - name: docs\n    only-paths: [docs/**]\n    inputs:\n      ignored: test\n",
+			base:        "main",
+			files:       []string{"docs/usage.md"},
+			wantIgnored: "test",
+		},
+		"applies nothing when other paths are changed": {
+			config: "policies:\n  - name: docs\n    only-paths: [docs/**]\n    inputs:\n      ignored: test\n",
+			base:   "main",
+			files:  []string{"docs/usage.md", "main.go"},
+		},
+		"applies nothing when no policy matches": {
+			config: "policies:\n  - name: release\n    branches: [release/*]\n    inputs:\n      ignored: lint\n",
+			base:   "main",
+		},
+		"applies nothing without policy file": {
+			base: "main",
+		},
+		"returns error when overriding excluded inputs": {
+			config:  "policies:\n  - name: default\n    inputs:\n      token: secret\n",
+			base:    "main",
+			wantErr: true,
+		},
+		"returns error when overriding unknown inputs": {
+			config:  "policies:\n  - name: default\n    inputs:\n      unknown: value\n",
+			base:    "main",
+			wantErr: true,
+		},
+		"returns error with invalid values": {
+			config:  "policies:\n  - name: default\n    inputs:\n      timeout: soon\n",
+			base:    "main",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var timeout uint
+			cmd := &cobra.Command{}
+			cmd.Flags().StringVar(&ghToken, "token", "", "")
+			cmd.Flags().StringVar(&ignoredJobs, "ignored", "", "")
+			cmd.Flags().UintVar(&timeout, "timeout", 600, "")
+			policyFile = policy.DefaultPath
+			prNumber = 1
+			defer func() {
+				policyFile = ""
+				prNumber = 0
+				ignoredJobs = ""
+			}()
+
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{Base: &github.PullRequestBranch{Ref: &tt.base}}, nil, nil
+				},
+				ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+					var files []*github.CommitFile
+					for i := range tt.files {
+						files = append(files, &github.CommitFile{Filename: &tt.files[i]})
+					}
+					return files, nil, nil
+				},
+				GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+					if path != policy.DefaultPath || opts.Ref != tt.base {
+						t.Errorf("path = %s, ref = %s, want %s at %s", path, opts.Ref, policy.DefaultPath, tt.base)
+					}
+					if len(tt.config) == 0 {
+						return nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+					}
+					content := base64.StdEncoding.EncodeToString([]byte(tt.config))
+					encoding := "base64"
+					return &github.RepositoryContent{Content: &content, Encoding: &encoding}, nil, nil, nil
+				},
+			}
+			err := applyPolicy(context.Background(), cmd, c, "owner", "repo")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ignoredJobs != tt.wantIgnored {
+				t.Errorf("ignored = %s, want %s", ignoredJobs, tt.wantIgnored)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/policy"
+	"github.com/aac228/merge-gatekeeper/internal/policytest"
+)
+
+// These variables will be set by command line flags.
+var (
+	policyTestPolicyFile string
+	policyTestFile       string
+)
+
+// Owner and repository of the pull requests of policy tests.
+const (
+	policyTestOwner = "policy-test"
+	policyTestRepo  = "policy-test"
+)
+
+func policyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Work with the policies overriding inputs",
+	}
+	// Policies are tested without calling the API, so the token required by the other commands is not.
+	cmd.PersistentFlags().StringVarP(&ghToken, "token", "t", "", "set github token. not used")
+	cmd.PersistentFlags().MarkHidden("token")
+	cmd.AddCommand(policyTestCmd())
+	return cmd
+}
+
+// policyTestCmd runs the test cases of the gate configuration, so that changes of the policies and inputs can be
+// checked before they gate any pull request. Each case is evaluated once by the same validators as the validate
+// command, against a pull request served from the case in place of the GitHub API.
+func policyTestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run the test cases of the gate configuration against the local policy file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := os.ReadFile(policyTestFile)
+			if err != nil {
+				return fmt.Errorf("failed to read policy tests: %w", err)
+			}
+			suite, err := policytest.Parse(b)
+			if err != nil {
+				return fmt.Errorf("failed to parse policy tests %s: %w", policyTestFile, err)
+			}
+			cfg := &policy.Config{}
+			if len(policyTestPolicyFile) != 0 {
+				b, err := os.ReadFile(policyTestPolicyFile)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("failed to read policy file: %w", err)
+				}
+				if err == nil {
+					if cfg, err = policy.Parse(b); err != nil {
+						return fmt.Errorf("failed to parse policy file %s: %w", policyTestPolicyFile, err)
+					}
+				}
+			}
+
+			cmd.SilenceUsage = true
+			failed := 0
+			for _, tc := range suite.Tests {
+				got, applied, err := runPolicyTest(cmd.Context(), cfg, tc)
+				switch {
+				case err != nil:
+					cmd.Printf("FAIL %s: %v\n", tc.Name, err)
+				case got != tc.Expect:
+					cmd.Printf("FAIL %s: expected %s, got %s\n", tc.Name, tc.Expect, got)
+				case len(tc.Policy) != 0 && applied != tc.Policy:
+					cmd.Printf("FAIL %s: expected policy %q to be applied, got %q\n", tc.Name, tc.Policy, applied)
+				default:
+					cmd.Printf("PASS %s\n", tc.Name)
+					continue
+				}
+				failed++
+			}
+			if failed != 0 {
+				return fmt.Errorf("%d of %d policy tests failed", failed, len(suite.Tests))
+			}
+			cmd.Printf("All %d policy tests passed\n", len(suite.Tests))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&policyTestPolicyFile, "policy-file", policy.DefaultPath, "set local path of the policy file under test. no policy is applied when empty or missing")
+	cmd.Flags().StringVar(&policyTestFile, "tests", policytest.DefaultPath, "set local path of the test cases")
+	return cmd
+}
+
+// runPolicyTest evaluates the gate once for the test case, and returns its result along with the name of the
+// applied policy, which is empty when no policy matches.
+func runPolicyTest(ctx context.Context, cfg *policy.Config, tc *policytest.Case) (string, string, error) {
+	// A fresh validate command resets the inputs to their defaults before the case overrides them.
+	cmd := validateCmd()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	names := make([]string, 0, len(tc.Inputs))
+	for name := range tc.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := make([]string, 0, len(names))
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("--%s=%s", name, tc.Inputs[name]))
+	}
+	if err := cmd.ParseFlags(args); err != nil {
+		return "", "", fmt.Errorf("invalid inputs: %w", err)
+	}
+
+	p := cfg.Select(tc.Target())
+	applyRequiredChecks(cmd, cfg, tc.Target().Paths)
+	if err := applyPolicyInputs(cmd, p); err != nil {
+		return "", "", err
+	}
+	applied := ""
+	if p != nil {
+		applied = p.Name
+	}
+
+	catalog, err := i18n.New(locale)
+	if err != nil {
+		return "", "", err
+	}
+	msgs = catalog
+	if err := summaryOptions().Validate(); err != nil {
+		return "", "", err
+	}
+	ghRef, prNumber = policytest.SHA, policytest.Number
+
+	vs, err := gateValidators(ctx, tc.Client(clk.Now()), policyTestOwner, policyTestRepo, catalog)
+	if err != nil {
+		return "", "", err
+	}
+	got := policytest.ExpectPass
+	for _, v := range vs {
+		r := validate(ctx, v, cmd)
+		switch {
+		case r.err != nil:
+			return policytest.ExpectFail, applied, nil
+		case !r.status.IsSuccess():
+			got = policytest.ExpectPending
+		}
+	}
+	return got, applied, nil
+}
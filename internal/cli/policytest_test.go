@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/policy"
+	"github.com/aac228/merge-gatekeeper/internal/policytest"
+)
+
+func Test_runPolicyTest(t *testing.T) {
+	cfg, err := policy.Parse([]byte(`
+policies:
+  - name: docs
+    only-paths: ["docs/**"]
+    inputs:
+      ignored: build
+  - name: default
+    inputs:
+      min-approvals: 1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Validate commands of the policy tests reset the inputs to their defaults, which other tests do not expect.
+	interval, timeout := validateInvalSecond, timeoutSecond
+	t.Cleanup(func() {
+		validateInvalSecond, timeoutSecond = interval, timeout
+	})
+	code := policytest.PullRequest{Base: "main", Author: "alice", Paths: []string{"main.go"}}
+	tests := map[string]struct {
+		tc          *policytest.Case
+		want        string
+		wantApplied string
+	}{
+		"passes when the ignored job of the policy is running": {
+			tc: &policytest.Case{
+				PullRequest: policytest.PullRequest{Base: "main", Author: "alice", Paths: []string{"docs/a.md"}},
+				Checks: []policytest.Check{
+					{Workflow: "ci", Name: "build", Status: "in_progress"},
+					{Workflow: "ci", Name: "lint", Conclusion: "success"},
+				},
+			},
+			want:        policytest.ExpectPass,
+			wantApplied: "docs",
+		},
+		"is pending while approvals required by the policy are missing": {
+			tc: &policytest.Case{
+				PullRequest: code,
+				Checks:      []policytest.Check{{Workflow: "ci", Name: "build", Conclusion: "success"}},
+			},
+			want:        policytest.ExpectPending,
+			wantApplied: "default",
+		},
+		"passes when approved": {
+			tc: &policytest.Case{
+				PullRequest: code,
+				Checks:      []policytest.Check{{Workflow: "ci", Name: "build", Conclusion: "success"}},
+				Reviews:     []policytest.Review{{User: "bob", State: "APPROVED"}},
+			},
+			want:        policytest.ExpectPass,
+			wantApplied: "default",
+		},
+		"fails when a job failed": {
+			tc: &policytest.Case{
+				PullRequest: code,
+				Checks:      []policytest.Check{{Workflow: "ci", Name: "build", Conclusion: "failure"}},
+				Reviews:     []policytest.Review{{User: "bob", State: "APPROVED"}},
+			},
+			want:        policytest.ExpectFail,
+			wantApplied: "default",
+		},
+		"applies the inputs of the case before the policy": {
+			tc: &policytest.Case{
+				Inputs:      map[string]string{"ignored": "e2e"},
+				PullRequest: code,
+				Checks:      []policytest.Check{{Workflow: "ci", Name: "e2e", Conclusion: "failure"}},
+				Reviews:     []policytest.Review{{User: "bob", State: "APPROVED"}},
+			},
+			want:        policytest.ExpectPass,
+			wantApplied: "default",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, applied, err := runPolicyTest(context.Background(), cfg, tt.tc)
+			if err != nil {
+				t.Fatalf("runPolicyTest() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("runPolicyTest() got = %s, want %s", got, tt.want)
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("runPolicyTest() applied = %s, want %s", applied, tt.wantApplied)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aac228/merge-gatekeeper/internal/fingerprint"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/profile"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// maxStatusDescription is the longest description of commit statuses accepted by the API.
+const maxStatusDescription = 140
+
+const maxStatusesPerPage = 100
+
+// namer renders the names of the statuses and comments the gate publishes.
+var namer *profile.Namer
+
+// newNamer returns the namer of the name template. The base branch is only looked up when the template refers to it.
+func newNamer(ctx context.Context, c github.Client, owner, repo string) (*profile.Namer, error) {
+	vars := profile.NameVars{Owner: owner, Repo: repo, Repository: owner + "/" + repo}
+	if profile.NeedsBaseBranch(nameTemplate) {
+		vars.BaseBranch = os.Getenv("GITHUB_BASE_REF")
+		if len(vars.BaseBranch) == 0 {
+			target, err := resolvePolicyTarget(ctx, c, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			if target != nil {
+				vars.BaseBranch = target.Base
+			}
+		}
+	}
+	return profile.NewNamer(nameTemplate, vars)
+}
+
+// profilePublisher publishes the profiles, and the summary of the optional jobs, as commit statuses of the ref on
+// every poll.
+var profilePublisher *publisher
+
+type publisher struct {
+	client    github.Client
+	owner     string
+	repo      string
+	ref       string
+	targetURL string
+	profiles  []*profile.Profile
+	optional  bool
+	published map[string]string // Fingerprints of the last published states and descriptions by context.
+	seeded    bool
+	forbidden bool // Set once the token is forbidden to publish statuses, which are not published anymore.
+}
+
+// publishedStatuses returns the names of the statuses published by the profiles and for the optional jobs. Invalid
+// profiles publish nothing, which is reported by newPublisher.
+func publishedStatuses() []string {
+	var names []string
+	if len(profileSpec) != 0 {
+		profiles, _ := profile.Parse(profileSpec)
+		for _, p := range profiles {
+			names = append(names, namer.Name(p.Name))
+		}
+	}
+	if optionalStatus {
+		names = append(names, namer.Name(profile.OptionalName))
+	}
+	return names
+}
+
+// newPublisher returns the publisher of the profiles and the optional jobs, or nil when neither is requested or the
+// token can not create commit statuses.
+func newPublisher(c github.Client, owner, repo string, logger logger) (*publisher, error) {
+	if len(profileSpec) == 0 && !optionalStatus {
+		return nil, nil
+	}
+	var profiles []*profile.Profile
+	if len(profileSpec) != 0 {
+		var err error
+		if profiles, err = profile.Parse(profileSpec); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range profiles {
+		if optionalStatus && p.Name == profile.OptionalName {
+			return nil, fmt.Errorf("profile %s conflicts with the status of the optional jobs", p.Name)
+		}
+	}
+	if readOnly {
+		logger.PrintErrln("WARNING: The token is read-only, profiles and the status of the optional jobs are not published")
+		return nil, nil
+	}
+	p := &publisher{
+		client:    c,
+		owner:     owner,
+		repo:      repo,
+		ref:       ghRef,
+		profiles:  profiles,
+		optional:  optionalStatus,
+		published: make(map[string]string, len(profiles)),
+	}
+	if server, runID := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_RUN_ID"); len(server) != 0 && len(runID) != 0 {
+		p.targetURL = fmt.Sprintf("%s/%s/%s/actions/runs/%s", server, owner, repo, runID)
+	}
+	return p, nil
+}
+
+// publish evaluates every profile from the decisions of the results. Once the validation has finished, profiles
+// still pending fail, as nothing will update them anymore. Optional jobs still running are left pending, as their
+// status is not required. Failures are only reported, as publishing must never change the decision itself.
+func (p *publisher) publish(ctx context.Context, logger logger, results []*result, final bool, verr error) {
+	if p.forbidden {
+		return
+	}
+	if !p.seeded {
+		p.seed(ctx, logger)
+	}
+	var decisions []validators.Decision
+	for _, r := range results {
+		if t, ok := r.status.(validators.Tracer); ok {
+			decisions = append(decisions, t.Decisions()...)
+		}
+	}
+	for _, pr := range p.profiles {
+		state, description := pr.Evaluate(decisions)
+		if final && state == profile.StatePending {
+			state = profile.StateFailure
+			if errors.Is(verr, context.DeadlineExceeded) {
+				description = "timed out: " + description
+			} else {
+				description = "stopped: " + description
+			}
+		}
+		p.publishStatus(ctx, logger, namer.Name(pr.Name), state, description)
+	}
+	if !p.optional {
+		return
+	}
+	if state, description, ok := profile.EvaluateOptional(decisions); ok {
+		p.publishStatus(ctx, logger, namer.Name(profile.OptionalName), state, description)
+	}
+}
+
+// seed records the statuses already published on the ref, which are correlated by their names, so that re-runs of
+// the gate only publish the statuses which have changed since the previous run.
+func (p *publisher) seed(ctx context.Context, logger logger) {
+	page := 1
+	for {
+		combined, _, err := p.client.GetCombinedStatus(ctx, p.owner, p.repo, p.ref, &github.ListOptions{Page: page, PerPage: maxStatusesPerPage})
+		if err != nil {
+			logger.PrintErrf("failed to get published statuses, every status is published again: %v\n", err)
+			break
+		}
+		for _, st := range combined.Statuses {
+			p.published[st.GetContext()] = fingerprint.Of(st.GetState(), st.GetDescription())
+		}
+		if len(combined.Statuses) < maxStatusesPerPage {
+			break
+		}
+		page++
+	}
+	p.seeded = true
+}
+
+// publishStatus creates the commit status, unless the same state and description were already published.
+// Statuses only carry the latest update of each context, so unchanged updates would only spend API writes.
+func (p *publisher) publishStatus(ctx context.Context, logger logger, statusContext, state, description string) {
+	if p.forbidden {
+		return
+	}
+	if r := []rune(description); len(r) > maxStatusDescription {
+		description = string(r[:maxStatusDescription-3]) + "..."
+	}
+	fp := fingerprint.Of(state, description)
+	if p.published[statusContext] == fp {
+		return
+	}
+
+	status := &github.RepoStatus{State: &state, Description: &description, Context: &statusContext}
+	if len(p.targetURL) != 0 {
+		status.TargetURL = &p.targetURL
+	}
+	if _, _, err := p.client.CreateStatus(ctx, p.owner, p.repo, p.ref, status); err != nil {
+		// Tokens of GitHub Apps are only found read-only by their writes, which would be forbidden on every poll.
+		if github.IsForbidden(err) {
+			p.forbidden = true
+			logger.PrintErrf("WARNING: The token is read-only, profiles and the status of the optional jobs are not published: %v\n", err)
+			return
+		}
+		logger.PrintErrf("failed to publish %s: %v\n", statusContext, err)
+		return
+	}
+	p.published[statusContext] = fp
+	logger.Printf("Published %s as %s: %s\n", statusContext, state, description)
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	ghmock "github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/profile"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+type tracedStatus struct {
+	mock.Status
+	decisions []validators.Decision
+}
+
+func (s *tracedStatus) Decisions() []validators.Decision {
+	return s.decisions
+}
+
+func TestPublisher_publish(t *testing.T) {
+	results := []*result{{name: "merge-gatekeeper", status: &tracedStatus{decisions: []validators.Decision{
+		{Check: "CI / unit-test", Verdict: validators.StateSuccess},
+		{Check: "E2E / e2e", Verdict: validators.StatePending},
+		{Check: "Perf / bench", Verdict: validators.StateIgnored, State: validators.StatePending},
+	}}}}
+
+	published := make(map[string][]string)
+	c := &ghmock.Client{
+		CreateStatusFunc: func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+			published[status.GetContext()] = append(published[status.GetContext()], status.GetState()+": "+status.GetDescription())
+			return status, nil, nil
+		},
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			// The fast gate has already been published by the previous run.
+			statusContext, state, description := "merge-gatekeeper/fast-gate", "success", "1 of 1 jobs succeeded"
+			return &github.CombinedStatus{Statuses: []*github.RepoStatus{{Context: &statusContext, State: &state, Description: &description}}}, nil, nil
+		},
+	}
+	profiles, err := profile.Parse("fast-gate=unit-test;full-gate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := profile.NewNamer(profile.DefaultNameTemplate, profile.NameVars{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	namer = n
+	defer func() { namer = nil }()
+	p := &publisher{client: c, owner: "owner", repo: "repo", ref: "sha", profiles: profiles, optional: true, published: make(map[string]string)}
+
+	p.publish(context.Background(), &cobra.Command{}, results, false, nil)
+	p.publish(context.Background(), &cobra.Command{}, results, false, nil)
+	p.publish(context.Background(), &cobra.Command{}, results, true, context.DeadlineExceeded)
+
+	want := map[string][]string{
+		"merge-gatekeeper/full-gate": {
+			"pending: 1 of 2 jobs succeeded, waiting for E2E / e2e",
+			"failure: timed out: 1 of 2 jobs succeeded, waiting for E2E / e2e",
+		},
+		"merge-gatekeeper/optional": {"pending: 0 of 1 optional jobs succeeded, waiting for Perf / bench"},
+	}
+	if !reflect.DeepEqual(published, want) {
+		t.Errorf("published = %v, want %v", published, want)
+	}
+}
+
+func TestPublisher_publish_forbidden(t *testing.T) {
+	results := []*result{{name: "merge-gatekeeper", status: &tracedStatus{decisions: []validators.Decision{
+		{Check: "CI / unit-test", Verdict: validators.StatePending},
+	}}}}
+
+	var attempts int
+	c := &ghmock.Client{
+		CreateStatusFunc: func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+			attempts++
+			return nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}
+		},
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{}, nil, nil
+		},
+	}
+	profiles, err := profile.Parse("fast-gate=unit-test;full-gate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := profile.NewNamer(profile.DefaultNameTemplate, profile.NameVars{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	namer = n
+	defer func() { namer = nil }()
+	p := &publisher{client: c, owner: "owner", repo: "repo", ref: "sha", profiles: profiles, optional: true, published: make(map[string]string)}
+
+	p.publish(context.Background(), &cobra.Command{}, results, false, nil)
+	p.publish(context.Background(), &cobra.Command{}, results, true, nil)
+
+	// Statuses are not published anymore once the token is forbidden to publish any of them.
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// protectedChecks returns the required status checks of the base branch of the ref, other than the jobs of the gate
+// and the statuses it publishes, which never succeed before the gate does. Reading the protection requires a token
+// with the administration permission of the repository.
+func protectedChecks(ctx context.Context, c github.Client, owner, repo string) ([]string, error) {
+	target, err := resolvePolicyTarget(ctx, c, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil || len(target.Base) == 0 {
+		return nil, errors.New("base branch of the ref is unknown, which is required by --protected-checks")
+	}
+	p, res, err := c.GetBranchProtection(ctx, owner, repo, target.Base)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("branch %s is not protected, which is required by --protected-checks", target.Base)
+		}
+		return nil, fmt.Errorf("failed to get protection of %s: %w", target.Base, err)
+	}
+
+	gate := make(map[string]bool)
+	for _, s := range strings.Split(selfJobName, ",") {
+		s = strings.TrimSpace(s)
+		gate[s] = true
+		if _, job, ok := strings.Cut(s, " / "); ok {
+			gate[strings.TrimSpace(job)] = true
+		}
+	}
+	for _, name := range publishedStatuses() {
+		gate[name] = true
+	}
+
+	checks := []string{}
+	required := p.GetRequiredStatusChecks()
+	if required != nil && required.Checks != nil {
+		for _, check := range *required.Checks {
+			if !gate[check.Context] {
+				checks = append(checks, check.Context)
+			}
+		}
+	}
+	return checks, nil
+}
+
+// appendMissing appends the checks which are not listed yet.
+func appendMissing(checks []string, more ...string) []string {
+	listed := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		listed[check] = true
+	}
+	for _, check := range more {
+		if !listed[check] {
+			listed[check] = true
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_protectedChecks(t *testing.T) {
+	tests := map[string]struct {
+		protection *github.Protection
+		status     int
+		want       []string
+		wantErr    bool
+	}{
+		"returns the required status checks other than the gate": {
+			protection: &github.Protection{RequiredStatusChecks: &github.RequiredStatusChecks{Checks: &[]*github.RequiredStatusCheck{
+				{Context: "merge-gatekeeper"}, {Context: "backend-gate"}, {Context: "test"}, {Context: "jenkins/lint"},
+			}}},
+			want: []string{"test", "jenkins/lint"},
+		},
+		"returns no checks when none is required": {
+			protection: &github.Protection{},
+			want:       []string{},
+		},
+		"fails on unprotected branches": {
+			status:  http.StatusNotFound,
+			wantErr: true,
+		},
+		"fails when the protection is unavailable": {
+			status:  http.StatusForbidden,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			prNumber = 1
+			selfJobName = "merge-gatekeeper,backend-gate"
+			defer func() {
+				prNumber = 0
+				selfJobName = defaultSelfJobName
+			}()
+
+			var gotBranch string
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					base := "main"
+					return &github.PullRequest{Base: &github.PullRequestBranch{Ref: &base}}, nil, nil
+				},
+				GetBranchProtectionFunc: func(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+					gotBranch = branch
+					if tt.status != 0 {
+						return nil, &github.Response{Response: &http.Response{StatusCode: tt.status}}, errors.New("protection is unavailable")
+					}
+					return tt.protection, nil, nil
+				},
+			}
+			got, err := protectedChecks(context.Background(), c, "owner", "repo")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("protectedChecks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("protectedChecks() = %v, want %v", got, tt.want)
+			}
+			if gotBranch != "main" {
+				t.Errorf("protection of %q is read, want main", gotBranch)
+			}
+		})
+	}
+}
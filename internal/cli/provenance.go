@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+)
+
+const traceSignaturePrefix = "sha256="
+
+// These variables will be set by command line flags.
+var (
+	verifiedTraceFile string
+)
+
+// newProvenance returns where and how the gate ran, recorded in signed traces, so that deployment systems can verify
+// that the gate ran on the commit with the expected policy.
+func newProvenance() *schema.Provenance {
+	p := &schema.Provenance{
+		Repository:  ghRepo,
+		SHA:         ghRef,
+		PullRequest: prNumber,
+		Workflow:    os.Getenv("GITHUB_WORKFLOW_REF"),
+		RunID:       os.Getenv("GITHUB_RUN_ID"),
+		RunAttempt:  os.Getenv("GITHUB_RUN_ATTEMPT"),
+		Version:     cliVersion,
+		PolicySHA:   policySHA,
+		Policy:      appliedPolicy,
+	}
+	if len(policySHA) != 0 {
+		p.PolicyFile = policyFile
+	}
+	if pathChecksOnly {
+		p.RequiredChecks = pathChecks
+	}
+	return p
+}
+
+// signTrace sets the HMAC-SHA256 signature of the trace, computed over its JSON output without the signature like
+// the release readiness report, so that holders of the key can verify the trace was not altered after the gate ran.
+func signTrace(t *schema.Trace, key []byte) error {
+	if len(key) == 0 {
+		return errors.New("signing key is empty")
+	}
+	mac, err := traceMAC(t, key)
+	if err != nil {
+		return err
+	}
+	t.Signature = traceSignaturePrefix + hex.EncodeToString(mac)
+	return nil
+}
+
+// verifyTrace reports whether the signature of the trace matches its content.
+func verifyTrace(t *schema.Trace, key []byte) bool {
+	if !strings.HasPrefix(t.Signature, traceSignaturePrefix) {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(t.Signature, traceSignaturePrefix))
+	if err != nil {
+		return false
+	}
+	want, err := traceMAC(t, key)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, want)
+}
+
+func traceMAC(t *schema.Trace, key []byte) ([]byte, error) {
+	unsigned := *t
+	unsigned.Signature = ""
+	b, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(b)
+	return h.Sum(nil), nil
+}
+
+// verifyTraceCmd verifies the signature of a trace written with --sign-trace, so that deployment systems can check
+// that the gate ran before deploying the commit. It fails unless the trace is signed by the key.
+func verifyTraceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-trace",
+		Short: "Verify the signature of a decision trace written with --sign-trace, by the key of " + signingKeyEnv,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := os.Getenv(signingKeyEnv)
+			if len(key) == 0 {
+				return fmt.Errorf("%s is not set", signingKeyEnv)
+			}
+			b, err := os.ReadFile(verifiedTraceFile)
+			if err != nil {
+				return fmt.Errorf("failed to read trace: %w", err)
+			}
+			t := &schema.Trace{}
+			if err := json.Unmarshal(b, t); err != nil {
+				return fmt.Errorf("failed to parse trace: %w", err)
+			}
+			if t.Provenance == nil || !verifyTrace(t, []byte(key)) {
+				return fmt.Errorf("trace %s is not signed by the key", verifiedTraceFile)
+			}
+			p := t.Provenance
+			cmd.Printf("The trace is signed by the key: %s of %s@%s, by policy %q of %s\n", t.Result, p.Repository, p.SHA, p.Policy, p.PolicySHA)
+			return nil
+		},
+	}
+	// The trace is verified without calling the API, so the token required by the other commands is not.
+	cmd.PersistentFlags().StringVarP(&ghToken, "token", "t", "", "set github token. not used")
+	cmd.PersistentFlags().MarkHidden("token")
+	cmd.Flags().StringVar(&verifiedTraceFile, "file", "", "set path of the trace to verify")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
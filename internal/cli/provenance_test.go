@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+)
+
+func Test_signTrace(t *testing.T) {
+	eta := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	tr := &trace{
+		Result:     traceResultSuccess,
+		Validators: []*validatorTrace{{Name: "validator-1", Success: true, ETA: &eta, Notes: []string{"note"}}},
+		Provenance: &schema.Provenance{Repository: "owner/repo", SHA: "sha", Policy: "release", PolicySHA: "blob-sha"},
+		signingKey: []byte("key"),
+	}
+	path := filepath.Join(t.TempDir(), "trace.json")
+	if err := writeTrace(path, tr); err != nil {
+		t.Fatalf("writeTrace() error = %v", err)
+	}
+	read := func(t *testing.T) *schema.Trace {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := &schema.Trace{}
+		if err := json.Unmarshal(b, got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	tests := map[string]struct {
+		key    string
+		modify func(t *schema.Trace)
+		want   bool
+	}{
+		"verifies the written trace by the key": {
+			key:  "key",
+			want: true,
+		},
+		"rejects other keys": {
+			key:  "other",
+			want: false,
+		},
+		"rejects altered results": {
+			key:    "key",
+			modify: func(t *schema.Trace) { t.Result = traceResultFailure },
+			want:   false,
+		},
+		"rejects altered provenance": {
+			key:    "key",
+			modify: func(t *schema.Trace) { t.Provenance.SHA = "other" },
+			want:   false,
+		},
+		"rejects unsigned traces": {
+			key:    "key",
+			modify: func(t *schema.Trace) { t.Signature = "" },
+			want:   false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := read(t)
+			if tt.modify != nil {
+				tt.modify(got)
+			}
+			if ok := verifyTrace(got, []byte(tt.key)); ok != tt.want {
+				t.Errorf("verifyTrace() = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func Test_signTrace_emptyKey(t *testing.T) {
+	if err := signTrace(&schema.Trace{}, nil); err == nil {
+		t.Error("signTrace() error = nil, want error")
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/reconcile"
+)
+
+// These variables will be set by command line flags.
+var (
+	reconcileBranch string
+	reconcileFix    bool
+)
+
+// reconcileCmd compares the checks as configured for the gate with the required status checks of branch protection,
+// which otherwise drift apart as either is edited without the other.
+func reconcileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Report, or fix, drift between the checks of the gate and the required status checks of branch protection",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			client := newClient(ctx)
+			branch := reconcileBranch
+			if len(branch) == 0 {
+				r, _, err := client.GetRepository(ctx, owner, repo)
+				if err != nil {
+					return fmt.Errorf("failed to get repository: %w", err)
+				}
+				branch = r.GetDefaultBranch()
+			}
+
+			classifier, err := gateClassifier(client, owner, repo, branch)
+			if err != nil {
+				return err
+			}
+			gate := reconcile.Gate{
+				Required: gateRequiredChecks(selfJob(), waitFor),
+				Ignores:  classifier.Ignores,
+			}
+
+			cmd.SilenceUsage = true
+			report, err := reconcile.Reconcile(ctx, client, owner, repo, branch, gate, reconcileFix)
+			if err != nil {
+				return err
+			}
+			for _, d := range report.Drifts {
+				cmd.Println(driftSummary(d))
+			}
+			switch {
+			case len(report.Drifts) == 0:
+				cmd.Printf("Branch protection of %s agrees with the gate\n", branch)
+			case report.Fixed:
+				cmd.Printf("Updated required status checks of %s\n", branch)
+			case report.Unfixable:
+				return fmt.Errorf("%s is protected without required status checks, which must be enabled by hand", branch)
+			default:
+				return fmt.Errorf("branch protection of %s drifted from the gate in %d checks", branch, len(report.Drifts))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+	cmd.Flags().StringVar(&reconcileBranch, "branch", "", "set protected branch to reconcile. the default branch of the repository when empty")
+	cmd.Flags().BoolVar(&reconcileFix, "fix", false, "set to update the required status checks of the branch to remove the drift, instead of failing on it")
+	cmd.Flags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name")
+	cmd.Flags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs by their names or qualified by their workflows (comma-separated list)")
+	cmd.Flags().StringVar(&ignoredWorkflows, "ignored-workflows", "", "set workflows whose jobs are all ignored (comma-separated list of workflow names)")
+	cmd.Flags().StringVar(&ignoredApps, "ignored-apps", "", "set GitHub Apps whose check runs are all ignored (comma-separated list of app slugs)")
+	cmd.Flags().StringVar(&rules, "rules", "", "set ordered rules, one per line or separated by semicolons, evaluated before the ignored jobs, workflows and apps")
+	cmd.Flags().StringVar(&waitFor, "wait-for", "", "set check runs or status contexts the gate waits for (comma-separated list)")
+
+	return cmd
+}
+
+// gateRequiredChecks returns the checks required by the gate: the gate itself, followed by the checks it waits for.
+func gateRequiredChecks(self, waitFor string) []string {
+	checks := []string{self}
+	for _, s := range strings.Split(waitFor, ",") {
+		if name := strings.TrimSpace(s); len(name) != 0 {
+			checks = append(checks, name)
+		}
+	}
+	return checks
+}
+
+func driftSummary(d reconcile.Drift) string {
+	if d.Kind == reconcile.DriftIgnored {
+		return fmt.Sprintf("- %s is required by branch protection, but ignored by the gate by %s", d.Check, d.Rule)
+	}
+	return fmt.Sprintf("- %s is required by the gate, but not by branch protection", d.Check)
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/reconcile"
+)
+
+func TestGateRequiredChecks(t *testing.T) {
+	got := gateRequiredChecks("merge-gatekeeper", "compliance-scan, ,license")
+	want := []string{"merge-gatekeeper", "compliance-scan", "license"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gateRequiredChecks() = %v, want %v", got, want)
+	}
+}
+
+func TestDriftSummary(t *testing.T) {
+	tests := map[string]struct {
+		drift reconcile.Drift
+		want  string
+	}{
+		"ignored": {
+			drift: reconcile.Drift{Check: "nightly", Kind: reconcile.DriftIgnored, Rule: "ignored-jobs"},
+			want:  "- nightly is required by branch protection, but ignored by the gate by ignored-jobs",
+		},
+		"unprotected": {
+			drift: reconcile.Drift{Check: "merge-gatekeeper", Kind: reconcile.DriftUnprotected},
+			want:  "- merge-gatekeeper is required by the gate, but not by branch protection",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := driftSummary(tt.drift); got != tt.want {
+				t.Errorf("driftSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
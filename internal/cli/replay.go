@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// These variables will be set by command line flags.
+var (
+	replayEvent string
+)
+
+// webhookDelivery is the subset of a webhook delivery, as saved from the API of the webhook deliveries
+// or the settings of the webhook, which is used to replay it.
+type webhookDelivery struct {
+	Event   string `json:"event"`
+	Request *struct {
+		Headers map[string]string `json:"headers"`
+		Payload json.RawMessage   `json:"payload"`
+	} `json:"request"`
+}
+
+// replayWebhookCmd validates as if the workflow was triggered by the saved webhook delivery, so that the evaluation
+// of a specific delivery can be reproduced locally. Webhook payloads are the payloads of the events of workflows,
+// so the delivery is fed into the same path resolving the target from the event of the workflow.
+func replayWebhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-webhook DELIVERY [-- VALIDATE FLAGS]",
+		Short: "Validate as if triggered by a saved webhook delivery, e.g) to reproduce the evaluation of a delivery",
+		Long: "Validate as if triggered by a saved webhook delivery, e.g) to reproduce the evaluation of a delivery.\n" +
+			"DELIVERY is a JSON file of either a delivery as returned by the API of webhook deliveries, or a bare payload along with --event. " +
+			"The signature of the delivery is not verified. Flags after -- are passed to the validate command.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read webhook delivery: %w", err)
+			}
+			name, payload, err := parseWebhookDelivery(b, replayEvent)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.CreateTemp("", "merge-gatekeeper-event-*.json")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(f.Name())
+			if _, err := f.Write(payload); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			var repo struct {
+				Repository *eventRepository `json:"repository"`
+			}
+			if err := json.Unmarshal(payload, &repo); err == nil && repo.Repository != nil {
+				os.Setenv("GITHUB_REPOSITORY", repo.Repository.FullName)
+			}
+			os.Setenv("GITHUB_EVENT_NAME", name)
+			os.Setenv("GITHUB_EVENT_PATH", f.Name())
+			cmd.Printf("Replaying %s event of %s\n", name, args[0])
+
+			// The ref is resolved from the event, unless it is given after --.
+			validate := validateCmd()
+			validate.SetArgs(append([]string{"--ref="}, args[1:]...))
+			validate.SetOut(cmd.OutOrStdout())
+			validate.SetErr(cmd.ErrOrStderr())
+			// Errors are reported once by this command.
+			validate.SilenceErrors = true
+			return validate.ExecuteContext(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&replayEvent, "event", "", "set name of the event of the delivery, e.g) pull_request. read from the delivery when empty")
+
+	return cmd
+}
+
+// parseWebhookDelivery returns the name of the event and the payload of the delivery. The event given by --event
+// takes precedence over the one recorded in the delivery.
+func parseWebhookDelivery(b []byte, event string) (string, []byte, error) {
+	d := &webhookDelivery{}
+	if err := json.Unmarshal(b, d); err != nil {
+		return "", nil, fmt.Errorf("failed to parse webhook delivery: %w", err)
+	}
+
+	payload := b
+	if d.Request != nil && len(d.Request.Payload) != 0 {
+		payload = d.Request.Payload
+		if len(event) == 0 {
+			event = d.Event
+		}
+		if len(event) == 0 {
+			event = d.Request.Headers["X-GitHub-Event"]
+		}
+	}
+	if len(event) == 0 {
+		return "", nil, errors.New("event of the webhook delivery is unknown, set it by --event")
+	}
+	return event, payload, nil
+}
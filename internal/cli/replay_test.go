@@ -0,0 +1,59 @@
+package cli
+
+import "testing"
+
+func Test_parseWebhookDelivery(t *testing.T) {
+	tests := map[string]struct {
+		delivery    string
+		event       string
+		wantEvent   string
+		wantPayload string
+		wantErr     bool
+	}{
+		"returns the event and payload of a delivery": {
+			delivery:    `{"id":1,"event":"pull_request","request":{"headers":{"X-GitHub-Event":"pull_request"},"payload":{"number":1}}}`,
+			wantEvent:   "pull_request",
+			wantPayload: `{"number":1}`,
+		},
+		"returns the event in the headers of a delivery": {
+			delivery:    `{"id":1,"request":{"headers":{"X-GitHub-Event":"workflow_run"},"payload":{"number":1}}}`,
+			wantEvent:   "workflow_run",
+			wantPayload: `{"number":1}`,
+		},
+		"prefers the given event": {
+			delivery:    `{"id":1,"event":"pull_request","request":{"payload":{"number":1}}}`,
+			event:       "pull_request_target",
+			wantEvent:   "pull_request_target",
+			wantPayload: `{"number":1}`,
+		},
+		"returns a bare payload along with the given event": {
+			delivery:    `{"number":1}`,
+			event:       "pull_request",
+			wantEvent:   "pull_request",
+			wantPayload: `{"number":1}`,
+		},
+		"returns error when the event of a bare payload is not given": {
+			delivery: `{"number":1}`,
+			wantErr:  true,
+		},
+		"returns error when the delivery is not JSON": {
+			delivery: `number: 1`,
+			event:    "pull_request",
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			event, payload, err := parseWebhookDelivery([]byte(tt.delivery), tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWebhookDelivery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if event != tt.wantEvent {
+				t.Errorf("parseWebhookDelivery() event = %s, want %s", event, tt.wantEvent)
+			}
+			if string(payload) != tt.wantPayload {
+				t.Errorf("parseWebhookDelivery() payload = %s, want %s", payload, tt.wantPayload)
+			}
+		})
+	}
+}
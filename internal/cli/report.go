@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/release"
+	"github.com/aac228/merge-gatekeeper/internal/slo"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/attestation"
+	"github.com/aac228/merge-gatekeeper/internal/validators/deployment"
+	"github.com/aac228/merge-gatekeeper/internal/validators/review"
+	"github.com/aac228/merge-gatekeeper/internal/validators/status"
+)
+
+// Gates which can be evaluated for the release readiness report.
+const (
+	gateChecks       = "checks"
+	gateDeployments  = "deployments"
+	gateApprovals    = "approvals"
+	gateAttestations = "attestations"
+)
+
+// signingKeyEnv holds the key to sign the release readiness report. It is read from the environment,
+// so that the key does not show up in the process arguments.
+const signingKeyEnv = "MERGE_GATEKEEPER_SIGNING_KEY"
+
+// These variables will be set by command line flags.
+var (
+	releaseGates        string
+	releaseEnvironments string
+	releaseApprovals    int
+	releaseFormat       string
+	releaseOutput       string
+
+	sloWindow    time.Duration
+	sloTarget    time.Duration
+	sloObjective float64
+	sloFormat    string
+	sloOutput    string
+)
+
+func reportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate reports from the state of github actions jobs",
+	}
+	cmd.AddCommand(releaseReportCmd())
+	cmd.AddCommand(sloReportCmd())
+	return cmd
+}
+
+func releaseReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Generate the readiness report of a tag or release branch",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+			if len(ghRef) == 0 {
+				return fmt.Errorf("ref of the release is empty")
+			}
+			if err := release.ValidateFormat(releaseFormat); err != nil {
+				return err
+			}
+
+			client := newClient(ctx)
+			ref := ghRef
+			if err := resolveRef(ctx, client, owner, repo, cmd); err != nil {
+				return err
+			}
+
+			vs, err := releaseValidators(client, owner, repo)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			return doReleaseReportCmd(ctx, cmd, ghRepo, ref, vs...)
+		},
+	}
+
+	cmd.Flags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+	cmd.Flags().StringVar(&ghRef, "ref", "", "set tag or release branch to report")
+	cmd.MarkFlagRequired("ref")
+	cmd.Flags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name")
+	cmd.Flags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs by their names or qualified by their workflows, e.g) Nightly / build (comma-separated list). entries starting with ^ or ending with $ are regular expressions, e.g) ^lint-.*$")
+
+	cmd.Flags().StringVar(&releaseGates, "gates", strings.Join([]string{gateChecks, gateDeployments, gateApprovals}, ","),
+		fmt.Sprintf("set gates to evaluate (comma-separated list of %s, %s, %s and %s)", gateChecks, gateDeployments, gateApprovals, gateAttestations))
+	cmd.Flags().StringVar(&releaseEnvironments, "environments", "", "set environments which must have been deployed (comma-separated list). every deployed environment is required when empty")
+	cmd.Flags().IntVar(&releaseApprovals, "min-approvals", 1, "set how many reviewers must have approved the pull request of the release")
+	cmd.Flags().StringVar(&attestedArtifacts, "attestation-artifacts", "", "set artifacts which must be attested (comma-separated list). every artifact of the ref must be attested when empty")
+	cmd.Flags().StringVar(&attestedPredicates, "attestation-predicates", attestation.PredicateSLSAProvenance, "set accepted predicate types of attestations (comma-separated list)")
+	cmd.Flags().StringVar(&releaseFormat, "format", release.FormatMarkdown, fmt.Sprintf("set format of the report (%s or %s)", release.FormatMarkdown, release.FormatJSON))
+	cmd.Flags().StringVarP(&releaseOutput, "output", "o", "", "write the report to the file instead of stdout")
+
+	return cmd
+}
+
+func releaseValidators(c github.Client, owner, repo string) ([]validators.Validator, error) {
+	var vs []validators.Validator
+	for _, s := range strings.Split(releaseGates, ",") {
+		var (
+			v   validators.Validator
+			err error
+		)
+		switch gate := strings.TrimSpace(s); gate {
+		case "":
+			continue
+		case gateChecks:
+			v, err = status.CreateValidator(c,
+				status.WithSelfJob(selfJobName),
+				status.WithGitHubOwnerAndRepo(owner, repo),
+				status.WithGitHubRef(ghRef),
+				status.WithIgnoredJobs(ignoredJobs),
+			)
+		case gateDeployments:
+			v, err = deployment.CreateValidator(c,
+				deployment.WithGitHubOwnerAndRepo(owner, repo),
+				deployment.WithGitHubRef(ghRef),
+				deployment.WithEnvironments(releaseEnvironments),
+			)
+		case gateAttestations:
+			v, err = attestation.CreateValidator(c,
+				attestation.WithGitHubOwnerAndRepo(owner, repo),
+				attestation.WithGitHubRef(ghRef),
+				attestation.WithArtifacts(attestedArtifacts),
+				attestation.WithPredicateTypes(attestedPredicates),
+			)
+		case gateApprovals:
+			v, err = review.CreateValidator(c,
+				review.WithGitHubOwnerAndRepo(owner, repo),
+				review.WithGitHubRef(ghRef),
+				review.WithMinApprovals(releaseApprovals),
+			)
+		default:
+			return nil, fmt.Errorf("unsupported gate: %s, supported gates: [%s %s %s %s]", gate, gateChecks, gateDeployments, gateApprovals, gateAttestations)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(vs) == 0 {
+		return nil, fmt.Errorf("no gates to evaluate")
+	}
+	return vs, nil
+}
+
+// doReleaseReportCmd writes the readiness report, and fails when the release is not ready, so that pipelines can
+// stop before the change-advisory submission.
+func doReleaseReportCmd(ctx context.Context, logger logger, repository, ref string, vs ...validators.Validator) error {
+	defer debug(logger, "release report")()
+
+	r := release.New(repository, ref, ghRef, clk.Now(), release.Evaluate(ctx, vs...))
+	if key := os.Getenv(signingKeyEnv); len(key) != 0 {
+		if err := r.Sign([]byte(key)); err != nil {
+			return fmt.Errorf("failed to sign report: %w", err)
+		}
+	} else {
+		logger.PrintErrf("WARNING: %s is not set, the report is not signed\n", signingKeyEnv)
+	}
+
+	out, err := r.Render(releaseFormat)
+	if err != nil {
+		return err
+	}
+	if len(releaseOutput) != 0 {
+		if err := os.WriteFile(releaseOutput, []byte(out), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	} else {
+		logger.Print(out)
+	}
+
+	if !r.Ready {
+		return fmt.Errorf("%s is not ready for release", ref)
+	}
+	return nil
+}
+
+func sloReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slo",
+		Short: "Generate the report of gate durations against the service level objective",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+			if err := slo.ValidateFormat(sloFormat); err != nil {
+				return err
+			}
+
+			t, err := slo.New(newClient(ctx),
+				slo.WithGitHubOwnerAndRepo(owner, repo),
+				slo.WithSelfJob(selfJob()),
+				slo.WithWindow(sloWindow),
+				slo.WithTarget(sloTarget),
+				slo.WithObjective(sloObjective),
+				slo.WithClock(clk),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create tracker: %w", err)
+			}
+
+			cmd.SilenceUsage = true
+			return doSLOReportCmd(ctx, cmd, t)
+		},
+	}
+
+	cmd.Flags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+	cmd.Flags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name, whose check runs measure the gates")
+	cmd.Flags().DurationVar(&sloWindow, "window", 7*24*time.Hour, "set how far back merged pull requests are measured")
+	cmd.Flags().DurationVar(&sloTarget, "target", slo.DefaultTarget, "set the duration within which gates are expected to complete")
+	cmd.Flags().Float64Var(&sloObjective, "objective", slo.DefaultObjective, "set the ratio of gates expected to complete within the target")
+	cmd.Flags().StringVar(&sloFormat, "format", slo.FormatMarkdown,
+		fmt.Sprintf("set format of the report (%s, %s or %s)", slo.FormatMarkdown, slo.FormatJSON, slo.FormatPrometheus))
+	cmd.Flags().StringVarP(&sloOutput, "output", "o", "", "write the report to the file instead of stdout")
+
+	return cmd
+}
+
+// doSLOReportCmd writes the report of gate durations. Missing the objective does not fail the command,
+// as the report is meant to be collected as a metric rather than to gate anything.
+func doSLOReportCmd(ctx context.Context, logger logger, t *slo.Tracker) error {
+	defer debug(logger, "slo report")()
+
+	r, err := t.Report(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to measure gates: %w", err)
+	}
+	out, err := r.Render(sloFormat)
+	if err != nil {
+		return err
+	}
+	if len(sloOutput) != 0 {
+		if err := os.WriteFile(sloOutput, []byte(out), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		return nil
+	}
+	logger.Print(out)
+	return nil
+}
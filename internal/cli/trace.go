@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const (
+	traceResultSuccess = "success"
+	traceResultFailure = "failure"
+	traceResultTimeout = "timeout"
+	// traceResultPending is only exported by --export, as the gate otherwise waits until it is decided.
+	traceResultPending = "pending"
+	// traceResultDisabled is neither a success nor a failure, as nothing was validated.
+	traceResultDisabled = "disabled"
+)
+
+// trace is the structured rationale of the final decision, written as JSON when requested.
+// It is written as schema.Trace, so that the JSON output does not follow changes of these fields.
+type trace struct {
+	Result     string
+	Validators []*validatorTrace
+	Usage      *usage
+	// Provenance and the signing key are only set with --sign-trace.
+	Provenance *schema.Provenance
+	signingKey []byte
+}
+
+type validatorTrace struct {
+	Name      string
+	Success   bool
+	Error     string
+	Decisions []validators.Decision
+	Groups    []validators.Group
+	ETA       *time.Time
+	Notes     []string
+}
+
+func newTrace(err error, results []*result) *trace {
+	t := &trace{Validators: make([]*validatorTrace, 0, len(results))}
+	switch {
+	case err == nil:
+		t.Result = traceResultSuccess
+	case errors.Is(err, context.DeadlineExceeded):
+		t.Result = traceResultTimeout
+	default:
+		t.Result = traceResultFailure
+	}
+
+	for _, r := range results {
+		vt := &validatorTrace{Name: r.name}
+		if r.err != nil {
+			vt.Error = r.err.Error()
+		}
+		if r.status != nil {
+			vt.Success = r.status.IsSuccess()
+			if tr, ok := r.status.(validators.Tracer); ok {
+				vt.Decisions = tr.Decisions()
+			}
+			if gr, ok := r.status.(validators.Grouper); ok {
+				vt.Groups = gr.Groups()
+			}
+			if nt, ok := r.status.(validators.Noter); ok {
+				vt.Notes = nt.Notes()
+			}
+			if es, ok := r.status.(validators.Estimator); ok {
+				if eta, ok := es.ETA(); ok {
+					vt.ETA = &eta
+				}
+			}
+		}
+		t.Validators = append(t.Validators, vt)
+	}
+	return t
+}
+
+func writeTrace(path string, t *trace) error {
+	out := t.versioned()
+	if len(t.signingKey) != 0 {
+		if err := signTrace(out, t.signingKey); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// versioned converts the trace into its versioned JSON output.
+func (t *trace) versioned() *schema.Trace {
+	out := &schema.Trace{
+		Header:     schema.NewHeader(schema.KindTrace),
+		Result:     t.Result,
+		Validators: make([]schema.Validator, 0, len(t.Validators)),
+	}
+	for _, vt := range t.Validators {
+		out.Validators = append(out.Validators, schema.Validator{
+			Name:      vt.Name,
+			Success:   vt.Success,
+			Error:     vt.Error,
+			Decisions: schema.FromDecisions(vt.Decisions),
+			Groups:    schema.FromGroups(vt.Groups),
+			ETA:       vt.ETA,
+			Notes:     vt.Notes,
+		})
+	}
+	if t.Usage != nil {
+		out.Usage = t.Usage.versioned()
+	}
+	out.Provenance = t.Provenance
+	return out
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/upgrade"
+)
+
+// noticeUpgrade annotates the run when the running version is behind the latest release, along with the defaults
+// changed since, so that users pinned on old tags notice them. It never fails, as the check is only informational.
+func noticeUpgrade(ctx context.Context, logger logger, c github.Client) {
+	n, err := upgrade.Check(ctx, c, cliVersion)
+	if err != nil {
+		logger.PrintErrf("WARNING: failed to check the latest release: %v\n", err)
+		return
+	}
+	if n == nil {
+		return
+	}
+	logger.Printf("::notice title=Merge Gatekeeper upgrade available::%s\n", msgs.Sprintf(i18n.UpgradeAvailable, n.Latest, n.Current))
+	for _, d := range n.ChangedDefaults {
+		logger.Printf("::warning title=Merge Gatekeeper default changed::%s\n", msgs.Sprintf(i18n.UpgradeChangedDefault, n.Current, d))
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_noticeUpgrade(t *testing.T) {
+	tag, body := "v1.3.0", "## Changed defaults\n\n- `summary` defaults to `true`"
+	tests := map[string]struct {
+		version string
+		err     error
+		want    string
+	}{
+		"notices newer release with its changed defaults": {
+			version: "v1.2.1",
+			want: "::notice title=Merge Gatekeeper upgrade available::Merge Gatekeeper v1.3.0 is available, while v1.2.1 is running. Update the version of the action to get the latest fixes.\n" +
+				"::warning title=Merge Gatekeeper default changed::Default changed since v1.2.1, which applies once updated: v1.3.0: `summary` defaults to `true`\n",
+		},
+		"does not notice the latest release": {
+			version: "v1.3.0",
+		},
+		"warns without failing when releases are unavailable": {
+			version: "v1.2.1",
+			err:     errors.New("not found"),
+			want:    "WARNING: failed to check the latest release: failed to list releases: not found\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			version := cliVersion
+			cliVersion = tt.version
+			defer func() { cliVersion = version }()
+
+			c := &mock.Client{
+				ListReleasesFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+					if tt.err != nil {
+						return nil, nil, tt.err
+					}
+					return []*github.RepositoryRelease{{TagName: &tag, Body: &body}}, nil, nil
+				},
+			}
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			noticeUpgrade(context.Background(), cmd, c)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("noticeUpgrade() logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
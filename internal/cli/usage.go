@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+)
+
+// usage is how much the Merge Gatekeeper job itself consumed while waiting for the validation to complete,
+// which is what running it on webhooks instead of a runner would save.
+type usage struct {
+	Waited time.Duration
+	Polls  int
+	API    *github.APIStats // Requests made to the API, with api-stats.
+}
+
+// RunnerMinutes returns the billable runner minutes of the wait, as GitHub rounds each job up to the whole minute.
+func (u *usage) RunnerMinutes() int {
+	minutes := int(u.Waited / time.Minute)
+	if u.Waited%time.Minute != 0 {
+		minutes++
+	}
+	return minutes
+}
+
+// versioned converts the usage into its versioned JSON output.
+func (u *usage) versioned() *schema.Usage {
+	v := &schema.Usage{
+		WaitedSeconds: u.Waited.Seconds(),
+		Polls:         u.Polls,
+		RunnerMinutes: u.RunnerMinutes(),
+	}
+	if u.API != nil {
+		v.API = &schema.API{
+			Requests:           u.API.Requests,
+			Endpoints:          make([]schema.APIEndpoint, 0, len(u.API.Endpoints)),
+			Pages:              u.API.Pages,
+			CacheHits:          u.API.CacheHits,
+			Retries:            u.API.Retries,
+			RateLimitRemaining: u.API.RateLimitRemaining,
+		}
+		for _, e := range u.API.Endpoints {
+			v.API.Endpoints = append(v.API.Endpoints, schema.APIEndpoint{Endpoint: e.Endpoint, Requests: e.Requests})
+		}
+	}
+	return v
+}
+
+// logAPIStats logs the requests made to the API, the most requested endpoints first.
+func logAPIStats(logger logger, st *github.APIStats) {
+	logger.Println(msgs.Sprintf(i18n.ValidationAPIStats, st.Requests, st.Pages, st.CacheHits, st.Retries))
+	for _, e := range st.Endpoints {
+		logger.Println(msgs.Sprintf(i18n.ValidationAPIEndpoint, e.Requests, e.Endpoint))
+	}
+	if st.RateLimitRemaining != nil {
+		logger.Println(msgs.Sprintf(i18n.ValidationAPIRemaining, *st.RateLimitRemaining))
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+func Test_usage_RunnerMinutes(t *testing.T) {
+	tests := map[string]struct {
+		waited time.Duration
+		want   int
+	}{
+		"returns zero when not waited":  {waited: 0, want: 0},
+		"rounds up to the whole minute": {waited: 61 * time.Second, want: 2},
+		"returns exact minutes":         {waited: 3 * time.Minute, want: 3},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := &usage{Waited: tt.waited}
+			if got := u.RunnerMinutes(); got != tt.want {
+				t.Errorf("RunnerMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_usage_versioned(t *testing.T) {
+	b, err := json.Marshal((&usage{Waited: 90 * time.Second, Polls: 10}).versioned())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"waited_seconds":90,"polls":10,"runner_minutes":2}`
+	if string(b) != want {
+		t.Errorf("versioned() = %s, want %s", b, want)
+	}
+}
+
+func Test_usage_versioned_api(t *testing.T) {
+	remaining := 4990
+	u := &usage{Waited: 30 * time.Second, Polls: 3, API: &github.APIStats{
+		Requests: 10,
+		Endpoints: []github.EndpointStats{
+			{Endpoint: "GET /repos/owner/repo/commits/:sha/check-runs", Requests: 6},
+			{Endpoint: "POST /graphql", Requests: 4},
+		},
+		Pages:              2,
+		CacheHits:          1,
+		RateLimitRemaining: &remaining,
+	}}
+	b, err := json.Marshal(u.versioned())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"waited_seconds":30,"polls":3,"runner_minutes":1,"api":{"requests":10,"endpoints":[` +
+		`{"endpoint":"GET /repos/owner/repo/commits/:sha/check-runs","requests":6},{"endpoint":"POST /graphql","requests":4}],` +
+		`"pages":2,"cache_hits":1,"retries":0,"rate_limit_remaining":4990}}`
+	if string(b) != want {
+		t.Errorf("versioned() = %s, want %s", b, want)
+	}
+}
@@ -2,17 +2,44 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/flagservice"
 	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/notify/automerge"
+	"github.com/aac228/merge-gatekeeper/internal/notify/escalation"
+	"github.com/aac228/merge-gatekeeper/internal/notify/flaky"
+	"github.com/aac228/merge-gatekeeper/internal/notify/labels"
+	"github.com/aac228/merge-gatekeeper/internal/owners"
+	"github.com/aac228/merge-gatekeeper/internal/policy"
+	"github.com/aac228/merge-gatekeeper/internal/profile"
+	"github.com/aac228/merge-gatekeeper/internal/report"
 	"github.com/aac228/merge-gatekeeper/internal/ticker"
 	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/artifact"
+	"github.com/aac228/merge-gatekeeper/internal/validators/attestation"
+	"github.com/aac228/merge-gatekeeper/internal/validators/benchmark"
+	"github.com/aac228/merge-gatekeeper/internal/validators/coverage"
+	"github.com/aac228/merge-gatekeeper/internal/validators/featureflag"
+	"github.com/aac228/merge-gatekeeper/internal/validators/junit"
+	"github.com/aac228/merge-gatekeeper/internal/validators/migration"
+	"github.com/aac228/merge-gatekeeper/internal/validators/milestone"
+	"github.com/aac228/merge-gatekeeper/internal/validators/pause"
+	"github.com/aac228/merge-gatekeeper/internal/validators/review"
+	"github.com/aac228/merge-gatekeeper/internal/validators/stale"
 	"github.com/aac228/merge-gatekeeper/internal/validators/status"
+	"github.com/aac228/merge-gatekeeper/internal/validators/terraform"
+	"github.com/aac228/merge-gatekeeper/internal/validators/vulnerability"
+	"github.com/aac228/merge-gatekeeper/internal/validators/waitfor"
 )
 
 const defaultSelfJobName = "merge-gatekeeper"
@@ -21,12 +48,128 @@ const defaultSelfJobName = "merge-gatekeeper"
 var (
 	ghRepo              string // e.g) upsidr/merge-gatekeeper
 	ghRef               string
+	prNumber            int
 	timeoutSecond       uint
 	validateInvalSecond uint
+	pollErrorBudget     string
+	incidentCheck       bool
+	incidentInterval    uint
 	selfJobName         string
+	ignoreSelfRun       bool
+	ignoreOtherGates    bool
 	ignoredJobs         string
+	ignoredWorkflows    string
+	ignoredApps         string
+	minChecks           int
+	failOnNoJobs        bool
+	includeStatuses     bool
+	anyOf               string
+	quorums             string
+	statusPrefixes      string
+	ignoredStatusPrefix string
+	protectedOnly       bool
+	skippedAs           string
+	neutralAs           string
+	cancelledAs         string
+	actionRequiredAs    string
+	staleAs             string
+	rules               string
+	explainCheck        string
+	waitFor             string
+	traceFile           string
+	traceSigned         bool
+	stepSummary         bool
+	summaryFormat       string
+	summaryEmoji        bool
+	summaryDetails      bool
+	summaryChanges      bool
+	logChanges          bool
+	exportStatus        bool
+	criticalPath        bool
+	rollupCache         bool
+	checksAPI           string
+	locale              string
+	messagesFile        string
+	auditWindow         time.Duration
+	auditRequired       string
+	auditIssues         bool
+	auditWorkflows      bool
+	apiStats            bool
+	checkVersion        bool
+	attestations        bool
+	attestedArtifacts   string
+	attestedPredicates  string
+	requiredArtifacts   string
+	coverageArtifact    string
+	coverageFile        string
+	coverageFormat      string
+	coverageMin         float64
+	coverageBase        string
+	coverageMaxDecrease float64
+	junitArtifacts      string
+	benchmarkArtifact   string
+	benchmarkBase       string
+	benchmarkThreshold  float64
+	scanArtifacts       string
+	scanSeverity        string
+	scanIgnored         string
+	terraformArtifact   string
+	terraformCheckRun   string
+	terraformLabel      string
+	migrationPaths      string
+	migrationTestJob    string
+	migrationTeam       string
+	flagService         string
+	flagServiceURL      string
+	flagServiceToken    string
+	flagProject         string
+	flagEnvironment     string
+	flagPattern         string
+	flagStates          string
+	jiraURL             string
+	jiraUser            string
+	jiraToken           string
+	jiraTransition      string
+	jiraProjects        string
+	escalationService   string
+	escalationKey       string
+	escalationURL       string
+	escalationLabels    string
+	escalationSLA       time.Duration
+	emailServer         string
+	emailUser           string
+	emailPassword       string
+	emailFrom           string
+	emailTo             string
+	flakyIssues         bool
+	flakyThreshold      int
+	ownersFile          string
+	autoMerge           bool
+	autoMergeAuthors    string
+	autoMergeTypes      string
+	autoMergeMethod     string
+	gateLabels          bool
+	deployWebhook       string
+	pausable            bool
+	minApprovals        int
+	twoPersonPaths      string
+	discountPushers     bool
+	approvalFreshness   string
+	staleAfter          time.Duration
+	staleResult         string
+	staleComment        bool
+	profileSpec         string
+	optionalStatus      bool
+	nameTemplate        string
+	milestoneRequired   string
 )
 
+// msgs renders user facing messages of the run loop.
+var msgs = i18n.Default()
+
+// clk drives the run loop. It is replaced in tests to control the passage of time.
+var clk = clock.New()
+
 func validateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -45,36 +188,515 @@ func validateCmd() *cobra.Command {
 				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
 			}
 
-			statusValidator, err := status.CreateValidator(github.NewClient(ctx, ghToken),
-				status.WithSelfJob(selfJobName),
-				status.WithGitHubOwnerAndRepo(owner, repo),
-				status.WithGitHubRef(ghRef),
-				status.WithIgnoredJobs(ignoredJobs),
-			)
+			catalog, err := i18n.New(locale)
 			if err != nil {
-				return fmt.Errorf("failed to create validator: %w", err)
+				return err
+			}
+			if len(messagesFile) != 0 {
+				if err := catalog.LoadOverrides(messagesFile); err != nil {
+					return fmt.Errorf("failed to load messages file: %w", err)
+				}
+			}
+			msgs = catalog
+
+			if isDisabled(cmd) {
+				cmd.SilenceUsage = true
+				return doDisabledCmd(cmd)
+			}
+
+			client := newClient(ctx)
+			if checkVersion {
+				noticeUpgrade(ctx, cmd, client)
+			}
+			if os.Getenv("GITHUB_EVENT_NAME") == eventSchedule {
+				detectReadOnly(ctx, client, owner, repo, cmd)
+				degradeForReadOnly(cmd)
+				cmd.SilenceUsage = true
+				return doAuditCmd(ctx, cmd, client, owner, repo)
+			}
+			if err := resolveTarget(ctx, client, owner, repo, cmd); err != nil {
+				return err
+			}
+			if len(ghRef) == 0 {
+				return errors.New("github ref is empty and can not be resolved from the event. set --ref")
+			}
+			if err := resolveRef(ctx, client, owner, repo, cmd); err != nil {
+				return err
+			}
+			if err := applyPolicy(ctx, cmd, client, owner, repo); err != nil {
+				return err
+			}
+			if err := summaryOptions().Validate(); err != nil {
+				return err
+			}
+			if pollFailureBudget, err = parseErrorBudget(pollErrorBudget, timeoutSecond, validateInvalSecond); err != nil {
+				return err
+			}
+			detectReadOnly(ctx, client, owner, repo, cmd)
+			degradeForReadOnly(cmd)
+
+			vs, err := gateValidators(ctx, client, owner, repo, catalog)
+			if err != nil {
+				return err
+			}
+			if pausable {
+				if pauseGate, err = pause.CreateValidator(client,
+					pause.WithGitHubOwnerAndRepo(owner, repo),
+					pause.WithGitHubRef(ghRef),
+					pause.WithPullRequest(prNumber),
+				); err != nil {
+					return fmt.Errorf("failed to create validator: %w", err)
+				}
+			}
+			if profilePublisher, err = newPublisher(client, owner, repo, cmd); err != nil {
+				return err
+			}
+			if notifiers, err = optionalNotifiers(client, owner, repo); err != nil {
+				return err
 			}
 
 			cmd.SilenceUsage = true
-			return doValidateCmd(ctx, cmd, statusValidator)
+			if len(explainCheck) != 0 {
+				return doExplainCmd(ctx, cmd, explainCheck, vs...)
+			}
+			if exportStatus {
+				return doExportCmd(ctx, cmd, vs...)
+			}
+			return doValidateCmd(ctx, cmd, vs...)
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name")
+	cmd.PersistentFlags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name, optionally qualified by its workflow, e.g) Gate / merge-gatekeeper, so that jobs of the same name in other workflows are not ignored. it may be followed by the jobs of the other instances of the gate, which are ignored too (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&ignoreSelfRun, "ignore-self-run", false, "ignore every job of the workflow run of this job, as found by $GITHUB_RUN_ID, rather than this job by its name. this job must run in a workflow of its own")
+	cmd.PersistentFlags().BoolVar(&ignoreOtherGates, "ignore-other-gates", false, "ignore the jobs running merge-gatekeeper in the workflows of the ref, i.e) other instances of the gate, without naming them in --self")
+	cmd.PersistentFlags().StringVar(&policyFile, "policy-file", policy.DefaultPath, "set path of the file in the repository selecting policies overriding inputs by the base branch, changed paths and author. no policy is applied when empty")
 
 	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
 
-	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
-	cmd.MarkPersistentFlagRequired("ref")
+	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name. resolved from the event when empty")
+	cmd.PersistentFlags().IntVar(&prNumber, "pr", 0, "set pull request number. resolved from the event when zero")
 
 	cmd.PersistentFlags().UintVar(&timeoutSecond, "timeout", 600, "set validate timeout second")
 	cmd.PersistentFlags().UintVar(&validateInvalSecond, "interval", 10, "set validate interval second")
+	cmd.PersistentFlags().StringVar(&pollErrorBudget, "poll-error-budget", "0", "set how many polls may fail with transient API errors before the validation fails, as a count, e.g) 3, or a percentage of the polls until the timeout, e.g) 5%")
+	cmd.PersistentFlags().BoolVar(&incidentCheck, "incident-check", false, "check the status page of GitHub while waiting, and poll every --incident-interval seconds during incidents affecting Actions or the API, tolerating failed polls without spending --poll-error-budget")
+	cmd.PersistentFlags().UintVar(&incidentInterval, "incident-interval", 60, "set validate interval second during incidents of GitHub with --incident-check")
+
+	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs by their names or qualified by their workflows, e.g) Nightly / build (comma-separated list). entries starting with ^ or ending with $ are regular expressions, e.g) ^lint-.*$")
+	cmd.PersistentFlags().StringVar(&ignoredWorkflows, "ignored-workflows", "", "set workflows whose jobs are all ignored (comma-separated list of workflow names)")
+	cmd.PersistentFlags().StringVar(&ignoredApps, "ignored-apps", "", "set GitHub Apps whose check runs are all ignored (comma-separated list of app slugs), e.g) codecov,sonarcloud")
+	cmd.PersistentFlags().StringVar(&skippedAs, "skipped-as", status.SkippedIgnore, fmt.Sprintf("set how skipped jobs, e.g) by paths filters, are treated (%s, %s or %s)", status.SkippedIgnore, status.SkippedSuccess, status.SkippedFailure))
+	cmd.PersistentFlags().StringVar(&neutralAs, "neutral-as", status.NeutralSuccess, fmt.Sprintf("set how neutral conclusions, which some apps use when a human needs to look at them, are treated (%s, %s or %s)", status.NeutralSuccess, status.NeutralPending, status.NeutralFailure))
+	cmd.PersistentFlags().StringVar(&cancelledAs, "cancelled-as", status.CancelledFailure, fmt.Sprintf("set how cancelled jobs, e.g) by concurrency groups, are treated (%s, %s, or %s waiting for them to be re-run)", status.CancelledFailure, status.CancelledIgnore, status.CancelledPending))
+	cmd.PersistentFlags().StringVar(&actionRequiredAs, "action-required-as", status.AttentionFailure, fmt.Sprintf("set how jobs requiring action, e.g) workflow runs awaiting approval, are treated (%s, %s or %s)", status.AttentionFailure, status.AttentionPending, status.AttentionIgnore))
+	cmd.PersistentFlags().StringVar(&staleAs, "stale-checks-as", status.AttentionFailure, fmt.Sprintf("set how stale jobs, which did not complete in time, are treated (%s, %s or %s)", status.AttentionFailure, status.AttentionPending, status.AttentionIgnore))
+	cmd.PersistentFlags().StringVar(&rules, "rules", "", "set ordered rules, one per line or separated by semicolons, evaluated before the ignored jobs, workflows and apps. the first rule matching a check captures it, e.g) require job:lint-security; ignore job:^lint-.*$; ignore workflow:Nightly; ignore app:codecov")
+	cmd.PersistentFlags().StringVar(&explainCheck, "explain", "", "evaluate the gate once and explain which rule captured the check of the name, and which other rules it matches, without gating")
+	cmd.PersistentFlags().BoolVar(&includeStatuses, "include-statuses", false, "validate the contexts of the commit statuses of the ref as jobs along with the check runs, e.g) of Jenkins, other than the statuses published by this job")
+	cmd.PersistentFlags().StringVar(&statusPrefixes, "status-prefixes", "", "set prefixes of the contexts of commit statuses validated with --include-statuses, e.g) ci/, ignoring the others. every context is validated when empty (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&ignoredStatusPrefix, "ignored-status-prefixes", "", "set prefixes of the contexts of commit statuses ignored with --include-statuses, e.g) license/ (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&protectedOnly, "protected-checks", false, "only validate the required status checks of the protected base branch of the ref, which must appear and succeed, ignoring every other job. the token must be able to read the protection")
+	cmd.PersistentFlags().StringVar(&anyOf, "any-of", "", "set groups of jobs, at least one of which must succeed, separated by semicolons, each of whose jobs are separated by |, e.g) build-linux|build-linux-arm")
+	cmd.PersistentFlags().StringVar(&quorums, "quorums", "", "set jobs a percent of which must succeed, separated by semicolons, each of which is a job or a regular expression followed by the percent, e.g) ^integration-=95%")
+	cmd.PersistentFlags().BoolVar(&failOnNoJobs, "fail-on-no-jobs", false, "fail the validation when no jobs, other than this job and the ignored jobs, are found by 3 polls in a row, instead of passing it, as CI never started for the ref")
+	cmd.PersistentFlags().IntVar(&minChecks, "min-checks", 0, "set how many jobs, other than this job and the ignored jobs, must be observed before the validation can succeed, so that workflows which failed to trigger do not pass it. not required when zero")
+
+	cmd.PersistentFlags().StringVar(&waitFor, "wait-for", "", "set check runs or status contexts which must appear and succeed, even when they are not required otherwise, e.g) external checks created after the gate started (comma-separated list)")
+
+	cmd.PersistentFlags().StringVar(&locale, "locale", i18n.DefaultLocale, fmt.Sprintf("set locale of user facing messages (one of %v)", i18n.Locales()))
+	cmd.PersistentFlags().StringVar(&messagesFile, "messages-file", "", "set JSON file overriding user facing messages by key")
+
+	cmd.PersistentFlags().BoolVar(&criticalPath, "critical-path", false, "report the chain of workflow_run triggered workflows keeping the validation open")
+	cmd.PersistentFlags().BoolVar(&rollupCache, "rollup-cache", false, "reuse the status of the previous poll once every check succeeded, while the counts of checks by their states, as rolled up by the GraphQL API, are unchanged")
+	cmd.PersistentFlags().StringVar(&checksAPI, "checks-api", status.ChecksAPIREST, fmt.Sprintf("set the api listing the check runs of the ref (%s, %s or %s). %s lists them from the GraphQL API in a single request, falling back to the REST API once it fails", status.ChecksAPIREST, status.ChecksAPIGraphQL, status.ChecksAPIAuto, status.ChecksAPIAuto))
+
+	cmd.PersistentFlags().IntVar(&minApprovals, "min-approvals", 0, "set how many reviewers must have approved the pull request, without outstanding change requests. not required when zero")
+	cmd.PersistentFlags().StringVar(&twoPersonPaths, "two-person-paths", "", "set patterns of sensitive paths whose changes must be approved by two reviewers other than the author and committers (comma-separated list). not required when empty")
+	cmd.PersistentFlags().BoolVar(&discountPushers, "discount-pushers", false, "discount approvals of reviewers who pushed commits to the pull request after approving")
+	cmd.PersistentFlags().StringVar(&approvalFreshness, "approval-freshness", "", fmt.Sprintf("hold the gate pending until an approval is of the latest commit (%s) or given within the duration, e.g) 24h. not required when empty", review.FreshnessLatestCommit))
+	cmd.PersistentFlags().DurationVar(&staleAfter, "stale-after", 0, "set how long a pull request may go without commits before it is stale, e.g) 720h. not checked when zero")
+	cmd.PersistentFlags().StringVar(&staleResult, "stale-result", stale.ResultFailure, fmt.Sprintf("set result of stale pull requests (%s, or %s only reporting the guidance)", stale.ResultFailure, stale.ResultNeutral))
+	cmd.PersistentFlags().BoolVar(&staleComment, "stale-comment", true, "comment the guidance to rebase on stale pull requests")
+	cmd.PersistentFlags().StringVar(&milestoneRequired, "milestone", "", fmt.Sprintf("hold the gate pending until the pull request is assigned to the open milestone due next (%s), or to an open milestone matching the regular expression. not required when empty", milestone.Current))
+
+	cmd.PersistentFlags().BoolVar(&attestations, "attestations", false, "require the build artifacts of the ref to be attested")
+	cmd.PersistentFlags().StringVar(&attestedArtifacts, "attestation-artifacts", "", "set artifacts which must be attested (comma-separated list). every artifact of the ref must be attested when empty")
+	cmd.PersistentFlags().StringVar(&attestedPredicates, "attestation-predicates", attestation.PredicateSLSAProvenance, "set accepted predicate types of attestations (comma-separated list)")
+
+	cmd.PersistentFlags().StringVar(&requiredArtifacts, "required-artifacts", "", "set artifacts which must be uploaded by the workflows of the ref (comma-separated list of workflow:artifact or artifact)")
+
+	cmd.PersistentFlags().StringVar(&coverageArtifact, "coverage-artifact", "", "set artifact containing the coverage summary. the coverage is not validated when empty")
+	cmd.PersistentFlags().StringVar(&coverageFile, "coverage-file", "", "set path of the coverage summary in the artifact. the first file in a known format is used when empty")
+	cmd.PersistentFlags().StringVar(&coverageFormat, "coverage-format", "", "set format of the coverage summary (lcov, cobertura or json). guessed from the file name when empty")
+	cmd.PersistentFlags().Float64Var(&coverageMin, "coverage-min", 0, "set minimum line coverage in percent")
+	cmd.PersistentFlags().StringVar(&coverageBase, "coverage-base", "", "set branch to compare the coverage with. the coverage delta is not validated when empty")
+	cmd.PersistentFlags().Float64Var(&coverageMaxDecrease, "coverage-max-decrease", 0, "set how many percentage points the coverage may decrease from the base branch")
+
+	cmd.PersistentFlags().StringVar(&junitArtifacts, "junit-artifacts", "", "set glob pattern of artifacts containing JUnit XML reports, e.g) test-results-*. the test reports are not validated when empty")
 
-	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&benchmarkArtifact, "benchmark-artifact", "", "set artifact containing the benchmark results. the benchmarks are not validated when empty")
+	cmd.PersistentFlags().StringVar(&benchmarkBase, "benchmark-base", "", "set branch storing the benchmark baseline. defaults to the base branch of the pull request ($GITHUB_BASE_REF)")
+	cmd.PersistentFlags().Float64Var(&benchmarkThreshold, "benchmark-threshold", 10, "set how many percent worse than the baseline a benchmark may get")
+
+	cmd.PersistentFlags().StringVar(&scanArtifacts, "scan-artifacts", "", "set glob pattern of artifacts containing SARIF results of image scans, e.g) trivy-*. the scans are not validated when empty")
+	cmd.PersistentFlags().StringVar(&scanSeverity, "scan-severity", vulnerability.SeverityCritical, "set lowest severity of vulnerabilities blocking the merge (low, medium, high or critical)")
+	cmd.PersistentFlags().StringVar(&scanIgnored, "scan-ignored", "", "set vulnerabilities which never block the merge (comma-separated list of rule IDs, such as CVE IDs)")
+
+	cmd.PersistentFlags().StringVar(&terraformArtifact, "terraform-artifact", "", "set artifact containing terraform plans. the plans are not validated when both this and --terraform-check-run are empty")
+	cmd.PersistentFlags().StringVar(&terraformCheckRun, "terraform-check-run", "", "set check run whose output contains the terraform plan")
+	cmd.PersistentFlags().StringVar(&terraformLabel, "terraform-label", terraform.DefaultApprovalLabel, "set label approving destructive changes of terraform plans")
+
+	cmd.PersistentFlags().StringVar(&migrationPaths, "migration-paths", migration.DefaultPaths, "set patterns of database migration files (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&migrationTestJob, "migration-test-job", "", "set job which must succeed when migrations are changed. migrations are not validated when both this and --migration-team are empty")
+	cmd.PersistentFlags().StringVar(&migrationTeam, "migration-team", "", "set team which must approve changes of migrations (org/team-slug or team-slug)")
+
+	cmd.PersistentFlags().StringVar(&flagService, "flag-service", "", fmt.Sprintf("set feature flag service looking up flags referenced by the pull request (%s or %s). the references are not validated when empty", flagservice.KindLaunchDarkly, flagservice.KindUnleash))
+	cmd.PersistentFlags().StringVar(&flagServiceURL, "flag-service-url", "", "set base URL of the feature flag service API. required by unleash")
+	cmd.PersistentFlags().StringVar(&flagServiceToken, "flag-service-token", "", "set API token of the feature flag service")
+	cmd.PersistentFlags().StringVar(&flagProject, "flag-project", "default", "set project of the feature flags")
+	cmd.PersistentFlags().StringVar(&flagEnvironment, "flag-environment", "production", "set environment in which the states of the feature flags are checked")
+	cmd.PersistentFlags().StringVar(&flagPattern, "flag-pattern", featureflag.DefaultPattern, "set regular expression matching flag references, whose first group captures the flag key")
+	cmd.PersistentFlags().StringVar(&flagStates, "flag-states", featureflag.DefaultAllowedStates, "set states referenced flags may be in (comma-separated list of on, off and archived)")
+
+	cmd.PersistentFlags().StringVar(&jiraURL, "jira-url", "", "set base URL of Jira, e.g) https://example.atlassian.net. linked tickets are not updated when empty")
+	cmd.PersistentFlags().StringVar(&jiraUser, "jira-user", "", "set user of the Jira API token. the token is sent as a bearer token when empty")
+	cmd.PersistentFlags().StringVar(&jiraToken, "jira-token", "", "set Jira API token")
+	cmd.PersistentFlags().StringVar(&jiraTransition, "jira-transition", "", "set transition applied to linked tickets when the validation succeeds. tickets are not transitioned when empty")
+	cmd.PersistentFlags().StringVar(&jiraProjects, "jira-projects", "", "set Jira projects of linked tickets (comma-separated list of project keys). every project is linked when empty")
+
+	cmd.PersistentFlags().StringVar(&escalationService, "escalation-service", "", fmt.Sprintf("set alerting service escalating urgent pull requests blocked longer than the SLA (%s or %s). not escalated when empty", escalation.KindPagerDuty, escalation.KindOpsgenie))
+	cmd.PersistentFlags().StringVar(&escalationKey, "escalation-key", "", "set integration key of the alerting service (PagerDuty routing key or Opsgenie API key)")
+	cmd.PersistentFlags().StringVar(&escalationURL, "escalation-url", "", "set base URL of the alerting service API, e.g) https://api.eu.opsgenie.com")
+	cmd.PersistentFlags().StringVar(&escalationLabels, "escalation-labels", escalation.DefaultLabels, "set labels of pull requests to escalate (comma-separated list)")
+	cmd.PersistentFlags().DurationVar(&escalationSLA, "escalation-sla", escalation.DefaultSLA, "set how long urgent pull requests may be blocked since opened before they are escalated")
+
+	cmd.PersistentFlags().StringVar(&emailServer, "email-server", "", "set address of the SMTP relay mailing the final result, e.g) smtp.example.com:587. not mailed when empty")
+	cmd.PersistentFlags().StringVar(&emailUser, "email-user", "", "set user of the SMTP relay. the relay is used without authentication when empty")
+	cmd.PersistentFlags().StringVar(&emailPassword, "email-password", "", "set password of the SMTP relay")
+	cmd.PersistentFlags().StringVar(&emailFrom, "email-from", "", "set sender address of the mail")
+	cmd.PersistentFlags().StringVar(&emailTo, "email-to", "", "set recipient addresses of the mail (comma-separated list)")
+
+	cmd.PersistentFlags().BoolVar(&flakyIssues, "flaky-issues", false, "open or update a tracking issue when a job fails the gate of a pull request --flaky-threshold consecutive times")
+	cmd.PersistentFlags().IntVar(&flakyThreshold, "flaky-threshold", flaky.DefaultThreshold, "set after how many consecutive failures of a job on a pull request it is escalated")
+	cmd.PersistentFlags().StringVar(&ownersFile, "owners-file", owners.DefaultPath, "set path of the map from jobs to their owning teams and users in the repository, falling back to CODEOWNERS of the workflow file")
+
+	cmd.PersistentFlags().BoolVar(&autoMerge, "auto-merge", false, "merge pull requests of dependency update bots once the gate has passed, when they only bump versions by --auto-merge-update-types")
+	cmd.PersistentFlags().StringVar(&autoMergeAuthors, "auto-merge-authors", automerge.DefaultAuthors, "set logins of the dependency update bots whose pull requests are merged (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&autoMergeTypes, "auto-merge-update-types", automerge.DefaultUpdateTypes, "set update types which are merged (comma-separated list of patch, minor and major)")
+	cmd.PersistentFlags().StringVar(&autoMergeMethod, "auto-merge-method", automerge.DefaultMergeMethod, "set how pull requests are merged (merge, squash or rebase)")
+
+	cmd.PersistentFlags().BoolVar(&gateLabels, "gate-labels", false, fmt.Sprintf("label the pull request with its size (%sXS to %sXXL) and the state of its gate (%s or %s)", labels.SizePrefix, labels.SizePrefix, labels.LabelPassing, labels.LabelBlocked))
+	cmd.PersistentFlags().StringVar(&deployWebhook, "deploy-webhook", "", "set URL of the deployment orchestrator receiving the commit, the policy and the digest of --trace-file once the gate has passed, signed by the key of "+signingKeyEnv+". not sent when empty")
+
+	cmd.PersistentFlags().StringVar(&profileSpec, "profiles", "", "set profiles published as commit statuses named by --name-template, requiring the listed jobs or every job when none is listed, e.g) fast-gate=unit-test,lint;full-gate")
+
+	cmd.PersistentFlags().BoolVar(&optionalStatus, "optional-status", false, fmt.Sprintf("publish the commit status %s, named by --name-template, summarizing the ignored jobs, which must not be required by branch protection", profile.OptionalName))
+	cmd.PersistentFlags().StringVar(&nameTemplate, "name-template", profile.DefaultNameTemplate, "set template of the names of published statuses and comments, with the variables .Name, .Owner, .Repo, .Repository and .BaseBranch")
+
+	cmd.PersistentFlags().BoolVar(&pausable, "pausable", false, fmt.Sprintf("keep the gate pending while the %s label is on an open issue or the pull request", pause.Label))
+
+	cmd.PersistentFlags().DurationVar(&auditWindow, "audit-window", 24*time.Hour, "set how far back merges are audited on schedule events")
+	cmd.PersistentFlags().StringVar(&auditRequired, "audit-required", "", "set jobs which must have succeeded on merged pull requests (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&auditIssues, "audit-issues", true, "open an issue for each merged pull request violating the audit")
+	cmd.PersistentFlags().BoolVar(&auditWorkflows, "audit-workflows", false, "report workflows added within the audit window which never run on pull requests or are ignored by the gate, opening an issue for each with audit-issues")
+
+	cmd.PersistentFlags().BoolVar(&exportStatus, "export", false, "evaluate the gate once and export its status as the result and status step outputs and to --trace-file, exiting successfully regardless of the result")
+	cmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "write the decision trace of the final result to the file as JSON")
+	cmd.PersistentFlags().BoolVar(&traceSigned, "sign-trace", false, "record where the gate ran and by which policy in --trace-file, and sign it by the key of "+signingKeyEnv)
+
+	cmd.PersistentFlags().BoolVar(&stepSummary, "summary", false, "write the final result as markdown to the job summary ($GITHUB_STEP_SUMMARY)")
+	cmd.PersistentFlags().StringVar(&summaryFormat, "summary-format", report.FormatList, "set format of jobs in the summary (list or table)")
+	cmd.PersistentFlags().BoolVar(&summaryEmoji, "summary-emoji", true, "use emoji in the summary")
+	cmd.PersistentFlags().BoolVar(&summaryDetails, "summary-details", true, "use collapsible <details> sections in the summary")
+	cmd.PersistentFlags().BoolVar(&summaryChanges, "summary-changes", false, "include the changes of every poll, e.g) newly completed, failed and appeared jobs, in the summary")
+	cmd.PersistentFlags().BoolVar(&checkVersion, "check-version", false, "notice when a newer minor or major release is available, along with the defaults it changed, without ever failing on it")
+	cmd.PersistentFlags().BoolVar(&apiStats, "api-stats", false, "log the API requests made during the validation by endpoint, along with the pages fetched, cache hits and retries, and include them in the usage of --trace-file")
+	cmd.PersistentFlags().BoolVar(&logChanges, "log-changes", false, "log only the changes of each validator since the previous poll instead of its whole status. the first poll is logged in full")
 
 	return cmd
 }
 
+// selfJob returns the name of this job, the first of the self job names, without the workflow it may be qualified
+// by, e.g) merge-gatekeeper of Gate / merge-gatekeeper, which is the name of its check runs.
+func selfJob() string {
+	self, _, _ := strings.Cut(selfJobName, ",")
+	if _, job, ok := strings.Cut(self, " / "); ok {
+		return strings.TrimSpace(job)
+	}
+	return strings.TrimSpace(self)
+}
+
+// gateClassifier returns the rules of the gate as given by the flags, to classify checks without evaluating the gate.
+// The ref only satisfies the status validator, as nothing is listed.
+func gateClassifier(c github.Client, owner, repo, ref string) (validators.Classifier, error) {
+	v, err := status.CreateValidator(c,
+		status.WithGitHubOwnerAndRepo(owner, repo),
+		status.WithGitHubRef(ref),
+		status.WithSelfJob(selfJobName),
+		status.WithIgnoredJobs(ignoredJobs),
+		status.WithIgnoredWorkflows(ignoredWorkflows),
+		status.WithIgnoredApps(ignoredApps),
+		status.WithRules(rules),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+	return v.(validators.Classifier), nil
+}
+
+// gateValidators returns the validators gating the ref: the status validator followed by the optional validators.
+func gateValidators(ctx context.Context, c github.Client, owner, repo string, catalog *i18n.Catalog) ([]validators.Validator, error) {
+	// The jobs of the matrix of this job are found by the workflow run of this job, which is only known in actions.
+	selfRunID, _ := strconv.ParseInt(os.Getenv("GITHUB_RUN_ID"), 10, 64)
+	// The statuses published by this job are named before validating, so that they are not validated as jobs.
+	var err error
+	if namer, err = newNamer(ctx, c, owner, repo); err != nil {
+		return nil, err
+	}
+	// With protected checks, the required status checks are gated by the settings of the repository rather than
+	// the flags, along with the jobs required by the changed paths, and waited for until they appear.
+	var required []string
+	if protectedOnly {
+		if required, err = protectedChecks(ctx, c, owner, repo); err != nil {
+			return nil, err
+		}
+	}
+	if pathChecksOnly {
+		required = appendMissing(required, pathChecks...)
+	}
+	statusValidator, err := status.CreateValidator(c,
+		status.WithSelfJob(selfJobName),
+		status.WithSelfRun(selfRunID),
+		status.WithIgnoredSelfRun(ignoreSelfRun),
+		status.WithIgnoredOtherGates(ignoreOtherGates),
+		status.WithGitHubOwnerAndRepo(owner, repo),
+		status.WithGitHubRef(ghRef),
+		status.WithRules(rules),
+		status.WithAnyOf(anyOf),
+		status.WithQuorums(quorums),
+		status.WithIgnoredJobs(ignoredJobs),
+		status.WithIgnoredWorkflows(ignoredWorkflows),
+		status.WithIgnoredApps(ignoredApps),
+		status.WithMinChecks(minChecks),
+		status.WithFailOnNoJobs(failOnNoJobs),
+		status.WithStatusContexts(includeStatuses, publishedStatuses()...),
+		status.WithStatusPrefixes(statusPrefixes, ignoredStatusPrefix),
+		status.WithRequiredChecks(protectedOnly || pathChecksOnly, required...),
+		status.WithSkippedAs(skippedAs),
+		status.WithNeutralAs(neutralAs),
+		status.WithCancelledAs(cancelledAs),
+		status.WithActionRequiredAs(actionRequiredAs),
+		status.WithStaleAs(staleAs),
+		status.WithMessageCatalog(catalog),
+		status.WithClock(clk),
+		status.WithCriticalPath(criticalPath),
+		status.WithRollupCache(rollupCache),
+		status.WithChecksAPI(checksAPI),
+		status.WithExplain(len(explainCheck) != 0),
+		status.WithOwners(owners.NewResolver(c, owner, repo, ghRef, ownersFile)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+	optional, err := optionalValidators(c, owner, repo, required)
+	if err != nil {
+		return nil, err
+	}
+	return append([]validators.Validator{statusValidator}, optional...), nil
+}
+
+// optionalValidators returns the validators enabled by flags, which run along with the status validator. The
+// required checks are waited for along with those of --wait-for.
+func optionalValidators(c github.Client, owner, repo string, required []string) ([]validators.Validator, error) {
+	var vs []validators.Validator
+	if minApprovals > 0 || len(twoPersonPaths) != 0 || len(approvalFreshness) != 0 {
+		v, err := review.CreateValidator(c,
+			review.WithGitHubOwnerAndRepo(owner, repo),
+			review.WithGitHubRef(ghRef),
+			review.WithPullRequest(prNumber),
+			review.WithMinApprovals(minApprovals),
+			review.WithTwoPersonPaths(twoPersonPaths),
+			review.WithDiscountPushers(discountPushers),
+			review.WithFreshness(approvalFreshness),
+			review.WithClock(clk),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if checks := strings.Join(append([]string{waitFor}, required...), ","); len(strings.Trim(checks, ",")) != 0 {
+		v, err := waitfor.CreateValidator(c,
+			waitfor.WithGitHubOwnerAndRepo(owner, repo),
+			waitfor.WithGitHubRef(ghRef),
+			waitfor.WithChecks(checks),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if staleAfter > 0 {
+		v, err := stale.CreateValidator(c,
+			stale.WithGitHubOwnerAndRepo(owner, repo),
+			stale.WithGitHubRef(ghRef),
+			stale.WithPullRequest(prNumber),
+			stale.WithMaxAge(staleAfter),
+			stale.WithResult(staleResult),
+			stale.WithComment(staleComment),
+			stale.WithTitle(namer.Name("stale")),
+			stale.WithClock(clk),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(milestoneRequired) != 0 {
+		v, err := milestone.CreateValidator(c,
+			milestone.WithGitHubOwnerAndRepo(owner, repo),
+			milestone.WithGitHubRef(ghRef),
+			milestone.WithPullRequest(prNumber),
+			milestone.WithMilestone(milestoneRequired),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if attestations {
+		v, err := attestation.CreateValidator(c,
+			attestation.WithGitHubOwnerAndRepo(owner, repo),
+			attestation.WithGitHubRef(ghRef),
+			attestation.WithArtifacts(attestedArtifacts),
+			attestation.WithPredicateTypes(attestedPredicates),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(requiredArtifacts) != 0 {
+		v, err := artifact.CreateValidator(c,
+			artifact.WithGitHubOwnerAndRepo(owner, repo),
+			artifact.WithGitHubRef(ghRef),
+			artifact.WithRequiredArtifacts(requiredArtifacts),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(coverageArtifact) != 0 {
+		v, err := coverage.CreateValidator(c,
+			coverage.WithGitHubOwnerAndRepo(owner, repo),
+			coverage.WithGitHubRef(ghRef),
+			coverage.WithArtifact(coverageArtifact),
+			coverage.WithFile(coverageFile, coverageFormat),
+			coverage.WithMinimum(coverageMin),
+			coverage.WithBaseline(coverageBase, coverageMaxDecrease),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(junitArtifacts) != 0 {
+		v, err := junit.CreateValidator(c,
+			junit.WithGitHubOwnerAndRepo(owner, repo),
+			junit.WithGitHubRef(ghRef),
+			junit.WithArtifactPattern(junitArtifacts),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(benchmarkArtifact) != 0 {
+		base := benchmarkBase
+		if len(base) == 0 {
+			base = os.Getenv("GITHUB_BASE_REF")
+		}
+		v, err := benchmark.CreateValidator(c,
+			benchmark.WithGitHubOwnerAndRepo(owner, repo),
+			benchmark.WithGitHubRef(ghRef),
+			benchmark.WithArtifact(benchmarkArtifact),
+			benchmark.WithBaseBranch(base),
+			benchmark.WithThreshold(benchmarkThreshold),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(scanArtifacts) != 0 {
+		v, err := vulnerability.CreateValidator(c,
+			vulnerability.WithGitHubOwnerAndRepo(owner, repo),
+			vulnerability.WithGitHubRef(ghRef),
+			vulnerability.WithArtifactPattern(scanArtifacts),
+			vulnerability.WithSeverity(scanSeverity),
+			vulnerability.WithIgnoredRules(scanIgnored),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(terraformArtifact) != 0 || len(terraformCheckRun) != 0 {
+		v, err := terraform.CreateValidator(c,
+			terraform.WithGitHubOwnerAndRepo(owner, repo),
+			terraform.WithGitHubRef(ghRef),
+			terraform.WithPullRequest(prNumber),
+			terraform.WithPlanArtifact(terraformArtifact),
+			terraform.WithPlanCheckRun(terraformCheckRun),
+			terraform.WithApprovalLabel(terraformLabel),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(migrationTestJob) != 0 || len(migrationTeam) != 0 {
+		v, err := migration.CreateValidator(c,
+			migration.WithGitHubOwnerAndRepo(owner, repo),
+			migration.WithGitHubRef(ghRef),
+			migration.WithPullRequest(prNumber),
+			migration.WithPaths(migrationPaths),
+			migration.WithTestJob(migrationTestJob),
+			migration.WithTeam(migrationTeam),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	if len(flagService) != 0 {
+		fs, err := flagservice.New(flagservice.Config{
+			Kind:        flagService,
+			URL:         flagServiceURL,
+			Token:       flagServiceToken,
+			Project:     flagProject,
+			Environment: flagEnvironment,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create feature flag service: %w", err)
+		}
+		v, err := featureflag.CreateValidator(c,
+			featureflag.WithGitHubOwnerAndRepo(owner, repo),
+			featureflag.WithGitHubRef(ghRef),
+			featureflag.WithPullRequest(prNumber),
+			featureflag.WithService(fs),
+			featureflag.WithPattern(flagPattern),
+			featureflag.WithAllowedStates(flagStates),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator: %w", err)
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
+
 func ownerAndRepository(str string) (owner string, repo string) {
 	sp := strings.Split(str, "/")
 	switch len(sp) {
@@ -96,53 +718,180 @@ func debug(logger logger, name string) func() {
 	}
 }
 
-func doValidateCmd(ctx context.Context, logger logger, vs ...validators.Validator) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecond)*time.Second)
-	defer cancel()
+func doValidateCmd(ctx context.Context, logger logger, vs ...validators.Validator) (err error) {
+	results := make([]*result, 0, len(vs))
+	var history []*pollChanges
+	u := &usage{}
+	started := clk.Now()
+	defer func() {
+		u.Waited = clk.Now().Sub(started)
+		logger.Println(msgs.Sprintf(i18n.ValidationUsage, u.Waited.Round(time.Second), u.Polls, u.RunnerMinutes()))
+		if apiStats {
+			st := github.DefaultStats.Snapshot()
+			u.API = &st
+			logAPIStats(logger, u.API)
+		}
+		if profilePublisher != nil {
+			profilePublisher.publish(context.WithoutCancel(ctx), logger, results, true, err)
+		}
+		writeOutputs(logger, err, results, u, history)
+		notifyOutcome(ctx, logger, err, results)
+	}()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	timeoutC := clk.After(time.Duration(timeoutSecond) * time.Second)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-timeoutC:
+			cancel(context.DeadlineExceeded)
+		}
+	}()
 
-	invalT := ticker.NewInstantTicker(time.Duration(validateInvalSecond) * time.Second)
+	invalT := ticker.NewInstantTickerWithClock(clk, time.Duration(validateInvalSecond)*time.Second)
 	defer invalT.Stop()
 
+	// tolerate spends the error budget on a poll failed with a transient API error, keeping the results of
+	// the previous poll, so that a single 502 does not fail a long wait. During incidents of GitHub, such polls
+	// are tolerated without spending the budget, as the outage is not of the checks.
+	watch := newIncidentWatch()
+	var failedPolls int
+	var observedAt time.Time
+	tolerate := func(r *result, prev []*result) bool {
+		if ctx.Err() != nil || !github.IsTransient(r.err) {
+			return false
+		}
+		if !watch.ongoing() && failedPolls >= pollFailureBudget {
+			return false
+		}
+		github.DefaultStats.Retry()
+		results = prev
+		logger.PrintErrln("")
+		if watch.ongoing() {
+			logger.PrintErrln(msgs.Sprintf(i18n.ValidationIncidentPoll, r.name, r.err))
+		} else {
+			failedPolls++
+			logger.PrintErrln(msgs.Sprintf(i18n.ValidationPollFailed, r.name, failedPolls, pollFailureBudget, r.err))
+		}
+		logger.PrintErrln(msgs.Sprintf(i18n.ValidationRetry, watch.interval()) + "\n")
+		return true
+	}
+	// fail fails the validation on the error of the result. Failures of the API itself are reported along with
+	// the results of the last completed poll, which tell how close the gate was to passing.
+	fail := func(r *result, prev []*result) error {
+		results = append(results, r)
+		err := fmt.Errorf("validation failed, err: %v", r.err)
+		if len(prev) == 0 || !github.IsAPIError(r.err) {
+			return err
+		}
+		return fmt.Errorf("%w\n\n%s", err, describeSnapshot(prev, clk.Now().Sub(observedAt)))
+	}
+
+poll:
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return context.Cause(ctx)
 		case <-invalT.C():
+			if watch.deferPoll(ctx, logger) {
+				continue poll
+			}
+			prev := results
+			results = make([]*result, 0, len(vs))
+			u.Polls++
+
+			if pauseGate != nil {
+				r := validate(ctx, pauseGate, logger)
+				if r.err != nil {
+					if tolerate(r, prev) {
+						continue poll
+					}
+					return fail(r, prev)
+				}
+				// While paused, the other validators are not run, so that failures are not reported either.
+				if !r.status.IsSuccess() {
+					results = append(results, r)
+					observedAt = clk.Now()
+					logger.PrintErrln("")
+					logger.PrintErrln(msgs.Sprintf(i18n.ValidationRetry, watch.interval()) + "\n")
+					break
+				}
+			}
+
 			var successCnt int
+			polled := &pollChanges{poll: u.Polls, elapsed: clk.Now().Sub(started)}
 			for _, v := range vs {
-				ok, err := validate(ctx, v, logger)
-				if err != nil {
-					return err
+				r := runValidator(ctx, v, logger)
+				if r.err != nil {
+					if tolerate(r, prev) {
+						continue poll
+					}
+					return fail(r, prev)
+				}
+				c := diffResults(findResult(prev, r.name), r)
+				logStatus(logger, r, c)
+				if c != nil && !c.empty() {
+					polled.changes = append(polled.changes, c)
 				}
-				if ok {
+				results = append(results, r)
+				if r.status.IsSuccess() {
 					successCnt++
 				}
 			}
+			observedAt = clk.Now()
+			if len(polled.changes) != 0 {
+				history = append(history, polled)
+			}
+			if profilePublisher != nil {
+				profilePublisher.publish(ctx, logger, results, false, nil)
+			}
 			if successCnt != len(vs) {
 				logger.PrintErrln("")
-				logger.PrintErrln("  WARNING: Validation is yet to be completed. This is most likely due to some other jobs still running.")
-				logger.PrintErrf("           Waiting for %d seconds before retrying.\n\n", validateInvalSecond)
+				logger.PrintErrln(msgs.Get(i18n.ValidationPending))
+				logger.PrintErrln(msgs.Sprintf(i18n.ValidationRetry, watch.interval()) + "\n")
 				break
 			}
 
-			logger.Println("All validations were successful!")
+			logger.Println(msgs.Get(i18n.ValidationSucceeded))
 			return nil
 		}
 	}
 }
 
-func validate(ctx context.Context, v validators.Validator, logger logger) (bool, error) {
-	defer debug(logger, "validator: "+v.Name())()
+// describeSnapshot renders the results of the last completed poll along with how long ago they were observed.
+func describeSnapshot(results []*result, age time.Duration) string {
+	var b strings.Builder
+	b.WriteString(msgs.Sprintf(i18n.ValidationLastObserved, age.Round(time.Second)))
+	for _, r := range results {
+		b.WriteString("\n")
+		b.WriteString(r.status.Detail())
+	}
+	return b.String()
+}
 
-	st, err := v.Validate(ctx)
-	if err != nil {
-		return false, fmt.Errorf("validation failed, err: %v", err)
+// validate runs the validator and logs its status.
+func validate(ctx context.Context, v validators.Validator, logger logger) *result {
+	r := runValidator(ctx, v, logger)
+	if r.err == nil {
+		logger.Println(r.status.Detail())
 	}
+	return r
+}
 
-	logger.Println(st.Detail())
+// runValidator runs the validator, leaving the status to be logged by the caller.
+func runValidator(ctx context.Context, v validators.Validator, logger logger) *result {
+	defer debug(logger, "validator: "+v.Name())()
 
-	if !st.IsSuccess() {
-		return false, nil
+	st, err := v.Validate(ctx)
+	if err != nil {
+		r := &result{name: v.Name(), err: err}
+		var failed *validators.FailedChecksError
+		if errors.As(err, &failed) {
+			r.status = failed.Status
+		}
+		return r
 	}
-	return true, nil
+	return &result{name: v.Name(), status: st}
 }
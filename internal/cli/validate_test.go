@@ -2,12 +2,25 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/schema"
 	"github.com/aac228/merge-gatekeeper/internal/validators"
 	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
 )
@@ -64,6 +77,23 @@ func Test_ownerAndRepository(t *testing.T) {
 	}
 }
 
+func Test_validateCmd_withoutRef(t *testing.T) {
+	t.Setenv("GITHUB_EVENT_NAME", "")
+	t.Setenv("GITHUB_REPOSITORY", "")
+	repo, ref := ghRepo, ghRef
+	defer func() { ghRepo, ghRef = repo, ref }()
+
+	// The ref is optional, as it is resolved from the event, and only fails the command when it can not be. Flags
+	// reset the intervals set by TestMain to their defaults, so they are given again.
+	cmd := validateCmd()
+	cmd.SetArgs([]string{"--repo", "owner/repo", "--interval", "1", "--timeout", "2"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "github ref is empty") {
+		t.Errorf("validateCmd() error = %v, want the ref to be resolved from the event", err)
+	}
+}
+
 func Test_doValidateCmd(t *testing.T) {
 	tests := map[string]struct {
 		ctx     context.Context
@@ -144,3 +174,263 @@ func Test_doValidateCmd(t *testing.T) {
 		})
 	}
 }
+
+func Test_doValidateCmd_traceFile(t *testing.T) {
+	traceFile = filepath.Join(t.TempDir(), "trace.json")
+	defer func() { traceFile = "" }()
+
+	vs := []validators.Validator{
+		&mock.Validator{
+			NameFunc: func() string { return "validator-1" },
+			ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+				return &mock.Status{
+					DetailFunc:    func() string { return "success-1" },
+					IsSuccessFunc: func() bool { return true },
+				}, nil
+			},
+		},
+	}
+	if err := doValidateCmd(context.Background(), &cobra.Command{}, vs...); err != nil {
+		t.Fatalf("doValidateCmd() error = %v", err)
+	}
+
+	b, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("failed to read trace file: %v", err)
+	}
+	got := &schema.Trace{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("failed to unmarshal trace: %v", err)
+	}
+	if got.Usage == nil || got.Usage.Polls != 1 {
+		t.Errorf("trace usage = %+v, want 1 poll", got.Usage)
+	}
+	got.Usage = nil
+	want := &schema.Trace{
+		Header: schema.NewHeader(schema.KindTrace),
+		Result: traceResultSuccess,
+		Validators: []schema.Validator{
+			{Name: "validator-1", Success: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("trace = %+v, want %+v", got, want)
+	}
+}
+
+func Test_doValidateCmd_clock(t *testing.T) {
+	fake := clockmock.NewClock(time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC))
+	clk = fake
+	defer func() { clk = clock.New() }()
+
+	var cnt int32
+	vs := []validators.Validator{
+		&mock.Validator{
+			NameFunc: func() string { return "validator-1" },
+			ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+				atomic.AddInt32(&cnt, 1)
+				return &mock.Status{
+					DetailFunc:    func() string { return "pending-1" },
+					IsSuccessFunc: func() bool { return false },
+				}, nil
+			},
+		},
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- doValidateCmd(context.Background(), &cobra.Command{}, vs...)
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("doValidateCmd() error = %v, want %v", err, context.DeadlineExceeded)
+			}
+			// The timeout is 2 seconds and the interval is 1 second, so at most 3 polls can happen.
+			if got := atomic.LoadInt32(&cnt); got < 1 || got > 3 {
+				t.Errorf("validate count = %d, want between 1 and 3", got)
+			}
+			return
+		default:
+			fake.Advance(500 * time.Millisecond)
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func Test_doValidateCmd_pollErrorBudget(t *testing.T) {
+	badGateway := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadGateway}}
+	tests := map[string]struct {
+		budget  int
+		err     error
+		wantErr bool
+	}{
+		"tolerates a transient error within the budget": {
+			budget:  1,
+			err:     badGateway,
+			wantErr: false,
+		},
+		"fails on a transient error without budget": {
+			budget:  0,
+			err:     badGateway,
+			wantErr: true,
+		},
+		"fails on an error of the validation within the budget": {
+			budget:  1,
+			err:     errors.New("job failed"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pollFailureBudget = tt.budget
+			defer func() { pollFailureBudget = 0 }()
+
+			var polls int32
+			vs := []validators.Validator{
+				&mock.Validator{
+					NameFunc: func() string { return "validator-1" },
+					ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+						if atomic.AddInt32(&polls, 1) == 1 {
+							return nil, tt.err
+						}
+						return &validators.BasicStatus{Succeeded: true, Message: "success-1"}, nil
+					},
+				},
+			}
+			if err := doValidateCmd(context.Background(), &cobra.Command{}, vs...); (err != nil) != tt.wantErr {
+				t.Errorf("doValidateCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_doValidateCmd_lastObserved(t *testing.T) {
+	notFound := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "Not Found"}
+	tests := map[string]struct {
+		err          error
+		wantSnapshot bool
+	}{
+		"reports the last observed status on an API error": {
+			err:          notFound,
+			wantSnapshot: true,
+		},
+		"does not report the last observed status on a failure of the validation": {
+			err:          errors.New("job failed"),
+			wantSnapshot: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var polls int32
+			vs := []validators.Validator{
+				&mock.Validator{
+					NameFunc: func() string { return "validator-1" },
+					ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+						if atomic.AddInt32(&polls, 1) == 1 {
+							return &validators.BasicStatus{Message: "1 out of 2 jobs completed"}, nil
+						}
+						return nil, tt.err
+					},
+				},
+			}
+			err := doValidateCmd(context.Background(), &cobra.Command{}, vs...)
+			if err == nil {
+				t.Fatal("doValidateCmd() error = nil, want error")
+			}
+			got := strings.Contains(err.Error(), "Last observed status") && strings.Contains(err.Error(), "1 out of 2 jobs completed")
+			if got != tt.wantSnapshot {
+				t.Errorf("doValidateCmd() error = %v, want snapshot %v", err, tt.wantSnapshot)
+			}
+		})
+	}
+}
+
+func Test_validate_failedChecks(t *testing.T) {
+	failed := &validators.BasicStatus{Message: "job-1 failed"}
+	tests := map[string]struct {
+		err        error
+		wantStatus validators.Status
+	}{
+		"keeps the status of failed checks": {
+			err:        fmt.Errorf("wrapped: %w", &validators.FailedChecksError{Status: failed}),
+			wantStatus: failed,
+		},
+		"has no status on other errors": {
+			err:        errors.New("err"),
+			wantStatus: nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &mock.Validator{
+				NameFunc: func() string { return "validator-1" },
+				ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+					return nil, tt.err
+				},
+			}
+			r := validate(context.Background(), v, &cobra.Command{})
+			if r.err != tt.err {
+				t.Errorf("validate() err = %v, want %v", r.err, tt.err)
+			}
+			if r.status != tt.wantStatus {
+				t.Errorf("validate() status = %v, want %v", r.status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_doValidateCmd_paused(t *testing.T) {
+	var paused atomic.Bool
+	paused.Store(true)
+	var validated int32
+	pauseGate = &mock.Validator{
+		NameFunc: func() string { return "pause" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			return &validators.BasicStatus{Succeeded: !paused.Swap(false), Message: "paused"}, nil
+		},
+	}
+	defer func() { pauseGate = nil }()
+
+	vs := []validators.Validator{
+		&mock.Validator{
+			NameFunc: func() string { return "validator-1" },
+			ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+				atomic.AddInt32(&validated, 1)
+				return nil, errors.New("fails-1")
+			},
+		},
+	}
+	err := doValidateCmd(context.Background(), &cobra.Command{}, vs...)
+	if err == nil {
+		t.Fatal("doValidateCmd() error = nil, want failure after resumed")
+	}
+	// The failing validator is not run on the first poll, while gating is paused.
+	if got := atomic.LoadInt32(&validated); got != 1 {
+		t.Errorf("validate count = %d, want 1", got)
+	}
+}
+
+func Test_selfJob(t *testing.T) {
+	tests := map[string]struct {
+		self string
+		want string
+	}{
+		"returns the name":                          {self: "merge-gatekeeper", want: "merge-gatekeeper"},
+		"returns the name without its workflow":     {self: "Gate / merge-gatekeeper", want: "merge-gatekeeper"},
+		"returns the first of the names":            {self: "merge-gatekeeper, Backend / gate", want: "merge-gatekeeper"},
+		"returns the first name without a workflow": {self: " Gate / merge-gatekeeper ,gate", want: "merge-gatekeeper"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			self := selfJobName
+			selfJobName = tt.self
+			defer func() { selfJobName = self }()
+			if got := selfJob(); got != tt.want {
+				t.Errorf("selfJob() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
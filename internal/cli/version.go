@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	rtdebug "runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+)
+
+// Formats of the version command.
+const (
+	versionFormatText = "text"
+	versionFormatJSON = "json"
+)
+
+// These variables will be set by command line flags.
+var (
+	versionFormat string
+)
+
+// versionCmd prints the version and build information of the binary, e.g) for bug reports.
+func versionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version, commit, build date and Go version of the binary",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := newBuildInfo(cliVersion, readBuildInfo())
+			switch versionFormat {
+			case versionFormatText:
+				cmd.Print(info.String())
+			case versionFormatJSON:
+				b, err := json.MarshalIndent(info.versioned(), "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(b))
+			default:
+				return fmt.Errorf("format %s is invalid. must be %s or %s", versionFormat, versionFormatText, versionFormatJSON)
+			}
+			return nil
+		},
+	}
+	// The version is printed without calling the API, so the token required by the other commands is not.
+	cmd.PersistentFlags().StringVarP(&ghToken, "token", "t", "", "set github token. not used")
+	cmd.PersistentFlags().MarkHidden("token")
+	cmd.Flags().StringVar(&versionFormat, "format", versionFormatText, fmt.Sprintf("set format of the version (%s or %s)", versionFormatText, versionFormatJSON))
+	return cmd
+}
+
+// buildInfo is the version of the binary, along with how it was built as recorded by the Go toolchain.
+type buildInfo struct {
+	Version   string
+	Commit    string
+	Modified  bool      // Whether the working tree had uncommitted changes when built.
+	BuildDate time.Time // Time of the commit, as builds are reproducible. Zero when unknown.
+	GoVersion string
+	Platform  string
+}
+
+func readBuildInfo() *rtdebug.BuildInfo {
+	bi, ok := rtdebug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	return bi
+}
+
+// newBuildInfo returns the build information of the version. The commit and build date are only known when the
+// binary was built within a git repository, as recorded by -buildvcs.
+func newBuildInfo(version string, bi *rtdebug.BuildInfo) *buildInfo {
+	info := &buildInfo{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if len(info.Version) == 0 {
+		info.Version = "dev"
+	}
+	if bi == nil {
+		return info
+	}
+	if len(bi.GoVersion) != 0 {
+		info.GoVersion = bi.GoVersion
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Commit = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		case "vcs.time":
+			if t, err := time.Parse(time.RFC3339, s.Value); err == nil {
+				info.BuildDate = t
+			}
+		}
+	}
+	return info
+}
+
+func (b *buildInfo) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "merge-gatekeeper %s\n", b.Version)
+	commit := b.Commit
+	switch {
+	case len(commit) == 0:
+		commit = "unknown"
+	case b.Modified:
+		commit += " (modified)"
+	}
+	fmt.Fprintf(&sb, "commit:     %s\n", commit)
+	date := "unknown"
+	if !b.BuildDate.IsZero() {
+		date = b.BuildDate.UTC().Format(time.RFC3339)
+	}
+	fmt.Fprintf(&sb, "build date: %s\n", date)
+	fmt.Fprintf(&sb, "go version: %s\n", b.GoVersion)
+	fmt.Fprintf(&sb, "platform:   %s\n", b.Platform)
+	return sb.String()
+}
+
+// versioned converts the build information into its versioned JSON output.
+func (b *buildInfo) versioned() *schema.Build {
+	v := &schema.Build{
+		Header:    schema.NewHeader(schema.KindVersion),
+		Version:   b.Version,
+		Commit:    b.Commit,
+		Modified:  b.Modified,
+		GoVersion: b.GoVersion,
+		Platform:  b.Platform,
+	}
+	if !b.BuildDate.IsZero() {
+		date := b.BuildDate.UTC()
+		v.BuildDate = &date
+	}
+	return v
+}
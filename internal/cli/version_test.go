@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"encoding/json"
+	rtdebug "runtime/debug"
+	"testing"
+	"time"
+)
+
+func Test_newBuildInfo(t *testing.T) {
+	bi := &rtdebug.BuildInfo{
+		GoVersion: "go1.21.0",
+		Settings: []rtdebug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123456789abcdef01234567"},
+			{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	info := newBuildInfo("v1.2.1", bi)
+	info.Platform = "linux/amd64"
+
+	want := "merge-gatekeeper v1.2.1\n" +
+		"commit:     0123456789abcdef0123456789abcdef01234567 (modified)\n" +
+		"build date: 2024-05-01T10:00:00Z\n" +
+		"go version: go1.21.0\n" +
+		"platform:   linux/amd64\n"
+	if got := info.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	b, err := json.Marshal(info.versioned())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"kind":"version","schema_version":"1","version":"v1.2.1","commit":"0123456789abcdef0123456789abcdef01234567",` +
+		`"modified":true,"build_date":"2024-05-01T10:00:00Z","go_version":"go1.21.0","platform":"linux/amd64"}`
+	if string(b) != wantJSON {
+		t.Errorf("versioned() = %s, want %s", b, wantJSON)
+	}
+}
+
+func Test_newBuildInfo_withoutVCS(t *testing.T) {
+	info := newBuildInfo("", nil)
+	if info.Version != "dev" || len(info.Commit) != 0 || !info.BuildDate.Equal(time.Time{}) {
+		t.Errorf("newBuildInfo() = %+v, want dev version without commit and build date", info)
+	}
+	b, err := json.Marshal(info.versioned())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"commit", "modified", "build_date"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("versioned() has %s, want it omitted", key)
+		}
+	}
+}
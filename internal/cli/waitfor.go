@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators/waitfor"
+)
+
+// waitForCmd waits for the named checks only, without the rest of the gate, so that workflows can be sequenced on
+// a single check, such as a compliance scanner.
+func waitForCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait-for CHECK...",
+		Short: "Wait for check runs or status contexts to appear and succeed",
+		Args:  cobra.MinimumNArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			v, err := waitfor.CreateValidator(newClient(ctx),
+				waitfor.WithGitHubOwnerAndRepo(owner, repo),
+				waitfor.WithGitHubRef(ghRef),
+				waitfor.WithChecks(strings.Join(args, ",")),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create validator: %w", err)
+			}
+
+			cmd.SilenceUsage = true
+			return doValidateCmd(ctx, cmd, v)
+		},
+	}
+
+	cmd.Flags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+	cmd.Flags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
+	cmd.MarkFlagRequired("ref")
+	cmd.Flags().UintVar(&timeoutSecond, "timeout", 600, "set wait timeout second")
+	cmd.Flags().UintVar(&validateInvalSecond, "interval", 10, "set wait interval second")
+
+	return cmd
+}
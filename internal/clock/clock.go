@@ -0,0 +1,46 @@
+package clock
+
+import "time"
+
+// Clock abstracts the passage of time, so that time dependent logic can be driven deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+type Ticker interface {
+	Stop()
+	C() <-chan time.Time
+}
+
+type realClock struct{}
+
+// New returns the Clock backed by the system time.
+func New() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{tic: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	tic *time.Ticker
+}
+
+func (rt *realTicker) Stop() {
+	rt.tic.Stop()
+}
+
+func (rt *realTicker) C() <-chan time.Time {
+	return rt.tic.C
+}
@@ -0,0 +1,102 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+)
+
+// Clock is a manually driven clock. Time only moves forward when Advance is called.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	at      time.Time
+	period  time.Duration
+	ch      chan time.Time
+	stopped bool
+}
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	return c.add(d, 0).ch
+}
+
+func (c *Clock) NewTicker(d time.Duration) clock.Ticker {
+	return &ticker{w: c.add(d, d), c: c}
+}
+
+// Advance moves the clock forward and fires all the timers and tickers which became due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	active := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.stopped {
+			continue
+		}
+		for !w.at.After(c.now) {
+			select {
+			case w.ch <- w.at:
+			default: // Drop the tick as time.Ticker does for slow receivers.
+			}
+			if w.period == 0 {
+				w.stopped = true
+				break
+			}
+			w.at = w.at.Add(w.period)
+		}
+		if !w.stopped {
+			active = append(active, w)
+		}
+	}
+	c.waiters = active
+}
+
+func (c *Clock) add(d, period time.Duration) *waiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &waiter{
+		at:     c.now.Add(d),
+		period: period,
+		ch:     make(chan time.Time, 1),
+	}
+	c.waiters = append(c.waiters, w)
+	return w
+}
+
+type ticker struct {
+	w *waiter
+	c *Clock
+}
+
+func (t *ticker) Stop() {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	t.w.stopped = true
+}
+
+func (t *ticker) C() <-chan time.Time {
+	return t.w.ch
+}
+
+var (
+	_ clock.Clock  = &Clock{}
+	_ clock.Ticker = &ticker{}
+)
@@ -0,0 +1,55 @@
+// Package fingerprint identifies the content the gate publishes, so that updates which would not change anything
+// are skipped instead of spamming notifications and spending API writes on every poll.
+package fingerprint
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+const markerPrefix = "<!-- merge-gatekeeper-fingerprint: "
+
+const maxCommentsPerPage = 100
+
+// Of returns the fingerprint of the content made of the parts.
+func Of(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		// The length prefix keeps the boundaries of the parts, e.g) ("ab", "c") differs from ("a", "bc").
+		fmt.Fprintf(h, "%d:%s", len(p), p)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Marker returns the hidden marker embedding the fingerprint in comments.
+func Marker(fp string) string {
+	return markerPrefix + fp + " -->"
+}
+
+// Commented reports whether any comment of the issue or pull request carries the marker of the fingerprint.
+func Commented(ctx context.Context, c github.Client, owner, repo string, number int, fp string) (bool, error) {
+	marker := Marker(fp)
+	page := 1
+	for {
+		comments, _, err := c.ListIssueComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{Page: page, PerPage: maxCommentsPerPage},
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to list comments of #%d: %w", number, err)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				return true, nil
+			}
+		}
+		if len(comments) < maxCommentsPerPage {
+			return false, nil
+		}
+		page++
+	}
+}
@@ -0,0 +1,55 @@
+package fingerprint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func TestOf(t *testing.T) {
+	if Of("ab", "c") == Of("a", "bc") {
+		t.Error("Of() must differ by the boundaries of the parts")
+	}
+	if Of("state", "description") != Of("state", "description") {
+		t.Error("Of() must be stable")
+	}
+}
+
+func TestCommented(t *testing.T) {
+	fp := Of("content")
+	tests := map[string]struct {
+		bodies []string
+		want   bool
+	}{
+		"finds the marker": {
+			bodies: []string{"LGTM", "guidance\n\n" + Marker(fp)},
+			want:   true,
+		},
+		"ignores markers of other content": {
+			bodies: []string{"guidance\n\n" + Marker(Of("other"))},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListIssueCommentsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+					comments := make([]*github.IssueComment, 0, len(tt.bodies))
+					for _, body := range tt.bodies {
+						body := body
+						comments = append(comments, &github.IssueComment{Body: &body})
+					}
+					return comments, nil, nil
+				},
+			}
+			got, err := Commented(context.Background(), c, "owner", "repo", 1, fp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Commented() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
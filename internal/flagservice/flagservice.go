@@ -0,0 +1,90 @@
+// Package flagservice looks up feature flags in feature flag services, such as LaunchDarkly and Unleash.
+package flagservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Supported services.
+const (
+	KindLaunchDarkly = "launchdarkly"
+	KindUnleash      = "unleash"
+)
+
+// States of feature flags in the environment.
+const (
+	StateOn       = "on"
+	StateOff      = "off"
+	StateArchived = "archived"
+)
+
+// ErrNotFound is returned when the flag does not exist in the service.
+var ErrNotFound = errors.New("feature flag not found")
+
+// Flag is a feature flag as seen in the environment.
+type Flag struct {
+	Key   string
+	State string
+}
+
+// Service looks up feature flags.
+type Service interface {
+	Flag(ctx context.Context, key string) (*Flag, error)
+}
+
+// Config describes how to reach the service.
+type Config struct {
+	Kind        string
+	URL         string // Base URL of the API. Defaults to the SaaS of LaunchDarkly, and is required by Unleash.
+	Token       string
+	Project     string
+	Environment string
+}
+
+// New returns the Service of the kind.
+func New(cfg Config) (Service, error) {
+	if len(cfg.Token) == 0 {
+		return nil, errors.New("token of feature flag service is empty")
+	}
+	if len(cfg.Environment) == 0 {
+		return nil, errors.New("environment of feature flag service is empty")
+	}
+	switch strings.ToLower(cfg.Kind) {
+	case KindLaunchDarkly:
+		return newLaunchDarkly(cfg), nil
+	case KindUnleash:
+		if len(cfg.URL) == 0 {
+			return nil, errors.New("url of unleash is empty")
+		}
+		return newUnleash(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported feature flag service: %s, supported services: [%s %s]", cfg.Kind, KindLaunchDarkly, KindUnleash)
+	}
+}
+
+// get requests the url with the token, and returns the body of successful responses.
+func get(ctx context.Context, httpClient *http.Client, url, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Accept", "application/json")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case res.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
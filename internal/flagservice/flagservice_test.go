@@ -0,0 +1,102 @@
+package flagservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		cfg     Config
+		wantErr bool
+	}{
+		"returns launchdarkly without url": {
+			cfg: Config{Kind: "LaunchDarkly", Token: "token", Environment: "production"},
+		},
+		"returns error for unleash without url": {
+			cfg:     Config{Kind: KindUnleash, Token: "token", Environment: "production"},
+			wantErr: true,
+		},
+		"returns error without token": {
+			cfg:     Config{Kind: KindLaunchDarkly, Environment: "production"},
+			wantErr: true,
+		},
+		"returns error for unsupported service": {
+			cfg:     Config{Kind: "flagsmith", Token: "token", Environment: "production"},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_Flag(t *testing.T) {
+	tests := map[string]struct {
+		kind    string
+		path    string
+		body    string
+		want    *Flag
+		wantErr error
+	}{
+		"returns enabled launchdarkly flag": {
+			kind: KindLaunchDarkly,
+			path: "/api/v2/flags/web/new-checkout",
+			body: `{"key": "new-checkout", "archived": false, "environments": {"production": {"on": true}}}`,
+			want: &Flag{Key: "new-checkout", State: StateOn},
+		},
+		"returns archived launchdarkly flag": {
+			kind: KindLaunchDarkly,
+			path: "/api/v2/flags/web/new-checkout",
+			body: `{"key": "new-checkout", "archived": true, "environments": {"production": {"on": true}}}`,
+			want: &Flag{Key: "new-checkout", State: StateArchived},
+		},
+		"returns disabled unleash toggle": {
+			kind: KindUnleash,
+			path: "/api/admin/projects/web/features/new-checkout",
+			body: `{"name": "new-checkout", "environments": [{"name": "development", "enabled": true}, {"name": "production", "enabled": false}]}`,
+			want: &Flag{Key: "new-checkout", State: StateOff},
+		},
+		"returns not found": {
+			kind:    KindUnleash,
+			path:    "/unknown",
+			wantErr: ErrNotFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "token" {
+					t.Errorf("Authorization = %s, want token", r.Header.Get("Authorization"))
+				}
+				if r.URL.Path != tt.path {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			s, err := New(Config{Kind: tt.kind, URL: srv.URL, Token: "token", Project: "web", Environment: "production"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.Flag(context.Background(), "new-checkout")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Flag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flag() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
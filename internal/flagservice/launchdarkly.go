@@ -0,0 +1,67 @@
+package flagservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const launchDarklyURL = "https://app.launchdarkly.com"
+
+type launchDarkly struct {
+	baseURL     string
+	token       string
+	project     string
+	environment string
+	httpClient  *http.Client
+}
+
+func newLaunchDarkly(cfg Config) *launchDarkly {
+	ld := &launchDarkly{
+		baseURL:     strings.TrimSuffix(cfg.URL, "/"),
+		token:       cfg.Token,
+		project:     cfg.Project,
+		environment: cfg.Environment,
+		httpClient:  http.DefaultClient,
+	}
+	if len(ld.baseURL) == 0 {
+		ld.baseURL = launchDarklyURL
+	}
+	if len(ld.project) == 0 {
+		ld.project = "default"
+	}
+	return ld
+}
+
+// Flag returns the flag in the environment.
+// NOTE: https://apidocs.launchdarkly.com/tag/Feature-flags#operation/getFeatureFlag
+func (ld *launchDarkly) Flag(ctx context.Context, key string) (*Flag, error) {
+	u := fmt.Sprintf("%s/api/v2/flags/%s/%s?env=%s", ld.baseURL, url.PathEscape(ld.project), url.PathEscape(key), url.QueryEscape(ld.environment))
+	b, err := get(ctx, ld.httpClient, u, ld.token)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Archived     bool `json:"archived"`
+		Environments map[string]struct {
+			On bool `json:"on"`
+		} `json:"environments"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode flag %s: %w", key, err)
+	}
+	env, ok := res.Environments[ld.environment]
+	switch {
+	case res.Archived:
+		return &Flag{Key: key, State: StateArchived}, nil
+	case !ok:
+		return nil, fmt.Errorf("flag %s has no environment %s", key, ld.environment)
+	case env.On:
+		return &Flag{Key: key, State: StateOn}, nil
+	default:
+		return &Flag{Key: key, State: StateOff}, nil
+	}
+}
@@ -0,0 +1,65 @@
+package flagservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type unleash struct {
+	baseURL     string
+	token       string
+	project     string
+	environment string
+	httpClient  *http.Client
+}
+
+func newUnleash(cfg Config) *unleash {
+	u := &unleash{
+		baseURL:     strings.TrimSuffix(cfg.URL, "/"),
+		token:       cfg.Token,
+		project:     cfg.Project,
+		environment: cfg.Environment,
+		httpClient:  http.DefaultClient,
+	}
+	if len(u.project) == 0 {
+		u.project = "default"
+	}
+	return u
+}
+
+// Flag returns the feature toggle in the environment. Archived toggles are not found by the admin API.
+// NOTE: https://docs.getunleash.io/reference/api/unleash/get-feature
+func (u *unleash) Flag(ctx context.Context, key string) (*Flag, error) {
+	endpoint := fmt.Sprintf("%s/api/admin/projects/%s/features/%s", u.baseURL, url.PathEscape(u.project), url.PathEscape(key))
+	b, err := get(ctx, u.httpClient, endpoint, u.token)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Archived     bool `json:"archived"`
+		Environments []struct {
+			Name    string `json:"name"`
+			Enabled bool   `json:"enabled"`
+		} `json:"environments"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode feature %s: %w", key, err)
+	}
+	if res.Archived {
+		return &Flag{Key: key, State: StateArchived}, nil
+	}
+	for _, env := range res.Environments {
+		if env.Name != u.environment {
+			continue
+		}
+		if env.Enabled {
+			return &Flag{Key: key, State: StateOn}, nil
+		}
+		return &Flag{Key: key, State: StateOff}, nil
+	}
+	return nil, fmt.Errorf("feature %s has no environment %s", key, u.environment)
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxArtifactSize limits the size of downloaded artifacts, as they are read into memory.
+const maxArtifactSize = 64 << 20
+
+// artifactTimeout bounds the download of an archive, including reading its body, so that a stalled download does
+// not hold the validation until it times out.
+const artifactTimeout = 2 * time.Minute
+
+// NOTE: go-github does not cover the digest of artifacts nor the attestations API yet, so they are requested directly.
+
+// Artifact is an artifact uploaded by a workflow run.
+type Artifact struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+	Digest      string `json:"digest"` // e.g) sha256:..., empty for artifacts uploaded before digests were recorded.
+	Expired     bool   `json:"expired"`
+}
+
+type ArtifactList struct {
+	TotalCount int         `json:"total_count"`
+	Artifacts  []*Artifact `json:"artifacts"`
+}
+
+// Attestation is a sigstore bundle attesting an artifact, such as a SLSA provenance or an SBOM.
+type Attestation struct {
+	RepositoryID int64           `json:"repository_id"`
+	Bundle       json.RawMessage `json:"bundle"`
+}
+
+type attestationList struct {
+	Attestations []*Attestation `json:"attestations"`
+}
+
+func (c *client) ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *ListOptions) (*ArtifactList, *Response, error) {
+	u := withListOptions(fmt.Sprintf("repos/%v/%v/actions/runs/%v/artifacts", owner, repo, runID), opts)
+	req, err := c.ghc.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	list := &ArtifactList{}
+	resp, err := c.ghc.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+func (c *client) ListAttestations(ctx context.Context, owner, repo, subjectDigest string, opts *ListOptions) ([]*Attestation, *Response, error) {
+	u := withListOptions(fmt.Sprintf("repos/%v/%v/attestations/%v", owner, repo, subjectDigest), opts)
+	req, err := c.ghc.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	list := &attestationList{}
+	resp, err := c.ghc.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list.Attestations, resp, nil
+}
+
+// DownloadArtifact returns the zip archive of the artifact.
+func (c *client) DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *Response, error) {
+	u, resp, err := c.ghc.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// The archive is served from a pre-signed URL, which must not receive the token.
+	ctx, cancel := context.WithTimeout(ctx, artifactTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, resp, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, resp, fmt.Errorf("unexpected status code of artifact %d: %s", artifactID, res.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(res.Body, maxArtifactSize+1))
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(b) > maxArtifactSize {
+		return nil, resp, fmt.Errorf("artifact %d exceeds %d bytes", artifactID, maxArtifactSize)
+	}
+	return b, resp, nil
+}
+
+func withListOptions(u string, opts *ListOptions) string {
+	if opts == nil {
+		return u
+	}
+	if opts.PerPage != 0 {
+		u += fmt.Sprintf("?per_page=%d&page=%d", opts.PerPage, opts.Page)
+	} else if opts.Page != 0 {
+		u += fmt.Sprintf("?page=%d", opts.Page)
+	}
+	return u
+}
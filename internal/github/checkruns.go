@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTooManyCheckRuns is returned when the check runs of a commit do not fit in a single query of the GraphQL API,
+// which are then listed from the REST API instead.
+var ErrTooManyCheckRuns = errors.New("too many check suites or check runs to list from the GraphQL API")
+
+// NOTE: Check suites and their check runs are nested connections, which can not be paginated together, so only the
+// first page of each is queried. The latest check runs are queried, as the REST API lists by default.
+const checkRunsQuery = `query($owner: String!, $repo: String!, $ref: String!) {
+  repository(owner: $owner, name: $repo) {
+    object(expression: $ref) {
+      ... on Commit {
+        checkSuites(first: 100) {
+          pageInfo { hasNextPage }
+          nodes {
+            databaseId
+            app { slug }
+            checkRuns(first: 100, filterBy: {checkType: LATEST}) {
+              pageInfo { hasNextPage }
+              nodes { databaseId name status conclusion startedAt completedAt }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type pageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+type checkRunsResponse struct {
+	Repository *struct {
+		Object *struct {
+			CheckSuites *struct {
+				PageInfo pageInfo `json:"pageInfo"`
+				Nodes    []struct {
+					DatabaseID int64 `json:"databaseId"`
+					App        *struct {
+						Slug string `json:"slug"`
+					} `json:"app"`
+					CheckRuns struct {
+						PageInfo pageInfo `json:"pageInfo"`
+						Nodes    []struct {
+							DatabaseID  int64      `json:"databaseId"`
+							Name        string     `json:"name"`
+							Status      string     `json:"status"`
+							Conclusion  *string    `json:"conclusion"`
+							StartedAt   *Timestamp `json:"startedAt"`
+							CompletedAt *Timestamp `json:"completedAt"`
+						} `json:"nodes"`
+					} `json:"checkRuns"`
+				} `json:"nodes"`
+			} `json:"checkSuites"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+// ListCheckRunsByGraphQL lists the check runs of the commit from the GraphQL API in a single request, as the REST
+// API lists them. The states of the GraphQL API, e.g) IN_PROGRESS, are converted to those of the REST API, e.g)
+// in_progress. ErrTooManyCheckRuns is returned when they do not fit in the request.
+func (c *client) ListCheckRunsByGraphQL(ctx context.Context, owner, repo, ref string) ([]*CheckRun, *Response, error) {
+	res := &checkRunsResponse{}
+	resp, err := c.queryGraphQL(ctx, checkRunsQuery, map[string]string{"owner": owner, "repo": repo, "ref": ref}, res)
+	if err != nil {
+		return nil, resp, err
+	}
+	if res.Repository == nil || res.Repository.Object == nil {
+		return nil, resp, fmt.Errorf("commit %s is not found", ref)
+	}
+	suites := res.Repository.Object.CheckSuites
+	if suites == nil {
+		return nil, resp, nil
+	}
+	if suites.PageInfo.HasNextPage {
+		return nil, resp, ErrTooManyCheckRuns
+	}
+
+	var runs []*CheckRun
+	for i := range suites.Nodes {
+		suite := &suites.Nodes[i]
+		if suite.CheckRuns.PageInfo.HasNextPage {
+			return nil, resp, ErrTooManyCheckRuns
+		}
+		var app *App
+		if suite.App != nil {
+			app = &App{Slug: &suite.App.Slug}
+		}
+		for j := range suite.CheckRuns.Nodes {
+			node := &suite.CheckRuns.Nodes[j]
+			run := &CheckRun{
+				ID:          &node.DatabaseID,
+				Name:        &node.Name,
+				Status:      lower(node.Status),
+				CheckSuite:  &CheckSuite{ID: &suite.DatabaseID},
+				App:         app,
+				StartedAt:   node.StartedAt,
+				CompletedAt: node.CompletedAt,
+			}
+			if node.Conclusion != nil {
+				run.Conclusion = lower(*node.Conclusion)
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs, resp, nil
+}
+
+func lower(s string) *string {
+	s = strings.ToLower(s)
+	return &s
+}
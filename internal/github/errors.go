@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/google/go-github/v66/github"
+)
+
+type (
+	ErrorResponse       = github.ErrorResponse
+	RateLimitError      = github.RateLimitError
+	AbuseRateLimitError = github.AbuseRateLimitError
+)
+
+// IsAPIError reports whether err is an error of calling the GitHub API, either returned by the API or by the network.
+func IsAPIError(err error) bool {
+	var rateLimitErr *RateLimitError
+	var abuseErr *AbuseRateLimitError
+	var resErr *ErrorResponse
+	var netErr net.Error
+	return errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) || errors.As(err, &resErr) || errors.As(err, &netErr)
+}
+
+// IsTransient reports whether err is an error of the GitHub API which is likely to go away when retried,
+// such as server errors, rate limits and failures of the network, rather than an error of the request itself.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var rateLimitErr *RateLimitError
+	var abuseErr *AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return true
+	}
+	var resErr *ErrorResponse
+	if errors.As(err, &resErr) {
+		return resErr.Response != nil && resErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsForbidden reports whether err is an error of the GitHub API refusing the request for the permissions of the
+// token, e.g) writes by read-only tokens. Rate limits, which are also responded with 403, are not.
+func IsForbidden(err error) bool {
+	var rateLimitErr *RateLimitError
+	var abuseErr *AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return false
+	}
+	var resErr *ErrorResponse
+	return errors.As(err, &resErr) && resErr.Response != nil && resErr.Response.StatusCode == http.StatusForbidden
+}
@@ -12,34 +12,152 @@ type (
 	CombinedStatus          = github.CombinedStatus
 	RepoStatus              = github.RepoStatus
 	Response                = github.Response
+	Timestamp               = github.Timestamp
 	ListWorkflowRunsOptions = github.ListWorkflowRunsOptions
 )
 
 type (
 	CheckRun             = github.CheckRun
+	CheckRunOutput       = github.CheckRunOutput
 	CheckSuite           = github.CheckSuite
+	App                  = github.App
 	ListCheckRunsOptions = github.ListCheckRunsOptions
 	ListCheckRunsResults = github.ListCheckRunsResults
 	WorkflowRuns         = github.WorkflowRuns
 	WorkflowRun          = github.WorkflowRun
+	Workflows            = github.Workflows
+	Workflow             = github.Workflow
+)
+
+type (
+	RepositoryContent           = github.RepositoryContent
+	RepositoryContentGetOptions = github.RepositoryContentGetOptions
+)
+
+type (
+	PullRequest            = github.PullRequest
+	PullRequestBranch      = github.PullRequestBranch
+	PullRequestListOptions = github.PullRequestListOptions
+	PullRequestOptions     = github.PullRequestOptions
+	PullRequestMergeResult = github.PullRequestMergeResult
+)
+
+type (
+	Repository             = github.Repository
+	Issue                  = github.Issue
+	IssueRequest           = github.IssueRequest
+	IssueListByRepoOptions = github.IssueListByRepoOptions
+	PullRequestLinks       = github.PullRequestLinks
+	Milestone              = github.Milestone
+	MilestoneListOptions   = github.MilestoneListOptions
+)
+
+type (
+	Deployment             = github.Deployment
+	DeploymentStatus       = github.DeploymentStatus
+	DeploymentsListOptions = github.DeploymentsListOptions
+	PullRequestReview      = github.PullRequestReview
+	Label                  = github.Label
+	User                   = github.User
+)
+
+type (
+	CommitFile                 = github.CommitFile
+	TeamListTeamMembersOptions = github.TeamListTeamMembersOptions
+	RepositoryCommit           = github.RepositoryCommit
+	IssueComment               = github.IssueComment
+	IssueListCommentsOptions   = github.IssueListCommentsOptions
+	Commit                     = github.Commit
+	CommitAuthor               = github.CommitAuthor
+)
+
+type (
+	Reference                    = github.Reference
+	GitObject                    = github.GitObject
+	RepositoryContentFileOptions = github.RepositoryContentFileOptions
+	RepositoryContentResponse    = github.RepositoryContentResponse
+	NewPullRequest               = github.NewPullRequest
+	Protection                   = github.Protection
+	ProtectionRequest            = github.ProtectionRequest
+	RequiredStatusChecks         = github.RequiredStatusChecks
+	RequiredStatusCheck          = github.RequiredStatusCheck
+	RequiredStatusChecksRequest  = github.RequiredStatusChecksRequest
+	RepositoryRelease            = github.RepositoryRelease
 )
 
 type Client interface {
 	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error)
 	ListWorkflowRuns(ctx context.Context, owner, repo string, opts *ListWorkflowRunsOptions) (*WorkflowRuns, *github.Response, error)
+	ListWorkflows(ctx context.Context, owner, repo string, opts *ListOptions) (*Workflows, *Response, error)
+	GetContents(ctx context.Context, owner, repo, path string, opts *RepositoryContentGetOptions) (*RepositoryContent, []*RepositoryContent, *Response, error)
+	ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *ListOptions) ([]*PullRequest, *Response, error)
+	ListPullRequests(ctx context.Context, owner, repo string, opts *PullRequestListOptions) ([]*PullRequest, *Response, error)
+	GetRepository(ctx context.Context, owner, repo string) (*Repository, *Response, error)
+	ListIssues(ctx context.Context, owner, repo string, opts *IssueListByRepoOptions) ([]*Issue, *Response, error)
+	CreateIssue(ctx context.Context, owner, repo string, issue *IssueRequest) (*Issue, *Response, error)
+	GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error)
+	ListDeployments(ctx context.Context, owner, repo string, opts *DeploymentsListOptions) ([]*Deployment, *Response, error)
+	ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *ListOptions) ([]*DeploymentStatus, *Response, error)
+	ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error)
+	ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *ListOptions) (*ArtifactList, *Response, error)
+	ListAttestations(ctx context.Context, owner, repo, subjectDigest string, opts *ListOptions) ([]*Attestation, *Response, error)
+	DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *Response, error)
+	ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*Label, *Response, error)
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error)
+	ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error)
+	ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*RepositoryCommit, *Response, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error)
+	EditIssue(ctx context.Context, owner, repo string, number int, issue *IssueRequest) (*Issue, *Response, error)
+	AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*Label, *Response, error)
+	RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*Response, error)
+	MergePullRequest(ctx context.Context, owner, repo string, number int, commitMessage string, opts *PullRequestOptions) (*PullRequestMergeResult, *Response, error)
+	ListMilestones(ctx context.Context, owner, repo string, opts *MilestoneListOptions) ([]*Milestone, *Response, error)
+	CreateStatus(ctx context.Context, owner, repo, ref string, status *RepoStatus) (*RepoStatus, *Response, error)
+	GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error)
+	ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error)
+	GetCheckRollup(ctx context.Context, owner, repo, ref string) (*CheckRollup, *Response, error)
+	ListCheckRunsByGraphQL(ctx context.Context, owner, repo, ref string) ([]*CheckRun, *Response, error)
+	CreateRef(ctx context.Context, owner, repo string, ref *Reference) (*Reference, *Response, error)
+	CreateFile(ctx context.Context, owner, repo, path string, opts *RepositoryContentFileOptions) (*RepositoryContentResponse, *Response, error)
+	CreatePullRequest(ctx context.Context, owner, repo string, pull *NewPullRequest) (*PullRequest, *Response, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*Protection, *Response, error)
+	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *ProtectionRequest) (*Protection, *Response, error)
+	UpdateRequiredStatusChecks(ctx context.Context, owner, repo, branch string, sreq *RequiredStatusChecksRequest) (*RequiredStatusChecks, *Response, error)
+	ListReleases(ctx context.Context, owner, repo string, opts *ListOptions) ([]*RepositoryRelease, *Response, error)
 }
 
 type client struct {
 	ghc *github.Client
 }
 
-func NewClient(ctx context.Context, token string) Client {
+// ClientOption configures the client returned by NewClient.
+type ClientOption func(*github.Client)
+
+// WithUserAgent sets the User-Agent of every request, so that administrators of GitHub Enterprise Server and GitHub
+// support can attribute the requests to the gate. The default User-Agent of go-github is used when empty.
+func WithUserAgent(ua string) ClientOption {
+	return func(ghc *github.Client) {
+		if len(ua) != 0 {
+			ghc.UserAgent = ua
+		}
+	}
+}
+
+// NewClient returns the client of the API authenticated by the token, whose requests are counted by DefaultStats.
+func NewClient(ctx context.Context, token string, opts ...ClientOption) Client {
+	hc := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
+		&oauth2.Token{
+			AccessToken: token,
+		},
+	))
+	hc.Transport = &countingTransport{base: hc.Transport, stats: DefaultStats}
+	ghc := github.NewClient(hc)
+	for _, opt := range opts {
+		opt(ghc)
+	}
 	return &client{
-		ghc: github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-			&oauth2.Token{
-				AccessToken: token,
-			},
-		))),
+		ghc: ghc,
 	}
 }
 
@@ -54,3 +172,127 @@ func (c *client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref strin
 func (c *client) ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
 	return c.ghc.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
 }
+
+func (c *client) ListWorkflows(ctx context.Context, owner, repo string, opts *ListOptions) (*Workflows, *Response, error) {
+	return c.ghc.Actions.ListWorkflows(ctx, owner, repo, opts)
+}
+
+func (c *client) GetContents(ctx context.Context, owner, repo, path string, opts *RepositoryContentGetOptions) (*RepositoryContent, []*RepositoryContent, *Response, error) {
+	return c.ghc.Repositories.GetContents(ctx, owner, repo, path, opts)
+}
+
+func (c *client) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *ListOptions) ([]*PullRequest, *Response, error) {
+	return c.ghc.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+}
+
+func (c *client) ListPullRequests(ctx context.Context, owner, repo string, opts *PullRequestListOptions) ([]*PullRequest, *Response, error) {
+	return c.ghc.PullRequests.List(ctx, owner, repo, opts)
+}
+
+func (c *client) GetRepository(ctx context.Context, owner, repo string) (*Repository, *Response, error) {
+	return c.ghc.Repositories.Get(ctx, owner, repo)
+}
+
+func (c *client) ListIssues(ctx context.Context, owner, repo string, opts *IssueListByRepoOptions) ([]*Issue, *Response, error) {
+	return c.ghc.Issues.ListByRepo(ctx, owner, repo, opts)
+}
+
+func (c *client) CreateIssue(ctx context.Context, owner, repo string, issue *IssueRequest) (*Issue, *Response, error) {
+	return c.ghc.Issues.Create(ctx, owner, repo, issue)
+}
+
+func (c *client) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error) {
+	return c.ghc.Repositories.GetCommitSHA1(ctx, owner, repo, ref, lastSHA)
+}
+
+func (c *client) ListDeployments(ctx context.Context, owner, repo string, opts *DeploymentsListOptions) ([]*Deployment, *Response, error) {
+	return c.ghc.Repositories.ListDeployments(ctx, owner, repo, opts)
+}
+
+func (c *client) ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *ListOptions) ([]*DeploymentStatus, *Response, error) {
+	return c.ghc.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment, opts)
+}
+
+func (c *client) ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error) {
+	return c.ghc.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+}
+
+func (c *client) ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*Label, *Response, error) {
+	return c.ghc.Issues.ListLabelsByIssue(ctx, owner, repo, number, opts)
+}
+
+func (c *client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error) {
+	return c.ghc.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+}
+
+func (c *client) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error) {
+	return c.ghc.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+}
+
+func (c *client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	return c.ghc.PullRequests.Get(ctx, owner, repo, number)
+}
+
+func (c *client) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*RepositoryCommit, *Response, error) {
+	return c.ghc.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+}
+
+func (c *client) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error) {
+	return c.ghc.Issues.CreateComment(ctx, owner, repo, number, comment)
+}
+
+func (c *client) EditIssue(ctx context.Context, owner, repo string, number int, issue *IssueRequest) (*Issue, *Response, error) {
+	return c.ghc.Issues.Edit(ctx, owner, repo, number, issue)
+}
+
+func (c *client) AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*Label, *Response, error) {
+	return c.ghc.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+}
+
+func (c *client) RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*Response, error) {
+	return c.ghc.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+}
+
+func (c *client) MergePullRequest(ctx context.Context, owner, repo string, number int, commitMessage string, opts *PullRequestOptions) (*PullRequestMergeResult, *Response, error) {
+	return c.ghc.PullRequests.Merge(ctx, owner, repo, number, commitMessage, opts)
+}
+
+func (c *client) ListMilestones(ctx context.Context, owner, repo string, opts *MilestoneListOptions) ([]*Milestone, *Response, error) {
+	return c.ghc.Issues.ListMilestones(ctx, owner, repo, opts)
+}
+
+func (c *client) CreateStatus(ctx context.Context, owner, repo, ref string, status *RepoStatus) (*RepoStatus, *Response, error) {
+	return c.ghc.Repositories.CreateStatus(ctx, owner, repo, ref, status)
+}
+
+func (c *client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error) {
+	return c.ghc.Issues.ListComments(ctx, owner, repo, number, opts)
+}
+
+func (c *client) CreateRef(ctx context.Context, owner, repo string, ref *Reference) (*Reference, *Response, error) {
+	return c.ghc.Git.CreateRef(ctx, owner, repo, ref)
+}
+
+func (c *client) CreateFile(ctx context.Context, owner, repo, path string, opts *RepositoryContentFileOptions) (*RepositoryContentResponse, *Response, error) {
+	return c.ghc.Repositories.CreateFile(ctx, owner, repo, path, opts)
+}
+
+func (c *client) CreatePullRequest(ctx context.Context, owner, repo string, pull *NewPullRequest) (*PullRequest, *Response, error) {
+	return c.ghc.PullRequests.Create(ctx, owner, repo, pull)
+}
+
+func (c *client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*Protection, *Response, error) {
+	return c.ghc.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+}
+
+func (c *client) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *ProtectionRequest) (*Protection, *Response, error) {
+	return c.ghc.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, preq)
+}
+
+func (c *client) UpdateRequiredStatusChecks(ctx context.Context, owner, repo, branch string, sreq *RequiredStatusChecksRequest) (*RequiredStatusChecks, *Response, error) {
+	return c.ghc.Repositories.UpdateRequiredStatusChecks(ctx, owner, repo, branch, sreq)
+}
+
+func (c *client) ListReleases(ctx context.Context, owner, repo string, opts *ListOptions) ([]*RepositoryRelease, *Response, error) {
+	return c.ghc.Repositories.ListReleases(ctx, owner, repo, opts)
+}
@@ -7,8 +7,45 @@ import (
 )
 
 type Client struct {
-	ListCheckRunsForRefFunc func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
-	ListWorkflowRunsFunc    func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	ListCheckRunsForRefFunc        func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	ListWorkflowRunsFunc           func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	ListWorkflowsFunc              func(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error)
+	GetContentsFunc                func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	ListPullRequestsWithCommitFunc func(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error)
+	ListPullRequestsFunc           func(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+	GetRepositoryFunc              func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	ListIssuesFunc                 func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	CreateIssueFunc                func(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	GetCommitSHA1Func              func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error)
+	ListDeploymentsFunc            func(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error)
+	ListDeploymentStatusesFunc     func(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error)
+	ListReviewsFunc                func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error)
+	ListWorkflowRunArtifactsFunc   func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error)
+	ListAttestationsFunc           func(ctx context.Context, owner, repo, subjectDigest string, opts *github.ListOptions) ([]*github.Attestation, *github.Response, error)
+	DownloadArtifactFunc           func(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error)
+	ListLabelsByIssueFunc          func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
+	ListPullRequestFilesFunc       func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	ListTeamMembersBySlugFunc      func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error)
+	GetPullRequestFunc             func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListPullRequestCommitsFunc     func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	CreateIssueCommentFunc         func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditIssueFunc                  func(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	AddLabelsToIssueFunc           func(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error)
+	RemoveLabelForIssueFunc        func(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error)
+	MergePullRequestFunc           func(ctx context.Context, owner, repo string, number int, commitMessage string, opts *github.PullRequestOptions) (*github.PullRequestMergeResult, *github.Response, error)
+	ListMilestonesFunc             func(ctx context.Context, owner, repo string, opts *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error)
+	CreateStatusFunc               func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error)
+	GetCombinedStatusFunc          func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
+	ListIssueCommentsFunc          func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	GetCheckRollupFunc             func(ctx context.Context, owner, repo, ref string) (*github.CheckRollup, *github.Response, error)
+	ListCheckRunsByGraphQLFunc     func(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, *github.Response, error)
+	CreateRefFunc                  func(ctx context.Context, owner, repo string, ref *github.Reference) (*github.Reference, *github.Response, error)
+	CreateFileFunc                 func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	CreatePullRequestFunc          func(ctx context.Context, owner, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error)
+	GetBranchProtectionFunc        func(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	UpdateBranchProtectionFunc     func(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error)
+	UpdateRequiredStatusChecksFunc func(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error)
+	ListReleasesFunc               func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
 }
 
 func (c *Client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
@@ -19,6 +56,154 @@ func (c *Client) ListWorkflowRuns(ctx context.Context, owner, repo string, opts
 	return c.ListWorkflowRunsFunc(ctx, owner, repo, opts)
 }
 
+func (c *Client) ListWorkflows(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+	return c.ListWorkflowsFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	return c.GetContentsFunc(ctx, owner, repo, path, opts)
+}
+
+func (c *Client) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return c.ListPullRequestsWithCommitFunc(ctx, owner, repo, sha, opts)
+}
+
+func (c *Client) ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return c.ListPullRequestsFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return c.GetRepositoryFunc(ctx, owner, repo)
+}
+
+func (c *Client) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return c.ListIssuesFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return c.CreateIssueFunc(ctx, owner, repo, issue)
+}
+
+func (c *Client) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+	return c.GetCommitSHA1Func(ctx, owner, repo, ref, lastSHA)
+}
+
+func (c *Client) ListDeployments(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error) {
+	return c.ListDeploymentsFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error) {
+	return c.ListDeploymentStatusesFunc(ctx, owner, repo, deployment, opts)
+}
+
+func (c *Client) ListReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	return c.ListReviewsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	return c.ListWorkflowRunArtifactsFunc(ctx, owner, repo, runID, opts)
+}
+
+func (c *Client) ListAttestations(ctx context.Context, owner, repo, subjectDigest string, opts *github.ListOptions) ([]*github.Attestation, *github.Response, error) {
+	return c.ListAttestationsFunc(ctx, owner, repo, subjectDigest, opts)
+}
+
+func (c *Client) DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+	return c.DownloadArtifactFunc(ctx, owner, repo, artifactID)
+}
+
+func (c *Client) ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	return c.ListLabelsByIssueFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	return c.ListPullRequestFilesFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+	return c.ListTeamMembersBySlugFunc(ctx, org, slug, opts)
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return c.GetPullRequestFunc(ctx, owner, repo, number)
+}
+
+func (c *Client) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return c.ListPullRequestCommitsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return c.CreateIssueCommentFunc(ctx, owner, repo, number, comment)
+}
+
+func (c *Client) EditIssue(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return c.EditIssueFunc(ctx, owner, repo, number, issue)
+}
+
+func (c *Client) AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	return c.AddLabelsToIssueFunc(ctx, owner, repo, number, labels)
+}
+
+func (c *Client) RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error) {
+	return c.RemoveLabelForIssueFunc(ctx, owner, repo, number, label)
+}
+
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, commitMessage string, opts *github.PullRequestOptions) (*github.PullRequestMergeResult, *github.Response, error) {
+	return c.MergePullRequestFunc(ctx, owner, repo, number, commitMessage, opts)
+}
+
+func (c *Client) ListMilestones(ctx context.Context, owner, repo string, opts *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error) {
+	return c.ListMilestonesFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	return c.CreateStatusFunc(ctx, owner, repo, ref, status)
+}
+
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	return c.GetCombinedStatusFunc(ctx, owner, repo, ref, opts)
+}
+
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return c.ListIssueCommentsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) GetCheckRollup(ctx context.Context, owner, repo, ref string) (*github.CheckRollup, *github.Response, error) {
+	return c.GetCheckRollupFunc(ctx, owner, repo, ref)
+}
+
+func (c *Client) ListCheckRunsByGraphQL(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, *github.Response, error) {
+	return c.ListCheckRunsByGraphQLFunc(ctx, owner, repo, ref)
+}
+
 var (
 	_ github.Client = &Client{}
 )
+
+func (c *Client) CreateRef(ctx context.Context, owner, repo string, ref *github.Reference) (*github.Reference, *github.Response, error) {
+	return c.CreateRefFunc(ctx, owner, repo, ref)
+}
+
+func (c *Client) CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return c.CreateFileFunc(ctx, owner, repo, path, opts)
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+	return c.CreatePullRequestFunc(ctx, owner, repo, pull)
+}
+
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	return c.GetBranchProtectionFunc(ctx, owner, repo, branch)
+}
+
+func (c *Client) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+	return c.UpdateBranchProtectionFunc(ctx, owner, repo, branch, preq)
+}
+
+func (c *Client) UpdateRequiredStatusChecks(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+	return c.UpdateRequiredStatusChecksFunc(ctx, owner, repo, branch, sreq)
+}
+
+func (c *Client) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return c.ListReleasesFunc(ctx, owner, repo, opts)
+}
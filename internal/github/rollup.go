@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NOTE: The rollup of the checks of a commit is only available from the GraphQL API, so it is queried directly.
+
+const checkRollupQuery = `query($owner: String!, $repo: String!, $ref: String!) {
+  repository(owner: $owner, name: $repo) {
+    object(expression: $ref) {
+      ... on Commit {
+        statusCheckRollup {
+          state
+          contexts(first: 1) {
+            totalCount
+            checkRunCountsByState { state count }
+            statusContextCountsByState { state count }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// RollupSuccess is the state of the rollups of commits whose checks all succeeded.
+const RollupSuccess = "SUCCESS"
+
+// CheckRollup is the rollup of the check runs and commit statuses of a commit.
+type CheckRollup struct {
+	// State is the combined state, e.g) SUCCESS, PENDING or FAILURE. It is empty when the commit has no checks.
+	State string
+	Total int
+	// Counts are the numbers of check runs and commit statuses by their states, e.g) IN_PROGRESS or SUCCESS.
+	Counts map[string]int
+}
+
+// Key identifies the rollup by the counts of checks by their states, which change whenever any check changes its state.
+func (r *CheckRollup) Key() string {
+	states := make([]string, 0, len(r.Counts))
+	for state := range r.Counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:%d", r.State, r.Total)
+	for _, state := range states {
+		fmt.Fprintf(&b, ",%s=%d", state, r.Counts[state])
+	}
+	return b.String()
+}
+
+type stateCount struct {
+	State string `json:"state"`
+	Count int    `json:"count"`
+}
+
+type checkRollupResponse struct {
+	Repository *struct {
+		Object *struct {
+			StatusCheckRollup *struct {
+				State    string `json:"state"`
+				Contexts struct {
+					TotalCount                 int          `json:"totalCount"`
+					CheckRunCountsByState      []stateCount `json:"checkRunCountsByState"`
+					StatusContextCountsByState []stateCount `json:"statusContextCountsByState"`
+				} `json:"contexts"`
+			} `json:"statusCheckRollup"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+// graphQLResponse is the envelope of the responses of the GraphQL API, whose data is decoded into Data.
+type graphQLResponse struct {
+	Data   any `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// queryGraphQL sends the query to the GraphQL API and decodes its data into data. Errors of the query are returned
+// as an error, as the API responds with 200 OK to them.
+func (c *client) queryGraphQL(ctx context.Context, query string, variables map[string]string, data any) (*Response, error) {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+	req, err := c.ghc.NewRequest("POST", "graphql", body)
+	if err != nil {
+		return nil, err
+	}
+	res := &graphQLResponse{Data: data}
+	resp, err := c.ghc.Do(ctx, req, res)
+	if err != nil {
+		return resp, err
+	}
+	if len(res.Errors) != 0 {
+		msgs := make([]string, 0, len(res.Errors))
+		for _, e := range res.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return resp, errors.New(strings.Join(msgs, "; "))
+	}
+	return resp, nil
+}
+
+func (c *client) GetCheckRollup(ctx context.Context, owner, repo, ref string) (*CheckRollup, *Response, error) {
+	res := &checkRollupResponse{}
+	resp, err := c.queryGraphQL(ctx, checkRollupQuery, map[string]string{"owner": owner, "repo": repo, "ref": ref}, res)
+	if err != nil {
+		return nil, resp, err
+	}
+	if res.Repository == nil || res.Repository.Object == nil {
+		return nil, resp, fmt.Errorf("commit %s is not found", ref)
+	}
+
+	rollup := &CheckRollup{Counts: make(map[string]int)}
+	if r := res.Repository.Object.StatusCheckRollup; r != nil {
+		rollup.State = r.State
+		rollup.Total = r.Contexts.TotalCount
+		for _, sc := range append(r.Contexts.CheckRunCountsByState, r.Contexts.StatusContextCountsByState...) {
+			rollup.Counts[sc.State] += sc.Count
+		}
+	}
+	return rollup, resp, nil
+}
@@ -0,0 +1,141 @@
+package github
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Stats counts the requests made to the API during a run, so that users investigating the exhaustion of their rate
+// limit can see where it went.
+type Stats struct {
+	mu        sync.Mutex
+	requests  map[string]int // Keyed by their endpoints, e.g) GET /repos/owner/repo/commits/:sha/check-runs.
+	pages     int
+	cacheHits int
+	retries   int
+	remaining int // Remaining requests of the rate limit, as of the last response, or -1 when unknown.
+}
+
+// APIStats is a snapshot of Stats.
+type APIStats struct {
+	Requests  int
+	Endpoints []EndpointStats // The most requested first.
+	// Pages are the requests of pages after the first one of paginated lists.
+	Pages int
+	// CacheHits are the requests saved by caches, either of the API, answering 304, or of Merge Gatekeeper itself.
+	CacheHits int
+	// Retries are the polls retried after transient failures of the API.
+	Retries int
+	// RateLimitRemaining is the number of requests left within the rate limit, or nil when unknown.
+	RateLimitRemaining *int
+}
+
+// EndpointStats is the number of requests made to a single endpoint.
+type EndpointStats struct {
+	Endpoint string
+	Requests int
+}
+
+// DefaultStats counts the requests of every client created by NewClient.
+var DefaultStats = NewStats()
+
+func NewStats() *Stats {
+	return &Stats{requests: make(map[string]int), remaining: -1}
+}
+
+// CacheHit records a request saved by a cache.
+func (s *Stats) CacheHit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cacheHits++
+}
+
+// Retry records a retry after a transient failure.
+func (s *Stats) Retry() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retries++
+}
+
+// Snapshot returns the counts as of now.
+func (s *Stats) Snapshot() APIStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := APIStats{
+		Endpoints: make([]EndpointStats, 0, len(s.requests)),
+		Pages:     s.pages,
+		CacheHits: s.cacheHits,
+		Retries:   s.retries,
+	}
+	for endpoint, n := range s.requests {
+		st.Requests += n
+		st.Endpoints = append(st.Endpoints, EndpointStats{Endpoint: endpoint, Requests: n})
+	}
+	sort.Slice(st.Endpoints, func(i, j int) bool {
+		if st.Endpoints[i].Requests != st.Endpoints[j].Requests {
+			return st.Endpoints[i].Requests > st.Endpoints[j].Requests
+		}
+		return st.Endpoints[i].Endpoint < st.Endpoints[j].Endpoint
+	})
+	if s.remaining >= 0 {
+		remaining := s.remaining
+		st.RateLimitRemaining = &remaining
+	}
+	return st
+}
+
+func (s *Stats) record(req *http.Request, res *http.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[endpoint(req)]++
+	if page, err := strconv.Atoi(req.URL.Query().Get("page")); err == nil && page > 1 {
+		s.pages++
+	}
+	if res == nil {
+		return
+	}
+	if res.StatusCode == http.StatusNotModified {
+		s.cacheHits++
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		s.remaining = remaining
+	}
+}
+
+var (
+	numberSegment = regexp.MustCompile(`^[0-9]+$`)
+	shaSegment    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// endpoint identifies the endpoint of the request by its method and path, where numbers and commit SHAs are masked,
+// so that e.g) the reviews of every pull request are counted together.
+func endpoint(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, s := range segments {
+		switch {
+		case numberSegment.MatchString(s):
+			segments[i] = ":number"
+		case shaSegment.MatchString(s):
+			segments[i] = ":sha"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// countingTransport records every request made through it.
+type countingTransport struct {
+	base  http.RoundTripper
+	stats *Stats
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	t.stats.record(req, res)
+	return res, err
+}
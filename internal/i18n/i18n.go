@@ -0,0 +1,340 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Key identifies a user facing message.
+type Key string
+
+// NOTE: The English catalog is the source of truth. Every key must be defined there.
+const (
+	DetailSummary         Key = "detail.summary"
+	DetailTotalCount      Key = "detail.count.total"
+	DetailCompletedCount  Key = "detail.count.completed"
+	DetailIncompleteCount Key = "detail.count.incomplete"
+	DetailFailedCount     Key = "detail.count.failed"
+	DetailIgnoredCount    Key = "detail.count.ignored"
+	DetailFailedJobs      Key = "detail.group.failed"
+	DetailCompletedJobs   Key = "detail.group.completed"
+	DetailIncompleteJobs  Key = "detail.group.incomplete"
+	DetailIgnoredJobs     Key = "detail.group.ignored"
+	DetailAllJobs         Key = "detail.group.all"
+	DetailWorkflows       Key = "detail.group.workflows"
+	DetailWorkflowCounts  Key = "detail.workflow.counts"
+	DetailJobState        Key = "detail.workflow.job"
+	DetailWorkflowETA     Key = "detail.workflow.eta"
+	DetailETA             Key = "detail.eta"
+	DetailCriticalPath    Key = "detail.critical_path"
+	DetailNoCriticalPath  Key = "detail.critical_path.unavailable"
+	DetailJobOwners       Key = "detail.job.owners"
+	DetailNoOwners        Key = "detail.job.owners.unavailable"
+	DetailNoWorkflows     Key = "detail.workflows.unavailable"
+	DetailNoGates         Key = "detail.gates.unavailable"
+	DetailChecksFallback  Key = "detail.checks.fallback"
+	DetailVanished        Key = "detail.vanished"
+	DetailUnresolved      Key = "detail.unresolved"
+	DetailQuorum          Key = "detail.quorum"
+	DetailTooFewJobs      Key = "detail.too_few_jobs"
+	DetailNoJobs          Key = "detail.no_jobs"
+	DetailNoJobsYet       Key = "detail.no_jobs_yet"
+	DetailActionRequired  Key = "detail.action_required"
+	DetailStale           Key = "detail.stale"
+	DetailExplainCaptured Key = "detail.explain.captured"
+	DetailExplainShadowed Key = "detail.explain.shadowed"
+	DetailExplainState    Key = "detail.explain.state"
+	DetailExplainRules    Key = "detail.explain.rules"
+	DetailExplainCaptures Key = "detail.explain.rule.captured"
+	DetailExplainShadows  Key = "detail.explain.rule.shadowed"
+	DetailExplainNoMatch  Key = "detail.explain.rule.unmatched"
+	DetailExplainSelf     Key = "detail.explain.self"
+	DetailExplainIgnored  Key = "detail.explain.ignored"
+	DetailExplainDecided  Key = "detail.explain.decided"
+	DetailExplainVanished Key = "detail.explain.vanished"
+	DetailExplainNoRun    Key = "detail.explain.unresolved"
+	DetailExplainAnyOf    Key = "detail.explain.any_of"
+	DetailExplainQuorum   Key = "detail.explain.quorum"
+
+	StateSucceeded Key = "state.succeeded"
+	StateFailed    Key = "state.failed"
+	StatePending   Key = "state.pending"
+	StateIgnored   Key = "state.ignored"
+
+	ValidationPending      Key = "validation.pending"
+	ValidationRetry        Key = "validation.retry"
+	ValidationSucceeded    Key = "validation.succeeded"
+	ValidationUsage        Key = "validation.usage"
+	UpgradeAvailable       Key = "upgrade.available"
+	UpgradeChangedDefault  Key = "upgrade.changed_default"
+	ValidationAPIStats     Key = "validation.api_stats"
+	ValidationAPIEndpoint  Key = "validation.api_stats.endpoint"
+	ValidationAPIRemaining Key = "validation.api_stats.remaining"
+	ValidationDisabled     Key = "validation.disabled"
+	ValidationPollFailed   Key = "validation.poll_failed"
+	ValidationIncidentPoll Key = "validation.poll_failed.incident"
+	ValidationIncident     Key = "validation.incident"
+	ValidationResolved     Key = "validation.incident.resolved"
+	ValidationLastObserved Key = "validation.last_observed"
+	ValidationChanged      Key = "validation.changed"
+	ValidationUnchanged    Key = "validation.unchanged"
+	ValidationCompleted    Key = "validation.changed.completed"
+	ValidationFailed       Key = "validation.changed.failed"
+	ValidationAppeared     Key = "validation.changed.appeared"
+	ValidationChanges      Key = "validation.changes"
+	ValidationExported     Key = "validation.exported"
+	ValidationNotExplained Key = "validation.not_explained"
+)
+
+const (
+	English  = "en"
+	Japanese = "ja"
+
+	DefaultLocale = English
+)
+
+var catalogs = map[string]map[Key]string{
+	English: {
+		DetailSummary:         "%d out of %d",
+		DetailTotalCount:      "Total job count:       %d",
+		DetailCompletedCount:  "Completed job count:   %d",
+		DetailIncompleteCount: "Incompleted job count: %d",
+		DetailFailedCount:     "Failed job count:      %d",
+		DetailIgnoredCount:    "Ignored job count:     %d",
+		DetailFailedJobs:      "Failed jobs",
+		DetailCompletedJobs:   "Completed jobs",
+		DetailIncompleteJobs:  "Incomplete jobs",
+		DetailIgnoredJobs:     "Ignored jobs",
+		DetailAllJobs:         "All jobs",
+		DetailWorkflows:       "Workflows",
+		DetailWorkflowCounts:  "%s: %d succeeded, %d failed, %d pending, %d ignored",
+		DetailJobState:        "%s: %s",
+		DetailWorkflowETA:     "(ETA: %s)",
+		DetailETA:             "Estimated time to completion: %s",
+		DetailCriticalPath:    "Critical path: %s",
+		DetailNoCriticalPath:  "Critical path is unavailable: %v",
+		DetailJobOwners:       "%s (owners: %s)",
+		DetailNoOwners:        "Owners of jobs are unavailable: %v",
+		DetailNoWorkflows:     "WARNING: Workflows are unavailable, so jobs are only identified by their names: %v",
+		DetailChecksFallback:  "WARNING: Check runs are listed from the REST API, as the GraphQL API failed: %v",
+		DetailNoGates:         "WARNING: Other instances of the gate are only identified by their names, as workflow definitions are unavailable: %v",
+		DetailVanished:        "WARNING: Jobs observed earlier vanished, and are kept pending until they reappear: %s",
+		DetailUnresolved:      "WARNING: Workflow runs of jobs are not listed yet, as they just started, and the jobs are kept pending for up to %d polls until they are: %s",
+		DetailQuorum:          "Quorum of %s: %d of %d jobs succeeded, %d required",
+		DetailTooFewJobs:      "WARNING: Waiting for at least %d jobs other than this job, but %d were observed. Their workflows may have failed to trigger.",
+		DetailNoJobs:          "No jobs other than this job were found, as CI never started for the commit.",
+		DetailNoJobsYet:       "WARNING: No jobs other than this job were found yet. The validation fails if none are found by %d polls in a row.",
+		DetailActionRequired:  "WARNING: %s requires action, such as approving its workflow run, before it can complete.",
+		DetailStale:           "WARNING: %s is stale, as it did not complete in time. Re-run it.",
+		DetailExplainCaptured: "%s is captured by the rule %s, whose verdict is %s.",
+		DetailExplainShadowed: "  It also matches %s, shadowed by the first match.",
+		DetailExplainState:    "  State: %s",
+		DetailExplainRules:    "  Rules in the order of evaluation:",
+		DetailExplainCaptures: "  - %s: captures the check",
+		DetailExplainShadows:  "  - %s: matches, but is shadowed",
+		DetailExplainNoMatch:  "  - %s: does not match",
+		DetailExplainSelf:     "  It is this job or a job of its matrix, which never gates itself.",
+		DetailExplainIgnored:  "  It is ignored regardless of its state, which is %s.",
+		DetailExplainDecided:  "  Its state decides the verdict, as no rule ignores it first.",
+		DetailExplainVanished: "  It vanished from the API since an earlier poll, so it is kept pending until it reappears.",
+		DetailExplainNoRun:    "  Its workflow run is not listed yet, so it is kept pending until its workflow is known.",
+		DetailExplainAnyOf:    "  It is of an any-of group, whose outcome decides the verdict, as at least one job of the group must succeed.",
+		DetailExplainQuorum:   "  It is of a quorum, whose outcome decides the verdict, as only a percent of the jobs of the quorum must succeed.",
+
+		StateSucceeded: "succeeded",
+		StateFailed:    "failed",
+		StatePending:   "pending",
+		StateIgnored:   "ignored",
+
+		ValidationPending:      "  WARNING: Validation is yet to be completed. This is most likely due to some other jobs still running.",
+		ValidationRetry:        "           Waiting for %d seconds before retrying.",
+		ValidationSucceeded:    "All validations were successful!",
+		ValidationUsage:        "Waited %s over %d polls, consuming about %d runner minutes.",
+		UpgradeAvailable:       "Merge Gatekeeper %s is available, while %s is running. Update the version of the action to get the latest fixes.",
+		UpgradeChangedDefault:  "Default changed since %s, which applies once updated: %s",
+		ValidationAPIStats:     "Made %d API requests, including %d further pages, saving %d by caches and retrying %d polls:",
+		ValidationAPIEndpoint:  "- %d %s",
+		ValidationAPIRemaining: "%d requests remain within the rate limit.",
+		ValidationDisabled:     "Merge Gatekeeper is disabled by %s. Nothing was validated.",
+		ValidationPollFailed:   "  WARNING: Poll of %s failed, tolerated as %d of %d failed polls: %v",
+		ValidationIncidentPoll: "  WARNING: Poll of %s failed during the incident of GitHub, tolerated without spending the error budget: %v",
+		ValidationIncident:     "GitHub reports an incident affecting %s: %s (%s). Polling every %d seconds until it is resolved.",
+		ValidationResolved:     "The incident of GitHub is resolved. Polling every %d seconds again.",
+		ValidationLastObserved: "Last observed status, %s before the failure:",
+		ValidationChanged:      "%s changed since the previous poll (%s):",
+		ValidationUnchanged:    "%s is unchanged since the previous poll (%s).",
+		ValidationCompleted:    "- newly completed: %s",
+		ValidationFailed:       "- newly failed: %s",
+		ValidationAppeared:     "- newly appeared: %s",
+		ValidationChanges:      "Changes between polls",
+		ValidationExported:     "Exported the status (%s) without gating.",
+		ValidationNotExplained: "No check named %s was observed.",
+	},
+	Japanese: {
+		DetailSummary:         "%d / %d 完了",
+		DetailTotalCount:      "ジョブ総数:       %d",
+		DetailCompletedCount:  "完了したジョブ数: %d",
+		DetailIncompleteCount: "未完了のジョブ数: %d",
+		DetailFailedCount:     "失敗したジョブ数: %d",
+		DetailIgnoredCount:    "無視したジョブ数: %d",
+		DetailFailedJobs:      "失敗したジョブ",
+		DetailCompletedJobs:   "完了したジョブ",
+		DetailIncompleteJobs:  "未完了のジョブ",
+		DetailIgnoredJobs:     "無視したジョブ",
+		DetailAllJobs:         "すべてのジョブ",
+		DetailWorkflows:       "ワークフロー",
+		DetailWorkflowCounts:  "%s: 成功 %d, 失敗 %d, 実行中 %d, 無視 %d",
+		DetailJobState:        "%s: %s",
+		DetailWorkflowETA:     "(完了予定: %s 後)",
+		DetailETA:             "完了までの予想時間: %s",
+		DetailCriticalPath:    "クリティカルパス: %s",
+		DetailNoCriticalPath:  "クリティカルパスを取得できませんでした: %v",
+		DetailJobOwners:       "%s (オーナー: %s)",
+		DetailNoOwners:        "ジョブのオーナーを取得できませんでした: %v",
+		DetailNoWorkflows:     "WARNING: ワークフローを取得できなかったため、ジョブを名前のみで識別しています: %v",
+		DetailChecksFallback:  "WARNING: GraphQL API が失敗したため、REST API からチェックランを取得しています: %v",
+		DetailNoGates:         "WARNING: ワークフロー定義を取得できなかったため、他のゲートを名前のみで識別しています: %v",
+		DetailVanished:        "WARNING: 以前に確認したジョブが消えました。再び現れるまで実行中として扱います: %s",
+		DetailUnresolved:      "WARNING: 開始直後のためワークフロー実行がまだ一覧にないジョブを、一覧に現れるまで最大 %d 回の確認の間、実行中として扱います: %s",
+		DetailQuorum:          "%s のクオラム: %d 件のジョブが成功 (全 %d 件、必要数 %d 件)",
+		DetailTooFewJobs:      "WARNING: このジョブ以外に少なくとも %d 個のジョブを待っていますが、%d 個しか確認できません。ワークフローの起動に失敗した可能性があります。",
+		DetailNoJobs:          "このジョブ以外のジョブが見つかりません。コミットに対して CI が起動していません。",
+		DetailNoJobsYet:       "WARNING: このジョブ以外のジョブがまだ見つかりません。%d 回続けて見つからない場合は検証に失敗します。",
+		DetailActionRequired:  "WARNING: %s は完了する前に、ワークフロー実行の承認などの対応が必要です。",
+		DetailStale:           "WARNING: %s は時間内に完了しなかったため古くなりました。再実行してください。",
+		DetailExplainCaptured: "%s はルール %s に一致し、判定は %s です。",
+		DetailExplainShadowed: "  %s にも一致しますが、最初に一致したルールが優先されます。",
+		DetailExplainState:    "  状態: %s",
+		DetailExplainRules:    "  評価順のルール:",
+		DetailExplainCaptures: "  - %s: このチェックを判定します",
+		DetailExplainShadows:  "  - %s: 一致しますが、優先されません",
+		DetailExplainNoMatch:  "  - %s: 一致しません",
+		DetailExplainSelf:     "  このジョブ自身かそのマトリックスのジョブであり、自身をゲートすることはありません。",
+		DetailExplainIgnored:  "  状態 (%s) にかかわらず無視されます。",
+		DetailExplainDecided:  "  無視するルールに先に一致しないため、状態により判定されます。",
+		DetailExplainVanished: "  以前の確認の後に API から消えたため、再び現れるまで実行中として扱われます。",
+		DetailExplainNoRun:    "  ワークフロー実行がまだ一覧にないため、ワークフローが判明するまで実行中として扱われます。",
+		DetailExplainAnyOf:    "  いずれか 1 つのジョブが成功すればよいグループに属するため、グループの結果が判定を決めます。",
+		DetailExplainQuorum:   "  一定の割合のジョブが成功すればよいクオラムに属するため、クオラムの結果が判定を決めます。",
+
+		StateSucceeded: "成功",
+		StateFailed:    "失敗",
+		StatePending:   "実行中",
+		StateIgnored:   "無視",
+
+		ValidationPending:      "  WARNING: 検証はまだ完了していません。他のジョブが実行中の可能性があります。",
+		ValidationRetry:        "           %d 秒後に再試行します。",
+		ValidationSucceeded:    "すべての検証に成功しました！",
+		ValidationUsage:        "%s 待機し (%d 回確認)、ランナーを約 %d 分消費しました。",
+		UpgradeAvailable:       "Merge Gatekeeper %s が利用可能です (実行中のバージョンは %s)。最新の修正を得るにはアクションのバージョンを更新してください。",
+		UpgradeChangedDefault:  "%s 以降にデフォルトが変更されており、更新すると適用されます: %s",
+		ValidationAPIStats:     "API を %d 回呼び出しました (うち追加のページ %d 回、キャッシュにより %d 回を節約、%d 回の確認を再試行):",
+		ValidationAPIEndpoint:  "- %d %s",
+		ValidationAPIRemaining: "レート制限の残りは %d 回です。",
+		ValidationDisabled:     "Merge Gatekeeper は %s により無効化されています。検証は行われませんでした。",
+		ValidationPollFailed:   "  WARNING: %s の確認に失敗しました。失敗した確認 %d / %d 回として許容します: %v",
+		ValidationIncidentPoll: "  WARNING: GitHub の障害中に %s の確認に失敗しました。エラーバジェットを消費せずに許容します: %v",
+		ValidationIncident:     "GitHub が %s に影響する障害を報告しています: %s (%s)。解消するまで %d 秒ごとに確認します。",
+		ValidationResolved:     "GitHub の障害が解消しました。再び %d 秒ごとに確認します。",
+		ValidationLastObserved: "失敗の %s 前に確認した状態:",
+		ValidationChanged:      "%s は前回の確認から変化しました (%s):",
+		ValidationUnchanged:    "%s は前回の確認から変化していません (%s)。",
+		ValidationCompleted:    "- 新たに完了: %s",
+		ValidationFailed:       "- 新たに失敗: %s",
+		ValidationAppeared:     "- 新たに出現: %s",
+		ValidationChanges:      "確認ごとの変化",
+		ValidationExported:     "ゲートせずに状態 (%s) を出力しました。",
+		ValidationNotExplained: "%s という名前のチェックは見つかりませんでした。",
+	},
+}
+
+// Catalog resolves messages for a single locale, falling back to English for missing keys.
+type Catalog struct {
+	locale    string
+	messages  map[Key]string
+	overrides map[Key]string
+}
+
+var defaultCatalog = &Catalog{locale: DefaultLocale, messages: catalogs[DefaultLocale]}
+
+// Default returns the English catalog.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// New returns the catalog for the locale. The default locale is used when locale is empty.
+func New(locale string) (*Catalog, error) {
+	if len(locale) == 0 {
+		locale = DefaultLocale
+	}
+	msgs, ok := catalogs[locale]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale: %s, supported locales: %v", locale, Locales())
+	}
+	return &Catalog{locale: locale, messages: msgs}, nil
+}
+
+// Locales returns the supported locales.
+func Locales() []string {
+	ls := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		ls = append(ls, l)
+	}
+	sort.Strings(ls)
+	return ls
+}
+
+// LoadOverrides reads a JSON object of key to message from the file, and uses them in preference to the catalog.
+func (c *Catalog) LoadOverrides(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	overrides := make(map[Key]string)
+	if err := json.Unmarshal(b, &overrides); err != nil {
+		return fmt.Errorf("failed to parse message overrides: %w", err)
+	}
+	for k := range overrides {
+		if _, ok := catalogs[English][k]; !ok {
+			return fmt.Errorf("unknown message key in overrides: %s", k)
+		}
+	}
+	c.overrides = overrides
+	return nil
+}
+
+func (c *Catalog) Locale() string {
+	return c.locale
+}
+
+func (c *Catalog) Get(key Key) string {
+	if msg, ok := c.overrides[key]; ok {
+		return msg
+	}
+	if msg, ok := c.messages[key]; ok {
+		return msg
+	}
+	return catalogs[English][key]
+}
+
+// State returns the localised name of the state reported by validators.
+func (c *Catalog) State(state string) string {
+	switch state {
+	case "success":
+		return c.Get(StateSucceeded)
+	case "failure":
+		return c.Get(StateFailed)
+	case "pending":
+		return c.Get(StatePending)
+	case "ignored":
+		return c.Get(StateIgnored)
+	default:
+		return state
+	}
+}
+
+func (c *Catalog) Sprintf(key Key, args ...interface{}) string {
+	return fmt.Sprintf(c.Get(key), args...)
+}
@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCatalogsDefineAllKeys(t *testing.T) {
+	for locale, msgs := range catalogs {
+		for key := range catalogs[English] {
+			if _, ok := msgs[key]; !ok {
+				t.Errorf("locale %s does not define key %s", locale, key)
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		locale     string
+		wantLocale string
+		wantErr    bool
+	}{
+		"returns the default catalog when locale is empty": {
+			locale:     "",
+			wantLocale: English,
+		},
+		"returns the catalog of the supported locale": {
+			locale:     Japanese,
+			wantLocale: Japanese,
+		},
+		"returns error when locale is not supported": {
+			locale:  "xx",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(tt.locale)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Locale() != tt.wantLocale {
+				t.Errorf("New() locale = %s, want %s", got.Locale(), tt.wantLocale)
+			}
+		})
+	}
+}
+
+func TestCatalog_LoadOverrides(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+		wantErr bool
+	}{
+		"overrides the message of the known key": {
+			content: `{"validation.succeeded": "Ready to merge"}`,
+			want:    "Ready to merge",
+		},
+		"returns error when the key is unknown": {
+			content: `{"unknown": "x"}`,
+			wantErr: true,
+		},
+		"returns error when the content is malformed": {
+			content: `[`,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "messages.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			c, _ := New(English)
+			err := c.LoadOverrides(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && c.Get(ValidationSucceeded) != tt.want {
+				t.Errorf("Get() = %s, want %s", c.Get(ValidationSucceeded), tt.want)
+			}
+		})
+	}
+}
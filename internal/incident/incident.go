@@ -0,0 +1,85 @@
+// Package incident finds incidents of GitHub on its status page, during which the gate waits longer rather than
+// failing on the outage of the platform.
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultURL is the API of the status page of GitHub listing its unresolved incidents.
+const DefaultURL = "https://www.githubstatus.com/api/v2/incidents/unresolved.json"
+
+// components are the components of the status page the gate depends on.
+var components = []string{"Actions", "API Requests"}
+
+// Incident is an unresolved incident of GitHub affecting the components the gate depends on.
+type Incident struct {
+	Name       string
+	URL        string
+	Impact     string
+	Components []string
+}
+
+// Check returns the first unresolved incident affecting Actions or the API, or nil when there is none. Incidents
+// which do not list their components yet are matched by their names, e.g) Incident with Actions.
+func Check(ctx context.Context, c *http.Client, url string) (*Incident, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status of the status page: %s", res.Status)
+	}
+
+	var page struct {
+		Incidents []struct {
+			Name       string `json:"name"`
+			Shortlink  string `json:"shortlink"`
+			Impact     string `json:"impact"`
+			Components []struct {
+				Name string `json:"name"`
+			} `json:"components"`
+		} `json:"incidents"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse the status page: %w", err)
+	}
+	for _, inc := range page.Incidents {
+		var affected []string
+		for _, comp := range inc.Components {
+			if isDependency(comp.Name) {
+				affected = append(affected, comp.Name)
+			}
+		}
+		if len(inc.Components) == 0 {
+			for _, comp := range components {
+				if strings.Contains(strings.ToLower(inc.Name), strings.ToLower(comp)) {
+					affected = append(affected, comp)
+				}
+			}
+		}
+		if len(affected) != 0 {
+			return &Incident{Name: inc.Name, URL: inc.Shortlink, Impact: inc.Impact, Components: affected}, nil
+		}
+	}
+	return nil, nil
+}
+
+func isDependency(name string) bool {
+	for _, comp := range components {
+		if strings.EqualFold(name, comp) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package incident
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want *Incident
+	}{
+		"returns incidents affecting actions": {
+			body: `{"incidents": [
+				{"name": "Degraded Pages", "shortlink": "https://stspg.io/pages", "impact": "minor", "components": [{"name": "Pages"}]},
+				{"name": "Delays of workflow runs", "shortlink": "https://stspg.io/actions", "impact": "major", "components": [{"name": "Actions"}, {"name": "Pages"}, {"name": "API Requests"}]}
+			]}`,
+			want: &Incident{Name: "Delays of workflow runs", URL: "https://stspg.io/actions", Impact: "major", Components: []string{"Actions", "API Requests"}},
+		},
+		"returns incidents without components by their names": {
+			body: `{"incidents": [{"name": "Incident with Actions", "shortlink": "https://stspg.io/actions", "impact": "none", "components": []}]}`,
+			want: &Incident{Name: "Incident with Actions", URL: "https://stspg.io/actions", Impact: "none", Components: []string{"Actions"}},
+		},
+		"ignores incidents of other components": {
+			body: `{"incidents": [{"name": "Degraded Pages", "shortlink": "https://stspg.io/pages", "impact": "minor", "components": [{"name": "Pages"}]}]}`,
+		},
+		"returns nothing without incidents": {
+			body: `{"incidents": []}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			got, err := Check(context.Background(), srv.Client(), srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck_error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	if _, err := Check(context.Background(), srv.Client(), srv.URL); err == nil {
+		t.Error("Check() error = nil, want error")
+	}
+}
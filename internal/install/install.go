@@ -0,0 +1,203 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+const (
+	// DefaultPath is where the workflow is added in the repository.
+	DefaultPath = ".github/workflows/merge-gatekeeper.yml"
+	// DefaultBranch is the branch the workflow is added on, so that reruns find the pull request they opened.
+	DefaultBranch = "merge-gatekeeper/install"
+)
+
+// Workflow is the standard workflow, as in /example/merge-gatekeeper.yml.
+const Workflow = `---
+name: Merge Gatekeeper
+
+on:
+  pull_request:
+    branches:
+      - main
+      - master
+
+jobs:
+  merge-gatekeeper:
+    runs-on: ubuntu-latest
+    permissions:
+      checks: read
+      statuses: read
+    steps:
+      - name: Run Merge Gatekeeper
+        uses: upsidr/merge-gatekeeper@v1
+        with:
+          token: ${{ secrets.GITHUB_TOKEN }}
+`
+
+// Actions taken, or planned in dry runs, on a repository.
+const (
+	ActionOpened    = "opened"    // The pull request adding the workflow was opened.
+	ActionPending   = "pending"   // The pull request opened by an earlier install is still open.
+	ActionInstalled = "installed" // The workflow is already on the default branch.
+
+	ActionProtected = "protected" // The branch was protected, requiring the check.
+	ActionRequired  = "required"  // The check was added to the required status checks of the branch.
+	ActionUnchanged = "unchanged" // The check is already required.
+	ActionSkipped   = "skipped"   // The branch is protected without required status checks, which is left to be edited by hand.
+)
+
+// Options describes how repositories are onboarded.
+type Options struct {
+	// Workflow is the content of the workflow file. Workflow is used when empty.
+	Workflow []byte
+	Path     string
+	Branch   string
+	// Check is the name of the job of the workflow, required by branch protection.
+	Check string
+	// DryRun only plans the actions, without writing anything.
+	DryRun bool
+}
+
+// Result describes what was done to onboard a repository.
+type Result struct {
+	Repository    string
+	DefaultBranch string
+	Workflow      string // One of ActionOpened, ActionPending or ActionInstalled.
+	PullRequest   string // URL of the pull request adding the workflow, unless it was already installed or in dry runs.
+	Protection    string // One of ActionProtected, ActionRequired, ActionUnchanged or ActionSkipped.
+}
+
+// Install opens a pull request adding the workflow to the repository, unless it is already installed or an earlier
+// install is pending, and requires the check by the protection of the default branch. Installs are idempotent,
+// so that they can be rerun over every repository of an organization.
+func Install(ctx context.Context, c github.Client, owner, repo string, opts Options) (*Result, error) {
+	if len(opts.Workflow) == 0 {
+		opts.Workflow = []byte(Workflow)
+	}
+	if len(opts.Path) == 0 {
+		opts.Path = DefaultPath
+	}
+	if len(opts.Branch) == 0 {
+		opts.Branch = DefaultBranch
+	}
+	if len(opts.Check) == 0 {
+		return nil, errors.New("check to require is empty")
+	}
+
+	r, _, err := c.GetRepository(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	res := &Result{Repository: owner + "/" + repo, DefaultBranch: r.GetDefaultBranch()}
+
+	if res.Workflow, res.PullRequest, err = addWorkflow(ctx, c, owner, repo, res.DefaultBranch, opts); err != nil {
+		return nil, err
+	}
+	if res.Protection, err = requireCheck(ctx, c, owner, repo, res.DefaultBranch, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func addWorkflow(ctx context.Context, c github.Client, owner, repo, base string, opts Options) (action, url string, err error) {
+	_, _, res, err := c.GetContents(ctx, owner, repo, opts.Path, &github.RepositoryContentGetOptions{Ref: base})
+	switch {
+	case err == nil:
+		return ActionInstalled, "", nil
+	case res == nil || res.StatusCode != http.StatusNotFound:
+		return "", "", fmt.Errorf("failed to get %s: %w", opts.Path, err)
+	}
+
+	prs, _, err := c.ListPullRequests(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + opts.Branch,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	if len(prs) != 0 {
+		return ActionPending, prs[0].GetHTMLURL(), nil
+	}
+	if opts.DryRun {
+		return ActionOpened, "", nil
+	}
+
+	sha, _, err := c.GetCommitSHA1(ctx, owner, repo, base, "")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get head of %s: %w", base, err)
+	}
+	ref := "refs/heads/" + opts.Branch
+	if _, _, err := c.CreateRef(ctx, owner, repo, &github.Reference{Ref: &ref, Object: &github.GitObject{SHA: &sha}}); err != nil {
+		return "", "", fmt.Errorf("failed to create branch %s: %w", opts.Branch, err)
+	}
+	message := "Add Merge Gatekeeper workflow"
+	if _, _, err := c.CreateFile(ctx, owner, repo, opts.Path, &github.RepositoryContentFileOptions{
+		Message: &message,
+		Content: opts.Workflow,
+		Branch:  &opts.Branch,
+	}); err != nil {
+		return "", "", fmt.Errorf("failed to add %s: %w", opts.Path, err)
+	}
+
+	title := "Add Merge Gatekeeper"
+	body := fmt.Sprintf("Adds the Merge Gatekeeper workflow at `%s`, whose `%s` job succeeds once every other job of a pull request has succeeded.\n\n"+
+		"The `%s` check is required by the protection of `%s`, so that pull requests can be merged once it passes.\n", opts.Path, opts.Check, opts.Check, base)
+	pr, _, err := c.CreatePullRequest(ctx, owner, repo, &github.NewPullRequest{
+		Title: &title,
+		Head:  &opts.Branch,
+		Base:  &base,
+		Body:  &body,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open pull request: %w", err)
+	}
+	return ActionOpened, pr.GetHTMLURL(), nil
+}
+
+// requireCheck adds the check to the required status checks of the branch. Unprotected branches are protected with
+// the check required only, while branches protected without required status checks are skipped, as enabling them
+// would replace every other setting of the protection.
+func requireCheck(ctx context.Context, c github.Client, owner, repo, branch string, opts Options) (string, error) {
+	p, res, err := c.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		if res == nil || res.StatusCode != http.StatusNotFound {
+			return "", fmt.Errorf("failed to get protection of %s: %w", branch, err)
+		}
+		if !opts.DryRun {
+			if _, _, err := c.UpdateBranchProtection(ctx, owner, repo, branch, &github.ProtectionRequest{
+				RequiredStatusChecks: &github.RequiredStatusChecks{Checks: &[]*github.RequiredStatusCheck{{Context: opts.Check}}},
+			}); err != nil {
+				return "", fmt.Errorf("failed to protect %s: %w", branch, err)
+			}
+		}
+		return ActionProtected, nil
+	}
+
+	required := p.GetRequiredStatusChecks()
+	if required == nil {
+		return ActionSkipped, nil
+	}
+	var checks []*github.RequiredStatusCheck
+	if required.Checks != nil {
+		checks = *required.Checks
+	}
+	for _, check := range checks {
+		if check.Context == opts.Check {
+			return ActionUnchanged, nil
+		}
+	}
+	if !opts.DryRun {
+		if _, _, err := c.UpdateRequiredStatusChecks(ctx, owner, repo, branch, &github.RequiredStatusChecksRequest{
+			Strict: &required.Strict,
+			Checks: append(checks, &github.RequiredStatusCheck{Context: opts.Check}),
+		}); err != nil {
+			return "", fmt.Errorf("failed to require %s on %s: %w", opts.Check, branch, err)
+		}
+	}
+	return ActionRequired, nil
+}
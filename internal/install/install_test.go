@@ -0,0 +1,215 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+var notFound = &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+// recorder records the writes made by Install.
+type recorder struct {
+	writes   []string
+	required []string
+	strict   bool
+}
+
+// repository is the state of the repository Install is run against.
+type repository struct {
+	installed  bool
+	pending    bool
+	protection *github.Protection // Unprotected when nil.
+}
+
+func (r *recorder) client(repo repository) *mock.Client {
+	return &mock.Client{
+		GetRepositoryFunc: func(ctx context.Context, owner, name string) (*github.Repository, *github.Response, error) {
+			return &github.Repository{DefaultBranch: stringPtr("main")}, nil, nil
+		},
+		GetContentsFunc: func(ctx context.Context, owner, name, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			if !repo.installed {
+				return nil, nil, notFound, errors.New("not found")
+			}
+			return &github.RepositoryContent{}, nil, nil, nil
+		},
+		ListPullRequestsFunc: func(ctx context.Context, owner, name string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+			if !repo.pending {
+				return nil, nil, nil
+			}
+			return []*github.PullRequest{{HTMLURL: stringPtr("https://github.com/owner/repo/pull/1")}}, nil, nil
+		},
+		GetCommitSHA1Func: func(ctx context.Context, owner, name, ref, lastSHA string) (string, *github.Response, error) {
+			return "sha", nil, nil
+		},
+		CreateRefFunc: func(ctx context.Context, owner, name string, ref *github.Reference) (*github.Reference, *github.Response, error) {
+			r.writes = append(r.writes, "ref "+ref.GetRef()+" "+ref.GetObject().GetSHA())
+			return ref, nil, nil
+		},
+		CreateFileFunc: func(ctx context.Context, owner, name, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+			r.writes = append(r.writes, "file "+path+" "+opts.GetBranch())
+			return &github.RepositoryContentResponse{}, nil, nil
+		},
+		CreatePullRequestFunc: func(ctx context.Context, owner, name string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+			r.writes = append(r.writes, "pull "+pull.GetHead()+" "+pull.GetBase())
+			return &github.PullRequest{HTMLURL: stringPtr("https://github.com/owner/repo/pull/2")}, nil, nil
+		},
+		GetBranchProtectionFunc: func(ctx context.Context, owner, name, branch string) (*github.Protection, *github.Response, error) {
+			if repo.protection == nil {
+				return nil, notFound, errors.New("branch not protected")
+			}
+			return repo.protection, nil, nil
+		},
+		UpdateBranchProtectionFunc: func(ctx context.Context, owner, name, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+			r.writes = append(r.writes, "protect "+branch)
+			for _, check := range *preq.RequiredStatusChecks.Checks {
+				r.required = append(r.required, check.Context)
+			}
+			return &github.Protection{}, nil, nil
+		},
+		UpdateRequiredStatusChecksFunc: func(ctx context.Context, owner, name, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+			r.writes = append(r.writes, "require "+branch)
+			for _, check := range sreq.Checks {
+				r.required = append(r.required, check.Context)
+			}
+			r.strict = sreq.GetStrict()
+			return &github.RequiredStatusChecks{}, nil, nil
+		},
+	}
+}
+
+func protected(strict bool, checks ...string) *github.Protection {
+	required := []*github.RequiredStatusCheck{}
+	for _, check := range checks {
+		required = append(required, &github.RequiredStatusCheck{Context: check})
+	}
+	return &github.Protection{RequiredStatusChecks: &github.RequiredStatusChecks{Strict: strict, Checks: &required}}
+}
+
+func TestInstall(t *testing.T) {
+	tests := map[string]struct {
+		repo         repository
+		dryRun       bool
+		want         *Result
+		wantWrites   []string
+		wantRequired []string
+		wantStrict   bool
+	}{
+		"opens pull request and protects unprotected branch": {
+			repo: repository{},
+			want: &Result{
+				Repository:    "owner/repo",
+				DefaultBranch: "main",
+				Workflow:      ActionOpened,
+				PullRequest:   "https://github.com/owner/repo/pull/2",
+				Protection:    ActionProtected,
+			},
+			wantWrites: []string{
+				"ref refs/heads/merge-gatekeeper/install sha",
+				"file .github/workflows/merge-gatekeeper.yml merge-gatekeeper/install",
+				"pull merge-gatekeeper/install main",
+				"protect main",
+			},
+			wantRequired: []string{"merge-gatekeeper"},
+		},
+		"keeps required status checks and strictness": {
+			repo: repository{installed: true, protection: protected(true, "build")},
+			want: &Result{
+				Repository:    "owner/repo",
+				DefaultBranch: "main",
+				Workflow:      ActionInstalled,
+				Protection:    ActionRequired,
+			},
+			wantWrites:   []string{"require main"},
+			wantRequired: []string{"build", "merge-gatekeeper"},
+			wantStrict:   true,
+		},
+		"reports pending pull request and required check without writing": {
+			repo: repository{pending: true, protection: protected(false, "merge-gatekeeper")},
+			want: &Result{
+				Repository:    "owner/repo",
+				DefaultBranch: "main",
+				Workflow:      ActionPending,
+				PullRequest:   "https://github.com/owner/repo/pull/1",
+				Protection:    ActionUnchanged,
+			},
+		},
+		"skips protection without required status checks": {
+			repo: repository{installed: true, protection: &github.Protection{}},
+			want: &Result{
+				Repository:    "owner/repo",
+				DefaultBranch: "main",
+				Workflow:      ActionInstalled,
+				Protection:    ActionSkipped,
+			},
+		},
+		"plans without writing in dry runs": {
+			repo:   repository{},
+			dryRun: true,
+			want: &Result{
+				Repository:    "owner/repo",
+				DefaultBranch: "main",
+				Workflow:      ActionOpened,
+				Protection:    ActionProtected,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			got, err := Install(context.Background(), r.client(tt.repo), "owner", "repo", Options{Check: "merge-gatekeeper", DryRun: tt.dryRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Install() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(r.writes, tt.wantWrites) {
+				t.Errorf("writes = %v, want %v", r.writes, tt.wantWrites)
+			}
+			if !reflect.DeepEqual(r.required, tt.wantRequired) {
+				t.Errorf("required = %v, want %v", r.required, tt.wantRequired)
+			}
+			if r.strict != tt.wantStrict {
+				t.Errorf("strict = %v, want %v", r.strict, tt.wantStrict)
+			}
+		})
+	}
+}
+
+func TestInstall_errors(t *testing.T) {
+	r := &recorder{}
+	c := r.client(repository{})
+	c.GetContentsFunc = func(ctx context.Context, owner, name, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+		return nil, nil, nil, errors.New("forbidden")
+	}
+	if _, err := Install(context.Background(), c, "owner", "repo", Options{Check: "merge-gatekeeper"}); err == nil {
+		t.Error("Install() error = nil, want error getting the workflow")
+	}
+	if _, err := Install(context.Background(), c, "owner", "repo", Options{}); err == nil {
+		t.Error("Install() error = nil, want error for empty check")
+	}
+	if len(r.writes) != 0 {
+		t.Errorf("writes = %v, want none", r.writes)
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	b, err := os.ReadFile("../../example/merge-gatekeeper.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != Workflow {
+		t.Error("Workflow differs from example/merge-gatekeeper.yml")
+	}
+}
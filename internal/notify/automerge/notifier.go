@@ -0,0 +1,141 @@
+// Package automerge merges pull requests of dependency update bots once they have passed the gate.
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+const notifierName = "auto-merge"
+
+// Defaults of the notifier.
+const (
+	DefaultAuthors     = "dependabot[bot],renovate[bot]"
+	DefaultUpdateTypes = UpdatePatch + "," + UpdateMinor
+	DefaultMergeMethod = "squash"
+)
+
+var mergeMethods = map[string]bool{
+	"merge":  true,
+	"squash": true,
+	"rebase": true,
+}
+
+const pullRequestOpenState = "open"
+
+type notifier struct {
+	owner       string
+	repo        string
+	prNumber    int
+	authors     []string
+	updateTypes []string
+	mergeMethod string
+	client      github.Client
+}
+
+// New returns the notifier which merges the pull request when the validation has succeeded, it was opened by one of
+// the dependency update bots and it only bumps versions by the allowed update types.
+func New(c github.Client, opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		client:      c,
+		authors:     splitList(DefaultAuthors),
+		updateTypes: splitList(DefaultUpdateTypes),
+		mergeMethod: DefaultMergeMethod,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	for _, typ := range n.updateTypes {
+		if _, ok := updateRanks[typ]; !ok {
+			errs = append(errs, fmt.Errorf("update type %s is invalid. must be one of %s, %s or %s", typ, UpdatePatch, UpdateMinor, UpdateMajor))
+		}
+	}
+	if !mergeMethods[n.mergeMethod] {
+		errs = append(errs, fmt.Errorf("merge method %s is invalid. must be one of merge, squash or rebase", n.mergeMethod))
+	}
+	if n.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	if !o.Succeeded() || n.prNumber == 0 {
+		return nil
+	}
+	pr, _, err := n.client.GetPullRequest(ctx, n.owner, n.repo, n.prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request #%d: %w", n.prNumber, err)
+	}
+	if pr.GetState() != pullRequestOpenState || pr.GetDraft() || !n.isAuthor(pr.GetUser().GetLogin()) {
+		return nil
+	}
+	// Only the validated commit is merged. Commits pushed since then are merged after their own validation.
+	if pr.GetHead().GetSHA() != o.Ref {
+		return nil
+	}
+	typ, ok := updateType(pr.GetTitle(), pr.GetBody())
+	if !ok || !n.allows(typ) {
+		return nil
+	}
+
+	res, _, err := n.client.MergePullRequest(ctx, n.owner, n.repo, n.prNumber, "", &github.PullRequestOptions{
+		MergeMethod: n.mergeMethod,
+		SHA:         o.Ref,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge pull request #%d: %w", n.prNumber, err)
+	}
+	if !res.GetMerged() {
+		return fmt.Errorf("pull request #%d was not merged: %s", n.prNumber, res.GetMessage())
+	}
+	return nil
+}
+
+func (n *notifier) isAuthor(login string) bool {
+	for _, author := range n.authors {
+		if strings.EqualFold(author, login) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *notifier) allows(typ string) bool {
+	for _, t := range n.updateTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
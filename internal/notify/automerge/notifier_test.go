@@ -0,0 +1,141 @@
+package automerge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func pullRequest(author, title, sha string) *github.PullRequest {
+	return &github.PullRequest{
+		State: stringPtr("open"),
+		Title: stringPtr(title),
+		User:  &github.User{Login: stringPtr(author)},
+		Head:  &github.PullRequestBranch{SHA: stringPtr(sha)},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns notifier": {
+			opts: []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithUpdateTypes("patch, major"), WithMergeMethod("rebase")},
+		},
+		"returns error when the update type is invalid": {
+			opts:    []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithUpdateTypes("patch,security")},
+			wantErr: true,
+		},
+		"returns error when the merge method is invalid": {
+			opts:    []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithMergeMethod("fast-forward")},
+			wantErr: true,
+		},
+		"returns error when the repository is empty": {
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(&mock.Client{}, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := map[string]struct {
+		result     string
+		pr         *github.PullRequest
+		mergeRes   *github.PullRequestMergeResult
+		mergeErr   error
+		wantMerged bool
+		wantErr    bool
+	}{
+		"merges patch update of dependabot": {
+			result:     notify.ResultSuccess,
+			pr:         pullRequest("dependabot[bot]", "Bump lodash from 4.17.20 to 4.17.21", "sha-1"),
+			mergeRes:   &github.PullRequestMergeResult{Merged: boolPtr(true)},
+			wantMerged: true,
+		},
+		"merges minor update of renovate": {
+			result:     notify.ResultSuccess,
+			pr:         pullRequest("Renovate[bot]", "Update module github.com/spf13/cobra from v1.7.0 to v1.8.0", "sha-1"),
+			mergeRes:   &github.PullRequestMergeResult{Merged: boolPtr(true)},
+			wantMerged: true,
+		},
+		"does not merge major update": {
+			result: notify.ResultSuccess,
+			pr:     pullRequest("dependabot[bot]", "Bump actions/checkout from 3 to 4", "sha-1"),
+		},
+		"does not merge pull request of other authors": {
+			result: notify.ResultSuccess,
+			pr:     pullRequest("octocat", "Bump lodash from 4.17.20 to 4.17.21", "sha-1"),
+		},
+		"does not merge commits pushed after the validation": {
+			result: notify.ResultSuccess,
+			pr:     pullRequest("dependabot[bot]", "Bump lodash from 4.17.20 to 4.17.21", "sha-2"),
+		},
+		"does not merge failed validation": {
+			result: notify.ResultFailure,
+			pr:     pullRequest("dependabot[bot]", "Bump lodash from 4.17.20 to 4.17.21", "sha-1"),
+		},
+		"returns error when not merged": {
+			result:     notify.ResultSuccess,
+			pr:         pullRequest("dependabot[bot]", "Bump lodash from 4.17.20 to 4.17.21", "sha-1"),
+			mergeRes:   &github.PullRequestMergeResult{Merged: boolPtr(false), Message: stringPtr("Base branch was modified")},
+			wantMerged: true,
+			wantErr:    true,
+		},
+		"returns error when the merge fails": {
+			result:     notify.ResultSuccess,
+			pr:         pullRequest("dependabot[bot]", "Bump lodash from 4.17.20 to 4.17.21", "sha-1"),
+			mergeErr:   errors.New("err"),
+			wantMerged: true,
+			wantErr:    true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var merged bool
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return tt.pr, nil, nil
+				},
+				MergePullRequestFunc: func(ctx context.Context, owner, repo string, number int, commitMessage string, opts *github.PullRequestOptions) (*github.PullRequestMergeResult, *github.Response, error) {
+					merged = true
+					if opts.SHA != "sha-1" || opts.MergeMethod != DefaultMergeMethod {
+						t.Errorf("MergePullRequest() opts = %+v", opts)
+					}
+					return tt.mergeRes, nil, tt.mergeErr
+				},
+			}
+			n, err := New(c, WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithPullRequest(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = n.Notify(context.Background(), &notify.Outcome{Ref: "sha-1", Result: tt.result})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if merged != tt.wantMerged {
+				t.Errorf("merged = %v, want %v", merged, tt.wantMerged)
+			}
+		})
+	}
+}
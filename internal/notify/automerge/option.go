@@ -0,0 +1,60 @@
+package automerge
+
+import "strings"
+
+type Option func(n *notifier)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(n *notifier) {
+		if len(owner) != 0 {
+			n.owner = owner
+		}
+		if len(repo) != 0 {
+			n.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to merge.
+func WithPullRequest(number int) Option {
+	return func(n *notifier) {
+		n.prNumber = number
+	}
+}
+
+// WithAuthors sets the logins of the dependency update bots whose pull requests are merged (comma-separated list).
+func WithAuthors(logins string) Option {
+	return func(n *notifier) {
+		if authors := splitList(logins); len(authors) != 0 {
+			n.authors = authors
+		}
+	}
+}
+
+// WithUpdateTypes sets the update types which are merged (comma-separated list of patch, minor and major).
+func WithUpdateTypes(types string) Option {
+	return func(n *notifier) {
+		if updateTypes := splitList(types); len(updateTypes) != 0 {
+			n.updateTypes = updateTypes
+		}
+	}
+}
+
+// WithMergeMethod sets how the pull request is merged (merge, squash or rebase).
+func WithMergeMethod(method string) Option {
+	return func(n *notifier) {
+		if len(method) != 0 {
+			n.mergeMethod = method
+		}
+	}
+}
+
+func splitList(str string) []string {
+	var items []string
+	for _, item := range strings.Split(str, ",") {
+		if item = strings.TrimSpace(item); len(item) != 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,105 @@
+package automerge
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Update types of dependency updates, from the least to the most disruptive.
+const (
+	UpdatePatch = "patch"
+	UpdateMinor = "minor"
+	UpdateMajor = "major"
+)
+
+var updateRanks = map[string]int{
+	UpdatePatch: 1,
+	UpdateMinor: 2,
+	UpdateMajor: 3,
+}
+
+var (
+	// titleVersionsPattern matches titles of Dependabot and Renovate, e.g) "Bump lodash from 4.17.20 to 4.17.21".
+	titleVersionsPattern = regexp.MustCompile(`(?i)\bfrom\s+v?(\S+?)\s+to\s+v?(\S+?)(?:\s|$)`)
+	// bodyVersionsPatterns match updates listed in bodies of grouped pull requests, e.g) "Updates `lodash` from 4.17.20 to 4.17.21"
+	// of Dependabot and "`4.17.20` -> `4.17.21`" of Renovate.
+	bodyVersionsPatterns = []*regexp.Regexp{
+		regexp.MustCompile("(?m)^Updates `[^`]+` from v?(\\S+?) to v?(\\S+?)(?:\\s|$)"),
+		regexp.MustCompile("`v?([^`\\s]+)` -> `v?([^`\\s]+)`"),
+	}
+)
+
+// updateType returns the most disruptive update type of the versions bumped by the pull request, or false when the
+// versions are unknown.
+func updateType(title, body string) (string, bool) {
+	matches := titleVersionsPattern.FindAllStringSubmatch(title, -1)
+	if len(matches) == 0 {
+		for _, pattern := range bodyVersionsPatterns {
+			matches = append(matches, pattern.FindAllStringSubmatch(body, -1)...)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	var typ string
+	for _, m := range matches {
+		t, ok := compareVersions(strings.TrimSuffix(m[1], "."), strings.TrimSuffix(m[2], "."))
+		if !ok {
+			return "", false
+		}
+		if updateRanks[t] > updateRanks[typ] {
+			typ = t
+		}
+	}
+	return typ, true
+}
+
+// compareVersions returns the update type from the version to the other version. As any update of versions below
+// 1.0.0 may break in semantic versioning, it is treated as one type more disruptive.
+func compareVersions(from, to string) (string, bool) {
+	f, ok := parseVersion(from)
+	if !ok {
+		return "", false
+	}
+	t, ok := parseVersion(to)
+	if !ok {
+		return "", false
+	}
+	switch {
+	case f[0] != t[0]:
+		return UpdateMajor, true
+	case f[1] != t[1]:
+		if f[0] == 0 {
+			return UpdateMajor, true
+		}
+		return UpdateMinor, true
+	default:
+		if f[0] == 0 {
+			return UpdateMinor, true
+		}
+		return UpdatePatch, true
+	}
+}
+
+// parseVersion returns the major, minor and patch numbers of the version. Missing numbers are zero, and pre-release
+// and build suffixes are ignored.
+func parseVersion(version string) ([3]int, bool) {
+	var v [3]int
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,73 @@
+package automerge
+
+import "testing"
+
+func Test_updateType(t *testing.T) {
+	tests := map[string]struct {
+		title  string
+		body   string
+		want   string
+		wantOK bool
+	}{
+		"dependabot patch update": {
+			title:  "Bump lodash from 4.17.20 to 4.17.21",
+			want:   UpdatePatch,
+			wantOK: true,
+		},
+		"dependabot minor update of a directory": {
+			title:  "Bump github.com/spf13/cobra from 1.7.0 to 1.8.0 in /tools",
+			want:   UpdateMinor,
+			wantOK: true,
+		},
+		"dependabot major update with prefixes": {
+			title:  "Bump actions/checkout from v3 to v4",
+			want:   UpdateMajor,
+			wantOK: true,
+		},
+		"minor update below 1.0.0 is major": {
+			title:  "Bump foo from 0.3.1 to 0.4.0",
+			want:   UpdateMajor,
+			wantOK: true,
+		},
+		"patch update below 1.0.0 is minor": {
+			title:  "Bump foo from 0.3.1 to 0.3.2",
+			want:   UpdateMinor,
+			wantOK: true,
+		},
+		"pre-release suffixes are ignored": {
+			title:  "Bump foo from 1.2.3-rc.1 to 1.2.3",
+			want:   UpdatePatch,
+			wantOK: true,
+		},
+		"dependabot group takes the most disruptive update": {
+			title:  "Bump the npm group with 2 updates",
+			body:   "Bumps the npm group with 2 updates.\n\nUpdates `lodash` from 4.17.20 to 4.17.21\nUpdates `react` from 18.2.0 to 18.3.0\n",
+			want:   UpdateMinor,
+			wantOK: true,
+		},
+		"renovate table": {
+			title:  "Update dependency lodash to v4.17.21",
+			body:   "| Package | Change |\n|---|---|\n| lodash | [`4.17.20` -> `4.17.21`](https://renovatebot.com/diffs/npm/lodash/4.17.20/4.17.21) |",
+			want:   UpdatePatch,
+			wantOK: true,
+		},
+		"unknown versions": {
+			title: "Update dependency lodash to v4.17.21",
+			body:  "This PR updates lodash.",
+		},
+		"unparsable versions": {
+			title: "Bump foo from a1b2c3 to d4e5f6",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := updateType(tt.title, tt.body)
+			if ok != tt.wantOK {
+				t.Fatalf("updateType() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("updateType() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
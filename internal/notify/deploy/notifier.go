@@ -0,0 +1,160 @@
+// Package deploy hands off commits which passed the gate to deployment orchestrators, by a payload signed with a
+// shared key, so that passing the gate can be verified as a precondition of deployments.
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+const notifierName = "deploy-webhook"
+
+// SignatureHeader is the header carrying the HMAC-SHA256 signature of the payload by the shared key, in the same
+// format as X-Hub-Signature-256 of GitHub webhooks, e.g) sha256=<hex>.
+const SignatureHeader = "X-Merge-Gatekeeper-Signature-256"
+
+const signaturePrefix = "sha256="
+
+// Payload is sent to the endpoint when the gate passed the commit.
+type Payload struct {
+	Repository  string `json:"repository"`
+	SHA         string `json:"sha"`
+	PullRequest int    `json:"pull_request,omitempty"`
+	Result      string `json:"result"`
+	// Policy is the name of the policy applied, empty when no policy matched.
+	Policy string `json:"policy,omitempty"`
+	// PolicySHA is the blob SHA of the policy file read from the base branch, empty when there is no file.
+	PolicySHA string `json:"policy_sha,omitempty"`
+	// TraceDigest is the SHA-256 digest of the decision trace written by the gate, e.g) sha256:<hex>, by which the
+	// orchestrator can match the trace uploaded as an artifact.
+	TraceDigest string `json:"trace_digest"`
+}
+
+type notifier struct {
+	owner      string
+	repo       string
+	prNumber   int
+	url        string
+	key        []byte
+	policy     string
+	policySHA  string
+	traceFile  string
+	httpClient *http.Client
+}
+
+// New returns the notifier which sends the signed payload to the endpoint once the validation succeeds. Nothing is
+// sent otherwise, as the orchestrator must only deploy commits which passed the gate.
+func New(opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(n.url) == 0 {
+		errs = append(errs, errors.New("deploy webhook url is empty"))
+	}
+	if len(n.key) == 0 {
+		errs = append(errs, errors.New("signing key is empty"))
+	}
+	if len(n.traceFile) == 0 {
+		errs = append(errs, errors.New("trace file is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	if !o.Succeeded() {
+		return nil
+	}
+	digest, err := n.traceDigest()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&Payload{
+		Repository:  n.owner + "/" + n.repo,
+		SHA:         o.Ref,
+		PullRequest: n.prNumber,
+		Result:      o.Result,
+		Policy:      n.policy,
+		PolicySHA:   n.policySHA,
+		TraceDigest: digest,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(body, n.key))
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status of the deploy webhook: %s", res.Status)
+	}
+	return nil
+}
+
+// traceDigest returns the digest of the trace, which is written before notifiers are told about the outcome.
+func (n *notifier) traceDigest() (string, error) {
+	b, err := os.ReadFile(n.traceFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read trace: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Sign returns the value of SignatureHeader for the body.
+func Sign(body, key []byte) string {
+	h := hmac.New(sha256.New, key)
+	h.Write(body)
+	return signaturePrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify reports whether the signature, as sent in SignatureHeader, is of the body by the key.
+func Verify(body, key []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(body, key)))
+}
@@ -0,0 +1,138 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	trace := filepath.Join(t.TempDir(), "trace.json")
+	if err := os.WriteFile(trace, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		outcome *notify.Outcome
+		status  int
+		want    *Payload
+		wantErr bool
+	}{
+		"sends the signed payload on success": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+			status:  http.StatusAccepted,
+			want: &Payload{
+				Repository:  "owner/repo",
+				SHA:         "sha",
+				PullRequest: 1,
+				Result:      notify.ResultSuccess,
+				Policy:      "release",
+				PolicySHA:   "blob-sha",
+				TraceDigest: "sha256:ca3d163bab055381827226140568f3bef7eaac187cebd76878e0b63e9e442356",
+			},
+		},
+		"does not send on failure": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultFailure},
+		},
+		"does not send on timeout": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultTimeout},
+		},
+		"returns error statuses of the endpoint": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+			status:  http.StatusForbidden,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got *Payload
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !Verify(body, []byte("key"), r.Header.Get(SignatureHeader)) {
+					t.Errorf("signature %q does not match the payload", r.Header.Get(SignatureHeader))
+				}
+				got = &Payload{}
+				if err := json.Unmarshal(body, got); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			n, err := New(
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithURL(srv.URL),
+				WithKey("key"),
+				WithPolicy("release", "blob-sha"),
+				WithTraceFile(trace),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Notify(context.Background(), tt.outcome); (err != nil) != tt.wantErr {
+				t.Fatalf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("payload = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"sha":"sha"}`)
+	tests := map[string]struct {
+		body      []byte
+		key       string
+		signature string
+		want      bool
+	}{
+		"verifies the signature by the key": {
+			body:      body,
+			key:       "key",
+			signature: Sign(body, []byte("key")),
+			want:      true,
+		},
+		"rejects other keys": {
+			body:      body,
+			key:       "other",
+			signature: Sign(body, []byte("key")),
+		},
+		"rejects altered payloads": {
+			body:      []byte(`{"sha":"other"}`),
+			key:       "key",
+			signature: Sign(body, []byte("key")),
+		},
+		"rejects missing signatures": {
+			body: body,
+			key:  "key",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Verify(tt.body, []byte(tt.key), tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	if _, err := New(WithGitHubOwnerAndRepo("owner", "repo"), WithURL("https://deploy.example.com")); err == nil {
+		t.Error("New() error = nil, want error without the key and the trace file")
+	}
+}
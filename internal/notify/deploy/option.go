@@ -0,0 +1,49 @@
+package deploy
+
+type Option func(n *notifier)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(n *notifier) {
+		if len(owner) != 0 {
+			n.owner = owner
+		}
+		if len(repo) != 0 {
+			n.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request of the commit.
+func WithPullRequest(number int) Option {
+	return func(n *notifier) {
+		n.prNumber = number
+	}
+}
+
+// WithURL sets the endpoint of the deployment orchestrator receiving the payload.
+func WithURL(url string) Option {
+	return func(n *notifier) {
+		n.url = url
+	}
+}
+
+// WithKey sets the key shared with the orchestrator, by which the payload is signed.
+func WithKey(key string) Option {
+	return func(n *notifier) {
+		n.key = []byte(key)
+	}
+}
+
+// WithPolicy sets the name of the policy applied and the blob SHA of the policy file.
+func WithPolicy(name, sha string) Option {
+	return func(n *notifier) {
+		n.policy, n.policySHA = name, sha
+	}
+}
+
+// WithTraceFile sets the path of the decision trace, whose digest is sent.
+func WithTraceFile(path string) Option {
+	return func(n *notifier) {
+		n.traceFile = path
+	}
+}
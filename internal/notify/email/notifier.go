@@ -0,0 +1,117 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+const notifierName = "email"
+
+type notifier struct {
+	owner    string
+	repo     string
+	prNumber int
+	addr     string
+	user     string
+	password string
+	from     string
+	to       []string
+
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+// New returns the notifier which mails the final result of the validation through an SMTP relay, for environments
+// where outbound webhooks are not allowed.
+func New(opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if _, _, err := net.SplitHostPort(n.addr); err != nil {
+		errs = append(errs, fmt.Errorf("invalid smtp server address: %w", err))
+	}
+	if len(n.from) == 0 {
+		errs = append(errs, errors.New("sender address is empty"))
+	}
+	if len(n.to) == 0 {
+		errs = append(errs, errors.New("recipient addresses are empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Notify sends the mail. net/smtp does not take a context, so the mail is sent even after the context is done.
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	var auth smtp.Auth
+	if len(n.user) != 0 {
+		host, _, _ := net.SplitHostPort(n.addr)
+		auth = smtp.PlainAuth("", n.user, n.password, host)
+	}
+	if err := n.sendMail(n.addr, auth, n.from, n.to, n.message(o)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+func (n *notifier) message(o *notify.Outcome) []byte {
+	target := fmt.Sprintf("%s/%s@%s", n.owner, n.repo, o.Ref)
+	if n.prNumber != 0 {
+		target = fmt.Sprintf("%s/%s#%d", n.owner, n.repo, n.prNumber)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&b, "Subject: [merge-gatekeeper] %s: %s\r\n", target, o.Result)
+	fmt.Fprintf(&b, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+
+	fmt.Fprintf(&b, "Merge Gatekeeper finished with %s on %s (%s).\r\n", o.Result, target, o.Ref)
+	if n.prNumber != 0 {
+		fmt.Fprintf(&b, "https://github.com/%s/%s/pull/%d\r\n", n.owner, n.repo, n.prNumber)
+	}
+	for _, f := range o.Failures {
+		fmt.Fprintf(&b, "\r\n%s:\r\n", f.Validator)
+		for _, line := range strings.Split(strings.TrimSpace(f.Detail), "\n") {
+			b.WriteString(line + "\r\n")
+		}
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,95 @@
+package email
+
+import (
+	"context"
+	"net/smtp"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	n, err := New(
+		WithGitHubOwnerAndRepo("owner", "repo"),
+		WithPullRequest(1),
+		WithServer("smtp.example.com:587"),
+		WithAuth("bot", "password"),
+		WithFrom("gatekeeper@example.com"),
+		WithTo("dev@example.com, ops@example.com"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nt := n.(*notifier)
+	nt.now = func() time.Time { return time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC) }
+
+	var got struct {
+		addr string
+		to   []string
+		msg  string
+	}
+	nt.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		if a == nil {
+			t.Error("auth = nil, want PLAIN auth")
+		}
+		got.addr, got.to, got.msg = addr, to, string(msg)
+		return nil
+	}
+
+	o := &notify.Outcome{Ref: "sha", Result: notify.ResultFailure, Failures: []notify.Failure{{Validator: "merge-gatekeeper", Detail: "failed jobs:\n- job-01\n"}}}
+	if err := n.Notify(context.Background(), o); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.addr != "smtp.example.com:587" {
+		t.Errorf("addr = %s, want smtp.example.com:587", got.addr)
+	}
+	if want := []string{"dev@example.com", "ops@example.com"}; !reflect.DeepEqual(got.to, want) {
+		t.Errorf("to = %v, want %v", got.to, want)
+	}
+	want := "From: gatekeeper@example.com\r\n" +
+		"To: dev@example.com, ops@example.com\r\n" +
+		"Subject: [merge-gatekeeper] owner/repo#1: failure\r\n" +
+		"Date: Tue, 02 Jan 2024 00:00:00 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		"Merge Gatekeeper finished with failure on owner/repo#1 (sha).\r\n" +
+		"https://github.com/owner/repo/pull/1\r\n" +
+		"\r\n" +
+		"merge-gatekeeper:\r\n" +
+		"failed jobs:\r\n" +
+		"- job-01\r\n"
+	if got.msg != want {
+		t.Errorf("message = %q, want %q", got.msg, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns notifier": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithServer("localhost:25"), WithFrom("a@example.com"), WithTo("b@example.com")},
+		},
+		"returns error without port": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithServer("localhost"), WithFrom("a@example.com"), WithTo("b@example.com")},
+			wantErr: true,
+		},
+		"returns error without recipients": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithServer("localhost:25"), WithFrom("a@example.com")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
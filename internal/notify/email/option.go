@@ -0,0 +1,58 @@
+package email
+
+import (
+	"strings"
+)
+
+type Option func(n *notifier)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(n *notifier) {
+		if len(owner) != 0 {
+			n.owner = owner
+		}
+		if len(repo) != 0 {
+			n.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request mentioned in the mail.
+func WithPullRequest(number int) Option {
+	return func(n *notifier) {
+		n.prNumber = number
+	}
+}
+
+// WithServer sets the address of the mail relay, e.g) smtp.example.com:587.
+func WithServer(addr string) Option {
+	return func(n *notifier) {
+		n.addr = addr
+	}
+}
+
+// WithAuth sets the credentials for PLAIN authentication. The relay is used without authentication when the user
+// is empty.
+func WithAuth(user, password string) Option {
+	return func(n *notifier) {
+		n.user, n.password = user, password
+	}
+}
+
+// WithFrom sets the sender address.
+func WithFrom(from string) Option {
+	return func(n *notifier) {
+		n.from = from
+	}
+}
+
+// WithTo sets the recipient addresses (comma-separated list).
+func WithTo(to string) Option {
+	return func(n *notifier) {
+		for _, addr := range strings.Split(to, ",") {
+			if addr = strings.TrimSpace(addr); len(addr) != 0 {
+				n.to = append(n.to, addr)
+			}
+		}
+	}
+}
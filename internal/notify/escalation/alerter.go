@@ -0,0 +1,156 @@
+package escalation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Supported alerting services.
+const (
+	KindPagerDuty = "pagerduty"
+	KindOpsgenie  = "opsgenie"
+)
+
+const (
+	pagerDutyURL = "https://events.pagerduty.com"
+	opsgenieURL  = "https://api.opsgenie.com"
+)
+
+// alert is raised for a blocked pull request. Alerts of the same key are deduplicated by the service.
+type alert struct {
+	key     string
+	summary string
+	details string
+	link    string
+}
+
+// alerter opens and resolves alerts in an alerting service.
+type alerter interface {
+	open(ctx context.Context, a *alert) error
+	resolve(ctx context.Context, key string) error
+}
+
+// pagerDuty sends events to a PagerDuty service through its Events API v2 integration.
+// NOTE: https://developer.pagerduty.com/docs/events-api-v2/trigger-events/
+type pagerDuty struct {
+	baseURL    string
+	routingKey string
+	httpClient *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	CustomDetails string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+func (pd *pagerDuty) open(ctx context.Context, a *alert) error {
+	return pd.send(ctx, &pagerDutyEvent{
+		RoutingKey:  pd.routingKey,
+		EventAction: "trigger",
+		DedupKey:    a.key,
+		Payload: &pagerDutyPayload{
+			Summary:       a.summary,
+			Source:        "merge-gatekeeper",
+			Severity:      "critical",
+			CustomDetails: a.details,
+		},
+		Links: []pagerDutyLink{{Href: a.link, Text: "Pull request"}},
+	})
+}
+
+func (pd *pagerDuty) resolve(ctx context.Context, key string) error {
+	return pd.send(ctx, &pagerDutyEvent{
+		RoutingKey:  pd.routingKey,
+		EventAction: "resolve",
+		DedupKey:    key,
+	})
+}
+
+func (pd *pagerDuty) send(ctx context.Context, e *pagerDutyEvent) error {
+	return post(ctx, pd.httpClient, pd.baseURL+"/v2/enqueue", "", e)
+}
+
+// opsgenie creates and closes alerts through the Opsgenie Alert API, using the key as the alias.
+// NOTE: https://docs.opsgenie.com/docs/alert-api
+type opsgenie struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func (og *opsgenie) open(ctx context.Context, a *alert) error {
+	return post(ctx, og.httpClient, og.baseURL+"/v2/alerts", "GenieKey "+og.apiKey, map[string]any{
+		"message":     a.summary,
+		"alias":       a.key,
+		"description": a.details + "\n\n" + a.link,
+		"priority":    "P1",
+		"source":      "merge-gatekeeper",
+	})
+}
+
+func (og *opsgenie) resolve(ctx context.Context, key string) error {
+	u := fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", og.baseURL, url.PathEscape(key))
+	return post(ctx, og.httpClient, u, "GenieKey "+og.apiKey, map[string]any{"source": "merge-gatekeeper"})
+}
+
+func post(ctx context.Context, httpClient *http.Client, u, authorization string, in any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(authorization) != 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+	return nil
+}
+
+func newAlerter(kind, key, baseURL string) (alerter, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	switch strings.ToLower(kind) {
+	case KindPagerDuty:
+		if len(baseURL) == 0 {
+			baseURL = pagerDutyURL
+		}
+		return &pagerDuty{baseURL: baseURL, routingKey: key, httpClient: http.DefaultClient}, nil
+	case KindOpsgenie:
+		if len(baseURL) == 0 {
+			baseURL = opsgenieURL
+		}
+		return &opsgenie{baseURL: baseURL, apiKey: key, httpClient: http.DefaultClient}, nil
+	default:
+		return nil, fmt.Errorf("unsupported alerting service: %s, supported services: [%s %s]", kind, KindPagerDuty, KindOpsgenie)
+	}
+}
@@ -0,0 +1,133 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+const notifierName = "escalation"
+
+// DefaultLabels are the labels of pull requests fixing incidents.
+const DefaultLabels = "urgent,hotfix"
+
+// DefaultSLA is how long incident fixes may be blocked when no SLA is given.
+const DefaultSLA = 30 * time.Minute
+
+type notifier struct {
+	owner    string
+	repo     string
+	prNumber int
+	kind     string
+	key      string
+	baseURL  string
+	labels   []string
+	sla      time.Duration
+	clock    clock.Clock
+	client   github.Client
+	alerter  alerter
+}
+
+// New returns the notifier which opens an alert when a pull request labelled as urgent has been blocked by the gate
+// for longer than the SLA, counted from when the pull request was opened. The alert is resolved once the gate passes.
+func New(c github.Client, opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		client: c,
+		sla:    DefaultSLA,
+		clock:  clock.New(),
+	}
+	WithLabels(DefaultLabels)(n)
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	a, err := newAlerter(n.kind, n.key, n.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	n.alerter = a
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(n.key) == 0 {
+		errs = append(errs, errors.New("integration key of alerting service is empty"))
+	}
+	if n.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	if n.prNumber == 0 {
+		return nil
+	}
+	pr, _, err := n.client.GetPullRequest(ctx, n.owner, n.repo, n.prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request #%d: %w", n.prNumber, err)
+	}
+	label, ok := n.escalatedLabel(pr)
+	if !ok {
+		return nil
+	}
+
+	key := fmt.Sprintf("merge-gatekeeper/%s/%s#%d", n.owner, n.repo, n.prNumber)
+	if o.Succeeded() {
+		return n.alerter.resolve(ctx, key)
+	}
+	blocked := n.clock.Now().Sub(pr.GetCreatedAt().Time)
+	if blocked < n.sla {
+		return nil
+	}
+
+	var details strings.Builder
+	fmt.Fprintf(&details, "Merge Gatekeeper %s on %s.\n", o.Result, o.Ref)
+	for _, f := range o.Failures {
+		fmt.Fprintf(&details, "\n%s:\n%s\n", f.Validator, strings.TrimSpace(f.Detail))
+	}
+	return n.alerter.open(ctx, &alert{
+		key:     key,
+		summary: fmt.Sprintf("%s %s/%s#%d has been blocked for %s: %s", label, n.owner, n.repo, n.prNumber, blocked.Round(time.Minute), pr.GetTitle()),
+		details: details.String(),
+		link:    pr.GetHTMLURL(),
+	})
+}
+
+// escalatedLabel returns the first label of the pull request which is escalated.
+func (n *notifier) escalatedLabel(pr *github.PullRequest) (string, bool) {
+	for _, l := range pr.Labels {
+		for _, name := range n.labels {
+			if strings.EqualFold(l.GetName(), name) {
+				return l.GetName(), true
+			}
+		}
+	}
+	return "", false
+}
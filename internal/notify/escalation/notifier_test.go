@@ -0,0 +1,142 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+var now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := map[string]struct {
+		kind    string
+		label   string
+		opened  time.Time
+		outcome *notify.Outcome
+		want    []string
+	}{
+		"triggers pagerduty alert after the SLA": {
+			kind:    KindPagerDuty,
+			label:   "hotfix",
+			opened:  now.Add(-time.Hour),
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultTimeout},
+			want:    []string{"/v2/enqueue trigger merge-gatekeeper/owner/repo#1"},
+		},
+		"resolves pagerduty alert on success": {
+			kind:    KindPagerDuty,
+			label:   "urgent",
+			opened:  now.Add(-time.Hour),
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+			want:    []string{"/v2/enqueue resolve merge-gatekeeper/owner/repo#1"},
+		},
+		"creates opsgenie alert after the SLA": {
+			kind:    KindOpsgenie,
+			label:   "Urgent",
+			opened:  now.Add(-time.Hour),
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultFailure},
+			want:    []string{"/v2/alerts  merge-gatekeeper/owner/repo#1"},
+		},
+		"closes opsgenie alert on success": {
+			kind:    KindOpsgenie,
+			label:   "urgent",
+			opened:  now.Add(-time.Hour),
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+			want:    []string{"/v2/alerts/merge-gatekeeper/owner/repo#1/close  "},
+		},
+		"does not alert within the SLA": {
+			kind:    KindPagerDuty,
+			label:   "hotfix",
+			opened:  now.Add(-10 * time.Minute),
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultFailure},
+		},
+		"does not alert pull requests without the labels": {
+			kind:    KindPagerDuty,
+			label:   "enhancement",
+			opened:  now.Add(-time.Hour),
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultFailure},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var in struct {
+					EventAction string `json:"event_action"`
+					DedupKey    string `json:"dedup_key"`
+					Alias       string `json:"alias"`
+				}
+				json.NewDecoder(r.Body).Decode(&in)
+				got = append(got, r.URL.Path+" "+in.EventAction+" "+in.DedupKey+in.Alias)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer srv.Close()
+
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{
+						Title:     stringPtr("fix outage"),
+						Labels:    []*github.Label{{Name: stringPtr(tt.label)}},
+						CreatedAt: &github.Timestamp{Time: tt.opened},
+					}, nil, nil
+				},
+			}
+			n, err := New(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithService(tt.kind, "key", srv.URL),
+				WithSLA(30*time.Minute),
+				WithClock(clockmock.NewClock(now)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Notify(context.Background(), tt.outcome); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns notifier": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithService(KindOpsgenie, "key", "")},
+		},
+		"returns error without key": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithService(KindPagerDuty, "", "")},
+			wantErr: true,
+		},
+		"returns error for unsupported service": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithService("victorops", "key", "")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(&mock.Client{}, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
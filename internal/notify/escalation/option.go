@@ -0,0 +1,68 @@
+package escalation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+)
+
+type Option func(n *notifier)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(n *notifier) {
+		if len(owner) != 0 {
+			n.owner = owner
+		}
+		if len(repo) != 0 {
+			n.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to escalate.
+func WithPullRequest(number int) Option {
+	return func(n *notifier) {
+		n.prNumber = number
+	}
+}
+
+// WithService sets the alerting service, its integration key, and optionally the base URL of its API.
+func WithService(kind, key, baseURL string) Option {
+	return func(n *notifier) {
+		n.kind, n.key, n.baseURL = kind, key, baseURL
+	}
+}
+
+// WithLabels sets the labels of pull requests to escalate (comma-separated list).
+func WithLabels(labels string) Option {
+	return func(n *notifier) {
+		if len(labels) == 0 {
+			return
+		}
+		n.labels = n.labels[:0]
+		for _, l := range strings.Split(labels, ",") {
+			if l = strings.TrimSpace(l); len(l) != 0 {
+				n.labels = append(n.labels, l)
+			}
+		}
+	}
+}
+
+// WithSLA sets how long a pull request may be blocked before it is escalated.
+func WithSLA(d time.Duration) Option {
+	return func(n *notifier) {
+		if d > 0 {
+			n.sla = d
+		}
+	}
+}
+
+// WithClock sets the clock used to measure how long the pull request has been blocked.
+func WithClock(c clock.Clock) Option {
+	return func(n *notifier) {
+		if c != nil {
+			n.clock = c
+		}
+	}
+}
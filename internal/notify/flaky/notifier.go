@@ -0,0 +1,299 @@
+package flaky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+	"github.com/aac228/merge-gatekeeper/internal/owners"
+)
+
+const notifierName = "flaky-issues"
+
+// IssueLabel is the label of tracking issues of failing jobs. It is also used to find the issues to update.
+const IssueLabel = "merge-gatekeeper-flaky"
+
+// DefaultThreshold is how many consecutive failures of a job are escalated when no threshold is given.
+const DefaultThreshold = 3
+
+const (
+	checkRunCompletedStatus   = "completed"
+	checkRunSuccessConclusion = "success"
+	checkRunFailureConclusion = "failure"
+	checkRunFilterAll         = "all"
+)
+
+const (
+	maxItemsPerPage = 100
+	// maxCommits bounds how many commits of the pull request are looked back to count consecutive failures.
+	maxCommits = 20
+)
+
+type notifier struct {
+	owner       string
+	repo        string
+	ref         string
+	prNumber    int
+	selfJobName string
+	ignoredJobs []string
+	threshold   int
+	ownersFile  string
+	client      github.Client
+}
+
+// New returns the notifier which opens a tracking issue, or comments on the open one, when a job has failed
+// the gate of the same pull request every threshold consecutive times. The issue mentions the owners of the job.
+func New(c github.Client, opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		client:     c,
+		threshold:  DefaultThreshold,
+		ownersFile: owners.DefaultPath,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(n.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if n.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	if o.Succeeded() || n.prNumber == 0 {
+		return nil
+	}
+	failed, err := n.failedJobs(ctx)
+	if err != nil {
+		return err
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	commits, err := n.recentCommits(ctx)
+	if err != nil {
+		return err
+	}
+
+	resolver := owners.NewResolver(n.client, n.owner, n.repo, n.ref, n.ownersFile)
+	errs := make(multierror.Errors, 0, len(failed))
+	for _, job := range failed {
+		count, err := n.consecutiveFailures(ctx, job.name, commits)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if count%n.threshold != 0 {
+			continue
+		}
+		jobOwners, err := resolver.Owners(ctx, job.name, job.workflowPath)
+		if err != nil {
+			return err
+		}
+		if err := n.escalate(ctx, job.name, count, jobOwners); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+type failedJob struct {
+	name         string
+	suiteID      int64
+	workflowPath string
+}
+
+// failedJobs returns the jobs whose latest run on the ref failed, except for this job and ignored jobs.
+func (n *notifier) failedJobs(ctx context.Context) ([]failedJob, error) {
+	cr, _, err := n.client.ListCheckRunsForRef(ctx, n.owner, n.repo, n.ref, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs: %w", err)
+	}
+	var failed []failedJob
+	for _, run := range cr.CheckRuns {
+		name := run.GetName()
+		if name == n.selfJobName || n.isIgnored(name) || run.GetConclusion() != checkRunFailureConclusion {
+			continue
+		}
+		failed = append(failed, failedJob{name: name, suiteID: run.GetCheckSuite().GetID()})
+	}
+	if len(failed) == 0 {
+		return nil, nil
+	}
+
+	// Workflow runs map the check suites of the jobs to the workflow files, whose code owners own the jobs by default.
+	runs, _, err := n.client.ListWorkflowRuns(ctx, n.owner, n.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     n.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	suiteToPath := make(map[int64]string)
+	for _, run := range runs.WorkflowRuns {
+		suiteToPath[run.GetCheckSuiteID()] = run.GetPath()
+	}
+	for i := range failed {
+		failed[i].workflowPath = suiteToPath[failed[i].suiteID]
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].name < failed[j].name })
+	return failed, nil
+}
+
+func (n *notifier) isIgnored(name string) bool {
+	for _, ignored := range n.ignoredJobs {
+		if ignored == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recentCommits returns the latest commits of the pull request, newest first.
+func (n *notifier) recentCommits(ctx context.Context) ([]string, error) {
+	var shas []string
+	page := 1
+	for {
+		commits, _, err := n.client.ListPullRequestCommits(ctx, n.owner, n.repo, n.prNumber, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of #%d: %w", n.prNumber, err)
+		}
+		for _, c := range commits {
+			shas = append(shas, c.GetSHA())
+		}
+		if len(commits) < maxItemsPerPage {
+			break
+		}
+		page++
+	}
+	// Commits are listed oldest first.
+	for i, j := 0, len(shas)-1; i < j; i, j = i+1, j-1 {
+		shas[i], shas[j] = shas[j], shas[i]
+	}
+	if len(shas) > maxCommits {
+		shas = shas[:maxCommits]
+	}
+	return shas, nil
+}
+
+// consecutiveFailures counts the failed runs of the job, including re-runs, since it last succeeded on the pull request.
+func (n *notifier) consecutiveFailures(ctx context.Context, job string, commits []string) (int, error) {
+	filter := checkRunFilterAll
+	var count int
+	for _, sha := range commits {
+		cr, _, err := n.client.ListCheckRunsForRef(ctx, n.owner, n.repo, sha, &github.ListCheckRunsOptions{
+			CheckName:   &job,
+			Filter:      &filter,
+			ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list runs of %s on %s: %w", job, sha, err)
+		}
+		runs := cr.CheckRuns
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].GetCompletedAt().After(runs[j].GetCompletedAt().Time)
+		})
+		for _, run := range runs {
+			if run.GetStatus() != checkRunCompletedStatus {
+				continue
+			}
+			switch run.GetConclusion() {
+			case checkRunSuccessConclusion:
+				return count, nil
+			case checkRunFailureConclusion:
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (n *notifier) escalate(ctx context.Context, job string, count int, jobOwners []string) error {
+	title := fmt.Sprintf("Job %s keeps failing", job)
+	mention := ""
+	if len(jobOwners) != 0 {
+		mention = fmt.Sprintf(" cc %s", strings.Join(jobOwners, " "))
+	}
+	summary := fmt.Sprintf("`%s` failed %d consecutive times on #%d (%s).%s", job, count, n.prNumber, n.ref, mention)
+
+	issue, err := n.findIssue(ctx, title)
+	if err != nil {
+		return err
+	}
+	if issue != nil {
+		if _, _, err := n.client.CreateIssueComment(ctx, n.owner, n.repo, issue.GetNumber(), &github.IssueComment{Body: &summary}); err != nil {
+			return fmt.Errorf("failed to comment on #%d: %w", issue.GetNumber(), err)
+		}
+		return nil
+	}
+
+	body := summary + "\n\nThis issue tracks the job blocking the merges of pull requests. It is updated on every " +
+		fmt.Sprintf("%d consecutive failures of the job on a pull request.", n.threshold)
+	if _, _, err := n.client.CreateIssue(ctx, n.owner, n.repo, &github.IssueRequest{
+		Title:  &title,
+		Body:   &body,
+		Labels: &[]string{IssueLabel},
+	}); err != nil {
+		return fmt.Errorf("failed to open issue for %s: %w", job, err)
+	}
+	return nil
+}
+
+func (n *notifier) findIssue(ctx context.Context, title string) (*github.Issue, error) {
+	page := 1
+	for {
+		issues, _, err := n.client.ListIssues(ctx, n.owner, n.repo, &github.IssueListByRepoOptions{
+			State:       "open",
+			Labels:      []string{IssueLabel},
+			ListOptions: github.ListOptions{Page: page, PerPage: maxItemsPerPage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tracking issues: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.GetTitle() == title {
+				return issue, nil
+			}
+		}
+		if len(issues) < maxItemsPerPage {
+			return nil, nil
+		}
+		page++
+	}
+}
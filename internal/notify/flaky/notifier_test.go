@@ -0,0 +1,128 @@
+package flaky
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func checkRun(name, conclusion string, completedAt time.Time) *github.CheckRun {
+	return &github.CheckRun{
+		Name:        &name,
+		Status:      stringPtr("completed"),
+		Conclusion:  &conclusion,
+		CompletedAt: &github.Timestamp{Time: completedAt},
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := map[string]struct {
+		history     map[string][]*github.CheckRun // runs of job-01 keyed by commit
+		issues      []*github.Issue
+		wantIssue   string
+		wantComment string
+	}{
+		"opens issue mentioning owners on the threshold": {
+			history: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "failure", now.Add(-3*time.Hour)), checkRun("job-01", "success", now.Add(-4*time.Hour))},
+				"sha-2": {checkRun("job-01", "failure", now.Add(-time.Hour)), checkRun("job-01", "failure", now.Add(-2*time.Hour))},
+			},
+			wantIssue: "`job-01` failed 3 consecutive times on #1 (sha-2). cc @org/qa\n\nThis issue tracks the job blocking the merges of pull requests. It is updated on every 3 consecutive failures of the job on a pull request.",
+		},
+		"comments on the open issue": {
+			history: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "failure", now.Add(-3*time.Hour))},
+				"sha-2": {checkRun("job-01", "failure", now.Add(-time.Hour)), checkRun("job-01", "failure", now.Add(-2*time.Hour))},
+			},
+			issues: []*github.Issue{
+				{Number: intPtr(5), Title: stringPtr("Job job-02 keeps failing")},
+				{Number: intPtr(6), Title: stringPtr("Job job-01 keeps failing")},
+			},
+			wantComment: "#6 `job-01` failed 3 consecutive times on #1 (sha-2). cc @org/qa",
+		},
+		"does nothing below the threshold": {
+			history: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("job-01", "success", now.Add(-3*time.Hour))},
+				"sha-2": {checkRun("job-01", "failure", now.Add(-time.Hour)), checkRun("job-01", "failure", now.Add(-2*time.Hour))},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotIssue, gotComment string
+			c := &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					if opts.CheckName == nil {
+						return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+							checkRun("merge-gatekeeper", "failure", now),
+							checkRun("job-01", "failure", now.Add(-time.Hour)),
+							checkRun("job-02", "success", now.Add(-time.Hour)),
+						}}, nil, nil
+					}
+					return &github.ListCheckRunsResults{CheckRuns: tt.history[ref]}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					return &github.WorkflowRuns{}, nil, nil
+				},
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					return []*github.RepositoryCommit{{SHA: stringPtr("sha-1")}, {SHA: stringPtr("sha-2")}}, nil, nil
+				},
+				GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+					if path != ".github/JOBOWNERS" {
+						return nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+					}
+					content := base64.StdEncoding.EncodeToString([]byte("* @org/platform\njob-* @org/qa\n"))
+					return &github.RepositoryContent{Content: &content, Encoding: stringPtr("base64")}, nil, nil, nil
+				},
+				ListIssuesFunc: func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+					return tt.issues, nil, nil
+				},
+				CreateIssueFunc: func(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+					gotIssue = issue.GetBody()
+					return &github.Issue{}, nil, nil
+				},
+				CreateIssueCommentFunc: func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+					gotComment = fmt.Sprintf("#%d %s", number, comment.GetBody())
+					return comment, nil, nil
+				},
+			}
+			n, err := New(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithGitHubRef("sha-2"),
+				WithPullRequest(1),
+				WithSelfJob("merge-gatekeeper"),
+				WithThreshold(3),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Notify(context.Background(), &notify.Outcome{Ref: "sha-2", Result: notify.ResultFailure}); err != nil {
+				t.Fatal(err)
+			}
+			if gotIssue != tt.wantIssue {
+				t.Errorf("issue = %q, want %q", gotIssue, tt.wantIssue)
+			}
+			if gotComment != tt.wantComment {
+				t.Errorf("comment = %q, want %q", gotComment, tt.wantComment)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package flaky
+
+import "strings"
+
+type Option func(n *notifier)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(n *notifier) {
+		if len(owner) != 0 {
+			n.owner = owner
+		}
+		if len(repo) != 0 {
+			n.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(n *notifier) {
+		if len(ref) != 0 {
+			n.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request whose failures are counted.
+func WithPullRequest(number int) Option {
+	return func(n *notifier) {
+		n.prNumber = number
+	}
+}
+
+// WithSelfJob sets the name of this job, whose failures are caused by the others.
+func WithSelfJob(name string) Option {
+	return func(n *notifier) {
+		if len(name) != 0 {
+			n.selfJobName = name
+		}
+	}
+}
+
+// WithIgnoredJobs sets the jobs which do not block the gate (comma-separated list).
+func WithIgnoredJobs(names string) Option {
+	return func(n *notifier) {
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); len(name) != 0 {
+				n.ignoredJobs = append(n.ignoredJobs, name)
+			}
+		}
+	}
+}
+
+// WithThreshold sets after how many consecutive failures of a job it is escalated.
+func WithThreshold(n int) Option {
+	return func(nt *notifier) {
+		if n > 0 {
+			nt.threshold = n
+		}
+	}
+}
+
+// WithOwnersFile sets the path of the ownership map of jobs in the repository.
+func WithOwnersFile(path string) Option {
+	return func(n *notifier) {
+		if len(path) != 0 {
+			n.ownersFile = path
+		}
+	}
+}
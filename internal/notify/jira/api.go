@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// NOTE: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issues/
+
+type transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
+type comment struct {
+	Body string `json:"body"`
+}
+
+func (n *notifier) status(ctx context.Context, key string) (string, error) {
+	var res struct {
+		Fields struct {
+			Status struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := n.do(ctx, http.MethodGet, "/rest/api/2/issue/"+url.PathEscape(key)+"?fields=status", nil, &res); err != nil {
+		return "", err
+	}
+	return res.Fields.Status.Name, nil
+}
+
+func (n *notifier) transitions(ctx context.Context, key string) ([]transition, error) {
+	var res struct {
+		Transitions []transition `json:"transitions"`
+	}
+	if err := n.do(ctx, http.MethodGet, "/rest/api/2/issue/"+url.PathEscape(key)+"/transitions", nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Transitions, nil
+}
+
+func (n *notifier) transition(ctx context.Context, key, id string) error {
+	req := map[string]any{"transition": map[string]string{"id": id}}
+	return n.do(ctx, http.MethodPost, "/rest/api/2/issue/"+url.PathEscape(key)+"/transitions", req, nil)
+}
+
+func (n *notifier) comments(ctx context.Context, key string) ([]comment, error) {
+	var res struct {
+		Comments []comment `json:"comments"`
+	}
+	if err := n.do(ctx, http.MethodGet, "/rest/api/2/issue/"+url.PathEscape(key)+"/comment?orderBy=-created", nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Comments, nil
+}
+
+func (n *notifier) addComment(ctx context.Context, key, body string) error {
+	return n.do(ctx, http.MethodPost, "/rest/api/2/issue/"+url.PathEscape(key)+"/comment", comment{Body: body}, nil)
+}
+
+// do sends the request to the Jira API. Jira Cloud authenticates with the email and API token of the user,
+// and Jira Data Center with personal access tokens, which are sent when the user is empty.
+func (n *notifier) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, n.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(n.user) != 0 {
+		req.SetBasicAuth(n.user, n.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status of %s %s: %s", method, path, res.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
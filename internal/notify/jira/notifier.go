@@ -0,0 +1,182 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+const notifierName = "jira"
+
+var issueKey = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-([1-9][0-9]*)\b`)
+
+type notifier struct {
+	owner          string
+	repo           string
+	prNumber       int
+	baseURL        string
+	user           string
+	token          string
+	transitionName string
+	projects       []string
+	client         github.Client
+	httpClient     *http.Client
+}
+
+// New returns the notifier which transitions the Jira tickets linked by the pull request when the validation succeeds,
+// and comments the failures on them otherwise. Tickets are linked by their keys in the title, the head branch
+// or the body of the pull request.
+func New(c github.Client, opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		client:     c,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(n.baseURL) == 0 {
+		errs = append(errs, errors.New("jira url is empty"))
+	}
+	if len(n.token) == 0 {
+		errs = append(errs, errors.New("jira token is empty"))
+	}
+	if n.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	if n.prNumber == 0 {
+		return nil
+	}
+	pr, _, err := n.client.GetPullRequest(ctx, n.owner, n.repo, n.prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request #%d: %w", n.prNumber, err)
+	}
+	keys := n.linkedIssues(pr.GetTitle(), pr.GetHead().GetRef(), pr.GetBody())
+
+	errs := make(multierror.Errors, 0, len(keys))
+	for _, key := range keys {
+		var err error
+		if o.Succeeded() {
+			err = n.transitionIssue(ctx, key)
+		} else {
+			err = n.commentFailures(ctx, key, o)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// linkedIssues returns the unique issue keys in the texts, in order of appearance.
+func (n *notifier) linkedIssues(texts ...string) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, text := range texts {
+		for _, m := range issueKey.FindAllStringSubmatch(text, -1) {
+			if seen[m[0]] || !n.inProjects(m[1]) {
+				continue
+			}
+			seen[m[0]] = true
+			keys = append(keys, m[0])
+		}
+	}
+	return keys
+}
+
+func (n *notifier) inProjects(project string) bool {
+	if len(n.projects) == 0 {
+		return true
+	}
+	for _, p := range n.projects {
+		if p == project {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionIssue applies the transition, unless the issue is already in the status it leads to.
+func (n *notifier) transitionIssue(ctx context.Context, key string) error {
+	if len(n.transitionName) == 0 {
+		return nil
+	}
+	status, err := n.status(ctx, key)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(status, n.transitionName) {
+		return nil
+	}
+	ts, err := n.transitions(ctx, key)
+	if err != nil {
+		return err
+	}
+	for _, t := range ts {
+		if strings.EqualFold(t.Name, n.transitionName) || strings.EqualFold(t.To.Name, n.transitionName) {
+			if strings.EqualFold(t.To.Name, status) {
+				return nil
+			}
+			return n.transition(ctx, key, t.ID)
+		}
+	}
+	return fmt.Errorf("transition %s is not available from %s", n.transitionName, status)
+}
+
+// commentFailures comments the failures once per ref, so that retries of the validation do not repeat the comment.
+func (n *notifier) commentFailures(ctx context.Context, key string, o *notify.Outcome) error {
+	heading := fmt.Sprintf("Merge Gatekeeper %s on %s/%s#%d (%s)", o.Result, n.owner, n.repo, n.prNumber, o.Ref)
+	cs, err := n.comments(ctx, key)
+	if err != nil {
+		return err
+	}
+	for _, c := range cs {
+		if strings.HasPrefix(c.Body, heading) {
+			return nil
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(heading + "\n")
+	for _, f := range o.Failures {
+		fmt.Fprintf(&b, "\n%s:\n%s\n", f.Validator, strings.TrimSpace(f.Detail))
+	}
+	return n.addComment(ctx, key, b.String())
+}
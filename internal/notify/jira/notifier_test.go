@@ -0,0 +1,134 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func Test_notifier_linkedIssues(t *testing.T) {
+	tests := map[string]struct {
+		projects string
+		texts    []string
+		want     []string
+	}{
+		"returns unique keys in order": {
+			texts: []string{"PROJ-12: fix login", "feature/PROJ-12-login", "Fixes OPS-3, see also PROJ-7."},
+			want:  []string{"PROJ-12", "OPS-3", "PROJ-7"},
+		},
+		"returns keys of the projects": {
+			projects: "PROJ",
+			texts:    []string{"PROJ-12 and OPS-3", "UTF-8"},
+			want:     []string{"PROJ-12"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			n := &notifier{}
+			WithProjects(tt.projects)(n)
+			if got := n.linkedIssues(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkedIssues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := map[string]struct {
+		outcome  *notify.Outcome
+		status   string
+		comments []comment
+		want     []string
+	}{
+		"transitions linked issues on success": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+			status:  "In Review",
+			want:    []string{"GET /rest/api/2/issue/PROJ-1", "GET /rest/api/2/issue/PROJ-1/transitions", "POST /rest/api/2/issue/PROJ-1/transitions 31"},
+		},
+		"does not transition issues already in the status": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultSuccess},
+			status:  "Ready for Deploy",
+			want:    []string{"GET /rest/api/2/issue/PROJ-1"},
+		},
+		"comments failures on failure": {
+			outcome: &notify.Outcome{Ref: "sha", Result: notify.ResultFailure, Failures: []notify.Failure{{Validator: "merge-gatekeeper", Detail: "job-01 failed"}}},
+			want: []string{
+				"GET /rest/api/2/issue/PROJ-1/comment",
+				"POST /rest/api/2/issue/PROJ-1/comment Merge Gatekeeper failure on owner/repo#1 (sha)\n\nmerge-gatekeeper:\njob-01 failed\n",
+			},
+		},
+		"does not repeat the comment of the ref": {
+			outcome:  &notify.Outcome{Ref: "sha", Result: notify.ResultFailure},
+			comments: []comment{{Body: "Merge Gatekeeper failure on owner/repo#1 (sha)\n"}},
+			want:     []string{"GET /rest/api/2/issue/PROJ-1/comment"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if user, token, _ := r.BasicAuth(); user != "bot@example.com" || token != "token" {
+					t.Errorf("credentials = %s:%s", user, token)
+				}
+				req := r.Method + " " + r.URL.Path
+				var in struct {
+					Body       string `json:"body"`
+					Transition struct {
+						ID string `json:"id"`
+					} `json:"transition"`
+				}
+				if r.Method == http.MethodPost {
+					json.NewDecoder(r.Body).Decode(&in)
+					req += " " + in.Body + in.Transition.ID
+				}
+				got = append(got, req)
+
+				switch {
+				case r.Method == http.MethodPost:
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasSuffix(r.URL.Path, "/transitions"):
+					w.Write([]byte(`{"transitions": [{"id": "21", "name": "Start", "to": {"name": "In Progress"}}, {"id": "31", "name": "Approve", "to": {"name": "Ready for Deploy"}}]}`))
+				case strings.HasSuffix(r.URL.Path, "/comment"):
+					json.NewEncoder(w).Encode(map[string]any{"comments": tt.comments})
+				default:
+					json.NewEncoder(w).Encode(map[string]any{"fields": map[string]any{"status": map[string]string{"name": tt.status}}})
+				}
+			}))
+			defer srv.Close()
+
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{Title: stringPtr("PROJ-1: add login"), Head: &github.PullRequestBranch{Ref: stringPtr("login")}}, nil, nil
+				},
+			}
+			n, err := New(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithURL(srv.URL+"/"),
+				WithCredentials("bot@example.com", "token"),
+				WithTransition("Ready for Deploy"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Notify(context.Background(), tt.outcome); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package jira
+
+import (
+	"strings"
+)
+
+type Option func(n *notifier)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(n *notifier) {
+		if len(owner) != 0 {
+			n.owner = owner
+		}
+		if len(repo) != 0 {
+			n.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request linking the tickets.
+func WithPullRequest(number int) Option {
+	return func(n *notifier) {
+		n.prNumber = number
+	}
+}
+
+// WithURL sets the base URL of Jira, e.g) https://example.atlassian.net.
+func WithURL(baseURL string) Option {
+	return func(n *notifier) {
+		n.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithCredentials sets the user and the API token. The token is sent as a bearer token when the user is empty.
+func WithCredentials(user, token string) Option {
+	return func(n *notifier) {
+		n.user = user
+		n.token = token
+	}
+}
+
+// WithTransition sets the transition, or the status it leads to, applied to the tickets when the validation succeeds.
+// Tickets are not transitioned when empty.
+func WithTransition(name string) Option {
+	return func(n *notifier) {
+		n.transitionName = name
+	}
+}
+
+// WithProjects restricts linked tickets to the projects (comma-separated list of project keys).
+func WithProjects(projects string) Option {
+	return func(n *notifier) {
+		for _, p := range strings.Split(projects, ",") {
+			if p = strings.TrimSpace(p); len(p) != 0 {
+				n.projects = append(n.projects, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package labels labels pull requests with their size and the state of their gate, so that pull request lists can
+// be scanned and searched by them.
+package labels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+const notifierName = "gate-labels"
+
+// Labels of the state of the gate.
+const (
+	LabelPassing = "gate:passing"
+	LabelBlocked = "gate:blocked"
+)
+
+// SizePrefix is the prefix of the size labels, e.g) size/S.
+const SizePrefix = "size/"
+
+// sizes are the size labels by the largest number of changed lines they are applied to.
+var sizes = []struct {
+	name     string
+	maxLines int
+}{
+	{name: "XS", maxLines: 9},
+	{name: "S", maxLines: 29},
+	{name: "M", maxLines: 99},
+	{name: "L", maxLines: 499},
+	{name: "XL", maxLines: 999},
+}
+
+const largestSize = "XXL"
+
+type notifier struct {
+	owner    string
+	repo     string
+	prNumber int
+	client   github.Client
+}
+
+// New returns the notifier which labels the pull request with its size by changed lines and the state of its gate,
+// replacing the labels applied by earlier validations.
+func New(c github.Client, opts ...Option) (notify.Notifier, error) {
+	n := &notifier{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if err := n.validateFields(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return notifierName
+}
+
+func (n *notifier) validateFields() error {
+	errs := make(multierror.Errors, 0, 3)
+
+	if len(n.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(n.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if n.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (n *notifier) Notify(ctx context.Context, o *notify.Outcome) error {
+	if n.prNumber == 0 {
+		return nil
+	}
+	pr, _, err := n.client.GetPullRequest(ctx, n.owner, n.repo, n.prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request #%d: %w", n.prNumber, err)
+	}
+
+	gate := LabelBlocked
+	if o.Succeeded() {
+		gate = LabelPassing
+	}
+	want := []string{gate, SizePrefix + size(pr.GetAdditions()+pr.GetDeletions())}
+
+	current := make(map[string]bool, len(pr.Labels))
+	errs := make(multierror.Errors, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		name := label.GetName()
+		current[name] = true
+		if !isManaged(name) || contains(want, name) {
+			continue
+		}
+		if _, err := n.client.RemoveLabelForIssue(ctx, n.owner, n.repo, n.prNumber, name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove label %s from pull request #%d: %w", name, n.prNumber, err))
+		}
+	}
+
+	var missing []string
+	for _, name := range want {
+		if !current[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		if _, _, err := n.client.AddLabelsToIssue(ctx, n.owner, n.repo, n.prNumber, missing); err != nil {
+			errs = append(errs, fmt.Errorf("failed to label pull request #%d: %w", n.prNumber, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// isManaged reports whether the label is one applied by the notifier.
+func isManaged(name string) bool {
+	return name == LabelPassing || name == LabelBlocked || strings.HasPrefix(name, SizePrefix)
+}
+
+// size returns the size of the pull request changing the number of lines.
+func size(lines int) string {
+	for _, s := range sizes {
+		if lines <= s.maxLines {
+			return s.name
+		}
+	}
+	return largestSize
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/notify"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func labels(names ...string) []*github.Label {
+	ls := make([]*github.Label, 0, len(names))
+	for _, name := range names {
+		ls = append(ls, &github.Label{Name: stringPtr(name)})
+	}
+	return ls
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := map[string]struct {
+		result      string
+		additions   int
+		deletions   int
+		labels      []*github.Label
+		addErr      error
+		wantAdded   []string
+		wantRemoved []string
+		wantErr     bool
+	}{
+		"labels passing small pull request": {
+			result:    notify.ResultSuccess,
+			additions: 12,
+			deletions: 3,
+			labels:    labels("bug"),
+			wantAdded: []string{"gate:passing", "size/S"},
+		},
+		"replaces labels of earlier validations": {
+			result:      notify.ResultFailure,
+			additions:   600,
+			deletions:   100,
+			labels:      labels("gate:passing", "size/L", "bug"),
+			wantAdded:   []string{"gate:blocked", "size/XL"},
+			wantRemoved: []string{"gate:passing", "size/L"},
+		},
+		"keeps current labels": {
+			result:    notify.ResultTimeout,
+			additions: 5000,
+			labels:    labels("gate:blocked", "size/XXL"),
+		},
+		"returns error when labels can not be added": {
+			result:    notify.ResultSuccess,
+			addErr:    errors.New("err"),
+			wantAdded: []string{"gate:passing", "size/XS"},
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var added, removed []string
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{Additions: intPtr(tt.additions), Deletions: intPtr(tt.deletions), Labels: tt.labels}, nil, nil
+				},
+				AddLabelsToIssueFunc: func(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+					added = append(added, labels...)
+					return nil, nil, tt.addErr
+				},
+				RemoveLabelForIssueFunc: func(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error) {
+					removed = append(removed, label)
+					return nil, nil
+				},
+			}
+			n, err := New(c, WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithPullRequest(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = n.Notify(context.Background(), &notify.Outcome{Ref: "sha-1", Result: tt.result})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			sort.Strings(removed)
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("added = %v, want %v", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
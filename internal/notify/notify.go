@@ -0,0 +1,35 @@
+// Package notify tells external systems, such as issue trackers, about the final decision of the validation.
+package notify
+
+import "context"
+
+// Results of the validation.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultTimeout = "timeout"
+)
+
+// Failure is a validator which did not succeed, with the detail of its last status or error.
+type Failure struct {
+	Validator string
+	Detail    string
+}
+
+// Outcome is the final decision of the validation of the ref.
+type Outcome struct {
+	Ref      string
+	Result   string
+	Failures []Failure
+}
+
+// Succeeded reports whether the validation succeeded.
+func (o *Outcome) Succeeded() bool {
+	return o.Result == ResultSuccess
+}
+
+// Notifier is told about the outcome once the validation finishes. Notifiers never change the decision itself.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, o *Outcome) error
+}
@@ -0,0 +1,83 @@
+package owners
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"strings"
+)
+
+// CodeOwnersPaths are the locations of CODEOWNERS, in the order GitHub looks them up.
+var CodeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type codeOwnersRule struct {
+	pattern string
+	owners  []string
+}
+
+// codeOwners maps paths of the repository to their owners.
+// NOTE: https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+type codeOwners struct {
+	rules []codeOwnersRule
+}
+
+func parseCodeOwners(r io.Reader) (*codeOwners, error) {
+	co := &codeOwners{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// Lines without owners make the paths unowned, overriding earlier lines.
+		co.rules = append(co.rules, codeOwnersRule{pattern: fields[0], owners: fields[1:]})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return co, nil
+}
+
+// Owners returns the owners of the file, where the last matching line takes precedence.
+func (co *codeOwners) Owners(file string) []string {
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if matchCodeOwners(co.rules[i].pattern, file) {
+			return co.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// matchCodeOwners matches the file against the pattern with the gitignore rules used by CODEOWNERS: patterns starting
+// with or containing a slash are relative to the root, others match at any depth, and directories match every file
+// under them.
+func matchCodeOwners(pattern, file string) bool {
+	if pattern == "*" {
+		return true
+	}
+	dir := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	segments := strings.Split(file, "/")
+	for start := 0; start < len(segments); start++ {
+		if anchored && start != 0 {
+			break
+		}
+		for end := start + 1; end <= len(segments); end++ {
+			if ok, _ := path.Match(pattern, strings.Join(segments[start:end], "/")); !ok {
+				continue
+			}
+			// The pattern matched a directory of the file, or the file itself.
+			if end < len(segments) || !dir {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package owners
+
+import "testing"
+
+func Test_matchCodeOwners(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		"matches everything with wildcard": {
+			pattern: "*",
+			file:    ".github/workflows/ci.yml",
+			want:    true,
+		},
+		"matches extension at any depth": {
+			pattern: "*.yml",
+			file:    ".github/workflows/ci.yml",
+			want:    true,
+		},
+		"matches anchored directory": {
+			pattern: "/.github/workflows/",
+			file:    ".github/workflows/ci.yml",
+			want:    true,
+		},
+		"matches unanchored directory at any depth": {
+			pattern: "workflows/",
+			file:    ".github/workflows/ci.yml",
+			want:    true,
+		},
+		"matches file path": {
+			pattern: ".github/workflows/e2e.yml",
+			file:    ".github/workflows/e2e.yml",
+			want:    true,
+		},
+		"does not match anchored path elsewhere": {
+			pattern: "/workflows/",
+			file:    ".github/workflows/ci.yml",
+		},
+		"does not match directory pattern with file": {
+			pattern: "ci.yml/",
+			file:    ".github/workflows/ci.yml",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := matchCodeOwners(tt.pattern, tt.file); got != tt.want {
+				t.Errorf("matchCodeOwners(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+			}
+		})
+	}
+}
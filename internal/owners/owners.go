@@ -0,0 +1,86 @@
+// Package owners maps jobs to the teams and users owning them, so that they can be pinged when their jobs fail.
+package owners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultPath is the path of the ownership map in the repository.
+const DefaultPath = ".github/JOBOWNERS"
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Map maps job names to their owners. It is written in the format of CODEOWNERS with patterns of job names instead
+// of paths, where `*` matches any characters, and the last matching line takes precedence. Patterns containing
+// spaces are quoted:
+//
+//	# pattern      owners
+//	*              @org/platform
+//	e2e*           @org/qa @alice
+//	"lint (*)"     @bob
+type Map struct {
+	rules []rule
+}
+
+// Parse reads the ownership map.
+func Parse(r io.Reader) (*Map, error) {
+	m := &Map{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern, rest := splitPattern(line)
+		if len(pattern) == 0 {
+			return nil, fmt.Errorf("line %d: invalid pattern", n)
+		}
+		owners := strings.Fields(rest)
+		if len(owners) == 0 {
+			return nil, fmt.Errorf("line %d: no owners for %s", n, pattern)
+		}
+		m.rules = append(m.rules, rule{pattern: compile(pattern), owners: owners})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// splitPattern splits the line into the pattern, which may be quoted, and the rest.
+func splitPattern(line string) (string, string) {
+	if strings.HasPrefix(line, `"`) {
+		end := strings.Index(line[1:], `"`)
+		if end < 0 {
+			return "", ""
+		}
+		return line[1 : end+1], line[end+2:]
+	}
+	if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+		return line[:i], line[i:]
+	}
+	return line, ""
+}
+
+func compile(pattern string) *regexp.Regexp {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+// Owners returns the owners of the job, or nil when no line matches.
+func (m *Map) Owners(job string) []string {
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if m.rules[i].pattern.MatchString(job) {
+			return m.rules[i].owners
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package owners
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMap_Owners(t *testing.T) {
+	m, err := Parse(strings.NewReader(`
+# pattern      owners
+*              @org/platform
+e2e*           @org/qa @alice
+"lint (*)"     @bob
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		job  string
+		want []string
+	}{
+		"returns owners of the last matching line": {
+			job:  "e2e (chrome)",
+			want: []string{"@org/qa", "@alice"},
+		},
+		"matches special characters literally": {
+			job:  "lint (go)",
+			want: []string{"@bob"},
+		},
+		"falls back to the wildcard": {
+			job:  "build",
+			want: []string{"@org/platform"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := m.Owners(tt.job); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.job, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_withoutOwners(t *testing.T) {
+	if _, err := Parse(strings.NewReader("build\n")); err == nil {
+		t.Error("Parse() error = nil, want error")
+	}
+}
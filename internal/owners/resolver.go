@@ -0,0 +1,93 @@
+package owners
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// Resolver resolves the owners of jobs from the ownership map of jobs, falling back to the CODEOWNERS of the workflow
+// file defining the job. Both files are read from the repository once, at the ref.
+type Resolver struct {
+	client github.Client
+	owner  string
+	repo   string
+	ref    string
+	path   string
+
+	loaded     bool
+	jobs       *Map
+	codeOwners *codeOwners
+}
+
+// NewResolver returns the Resolver reading the ownership map of jobs at the path.
+func NewResolver(c github.Client, owner, repo, ref, path string) *Resolver {
+	if len(path) == 0 {
+		path = DefaultPath
+	}
+	return &Resolver{client: c, owner: owner, repo: repo, ref: ref, path: path}
+}
+
+// Owners returns the owners of the job defined in the workflow file, which may be empty when unknown.
+func (r *Resolver) Owners(ctx context.Context, job, workflowPath string) ([]string, error) {
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+	if owners := r.jobs.Owners(job); len(owners) != 0 {
+		return owners, nil
+	}
+	if len(workflowPath) == 0 || r.codeOwners == nil {
+		return nil, nil
+	}
+	return r.codeOwners.Owners(workflowPath), nil
+}
+
+func (r *Resolver) load(ctx context.Context) error {
+	if r.loaded {
+		return nil
+	}
+	jobs := &Map{}
+	str, found, err := r.getContent(ctx, r.path)
+	if err != nil {
+		return err
+	}
+	if found {
+		if jobs, err = Parse(strings.NewReader(str)); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", r.path, err)
+		}
+	}
+	for _, p := range CodeOwnersPaths {
+		str, found, err := r.getContent(ctx, p)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		if r.codeOwners, err = parseCodeOwners(strings.NewReader(str)); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", p, err)
+		}
+		break
+	}
+	r.jobs = jobs
+	r.loaded = true
+	return nil
+}
+
+func (r *Resolver) getContent(ctx context.Context, path string) (string, bool, error) {
+	content, _, res, err := r.client.GetContents(ctx, r.owner, r.repo, path, &github.RepositoryContentGetOptions{Ref: r.ref})
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	str, err := content.GetContent()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return str, true, nil
+}
@@ -0,0 +1,76 @@
+package owners
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func TestResolver_Owners(t *testing.T) {
+	files := map[string]string{
+		DefaultPath:          "e2e* @org/qa\n",
+		".github/CODEOWNERS": "* @org/everyone\n/.github/workflows/ @org/platform\n.github/workflows/release.yml @org/release # releases\n",
+	}
+	var calls int
+	c := &mock.Client{
+		GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			calls++
+			if opts.Ref != "sha" {
+				t.Errorf("ref = %s, want sha", opts.Ref)
+			}
+			str, ok := files[path]
+			if !ok {
+				return nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+			}
+			content := base64.StdEncoding.EncodeToString([]byte(str))
+			encoding := "base64"
+			return &github.RepositoryContent{Content: &content, Encoding: &encoding}, nil, nil, nil
+		},
+	}
+	r := NewResolver(c, "owner", "repo", "sha", "")
+
+	tests := map[string]struct {
+		job          string
+		workflowPath string
+		want         []string
+	}{
+		"returns owners of the job": {
+			job:          "e2e (chrome)",
+			workflowPath: ".github/workflows/ci.yml",
+			want:         []string{"@org/qa"},
+		},
+		"falls back to code owners of the workflow": {
+			job:          "build",
+			workflowPath: ".github/workflows/release.yml",
+			want:         []string{"@org/release"},
+		},
+		"falls back to code owners of the workflow directory": {
+			job:          "build",
+			workflowPath: ".github/workflows/ci.yml",
+			want:         []string{"@org/platform"},
+		},
+		"returns no owners without workflow": {
+			job: "build",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := r.Owners(context.Background(), tt.job, tt.workflowPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if calls != 2 {
+		t.Errorf("GetContents() called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,53 @@
+package pathfilter
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		"matches nested directory": {
+			pattern: "**/migrations/**",
+			name:    "services/api/migrations/0001_init.sql",
+			want:    true,
+		},
+		"matches directory at the root": {
+			pattern: "**/migrations/**",
+			name:    "migrations/0001_init.sql",
+			want:    true,
+		},
+		"matches segments with wildcards": {
+			pattern: "db/migrate/*.rb",
+			name:    "db/migrate/20240101000000_create_users.rb",
+			want:    true,
+		},
+		"does not match file of another directory": {
+			pattern: "**/migrations/**",
+			name:    "docs/migrations.md",
+		},
+		"does not match deeper file without double star": {
+			pattern: "db/migrate/*.rb",
+			name:    "db/migrate/old/20240101000000_create_users.rb",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Match(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, pattern := range []string{"**/migrations/**", "docs/*.md", "*"} {
+		if err := Validate(pattern); err != nil {
+			t.Errorf("Validate(%q) error = %v", pattern, err)
+		}
+	}
+	if err := Validate("docs/[.md"); err == nil {
+		t.Error("Validate() error = nil with malformed pattern")
+	}
+}
@@ -0,0 +1,249 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/pathfilter"
+)
+
+// DefaultPath is the location of the policy file in the repository.
+const DefaultPath = ".github/merge-gatekeeper.yml"
+
+// Policy overrides inputs of the validation for the targets it matches. A policy without conditions matches
+// every target.
+type Policy struct {
+	Name string `yaml:"name"`
+	// Branches are patterns of base branches, e.g. release/*, matched by path.Match.
+	Branches []string `yaml:"branches"`
+	// Paths are patterns of files, any of which the pull request must change, e.g. infra/**.
+	Paths []string `yaml:"paths"`
+	// OnlyPaths are patterns of files, which all files changed by the pull request must match, e.g. docs/**.
+	OnlyPaths []string `yaml:"only-paths"`
+	// Authors are logins of authors of the pull request, e.g. dependabot[bot]. Logins are compared case-insensitively.
+	Authors []string `yaml:"authors"`
+	// Associations are author associations of the author with the repository, e.g. FIRST_TIME_CONTRIBUTOR.
+	// NOTE: https://docs.github.com/en/graphql/reference/enums#commentauthorassociation
+	Associations []string `yaml:"associations"`
+	// Inputs are the action inputs, i.e. the command line flags, to override, keyed by name.
+	Inputs map[string]string `yaml:"inputs"`
+}
+
+// Target is what the validation is gating, against which the conditions of policies are matched.
+type Target struct {
+	// Base is the branch the pull request is merged into, or the pushed branch.
+	Base string
+	// Paths are the files changed by the pull request. Conditions on paths never match when they are unknown.
+	Paths []string
+	// Author is the login of the author of the pull request, and Association is how the author is associated
+	// with the repository. Both are empty for pushes.
+	Author      string
+	Association string
+}
+
+// RequiredChecks requires the jobs of pull requests changing any file matching the paths, e.g. docs/** requiring
+// lint-docs, so that monorepos only gate on the jobs of the components changed.
+type RequiredChecks struct {
+	// Paths are patterns of files, where ** matches any number of directories.
+	Paths []string `yaml:"paths"`
+	// Jobs are names of the jobs, optionally qualified by their workflows, e.g. API / test.
+	Jobs []string `yaml:"jobs"`
+}
+
+// Config is the list of policies, of which the first matching one is applied, and of the checks required by the
+// changed paths, all of which matching are applied.
+type Config struct {
+	Policies       []*Policy         `yaml:"policies"`
+	RequiredChecks []*RequiredChecks `yaml:"required-checks"`
+	// SHA is the blob SHA of the policy file, as loaded by Load.
+	SHA string `yaml:"-"`
+}
+
+// Parse parses the policy file, and validates the policies.
+func Parse(b []byte) (*Config, error) {
+	c := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return nil, err
+	}
+	for i, p := range c.Policies {
+		if len(p.Name) == 0 {
+			return nil, fmt.Errorf("policy %d has no name", i+1)
+		}
+		for _, pattern := range p.Branches {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("policy %s has invalid branch pattern %q: %w", p.Name, pattern, err)
+			}
+		}
+		for _, pattern := range append(p.Paths, p.OnlyPaths...) {
+			if err := pathfilter.Validate(pattern); err != nil {
+				return nil, fmt.Errorf("policy %s has %w", p.Name, err)
+			}
+		}
+	}
+	for i, rc := range c.RequiredChecks {
+		if len(rc.Paths) == 0 || len(rc.Jobs) == 0 {
+			return nil, fmt.Errorf("required checks %d must have both paths and jobs", i+1)
+		}
+		for _, pattern := range rc.Paths {
+			if err := pathfilter.Validate(pattern); err != nil {
+				return nil, fmt.Errorf("required checks %d has %w", i+1, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+// Load reads the policy file from the repository at the ref. It returns an empty config when there is no file.
+func Load(ctx context.Context, c github.Client, owner, repo, ref, file string) (*Config, error) {
+	content, _, res, err := c.GetContents(ctx, owner, repo, file, &github.RepositoryContentGetOptions{Ref: ref})
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", file, err)
+	}
+	if content == nil {
+		return nil, errors.New(file + " is not a file")
+	}
+	str, err := content.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	cfg, err := Parse([]byte(str))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	cfg.SHA = content.GetSHA()
+	return cfg, nil
+}
+
+// Select returns the first policy matching the target, or nil when none matches.
+func (c *Config) Select(t *Target) *Policy {
+	for _, p := range c.Policies {
+		if p.Matches(t) {
+			return p
+		}
+	}
+	return nil
+}
+
+// NeedsPaths reports whether any policy has conditions on changed files, or any check is required by them, which are
+// costly to list.
+func (c *Config) NeedsPaths() bool {
+	if len(c.RequiredChecks) != 0 {
+		return true
+	}
+	for _, p := range c.Policies {
+		if len(p.Paths) != 0 || len(p.OnlyPaths) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Required returns the jobs required by the changed paths, in the order they are found, and whether only they are
+// required. Every job is required when any changed file is matched by none of the required checks, as its jobs are
+// unknown, or the changed paths are unknown, e.g) for pushes.
+func (c *Config) Required(paths []string) ([]string, bool) {
+	if len(c.RequiredChecks) == 0 || len(paths) == 0 {
+		return nil, false
+	}
+	jobs := []string{}
+	seen := make(map[string]bool)
+	for _, name := range paths {
+		var covered bool
+		for _, rc := range c.RequiredChecks {
+			if !pathfilter.MatchAny(rc.Paths, name) {
+				continue
+			}
+			covered = true
+			for _, job := range rc.Jobs {
+				if !seen[job] {
+					seen[job] = true
+					jobs = append(jobs, job)
+				}
+			}
+		}
+		if !covered {
+			return nil, false
+		}
+	}
+	return jobs, true
+}
+
+// Matches reports whether the target satisfies every condition of the policy.
+func (p *Policy) Matches(t *Target) bool {
+	if !matchAny(p.Branches, t.Base) {
+		return false
+	}
+	if len(p.Paths) != 0 && !p.changesAny(t.Paths) {
+		return false
+	}
+	if len(p.OnlyPaths) != 0 && !p.changesOnly(t.Paths) {
+		return false
+	}
+	if len(p.Authors) != 0 && !containsFold(p.Authors, t.Author) {
+		return false
+	}
+	if len(p.Associations) != 0 && !containsFold(p.Associations, t.Association) {
+		return false
+	}
+	return true
+}
+
+func containsFold(ss []string, s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, v := range ss {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) changesAny(paths []string) bool {
+	for _, name := range paths {
+		if pathfilter.MatchAny(p.Paths, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) changesOnly(paths []string) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	for _, name := range paths {
+		if !pathfilter.MatchAny(p.OnlyPaths, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAny reports whether the name matches any of the patterns. Empty patterns match any name.
+func matchAny(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		// Patterns are validated by Parse.
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package policy
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+const config = `
+policies:
+  - name: bots
+    authors: ["dependabot[bot]", "renovate[bot]"]
+    inputs:
+      min-approvals: 0
+  - name: first-time
+    associations: [FIRST_TIME_CONTRIBUTOR, FIRST_TIMER, NONE]
+    inputs:
+      min-approvals: 2
+  - name: docs
+    only-paths: ["docs/**", "**/*.md"]
+    inputs:
+      timeout: 60
+  - name: infra
+    paths: ["infra/**"]
+    inputs:
+      terraform-check-run: plan
+  - name: release
+    branches: ["release/*", "hotfix/*"]
+    inputs:
+      min-approvals: 2
+      attestations: true
+  - name: default
+    inputs:
+      ignored: lint
+`
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		config  string
+		want    *Config
+		wantErr bool
+	}{
+		"parses policies": {
+			config: config,
+			want: &Config{Policies: []*Policy{
+				{Name: "bots", Authors: []string{"dependabot[bot]", "renovate[bot]"}, Inputs: map[string]string{"min-approvals": "0"}},
+				{Name: "first-time", Associations: []string{"FIRST_TIME_CONTRIBUTOR", "FIRST_TIMER", "NONE"}, Inputs: map[string]string{"min-approvals": "2"}},
+				{Name: "docs", OnlyPaths: []string{"docs/**", "**/*.md"}, Inputs: map[string]string{"timeout": "60"}},
+				{Name: "infra", Paths: []string{"infra/**"}, Inputs: map[string]string{"terraform-check-run": "plan"}},
+				{Name: "release", Branches: []string{"release/*", "hotfix/*"}, Inputs: map[string]string{"min-approvals": "2", "attestations": "true"}},
+				{Name: "default", Inputs: map[string]string{"ignored": "lint"}},
+			}},
+		},
+		"returns error when a policy has no name": {
+			config:  "policies:\n  - branches: [main]\n",
+			wantErr: true,
+		},
+		"returns error with invalid branch pattern": {
+			config:  "policies:\n  - name: broken\n    branches: ['release/[']\n",
+			wantErr: true,
+		},
+		"returns error with invalid path pattern": {
+			config:  "policies:\n  - name: broken\n    paths: ['infra/[']\n",
+			wantErr: true,
+		},
+		"parses required checks": {
+			config: "required-checks:\n  - paths: [\"docs/**\"]\n    jobs: [lint-docs]\n",
+			want:   &Config{RequiredChecks: []*RequiredChecks{{Paths: []string{"docs/**"}, Jobs: []string{"lint-docs"}}}},
+		},
+		"returns error when required checks have no jobs": {
+			config:  "required-checks:\n  - paths: [\"docs/**\"]\n",
+			wantErr: true,
+		},
+		"returns error with invalid path pattern of required checks": {
+			config:  "required-checks:\n  - paths: ['docs/[']\n    jobs: [lint-docs]\n",
+			wantErr: true,
+		},
+		"returns error with unknown fields": {
+			config:  "policies:\n  - name: typo\n    branch: [main]\n",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Select(t *testing.T) {
+	c, err := Parse([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		target *Target
+		want   string
+	}{
+		"selects the first matching policy":           {target: &Target{Base: "release/1.0"}, want: "release"},
+		"falls back to the policy without conditions": {target: &Target{Base: "main"}, want: "default"},
+		"does not match nested branches":              {target: &Target{Base: "release/1.0/rc"}, want: "default"},
+		"selects policy when only matching paths are changed": {
+			target: &Target{Base: "main", Paths: []string{"docs/usage.md", "README.md"}},
+			want:   "docs",
+		},
+		"selects policy when any matching path is changed": {
+			target: &Target{Base: "release/1.0", Paths: []string{"README.md", "infra/main.tf"}},
+			want:   "infra",
+		},
+		"selects policy of the author": {
+			target: &Target{Base: "main", Author: "Dependabot[bot]", Association: "CONTRIBUTOR"},
+			want:   "bots",
+		},
+		"selects policy of the author association": {
+			target: &Target{Base: "main", Author: "alice", Association: "first_time_contributor"},
+			want:   "first-time",
+		},
+		"does not match authors when they are unknown": {
+			target: &Target{Base: "main"},
+			want:   "default",
+		},
+		"does not match paths when they are unknown": {
+			target: &Target{Base: "release/1.0"},
+			want:   "release",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := c.Select(tt.target)
+			if got == nil || got.Name != tt.want {
+				t.Errorf("Select() = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&Config{}).Select(&Target{Base: "main"}); got != nil {
+		t.Errorf("Select() = %+v, want nil", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c := &mock.Client{
+		GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			if opts.Ref != "main" {
+				t.Errorf("ref = %s, want main", opts.Ref)
+			}
+			if path != DefaultPath {
+				return nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+			}
+			content := base64.StdEncoding.EncodeToString([]byte(config))
+			encoding, sha := "base64", "blob-sha"
+			return &github.RepositoryContent{Content: &content, Encoding: &encoding, SHA: &sha}, nil, nil, nil
+		},
+	}
+
+	got, err := Load(context.Background(), c, "owner", "repo", "main", DefaultPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Policies) != 6 || got.SHA != "blob-sha" {
+		t.Errorf("Load() = %+v, want 6 policies of blob-sha", got)
+	}
+
+	got, err = Load(context.Background(), c, "owner", "repo", "main", "missing.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Policies) != 0 {
+		t.Errorf("Load() = %+v, want no policies", got)
+	}
+}
+
+func TestConfig_NeedsPaths(t *testing.T) {
+	c, err := Parse([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.NeedsPaths() {
+		t.Error("NeedsPaths() = false, want true")
+	}
+	if !(&Config{RequiredChecks: []*RequiredChecks{{Paths: []string{"docs/**"}, Jobs: []string{"lint-docs"}}}}).NeedsPaths() {
+		t.Error("NeedsPaths() = false, want true")
+	}
+	if (&Config{Policies: []*Policy{{Name: "default"}}}).NeedsPaths() {
+		t.Error("NeedsPaths() = true, want false")
+	}
+}
+
+func TestConfig_Required(t *testing.T) {
+	c := &Config{RequiredChecks: []*RequiredChecks{
+		{Paths: []string{"docs/**", "**/*.md"}, Jobs: []string{"lint-docs"}},
+		{Paths: []string{"services/api/**"}, Jobs: []string{"API / test", "lint"}},
+		{Paths: []string{"services/web/**"}, Jobs: []string{"Web / test", "lint"}},
+	}}
+	tests := map[string]struct {
+		paths    []string
+		want     []string
+		wantOnly bool
+	}{
+		"requires the jobs of the changed paths": {
+			paths:    []string{"docs/usage.md"},
+			want:     []string{"lint-docs"},
+			wantOnly: true,
+		},
+		"requires the jobs of every changed path once": {
+			paths:    []string{"services/api/main.go", "services/web/index.ts", "services/api/README.md"},
+			want:     []string{"API / test", "lint", "Web / test", "lint-docs"},
+			wantOnly: true,
+		},
+		"requires every job when a changed path is not covered": {
+			paths: []string{"docs/usage.md", "go.mod"},
+		},
+		"requires every job when the changed paths are unknown": {},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, only := c.Required(tt.paths)
+			if !reflect.DeepEqual(got, tt.want) || only != tt.wantOnly {
+				t.Errorf("Required() = %v, %v, want %v, %v", got, only, tt.want, tt.wantOnly)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package policytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// Number and SHA of the pull request of every test case.
+const (
+	Number = 1
+	SHA    = "0000000000000000000000000000000000000001"
+)
+
+var errUnsupported = errors.New("not supported by policy tests")
+
+// fixture serves the pull request of a test case in place of the GitHub API. Everything not described by
+// the test case, such as files of the repository, is missing, and writes are not supported.
+type fixture struct {
+	c   *Case
+	now time.Time
+}
+
+// Client returns the client serving the pull request of the test case, as observed at now.
+func (c *Case) Client(now time.Time) github.Client {
+	return &fixture{c: c, now: now}
+}
+
+func notFound(what string) (*github.Response, error) {
+	return &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, fmt.Errorf("%s: %w", what, errUnsupported)
+}
+
+// firstPage reports whether the list options request the first page, as fixtures are served in a single page.
+func firstPage(opts *github.ListOptions) bool {
+	return opts == nil || opts.Page <= 1
+}
+
+func (f *fixture) pullRequest() *github.PullRequest {
+	number, sha, state := Number, SHA, "open"
+	pr := &f.c.PullRequest
+	return &github.PullRequest{
+		Number:            &number,
+		State:             &state,
+		Draft:             &pr.Draft,
+		Labels:            f.labels(),
+		AuthorAssociation: &pr.Association,
+		User:              &github.User{Login: &pr.Author},
+		Head:              &github.PullRequestBranch{SHA: &sha},
+		Base:              &github.PullRequestBranch{Ref: &pr.Base},
+	}
+}
+
+func (f *fixture) labels() []*github.Label {
+	labels := make([]*github.Label, 0, len(f.c.PullRequest.Labels))
+	for i := range f.c.PullRequest.Labels {
+		labels = append(labels, &github.Label{Name: &f.c.PullRequest.Labels[i]})
+	}
+	return labels
+}
+
+// suiteID is the check suite of the workflow, as each workflow runs in its own check suite.
+func (f *fixture) suiteID(workflow string) int64 {
+	for i, ch := range f.c.Checks {
+		if ch.Workflow == workflow {
+			return int64(i + 1)
+		}
+	}
+	return 0
+}
+
+func (f *fixture) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	runs := make([]*github.CheckRun, 0, len(f.c.Checks))
+	for i := range f.c.Checks {
+		ch := &f.c.Checks[i]
+		status := ch.Status
+		if len(status) == 0 {
+			status = "queued"
+			if len(ch.Conclusion) != 0 {
+				status = "completed"
+			}
+		}
+		id := f.suiteID(ch.Workflow)
+		started := github.Timestamp{Time: f.now}
+		run := &github.CheckRun{Name: &ch.Name, Status: &status, CheckSuite: &github.CheckSuite{ID: &id}, StartedAt: &started}
+		if len(ch.Conclusion) != 0 {
+			run.Conclusion = &ch.Conclusion
+			run.CompletedAt = &started
+		}
+		runs = append(runs, run)
+	}
+	total := len(runs)
+	if opts != nil && !firstPage(&opts.ListOptions) {
+		runs = nil
+	}
+	return &github.ListCheckRunsResults{Total: &total, CheckRuns: runs}, nil, nil
+}
+
+func (f *fixture) ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	seen := make(map[string]bool)
+	runs := make([]*github.WorkflowRun, 0, len(f.c.Checks))
+	for i := range f.c.Checks {
+		name := f.c.Checks[i].Workflow
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		id := f.suiteID(name)
+		runs = append(runs, &github.WorkflowRun{Name: &f.c.Checks[i].Workflow, CheckSuiteID: &id})
+	}
+	total := len(runs)
+	return &github.WorkflowRuns{TotalCount: &total, WorkflowRuns: runs}, nil, nil
+}
+
+func (f *fixture) ListWorkflows(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+	total := 0
+	return &github.Workflows{TotalCount: &total}, nil, nil
+}
+
+func (f *fixture) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	res, err := notFound(path)
+	return nil, nil, res, err
+}
+
+func (f *fixture) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	if sha != SHA || !firstPage(opts) {
+		return nil, nil, nil
+	}
+	return []*github.PullRequest{f.pullRequest()}, nil, nil
+}
+
+func (f *fixture) ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	if opts != nil && !firstPage(&opts.ListOptions) {
+		return nil, nil, nil
+	}
+	return []*github.PullRequest{f.pullRequest()}, nil, nil
+}
+
+func (f *fixture) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return &github.Repository{DefaultBranch: &f.c.PullRequest.Base}, nil, nil
+}
+
+func (f *fixture) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return nil, nil, nil
+}
+
+func (f *fixture) CreateIssue(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+	return SHA, nil, nil
+}
+
+func (f *fixture) ListDeployments(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error) {
+	return nil, nil, nil
+}
+
+func (f *fixture) ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error) {
+	return nil, nil, nil
+}
+
+func (f *fixture) ListReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	if !firstPage(opts) {
+		return nil, nil, nil
+	}
+	sha := SHA
+	submitted := github.Timestamp{Time: f.now}
+	reviews := make([]*github.PullRequestReview, 0, len(f.c.Reviews))
+	for i := range f.c.Reviews {
+		r := &f.c.Reviews[i]
+		id := int64(i + 1)
+		reviews = append(reviews, &github.PullRequestReview{
+			ID:          &id,
+			User:        &github.User{Login: &r.User},
+			State:       &r.State,
+			CommitID:    &sha,
+			SubmittedAt: &submitted,
+		})
+	}
+	return reviews, nil, nil
+}
+
+func (f *fixture) ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) ListAttestations(ctx context.Context, owner, repo, subjectDigest string, opts *github.ListOptions) ([]*github.Attestation, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	if !firstPage(opts) {
+		return nil, nil, nil
+	}
+	return f.labels(), nil, nil
+}
+
+func (f *fixture) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	if !firstPage(opts) {
+		return nil, nil, nil
+	}
+	files := make([]*github.CommitFile, 0, len(f.c.PullRequest.Paths))
+	for i := range f.c.PullRequest.Paths {
+		files = append(files, &github.CommitFile{Filename: &f.c.PullRequest.Paths[i]})
+	}
+	return files, nil, nil
+}
+
+func (f *fixture) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	if number != Number {
+		res, err := notFound(fmt.Sprintf("pull request #%d", number))
+		return nil, res, err
+	}
+	return f.pullRequest(), nil, nil
+}
+
+// ListPullRequestCommits returns the head commit of the pull request, authored by its author right before now.
+func (f *fixture) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	if !firstPage(opts) {
+		return nil, nil, nil
+	}
+	sha := SHA
+	author := &github.CommitAuthor{Name: &f.c.PullRequest.Author, Date: &github.Timestamp{Time: f.now.Add(-time.Minute)}}
+	user := &github.User{Login: &f.c.PullRequest.Author}
+	return []*github.RepositoryCommit{{
+		SHA:       &sha,
+		Author:    user,
+		Committer: user,
+		Commit:    &github.Commit{Author: author, Committer: author},
+	}}, nil, nil
+}
+
+func (f *fixture) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) EditIssue(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error) {
+	return nil, errUnsupported
+}
+
+func (f *fixture) MergePullRequest(ctx context.Context, owner, repo string, number int, commitMessage string, opts *github.PullRequestOptions) (*github.PullRequestMergeResult, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) ListMilestones(ctx context.Context, owner, repo string, opts *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error) {
+	return nil, nil, nil
+}
+
+func (f *fixture) CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	return &github.CombinedStatus{}, nil, nil
+}
+
+func (f *fixture) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return nil, nil, nil
+}
+
+func (f *fixture) GetCheckRollup(ctx context.Context, owner, repo, ref string) (*github.CheckRollup, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) ListCheckRunsByGraphQL(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, *github.Response, error) {
+	cr, resp, err := f.ListCheckRunsForRef(ctx, owner, repo, ref, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	return cr.CheckRuns, resp, nil
+}
+
+var (
+	_ github.Client = &fixture{}
+)
+
+func (f *fixture) CreateRef(ctx context.Context, owner, repo string, ref *github.Reference) (*github.Reference, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) CreatePullRequest(ctx context.Context, owner, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) UpdateRequiredStatusChecks(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
+
+func (f *fixture) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return nil, nil, errUnsupported
+}
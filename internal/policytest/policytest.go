@@ -0,0 +1,107 @@
+// Package policytest defines test cases of the gate configuration, which describe a pull request along with its
+// checks, reviews and labels, and the result the gate is expected to reach for it.
+package policytest
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aac228/merge-gatekeeper/internal/policy"
+)
+
+// DefaultPath is the location of the test cases in the repository.
+const DefaultPath = ".github/merge-gatekeeper.test.yml"
+
+// Results the gate can reach for a test case.
+const (
+	ExpectPass    = "pass"
+	ExpectFail    = "fail"
+	ExpectPending = "pending"
+)
+
+// Suite is the list of test cases of the gate configuration.
+type Suite struct {
+	Tests []*Case `yaml:"tests"`
+}
+
+// Case is a single test case. The gate is evaluated once, as if polled right after the checks and reviews of
+// the case were observed.
+type Case struct {
+	Name string `yaml:"name"`
+	// Inputs are the inputs of the workflow running the gate, before the policies override them.
+	Inputs      map[string]string `yaml:"inputs"`
+	PullRequest PullRequest       `yaml:"pull-request"`
+	Checks      []Check           `yaml:"checks"`
+	Reviews     []Review          `yaml:"reviews"`
+	// Expect is the result the gate is expected to reach: pass, fail or pending.
+	Expect string `yaml:"expect"`
+	// Policy is the name of the policy expected to be applied. It is not checked when empty.
+	Policy string `yaml:"policy"`
+}
+
+// PullRequest describes the pull request of a test case.
+type PullRequest struct {
+	Base        string   `yaml:"base"`
+	Author      string   `yaml:"author"`
+	Association string   `yaml:"association"`
+	Paths       []string `yaml:"paths"`
+	Labels      []string `yaml:"labels"`
+	Draft       bool     `yaml:"draft"`
+}
+
+// Check is a job run on the head of the pull request.
+type Check struct {
+	Workflow string `yaml:"workflow"`
+	Name     string `yaml:"name"`
+	// Status is queued, in_progress or completed. Checks with a conclusion are completed by default.
+	Status string `yaml:"status"`
+	// Conclusion is the conclusion of completed checks, e.g. success, failure or skipped.
+	Conclusion string `yaml:"conclusion"`
+}
+
+// Review is a review of the pull request, submitted on its head.
+type Review struct {
+	User  string `yaml:"user"`
+	State string `yaml:"state"`
+}
+
+// Parse parses the test cases, and validates them.
+func Parse(b []byte) (*Suite, error) {
+	s := &Suite{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(s); err != nil {
+		return nil, err
+	}
+	for i, c := range s.Tests {
+		if len(c.Name) == 0 {
+			return nil, fmt.Errorf("test %d has no name", i+1)
+		}
+		switch c.Expect {
+		case ExpectPass, ExpectFail, ExpectPending:
+		default:
+			return nil, fmt.Errorf("test %s expects unknown result %q, expected one of %s, %s or %s", c.Name, c.Expect, ExpectPass, ExpectFail, ExpectPending)
+		}
+		if len(c.PullRequest.Base) == 0 {
+			return nil, fmt.Errorf("test %s has no base branch of the pull request", c.Name)
+		}
+		for _, ch := range c.Checks {
+			if len(ch.Name) == 0 {
+				return nil, fmt.Errorf("test %s has a check without name", c.Name)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Target returns the target against which the policies are matched.
+func (c *Case) Target() *policy.Target {
+	return &policy.Target{
+		Base:        c.PullRequest.Base,
+		Paths:       c.PullRequest.Paths,
+		Author:      c.PullRequest.Author,
+		Association: c.PullRequest.Association,
+	}
+}
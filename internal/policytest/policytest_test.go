@@ -0,0 +1,76 @@
+package policytest
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		"parses test cases": {
+			in: `
+tests:
+  - name: docs
+    pull-request: {base: main, paths: [README.md]}
+    checks:
+      - {workflow: ci, name: build, conclusion: success}
+    reviews:
+      - {user: alice, state: APPROVED}
+    expect: pass
+  - name: pending
+    pull-request: {base: main}
+    expect: pending
+`,
+			want: 2,
+		},
+		"returns error when the result is unknown": {
+			in: `
+tests:
+  - name: docs
+    pull-request: {base: main}
+    expect: success
+`,
+			wantErr: true,
+		},
+		"returns error when the base branch is missing": {
+			in: `
+tests:
+  - name: docs
+    expect: pass
+`,
+			wantErr: true,
+		},
+		"returns error when a check has no name": {
+			in: `
+tests:
+  - name: docs
+    pull-request: {base: main}
+    checks:
+      - {workflow: ci, conclusion: success}
+    expect: pass
+`,
+			wantErr: true,
+		},
+		"returns error for unknown fields": {
+			in: `
+tests:
+  - name: docs
+    pull-request: {base: main, branch: feature}
+    expect: pass
+`,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(got.Tests) != tt.want {
+				t.Errorf("Parse() got %d tests, want %d", len(got.Tests), tt.want)
+			}
+		})
+	}
+}
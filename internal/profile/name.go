@@ -0,0 +1,70 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultNameTemplate names what the gate publishes by the merge-gatekeeper prefix and the name of the profile.
+const DefaultNameTemplate = "merge-gatekeeper/{{.Name}}"
+
+// NameVars are the variables of name templates.
+type NameVars struct {
+	// Name is the name of the published item, such as the profile.
+	Name       string
+	Owner      string
+	Repo       string
+	Repository string
+	BaseBranch string
+}
+
+// Namer renders the names of the statuses and comments the gate publishes from a template.
+type Namer struct {
+	tmpl *template.Template
+	vars NameVars
+}
+
+// NewNamer returns the namer rendering the template, e.g) "gate/{{.Repo}}/{{.BaseBranch}}/{{.Name}}", with the
+// variables shared by every name.
+func NewNamer(text string, vars NameVars) (*Namer, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("name template is invalid: %w", err)
+	}
+	n := &Namer{tmpl: tmpl, vars: vars}
+	// Rendering a sample name surfaces unknown variables before anything is published.
+	if _, err := n.render("sample"); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(text, ".Name") {
+		return nil, errors.New("name template must contain {{.Name}}, as the names must differ")
+	}
+	return n, nil
+}
+
+// NeedsBaseBranch reports whether the template refers to the base branch, which may have to be looked up.
+func NeedsBaseBranch(text string) bool {
+	return strings.Contains(text, ".BaseBranch")
+}
+
+// Name returns the rendered name of the item.
+func (n *Namer) Name(name string) string {
+	// The template has already been rendered successfully, and only the name differs.
+	s, _ := n.render(name)
+	return s
+}
+
+func (n *Namer) render(name string) (string, error) {
+	vars := n.vars
+	vars.Name = name
+	var b strings.Builder
+	if err := n.tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("failed to render name template: %w", err)
+	}
+	if len(strings.TrimSpace(b.String())) == 0 {
+		return "", errors.New("name template renders an empty name")
+	}
+	return b.String(), nil
+}
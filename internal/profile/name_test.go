@@ -0,0 +1,43 @@
+package profile
+
+import "testing"
+
+func TestNewNamer(t *testing.T) {
+	vars := NameVars{Owner: "owner", Repo: "repo", Repository: "owner/repo", BaseBranch: "release/v1"}
+	tests := map[string]struct {
+		template string
+		want     string
+		wantErr  bool
+	}{
+		"renders default template": {
+			template: DefaultNameTemplate,
+			want:     "merge-gatekeeper/fast-gate",
+		},
+		"renders repository and base branch": {
+			template: "{{.Repository}}@{{.BaseBranch}}: {{.Name}}",
+			want:     "owner/repo@release/v1: fast-gate",
+		},
+		"returns error for unknown variable": {
+			template: "{{.Branch}}/{{.Name}}",
+			wantErr:  true,
+		},
+		"returns error without name": {
+			template: "merge-gatekeeper/{{.Repo}}",
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			n, err := NewNamer(tt.template, vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewNamer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := n.Name("fast-gate"); got != tt.want {
+				t.Errorf("Name() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
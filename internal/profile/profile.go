@@ -0,0 +1,152 @@
+// Package profile evaluates named subsets of the jobs validated by the gate, so that a single run can publish
+// separate gates, such as a fast gate requiring only unit tests and a full gate requiring every job.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// States of the commit statuses of profiles.
+// NOTE: https://docs.github.com/en/rest/commits/statuses
+const (
+	StatePending = "pending"
+	StateSuccess = "success"
+	StateFailure = "failure"
+)
+
+// OptionalName is the name of the commit status summarizing the optional jobs, which must not be required by
+// branch protection.
+const OptionalName = "optional"
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Profile is a named gate requiring the listed jobs, or every job validated by the gate when no job is listed.
+type Profile struct {
+	Name string
+	Jobs []string
+}
+
+// Parse parses profiles in the form of "name=job,job;name", e.g) "fast-gate=unit-test,lint;full-gate".
+func Parse(spec string) ([]*Profile, error) {
+	var profiles []*Profile
+	seen := make(map[string]bool)
+	for _, def := range strings.Split(spec, ";") {
+		if def = strings.TrimSpace(def); len(def) == 0 {
+			continue
+		}
+		name, jobs, _ := strings.Cut(def, "=")
+		p := &Profile{Name: strings.TrimSpace(name)}
+		if !namePattern.MatchString(p.Name) {
+			return nil, fmt.Errorf("profile name %q is invalid. must consist of letters, digits, '.', '_' and '-'", p.Name)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("profile %s is defined more than once", p.Name)
+		}
+		seen[p.Name] = true
+		for _, job := range strings.Split(jobs, ",") {
+			if job = strings.TrimSpace(job); len(job) != 0 {
+				p.Jobs = append(p.Jobs, job)
+			}
+		}
+		profiles = append(profiles, p)
+	}
+	if len(profiles) == 0 {
+		return nil, errors.New("no profile is defined")
+	}
+	return profiles, nil
+}
+
+// Evaluate returns the state of the profile from the decisions of the checks, with a short description. Listed jobs
+// which have not reported yet keep the profile pending.
+func (p *Profile) Evaluate(decisions []validators.Decision) (state, description string) {
+	var total, succeeded int
+	var failed, pending []string
+	reported := make(map[string]bool, len(p.Jobs))
+	for _, d := range decisions {
+		job, ok := p.requires(d.Check)
+		if !ok {
+			continue
+		}
+		reported[job] = true
+		switch d.Verdict {
+		case validators.StateIgnored:
+			continue
+		case validators.StateSuccess:
+			succeeded++
+		case validators.StateFailure:
+			failed = append(failed, d.Check)
+		default:
+			pending = append(pending, d.Check)
+		}
+		total++
+	}
+	for _, job := range p.Jobs {
+		if !reported[job] {
+			pending = append(pending, job)
+			total++
+		}
+	}
+
+	switch {
+	case len(failed) != 0:
+		return StateFailure, fmt.Sprintf("%d of %d jobs failed: %s", len(failed), total, strings.Join(failed, ", "))
+	case len(pending) != 0:
+		return StatePending, fmt.Sprintf("%d of %d jobs succeeded, waiting for %s", succeeded, total, strings.Join(pending, ", "))
+	default:
+		return StateSuccess, fmt.Sprintf("%d of %d jobs succeeded", succeeded, total)
+	}
+}
+
+// requires returns the listed job matching the check, named "workflow / job", by either its job name or full name.
+func (p *Profile) requires(check string) (string, bool) {
+	if len(p.Jobs) == 0 {
+		return check, true
+	}
+	job := check
+	if i := strings.LastIndex(check, " / "); i >= 0 {
+		job = check[i+len(" / "):]
+	}
+	for _, j := range p.Jobs {
+		if j == job || j == check {
+			return j, true
+		}
+	}
+	return "", false
+}
+
+// EvaluateOptional returns the state of the optional jobs, which are ignored by the gate but keep their observed state
+// in the decisions, with a short description. It returns false when no job is optional.
+func EvaluateOptional(decisions []validators.Decision) (state, description string, ok bool) {
+	var total, succeeded int
+	var failed, pending []string
+	for _, d := range decisions {
+		if d.Verdict != validators.StateIgnored || len(d.State) == 0 {
+			continue
+		}
+		switch d.State {
+		case validators.StateSuccess:
+			succeeded++
+		case validators.StateFailure:
+			failed = append(failed, d.Check)
+		default:
+			pending = append(pending, d.Check)
+		}
+		total++
+	}
+
+	switch {
+	case total == 0:
+		return "", "", false
+	case len(failed) != 0:
+		return StateFailure, fmt.Sprintf("%d of %d optional jobs failed: %s", len(failed), total, strings.Join(failed, ", ")), true
+	case len(pending) != 0:
+		return StatePending, fmt.Sprintf("%d of %d optional jobs succeeded, waiting for %s", succeeded, total, strings.Join(pending, ", ")), true
+	default:
+		return StateSuccess, fmt.Sprintf("%d of %d optional jobs succeeded", succeeded, total), true
+	}
+}
@@ -0,0 +1,143 @@
+package profile
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		want    []*Profile
+		wantErr bool
+	}{
+		"parses profiles": {
+			spec: "fast-gate=unit-test, lint ; full-gate",
+			want: []*Profile{
+				{Name: "fast-gate", Jobs: []string{"unit-test", "lint"}},
+				{Name: "full-gate"},
+			},
+		},
+		"returns error for duplicated profile": {
+			spec:    "gate=a;gate=b",
+			wantErr: true,
+		},
+		"returns error for invalid name": {
+			spec:    "fast gate=a",
+			wantErr: true,
+		},
+		"returns error without profile": {
+			spec:    " ; ",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfile_Evaluate(t *testing.T) {
+	decisions := []validators.Decision{
+		{Check: "CI / unit-test", Verdict: validators.StateSuccess},
+		{Check: "CI / lint", Verdict: validators.StateSuccess},
+		{Check: "E2E / e2e", Verdict: validators.StatePending},
+		{Check: "Merge / merge-gatekeeper", Verdict: validators.StateIgnored},
+	}
+	tests := map[string]struct {
+		profile         *Profile
+		decisions       []validators.Decision
+		wantState       string
+		wantDescription string
+	}{
+		"succeeds when listed jobs succeeded": {
+			profile:         &Profile{Name: "fast-gate", Jobs: []string{"unit-test", "CI / lint"}},
+			decisions:       decisions,
+			wantState:       StateSuccess,
+			wantDescription: "2 of 2 jobs succeeded",
+		},
+		"is pending until every job succeeded": {
+			profile:         &Profile{Name: "full-gate"},
+			decisions:       decisions,
+			wantState:       StatePending,
+			wantDescription: "2 of 3 jobs succeeded, waiting for E2E / e2e",
+		},
+		"is pending until listed jobs are reported": {
+			profile:         &Profile{Name: "fast-gate", Jobs: []string{"unit-test", "build"}},
+			decisions:       decisions,
+			wantState:       StatePending,
+			wantDescription: "1 of 2 jobs succeeded, waiting for build",
+		},
+		"fails when a listed job failed": {
+			profile:         &Profile{Name: "fast-gate", Jobs: []string{"unit-test"}},
+			decisions:       []validators.Decision{{Check: "CI / unit-test", Verdict: validators.StateFailure}},
+			wantState:       StateFailure,
+			wantDescription: "1 of 1 jobs failed: CI / unit-test",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, description := tt.profile.Evaluate(tt.decisions)
+			if state != tt.wantState {
+				t.Errorf("Evaluate() state = %s, want %s", state, tt.wantState)
+			}
+			if description != tt.wantDescription {
+				t.Errorf("Evaluate() description = %s, want %s", description, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestEvaluateOptional(t *testing.T) {
+	tests := map[string]struct {
+		decisions       []validators.Decision
+		wantState       string
+		wantDescription string
+		wantOK          bool
+	}{
+		"reports failed optional jobs": {
+			decisions: []validators.Decision{
+				{Check: "CI / unit-test", Verdict: validators.StateSuccess},
+				{Check: "Perf / bench", Verdict: validators.StateIgnored, State: validators.StateFailure},
+				{Check: "Docs / links", Verdict: validators.StateIgnored, State: validators.StateSuccess},
+				{Check: "Merge / merge-gatekeeper", Verdict: validators.StateIgnored},
+			},
+			wantState:       StateFailure,
+			wantDescription: "1 of 2 optional jobs failed: Perf / bench",
+			wantOK:          true,
+		},
+		"reports pending optional jobs": {
+			decisions: []validators.Decision{
+				{Check: "Perf / bench", Verdict: validators.StateIgnored, State: validators.StatePending},
+			},
+			wantState:       StatePending,
+			wantDescription: "0 of 1 optional jobs succeeded, waiting for Perf / bench",
+			wantOK:          true,
+		},
+		"returns false without optional jobs": {
+			decisions: []validators.Decision{
+				{Check: "CI / unit-test", Verdict: validators.StateSuccess},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, description, ok := EvaluateOptional(tt.decisions)
+			if ok != tt.wantOK {
+				t.Fatalf("EvaluateOptional() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if state != tt.wantState || description != tt.wantDescription {
+				t.Errorf("EvaluateOptional() = %s, %s, want %s, %s", state, description, tt.wantState, tt.wantDescription)
+			}
+		})
+	}
+}
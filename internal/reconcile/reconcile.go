@@ -0,0 +1,111 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// Kinds of drift between the gate and branch protection.
+const (
+	// DriftUnprotected is a check required by the gate, but not by branch protection, such as the gate itself, which
+	// lets pull requests be merged before the gate passes.
+	DriftUnprotected = "unprotected"
+	// DriftIgnored is a check required by branch protection, but ignored by the gate, which keeps pull requests
+	// blocked on checks the gate was told to let through, e.g) jobs which never run on some pull requests.
+	DriftIgnored = "ignored"
+)
+
+// Gate describes the checks as configured for the gate.
+type Gate struct {
+	// Required are the checks the gate requires, starting with the gate itself.
+	Required []string
+	// Ignores reports which rule of the gate ignores a check, if any.
+	Ignores func(check string) (string, bool)
+}
+
+// Drift is a check on which the gate and branch protection disagree.
+type Drift struct {
+	Check string
+	Kind  string
+	Rule  string // Rule of the gate ignoring the check, for DriftIgnored.
+}
+
+// Report is the result of reconciling the gate with the protection of a branch.
+type Report struct {
+	Branch string
+	Drifts []Drift
+	// Fixed reports whether the required status checks of the branch were updated to remove the drifts.
+	Fixed bool
+	// Unfixable reports whether the drifts were left, as the branch is protected without required status checks,
+	// whose enabling through the API would replace every other setting of the protection.
+	Unfixable bool
+}
+
+// Reconcile compares the checks required by the gate with the required status checks of the branch, and updates the
+// latter to remove the drifts when fix is set: checks required by the gate are added, while checks ignored by the gate
+// are removed. Unprotected branches are protected with the checks required by the gate only.
+func Reconcile(ctx context.Context, c github.Client, owner, repo, branch string, gate Gate, fix bool) (*Report, error) {
+	p, res, err := c.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil && (res == nil || res.StatusCode != http.StatusNotFound) {
+		return nil, fmt.Errorf("failed to get protection of %s: %w", branch, err)
+	}
+	protected := err == nil
+
+	var required *github.RequiredStatusChecks
+	var checks []*github.RequiredStatusCheck
+	if protected {
+		required = p.GetRequiredStatusChecks()
+		if required != nil && required.Checks != nil {
+			checks = *required.Checks
+		}
+	}
+
+	report := &Report{Branch: branch}
+	kept := make([]*github.RequiredStatusCheck, 0, len(checks)+len(gate.Required))
+	protects := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		protects[check.Context] = true
+		if gate.Ignores != nil {
+			if rule, ok := gate.Ignores(check.Context); ok {
+				report.Drifts = append(report.Drifts, Drift{Check: check.Context, Kind: DriftIgnored, Rule: rule})
+				continue
+			}
+		}
+		kept = append(kept, check)
+	}
+	for _, check := range gate.Required {
+		if protects[check] {
+			continue
+		}
+		protects[check] = true
+		report.Drifts = append(report.Drifts, Drift{Check: check, Kind: DriftUnprotected})
+		kept = append(kept, &github.RequiredStatusCheck{Context: check})
+	}
+
+	if len(report.Drifts) == 0 || !fix {
+		return report, nil
+	}
+	switch {
+	case !protected:
+		if _, _, err := c.UpdateBranchProtection(ctx, owner, repo, branch, &github.ProtectionRequest{
+			RequiredStatusChecks: &github.RequiredStatusChecks{Checks: &kept},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to protect %s: %w", branch, err)
+		}
+	case required == nil:
+		report.Unfixable = true
+		return report, nil
+	default:
+		if _, _, err := c.UpdateRequiredStatusChecks(ctx, owner, repo, branch, &github.RequiredStatusChecksRequest{
+			Strict: &required.Strict,
+			Checks: kept,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update required status checks of %s: %w", branch, err)
+		}
+	}
+	report.Fixed = true
+	return report, nil
+}
@@ -0,0 +1,127 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func protection(checks ...string) *github.Protection {
+	required := []*github.RequiredStatusCheck{}
+	for _, check := range checks {
+		required = append(required, &github.RequiredStatusCheck{Context: check})
+	}
+	return &github.Protection{RequiredStatusChecks: &github.RequiredStatusChecks{Strict: true, Checks: &required}}
+}
+
+func TestReconcile(t *testing.T) {
+	gate := Gate{
+		Required: []string{"merge-gatekeeper", "compliance-scan"},
+		Ignores: func(check string) (string, bool) {
+			return "ignored-jobs", check == "nightly"
+		},
+	}
+	tests := map[string]struct {
+		protection *github.Protection // Unprotected when nil.
+		fix        bool
+		want       *Report
+		wantChecks []string
+		wantStrict bool
+	}{
+		"reports no drift": {
+			protection: protection("merge-gatekeeper", "compliance-scan", "build"),
+			fix:        true,
+			want:       &Report{Branch: "main"},
+		},
+		"reports drifts without fixing them": {
+			protection: protection("build", "nightly", "merge-gatekeeper"),
+			want: &Report{Branch: "main", Drifts: []Drift{
+				{Check: "nightly", Kind: DriftIgnored, Rule: "ignored-jobs"},
+				{Check: "compliance-scan", Kind: DriftUnprotected},
+			}},
+		},
+		"fixes drifts keeping the other checks and strictness": {
+			protection: protection("build", "nightly", "merge-gatekeeper"),
+			fix:        true,
+			want: &Report{Branch: "main", Fixed: true, Drifts: []Drift{
+				{Check: "nightly", Kind: DriftIgnored, Rule: "ignored-jobs"},
+				{Check: "compliance-scan", Kind: DriftUnprotected},
+			}},
+			wantChecks: []string{"build", "merge-gatekeeper", "compliance-scan"},
+			wantStrict: true,
+		},
+		"protects unprotected branch": {
+			fix: true,
+			want: &Report{Branch: "main", Fixed: true, Drifts: []Drift{
+				{Check: "merge-gatekeeper", Kind: DriftUnprotected},
+				{Check: "compliance-scan", Kind: DriftUnprotected},
+			}},
+			wantChecks: []string{"merge-gatekeeper", "compliance-scan"},
+		},
+		"leaves protection without required status checks": {
+			protection: &github.Protection{},
+			fix:        true,
+			want: &Report{Branch: "main", Unfixable: true, Drifts: []Drift{
+				{Check: "merge-gatekeeper", Kind: DriftUnprotected},
+				{Check: "compliance-scan", Kind: DriftUnprotected},
+			}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotChecks []string
+			var gotStrict bool
+			c := &mock.Client{
+				GetBranchProtectionFunc: func(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+					if tt.protection == nil {
+						return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("branch not protected")
+					}
+					return tt.protection, nil, nil
+				},
+				UpdateBranchProtectionFunc: func(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+					for _, check := range *preq.RequiredStatusChecks.Checks {
+						gotChecks = append(gotChecks, check.Context)
+					}
+					return &github.Protection{}, nil, nil
+				},
+				UpdateRequiredStatusChecksFunc: func(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+					for _, check := range sreq.Checks {
+						gotChecks = append(gotChecks, check.Context)
+					}
+					gotStrict = sreq.GetStrict()
+					return &github.RequiredStatusChecks{}, nil, nil
+				},
+			}
+
+			got, err := Reconcile(context.Background(), c, "owner", "repo", "main", gate, tt.fix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotChecks, tt.wantChecks) {
+				t.Errorf("required checks = %v, want %v", gotChecks, tt.wantChecks)
+			}
+			if gotStrict != tt.wantStrict {
+				t.Errorf("strict = %v, want %v", gotStrict, tt.wantStrict)
+			}
+		})
+	}
+}
+
+func TestReconcile_error(t *testing.T) {
+	c := &mock.Client{
+		GetBranchProtectionFunc: func(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+			return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}, errors.New("forbidden")
+		},
+	}
+	if _, err := Reconcile(context.Background(), c, "owner", "repo", "main", Gate{}, true); err == nil {
+		t.Error("Reconcile() error = nil, want error")
+	}
+}
@@ -0,0 +1,194 @@
+package release
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+const signaturePrefix = "sha256="
+
+// ValidateFormat returns error when the format is not supported by Render.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatMarkdown, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s, supported formats: [%s %s]", format, FormatMarkdown, FormatJSON)
+	}
+}
+
+// Gate is the outcome of a single validator evaluated for the release.
+type Gate struct {
+	Name   string `json:"name"`
+	Ready  bool   `json:"ready"`
+	Detail string `json:"detail"`
+}
+
+// Report is the readiness of a tag or release branch, suitable for change-advisory submission.
+type Report struct {
+	Repository  string    `json:"repository"`
+	Ref         string    `json:"ref"`
+	SHA         string    `json:"sha"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Ready       bool      `json:"ready"`
+	Gates       []Gate    `json:"gates"`
+	Signature   string    `json:"signature,omitempty"`
+}
+
+// Evaluate runs every validator once. Unlike the validation of pull requests, pending validators are not waited for,
+// as the report describes the readiness at the time it is generated.
+func Evaluate(ctx context.Context, vs ...validators.Validator) []Gate {
+	gates := make([]Gate, 0, len(vs))
+	for _, v := range vs {
+		st, err := v.Validate(ctx)
+		if err != nil {
+			gates = append(gates, Gate{Name: v.Name(), Detail: err.Error()})
+			continue
+		}
+		gates = append(gates, Gate{Name: v.Name(), Ready: st.IsSuccess(), Detail: strings.TrimSpace(st.Detail())})
+	}
+	return gates
+}
+
+// New returns the report, which is ready only when every gate is ready.
+func New(repository, ref, sha string, at time.Time, gates []Gate) *Report {
+	r := &Report{
+		Repository:  repository,
+		Ref:         ref,
+		SHA:         sha,
+		GeneratedAt: at.UTC(),
+		Ready:       len(gates) != 0,
+		Gates:       gates,
+	}
+	for _, g := range gates {
+		if !g.Ready {
+			r.Ready = false
+		}
+	}
+	return r
+}
+
+// Sign sets the HMAC-SHA256 signature of the report content, so that reviewers holding the key can verify
+// the report was not altered after generation.
+func (r *Report) Sign(key []byte) error {
+	if len(key) == 0 {
+		return errors.New("signing key is empty")
+	}
+	mac, err := r.mac(key)
+	if err != nil {
+		return err
+	}
+	r.Signature = signaturePrefix + hex.EncodeToString(mac)
+	return nil
+}
+
+// Verify reports whether the signature of the report matches its content.
+func (r *Report) Verify(key []byte) bool {
+	if !strings.HasPrefix(r.Signature, signaturePrefix) {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(r.Signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+	want, err := r.mac(key)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, want)
+}
+
+// mac is computed over the JSON output without the signature, so that the signed content is what consumers read.
+func (r *Report) mac(key []byte) ([]byte, error) {
+	unsigned := r.Schema()
+	unsigned.Signature = ""
+	b, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(b)
+	return h.Sum(nil), nil
+}
+
+// Render renders the report in the format.
+func (r *Report) Render(format string) (string, error) {
+	if err := ValidateFormat(format); err != nil {
+		return "", err
+	}
+	if format == FormatMarkdown {
+		return r.markdown(), nil
+	}
+	b, err := json.MarshalIndent(r.Schema(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// Schema converts the report into its versioned JSON output.
+func (r *Report) Schema() *schema.ReleaseReport {
+	gates := make([]schema.ReleaseGate, 0, len(r.Gates))
+	for _, g := range r.Gates {
+		gates = append(gates, schema.ReleaseGate{Name: g.Name, Ready: g.Ready, Detail: g.Detail})
+	}
+	return &schema.ReleaseReport{
+		Header:      schema.NewHeader(schema.KindRelease),
+		Repository:  r.Repository,
+		Ref:         r.Ref,
+		SHA:         r.SHA,
+		GeneratedAt: r.GeneratedAt,
+		Ready:       r.Ready,
+		Gates:       gates,
+		Signature:   r.Signature,
+	}
+}
+
+func (r *Report) markdown() string {
+	verdict := "Ready"
+	if !r.Ready {
+		verdict = "Not ready"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Release readiness: %s\n\n", r.Ref)
+	fmt.Fprintf(&b, "- Repository: %s\n", r.Repository)
+	fmt.Fprintf(&b, "- Commit: `%s`\n", r.SHA)
+	fmt.Fprintf(&b, "- Generated at: %s\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Verdict: **%s**\n\n", verdict)
+
+	b.WriteString("| Gate | Ready | Detail |\n")
+	b.WriteString("| ---- | :---: | ------ |\n")
+	for _, g := range r.Gates {
+		ready := "no"
+		if g.Ready {
+			ready = "yes"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", g.Name, ready, tableCell(g.Detail))
+	}
+
+	if len(r.Signature) != 0 {
+		fmt.Fprintf(&b, "\nSignature: `%s`\n", r.Signature)
+	}
+	return b.String()
+}
+
+func tableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,127 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+	"github.com/aac228/merge-gatekeeper/internal/validators/mock"
+)
+
+func TestEvaluate(t *testing.T) {
+	vs := []validators.Validator{
+		&mock.Validator{
+			NameFunc: func() string { return "ready" },
+			ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+				return &validators.BasicStatus{Succeeded: true, Message: "ok\n"}, nil
+			},
+		},
+		&mock.Validator{
+			NameFunc: func() string { return "broken" },
+			ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+				return nil, errors.New("err")
+			},
+		},
+	}
+	want := []Gate{
+		{Name: "ready", Ready: true, Detail: "ok"},
+		{Name: "broken", Detail: "err"},
+	}
+	if got := Evaluate(context.Background(), vs...); !reflect.DeepEqual(got, want) {
+		t.Errorf("Evaluate() = %v, want %v", got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		gates     []Gate
+		wantReady bool
+	}{
+		"is ready when every gate is ready": {
+			gates:     []Gate{{Name: "checks", Ready: true}, {Name: "approvals", Ready: true}},
+			wantReady: true,
+		},
+		"is not ready when any gate is not ready": {
+			gates:     []Gate{{Name: "checks", Ready: true}, {Name: "approvals"}},
+			wantReady: false,
+		},
+		"is not ready without gates": {
+			wantReady: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := New("owner/repo", "v1.0.0", "sha", time.Now(), tt.gates); got.Ready != tt.wantReady {
+				t.Errorf("Ready = %v, want %v", got.Ready, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestReport_Sign(t *testing.T) {
+	r := New("owner/repo", "v1.0.0", "sha", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), []Gate{{Name: "checks", Ready: true}})
+	if err := r.Sign([]byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(r.Signature, signaturePrefix) {
+		t.Errorf("Signature = %s, want prefix %s", r.Signature, signaturePrefix)
+	}
+	if !r.Verify([]byte("key")) {
+		t.Error("Verify() = false with the signing key")
+	}
+	if r.Verify([]byte("other")) {
+		t.Error("Verify() = true with another key")
+	}
+
+	r.Gates[0].Ready = false
+	if r.Verify([]byte("key")) {
+		t.Error("Verify() = true after the report was altered")
+	}
+
+	if err := r.Sign(nil); err == nil {
+		t.Error("Sign() returns no error with empty key")
+	}
+}
+
+func TestReport_Render(t *testing.T) {
+	r := New("owner/repo", "v1.0.0", "sha", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), []Gate{
+		{Name: "checks", Ready: true, Detail: "1 out of 1"},
+		{Name: "approvals", Detail: "a | b\nc"},
+	})
+
+	got, err := r.Render(FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Release readiness: v1.0.0
+
+- Repository: owner/repo
+- Commit: ` + "`sha`" + `
+- Generated at: 2024-01-01T00:00:00Z
+- Verdict: **Not ready**
+
+| Gate | Ready | Detail |
+| ---- | :---: | ------ |
+| checks | yes | 1 out of 1 |
+| approvals | no | a \| b<br>c |
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, err = r.Render(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `"ready": false`) {
+		t.Errorf("Render() = %s, want JSON with the verdict", got)
+	}
+
+	if _, err := r.Render("xml"); err == nil {
+		t.Error("Render() returns no error with unsupported format")
+	}
+}
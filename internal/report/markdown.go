@@ -0,0 +1,213 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const (
+	FormatList  = "list"
+	FormatTable = "table"
+)
+
+// Options controls how rich the rendered markdown is, as some tooling strips or mangles rich markdown.
+type Options struct {
+	Format  string
+	Emoji   bool
+	Details bool
+}
+
+func (o Options) Validate() error {
+	switch o.Format {
+	case FormatList, FormatTable:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s, supported formats: [%s %s]", o.Format, FormatList, FormatTable)
+	}
+}
+
+var emojis = map[string]string{
+	validators.StateSuccess: "✅",
+	validators.StateFailure: "❌",
+	validators.StatePending: "⏳",
+	validators.StateIgnored: "⏭️",
+}
+
+// Markdown renders the report of a single validator.
+func Markdown(name, state string, rp validators.Reporter, opts Options) string {
+	summary := rp.Summary()
+	if es, ok := rp.(validators.Estimator); ok {
+		if eta, ok := es.ETA(); ok {
+			summary = fmt.Sprintf("%s (ETA %s)", summary, formatETA(eta))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(heading(name, state, summary, opts))
+	if nt, ok := rp.(validators.Noter); ok && len(nt.Notes()) != 0 {
+		for _, note := range nt.Notes() {
+			fmt.Fprintf(&b, "> %s\n", note)
+		}
+		b.WriteString("\n")
+	}
+
+	if gr, ok := rp.(validators.Grouper); ok {
+		writeGroups(&b, gr.Groups(), opts)
+		return b.String()
+	}
+
+	sections := make([]validators.Section, 0, len(rp.Sections()))
+	for _, s := range rp.Sections() {
+		if len(s.Items) != 0 {
+			sections = append(sections, s)
+		}
+	}
+	if len(sections) == 0 {
+		return b.String()
+	}
+
+	switch opts.Format {
+	case FormatTable:
+		writeTable(&b, sections, opts)
+	default:
+		for _, s := range sections {
+			writeList(&b, s, opts, true)
+		}
+	}
+	return b.String()
+}
+
+// MarkdownError renders a validator which could not produce its report.
+func MarkdownError(name string, err error, opts Options) string {
+	return heading(name, validators.StateFailure, "", opts) + fmt.Sprintf("```\n%v\n```\n\n", err)
+}
+
+func heading(name, state, summary string, opts Options) string {
+	title := name
+	if len(summary) != 0 {
+		title = fmt.Sprintf("%s: %s", name, summary)
+	}
+	return fmt.Sprintf("### %s\n\n", withEmoji(title, state, opts))
+}
+
+func withEmoji(str, state string, opts Options) string {
+	if !opts.Emoji {
+		return str
+	}
+	return emojis[state] + " " + str
+}
+
+func writeList(b *strings.Builder, s validators.Section, opts Options, count bool) {
+	title := s.Title
+	if count {
+		title = fmt.Sprintf("%s (%d)", s.Title, len(s.Items))
+	}
+	title = withEmoji(title, s.State, opts)
+	if opts.Details {
+		fmt.Fprintf(b, "<details><summary>%s</summary>\n\n", title)
+	} else {
+		fmt.Fprintf(b, "**%s**\n\n", title)
+	}
+	for _, item := range s.Items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+	if opts.Details {
+		b.WriteString("</details>\n")
+	}
+	b.WriteString("\n")
+}
+
+func writeTable(b *strings.Builder, sections []validators.Section, opts Options) {
+	var cnt int
+	for _, s := range sections {
+		cnt += len(s.Items)
+	}
+	if opts.Details {
+		fmt.Fprintf(b, "<details><summary>Jobs (%d)</summary>\n\n", cnt)
+	}
+	b.WriteString("| State | Job |\n| --- | --- |\n")
+	for _, s := range sections {
+		for _, item := range s.Items {
+			fmt.Fprintf(b, "| %s | %s |\n", withEmoji(s.Title, s.State, opts), escapeCell(item))
+		}
+	}
+	if opts.Details {
+		b.WriteString("\n</details>\n")
+	}
+	b.WriteString("\n")
+}
+
+// writeGroups renders checks under their group. Green groups are collapsed into a single line.
+func writeGroups(b *strings.Builder, groups []validators.Group, opts Options) {
+	if len(groups) == 0 {
+		return
+	}
+	if opts.Format == FormatTable {
+		b.WriteString("| Workflow | Job | State |\n| --- | --- | --- |\n")
+	}
+	for _, g := range groups {
+		state := groupState(g)
+		total := g.Succeeded + g.Failed + g.Pending
+		switch {
+		case opts.Format == FormatTable && g.IsGreen():
+			fmt.Fprintf(b, "| %s | %d/%d | %s |\n", escapeCell(g.Name), g.Succeeded, total, withEmoji(state, state, opts))
+		case opts.Format == FormatTable:
+			for _, d := range g.Checks {
+				fmt.Fprintf(b, "| %s | %s | %s |\n", escapeCell(g.Name), escapeCell(jobName(g, d)), withEmoji(d.Verdict, d.Verdict, opts))
+			}
+		case g.IsGreen():
+			fmt.Fprintf(b, "- %s\n\n", withEmoji(fmt.Sprintf("%s (%d/%d)", g.Name, g.Succeeded, total), state, opts))
+		default:
+			title := fmt.Sprintf("%s (%d/%d)", g.Name, g.Succeeded, total)
+			if g.ETA != nil {
+				title = fmt.Sprintf("%s (%d/%d, ETA %s)", g.Name, g.Succeeded, total, formatETA(*g.ETA))
+			}
+			writeList(b, validators.Section{
+				Title: title,
+				State: state,
+				Items: groupItems(g, opts),
+			}, opts, false)
+		}
+	}
+	if opts.Format == FormatTable {
+		b.WriteString("\n")
+	}
+}
+
+func formatETA(eta time.Time) string {
+	return eta.UTC().Format("15:04 UTC")
+}
+
+func groupState(g validators.Group) string {
+	switch {
+	case g.Failed != 0:
+		return validators.StateFailure
+	case g.Pending != 0:
+		return validators.StatePending
+	default:
+		return validators.StateSuccess
+	}
+}
+
+func groupItems(g validators.Group, opts Options) []string {
+	items := make([]string, 0, len(g.Checks))
+	for _, d := range g.Checks {
+		if opts.Emoji {
+			items = append(items, withEmoji(jobName(g, d), d.Verdict, opts))
+		} else {
+			items = append(items, fmt.Sprintf("%s: %s", jobName(g, d), d.Verdict))
+		}
+	}
+	return items
+}
+
+func jobName(g validators.Group, d validators.Decision) string {
+	return strings.TrimPrefix(d.Check, g.Name+" / ")
+}
+
+func escapeCell(str string) string {
+	return strings.ReplaceAll(str, "|", "\\|")
+}
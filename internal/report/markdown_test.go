@@ -0,0 +1,168 @@
+package report
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+type reporter struct {
+	summary  string
+	sections []validators.Section
+}
+
+func (r *reporter) Summary() string                { return r.summary }
+func (r *reporter) Sections() []validators.Section { return r.sections }
+
+func TestMarkdown(t *testing.T) {
+	rp := &reporter{
+		summary: "1 out of 2",
+		sections: []validators.Section{
+			{Title: "Failed jobs", State: validators.StateFailure},
+			{Title: "Completed jobs", State: validators.StateSuccess, Items: []string{"CI / build"}},
+			{Title: "Incomplete jobs", State: validators.StatePending, Items: []string{"CI / a|b"}},
+		},
+	}
+	tests := map[string]struct {
+		opts Options
+		want string
+	}{
+		"renders list with emoji and details": {
+			opts: Options{Format: FormatList, Emoji: true, Details: true},
+			want: `### ⏳ gate: 1 out of 2
+
+<details><summary>✅ Completed jobs (1)</summary>
+
+- CI / build
+</details>
+
+<details><summary>⏳ Incomplete jobs (1)</summary>
+
+- CI / a|b
+</details>
+
+`,
+		},
+		"renders plain list": {
+			opts: Options{Format: FormatList},
+			want: `### gate: 1 out of 2
+
+**Completed jobs (1)**
+
+- CI / build
+
+**Incomplete jobs (1)**
+
+- CI / a|b
+
+`,
+		},
+		"renders table": {
+			opts: Options{Format: FormatTable, Emoji: true},
+			want: `### ⏳ gate: 1 out of 2
+
+| State | Job |
+| --- | --- |
+| ✅ Completed jobs | CI / build |
+| ⏳ Incomplete jobs | CI / a\|b |
+
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Markdown("gate", validators.StatePending, rp, tt.opts)
+			if got != tt.want {
+				t.Errorf("Markdown() didn't match\n  got:\n%s\n\n  want:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownError(t *testing.T) {
+	got := MarkdownError("gate", errors.New("boom"), Options{Format: FormatList, Emoji: true})
+	want := "### ❌ gate\n\n```\nboom\n```\n\n"
+	if got != want {
+		t.Errorf("MarkdownError() = %q, want %q", got, want)
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	if err := (Options{Format: FormatTable}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := (Options{Format: "html"}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want error")
+	}
+}
+
+type groupReporter struct {
+	reporter
+	groups []validators.Group
+}
+
+func (r *groupReporter) Groups() []validators.Group { return r.groups }
+
+func TestMarkdown_groups(t *testing.T) {
+	rp := &groupReporter{
+		reporter: reporter{summary: "2 out of 3"},
+		groups: []validators.Group{
+			{
+				Name:      "CI",
+				Succeeded: 1,
+				Failed:    1,
+				Checks: []validators.Decision{
+					{Check: "CI / build", Group: "CI", Verdict: validators.StateSuccess},
+					{Check: "CI / test", Group: "CI", Verdict: validators.StateFailure},
+				},
+			},
+			{
+				Name:      "Lint",
+				Succeeded: 1,
+				Checks: []validators.Decision{
+					{Check: "Lint / lint", Group: "Lint", Verdict: validators.StateSuccess},
+				},
+			},
+		},
+	}
+	tests := map[string]struct {
+		opts Options
+		want string
+	}{
+		"renders workflows as list and collapses green workflows": {
+			opts: Options{Format: FormatList, Emoji: true, Details: true},
+			want: `### ❌ gate: 2 out of 3
+
+<details><summary>❌ CI (1/2)</summary>
+
+- ✅ build
+- ❌ test
+</details>
+
+- ✅ Lint (1/1)
+
+`,
+		},
+		"renders workflows as table": {
+			opts: Options{Format: FormatTable},
+			want: `### gate: 2 out of 3
+
+| Workflow | Job | State |
+| --- | --- | --- |
+| CI | build | success |
+| CI | test | failure |
+| Lint | 1/1 | success |
+
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Markdown("gate", validators.StateFailure, rp, tt.opts)
+			if got != tt.want {
+				t.Errorf("Markdown() didn't match\n  got:\n%s\n\n  want:\n%s", got, tt.want)
+			}
+		})
+	}
+}
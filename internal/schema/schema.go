@@ -0,0 +1,53 @@
+// Package schema defines the versioned JSON outputs of Merge Gatekeeper. Producers convert their internal structs
+// into these types, so that external consumers are insulated from changes of the internal structs.
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+)
+
+// Version is the version of every JSON output, recorded in its schema_version field. Fields may be added within
+// a version, while removing, renaming or changing the meaning of fields requires a new version.
+const Version = "1"
+
+// Kinds of JSON outputs, recorded in their kind field.
+const (
+	KindTrace   = "trace"
+	KindRelease = "release-report"
+	KindSLO     = "slo-report"
+	KindAudit   = "audit"
+	KindVersion = "version"
+)
+
+//go:embed v1/*.schema.json
+var documents embed.FS
+
+// Header identifies the kind and schema version of a JSON output. It is embedded in every output.
+type Header struct {
+	Kind          string `json:"kind"`
+	SchemaVersion string `json:"schema_version"`
+}
+
+// NewHeader returns the header of the kind of JSON output in the current version.
+func NewHeader(kind string) Header {
+	return Header{Kind: kind, SchemaVersion: Version}
+}
+
+// Kinds returns every kind of JSON output, sorted by name.
+func Kinds() []string {
+	kinds := []string{KindTrace, KindRelease, KindSLO, KindAudit, KindVersion}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Document returns the JSON schema of the kind of JSON output in the current version.
+func Document(kind string) ([]byte, error) {
+	b, err := documents.ReadFile(path.Join("v"+Version, kind+".schema.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown kind of JSON output: %s, known kinds: %v", kind, Kinds())
+	}
+	return b, nil
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	tests := map[string]struct {
+		kind    string
+		typ     any
+		defs    map[string]any
+		wantErr bool
+	}{
+		"describes the trace": {
+			kind: KindTrace,
+			typ:  Trace{},
+			defs: map[string]any{"validator": Validator{}, "decision": Decision{}, "group": Group{}, "usage": Usage{}, "api": API{}, "provenance": Provenance{}},
+		},
+		"describes the release report": {
+			kind: KindRelease,
+			typ:  ReleaseReport{},
+			defs: map[string]any{"gate": ReleaseGate{}},
+		},
+		"describes the slo report": {
+			kind: KindSLO,
+			typ:  SLOReport{},
+		},
+		"describes the audit": {
+			kind: KindAudit,
+			typ:  Audit{},
+			defs: map[string]any{"violation": Violation{}},
+		},
+		"describes the version": {
+			kind: KindVersion,
+			typ:  Build{},
+		},
+		"returns error when the kind is unknown": {
+			kind:    "unknown",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := Document(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Document() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var doc struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+				Defs       map[string]struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"$defs"`
+			}
+			if err := json.Unmarshal(b, &doc); err != nil {
+				t.Fatalf("Document() is not JSON: %v", err)
+			}
+			// Every field of the types must be described by the schema, and nothing else.
+			if got, want := keys(doc.Properties), fields(reflect.TypeOf(tt.typ)); !reflect.DeepEqual(got, want) {
+				t.Errorf("properties = %v, want %v", got, want)
+			}
+			for def, typ := range tt.defs {
+				if got, want := keys(doc.Defs[def].Properties), fields(reflect.TypeOf(typ)); !reflect.DeepEqual(got, want) {
+					t.Errorf("properties of %s = %v, want %v", def, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestKinds(t *testing.T) {
+	for _, kind := range Kinds() {
+		if _, err := Document(kind); err != nil {
+			t.Errorf("Document(%s) error = %v", kind, err)
+		}
+	}
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// fields returns the JSON names of the fields of the struct, including those of embedded structs.
+func fields(typ reflect.Type) []string {
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Anonymous {
+			names = append(names, fields(f.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
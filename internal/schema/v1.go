@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+// Trace is the decision trace of a validation, either its final result or a snapshot of a poll.
+type Trace struct {
+	Header
+	Result     string      `json:"result"`
+	Validators []Validator `json:"validators"`
+	Usage      *Usage      `json:"usage,omitempty"`
+	Provenance *Provenance `json:"provenance,omitempty"`
+	Signature  string      `json:"signature,omitempty"`
+}
+
+// Provenance is where and how the gate ran, so that consumers of the signed trace can verify which policy gated the
+// commit.
+type Provenance struct {
+	Repository  string `json:"repository"`
+	SHA         string `json:"sha"`
+	PullRequest int    `json:"pull_request,omitempty"`
+	Workflow    string `json:"workflow,omitempty"`
+	RunID       string `json:"run_id,omitempty"`
+	RunAttempt  string `json:"run_attempt,omitempty"`
+	Version     string `json:"version"`
+	PolicyFile  string `json:"policy_file,omitempty"`
+	// PolicySHA is the blob SHA of the policy file read from the base branch, empty when there is no file.
+	PolicySHA string `json:"policy_sha,omitempty"`
+	// Policy is the name of the policy applied, empty when no policy matched.
+	Policy string `json:"policy,omitempty"`
+	// RequiredChecks are the jobs required by the changed paths, when only they were gated.
+	RequiredChecks []string `json:"required_checks,omitempty"`
+}
+
+// Validator is the outcome of a single validator in the trace.
+type Validator struct {
+	Name      string     `json:"name"`
+	Success   bool       `json:"success"`
+	Error     string     `json:"error,omitempty"`
+	Decisions []Decision `json:"decisions,omitempty"`
+	Groups    []Group    `json:"groups,omitempty"`
+	ETA       *time.Time `json:"eta,omitempty"`
+	Notes     []string   `json:"notes,omitempty"`
+}
+
+// Decision is how a validator classified a single check, and which rule led to it.
+type Decision struct {
+	Check   string `json:"check"`
+	Group   string `json:"group,omitempty"`
+	Rule    string `json:"rule"`
+	Verdict string `json:"verdict"`
+	State   string `json:"state,omitempty"`
+}
+
+// Group summarises the checks belonging to the same group, such as a workflow.
+type Group struct {
+	Name      string     `json:"name"`
+	Succeeded int        `json:"succeeded"`
+	Failed    int        `json:"failed"`
+	Pending   int        `json:"pending"`
+	Ignored   int        `json:"ignored"`
+	ETA       *time.Time `json:"eta,omitempty"`
+}
+
+// Usage is how much the Merge Gatekeeper job consumed while waiting for the validation to complete.
+type Usage struct {
+	WaitedSeconds float64 `json:"waited_seconds"`
+	Polls         int     `json:"polls"`
+	RunnerMinutes int     `json:"runner_minutes"`
+	API           *API    `json:"api,omitempty"`
+}
+
+// API is how much of the rate limit of the API the run consumed.
+type API struct {
+	Requests           int           `json:"requests"`
+	Endpoints          []APIEndpoint `json:"endpoints"`
+	Pages              int           `json:"pages"`
+	CacheHits          int           `json:"cache_hits"`
+	Retries            int           `json:"retries"`
+	RateLimitRemaining *int          `json:"rate_limit_remaining,omitempty"`
+}
+
+// APIEndpoint is the number of requests made to a single endpoint of the API.
+type APIEndpoint struct {
+	Endpoint string `json:"endpoint"`
+	Requests int    `json:"requests"`
+}
+
+// ReleaseReport is the readiness of a tag or release branch.
+type ReleaseReport struct {
+	Header
+	Repository  string        `json:"repository"`
+	Ref         string        `json:"ref"`
+	SHA         string        `json:"sha"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Ready       bool          `json:"ready"`
+	Gates       []ReleaseGate `json:"gates"`
+	Signature   string        `json:"signature,omitempty"`
+}
+
+// ReleaseGate is the outcome of a single gate evaluated for the release.
+type ReleaseGate struct {
+	Name   string `json:"name"`
+	Ready  bool   `json:"ready"`
+	Detail string `json:"detail"`
+}
+
+// SLOReport describes how long gates took in the window, against the objective of completing within the target.
+type SLOReport struct {
+	Header
+	Repository    string    `json:"repository"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	WindowSeconds float64   `json:"window_seconds"`
+	TargetSeconds float64   `json:"target_seconds"`
+	Objective     float64   `json:"objective"`
+	Gates         int       `json:"gates"`
+	P50Seconds    float64   `json:"p50_seconds"`
+	P95Seconds    float64   `json:"p95_seconds"`
+	Exceeded      int       `json:"exceeded"`
+	ExceededRatio float64   `json:"exceeded_ratio"`
+	BurnRate      float64   `json:"burn_rate"`
+	RunnerMinutes int       `json:"runner_minutes"`
+}
+
+// Audit is the result of auditing the recent merges.
+type Audit struct {
+	Header
+	Repository    string      `json:"repository"`
+	GeneratedAt   time.Time   `json:"generated_at"`
+	WindowSeconds float64     `json:"window_seconds"`
+	Violations    []Violation `json:"violations"`
+}
+
+// Violation is a pull request which was merged without all of its checks succeeding.
+type Violation struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	URL      string    `json:"url,omitempty"`
+	SHA      string    `json:"sha"`
+	MergedAt time.Time `json:"merged_at"`
+	Failed   []string  `json:"failed"`
+	Missing  []string  `json:"missing"`
+}
+
+// FromDecisions converts the decisions of validators.
+func FromDecisions(ds []validators.Decision) []Decision {
+	if ds == nil {
+		return nil
+	}
+	out := make([]Decision, 0, len(ds))
+	for _, d := range ds {
+		out = append(out, Decision{Check: d.Check, Group: d.Group, Rule: d.Rule, Verdict: d.Verdict, State: d.State})
+	}
+	return out
+}
+
+// FromGroups converts the groups of validators.
+func FromGroups(gs []validators.Group) []Group {
+	if gs == nil {
+		return nil
+	}
+	out := make([]Group, 0, len(gs))
+	for _, g := range gs {
+		out = append(out, Group{Name: g.Name, Succeeded: g.Succeeded, Failed: g.Failed, Pending: g.Pending, Ignored: g.Ignored, ETA: g.ETA})
+	}
+	return out
+}
+
+// Build is the version of the binary, along with how it was built.
+type Build struct {
+	Header
+	Version   string     `json:"version"`
+	Commit    string     `json:"commit,omitempty"`
+	Modified  bool       `json:"modified,omitempty"`
+	BuildDate *time.Time `json:"build_date,omitempty"`
+	GoVersion string     `json:"go_version"`
+	Platform  string     `json:"platform"`
+}
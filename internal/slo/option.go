@@ -0,0 +1,63 @@
+package slo
+
+import (
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+)
+
+type Option func(t *Tracker)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(t *Tracker) {
+		if len(owner) != 0 {
+			t.owner = owner
+		}
+		if len(repo) != 0 {
+			t.repo = repo
+		}
+	}
+}
+
+// WithSelfJob sets the name of the Merge Gatekeeper job, whose check runs measure the gates.
+func WithSelfJob(name string) Option {
+	return func(t *Tracker) {
+		if len(name) != 0 {
+			t.selfJobName = name
+		}
+	}
+}
+
+// WithWindow sets how far back merged pull requests are measured.
+func WithWindow(d time.Duration) Option {
+	return func(t *Tracker) {
+		t.window = d
+	}
+}
+
+// WithTarget sets the duration within which gates are expected to complete.
+func WithTarget(d time.Duration) Option {
+	return func(t *Tracker) {
+		if d != 0 {
+			t.target = d
+		}
+	}
+}
+
+// WithObjective sets the ratio of gates which are expected to complete within the target, e.g. 0.95.
+func WithObjective(ratio float64) Option {
+	return func(t *Tracker) {
+		if ratio != 0 {
+			t.objective = ratio
+		}
+	}
+}
+
+// WithClock sets the clock used to resolve the start of the window.
+func WithClock(c clock.Clock) Option {
+	return func(t *Tracker) {
+		if c != nil {
+			t.clock = c
+		}
+	}
+}
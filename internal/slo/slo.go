@@ -0,0 +1,387 @@
+package slo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/schema"
+)
+
+const (
+	FormatMarkdown   = "markdown"
+	FormatJSON       = "json"
+	FormatPrometheus = "prometheus"
+)
+
+// Defaults of the objective, which are used unless overridden by options.
+const (
+	DefaultTarget    = 30 * time.Minute
+	DefaultObjective = 0.95
+)
+
+// NOTE: https://docs.github.com/en/rest/checks/runs#list-check-runs-for-a-git-reference
+const checkRunFilterAll = "all"
+
+const maxItemsPerPage = 100
+
+// ValidateFormat returns error when the format is not supported by Render.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatMarkdown, FormatJSON, FormatPrometheus:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s, supported formats: [%s %s %s]", format, FormatMarkdown, FormatJSON, FormatPrometheus)
+	}
+}
+
+// Gate is the duration of the gate of a single merged pull request.
+type Gate struct {
+	Number   int
+	SHA      string
+	Duration time.Duration
+	// RunnerMinutes is the billable runner minutes consumed by the runs of the Merge Gatekeeper job.
+	RunnerMinutes int
+}
+
+// Report describes how long gates took in the window, against the objective of completing within the target.
+type Report struct {
+	Repository  string
+	GeneratedAt time.Time
+	Window      time.Duration
+	Target      time.Duration
+	Objective   float64
+	Gates       int
+	P50         time.Duration
+	P95         time.Duration
+	Exceeded    int
+	// ExceededRatio is the ratio of gates which took longer than the target.
+	ExceededRatio float64
+	// BurnRate is how fast the error budget of the objective is consumed. Above 1, the objective is missed.
+	BurnRate float64
+	// RunnerMinutes is the billable runner minutes consumed by the Merge Gatekeeper job waiting for the gates.
+	RunnerMinutes int
+}
+
+// MarshalJSON encodes the report as its versioned JSON output.
+func (r *Report) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Schema())
+}
+
+// Schema converts the report into its versioned JSON output. Durations are in seconds, so that the report can be
+// consumed by tools other than Go.
+func (r *Report) Schema() *schema.SLOReport {
+	return &schema.SLOReport{
+		Header:        schema.NewHeader(schema.KindSLO),
+		Repository:    r.Repository,
+		GeneratedAt:   r.GeneratedAt,
+		WindowSeconds: r.Window.Seconds(),
+		TargetSeconds: r.Target.Seconds(),
+		Objective:     r.Objective,
+		Gates:         r.Gates,
+		P50Seconds:    r.P50.Seconds(),
+		P95Seconds:    r.P95.Seconds(),
+		Exceeded:      r.Exceeded,
+		ExceededRatio: r.ExceededRatio,
+		BurnRate:      r.BurnRate,
+		RunnerMinutes: r.RunnerMinutes,
+	}
+}
+
+// Tracker measures the end-to-end duration of the gates of pull requests merged into the default branch,
+// from the first run of the Merge Gatekeeper job to the completion of its last run, re-runs included.
+type Tracker struct {
+	owner       string
+	repo        string
+	selfJobName string
+	window      time.Duration
+	target      time.Duration
+	objective   float64
+	clock       clock.Clock
+	client      github.Client
+}
+
+func New(c github.Client, opts ...Option) (*Tracker, error) {
+	t := &Tracker{
+		client:    c,
+		target:    DefaultTarget,
+		objective: DefaultObjective,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if err := t.validateFields(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Tracker) validateFields() error {
+	errs := make(multierror.Errors, 0, 6)
+
+	if len(t.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(t.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(t.selfJobName) == 0 {
+		errs = append(errs, errors.New("self job name is empty"))
+	}
+	if t.window <= 0 {
+		errs = append(errs, errors.New("window must be positive"))
+	}
+	if t.target <= 0 {
+		errs = append(errs, errors.New("target must be positive"))
+	}
+	if t.objective <= 0 || t.objective >= 1 {
+		errs = append(errs, errors.New("objective must be between 0 and 1 exclusive"))
+	}
+	if t.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (t *Tracker) now() time.Time {
+	if t.clock == nil {
+		return time.Now()
+	}
+	return t.clock.Now()
+}
+
+// Gates returns the durations of the gates of pull requests merged within the window. Pull requests whose gate
+// never completed, such as those merged by administrators, are not included.
+func (t *Tracker) Gates(ctx context.Context) ([]Gate, error) {
+	repository, _, err := t.client.GetRepository(ctx, t.owner, t.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	prs, err := t.listMergedPullRequests(ctx, repository.GetDefaultBranch(), t.now().Add(-t.window))
+	if err != nil {
+		return nil, err
+	}
+
+	var gates []Gate
+	for _, pr := range prs {
+		sha := pr.GetHead().GetSHA()
+		g, ok, err := t.measure(ctx, sha)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs of #%d: %w", pr.GetNumber(), err)
+		}
+		if ok {
+			g.Number = pr.GetNumber()
+			gates = append(gates, g)
+		}
+	}
+	return gates, nil
+}
+
+// Report measures the gates of the window and summarises them against the objective.
+func (t *Tracker) Report(ctx context.Context) (*Report, error) {
+	gates, err := t.Gates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.summarise(gates), nil
+}
+
+func (t *Tracker) summarise(gates []Gate) *Report {
+	r := &Report{
+		Repository:  t.owner + "/" + t.repo,
+		GeneratedAt: t.now(),
+		Window:      t.window,
+		Target:      t.target,
+		Objective:   t.objective,
+		Gates:       len(gates),
+	}
+	if len(gates) == 0 {
+		return r
+	}
+
+	durations := make([]time.Duration, 0, len(gates))
+	for _, g := range gates {
+		durations = append(durations, g.Duration)
+		r.RunnerMinutes += g.RunnerMinutes
+		if g.Duration > t.target {
+			r.Exceeded++
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	r.P50 = percentile(durations, 50)
+	r.P95 = percentile(durations, 95)
+	r.ExceededRatio = float64(r.Exceeded) / float64(len(gates))
+	r.BurnRate = r.ExceededRatio / (1 - t.objective)
+	return r
+}
+
+// percentile returns the p-th percentile of the sorted durations by the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// measure returns how long the gate of the commit took, and false when it has never completed.
+func (t *Tracker) measure(ctx context.Context, ref string) (Gate, bool, error) {
+	filter := checkRunFilterAll
+	g := Gate{SHA: ref}
+	var started, completed time.Time
+	var listed int
+	page := 1
+	for {
+		cr, _, err := t.client.ListCheckRunsForRef(ctx, t.owner, t.repo, ref, &github.ListCheckRunsOptions{
+			CheckName:   &t.selfJobName,
+			Filter:      &filter,
+			ListOptions: github.ListOptions{Page: page, PerPage: maxItemsPerPage},
+		})
+		if err != nil {
+			return Gate{}, false, err
+		}
+		listed += len(cr.CheckRuns)
+		for _, run := range cr.CheckRuns {
+			if run.GetName() != t.selfJobName || run.StartedAt == nil {
+				continue
+			}
+			if s := run.GetStartedAt().Time; started.IsZero() || s.Before(started) {
+				started = s
+			}
+			if run.CompletedAt == nil {
+				continue
+			}
+			c := run.GetCompletedAt().Time
+			if c.After(completed) {
+				completed = c
+			}
+			g.RunnerMinutes += runnerMinutes(c.Sub(run.GetStartedAt().Time))
+		}
+		if cr.GetTotal() <= listed || len(cr.CheckRuns) == 0 {
+			break
+		}
+		page++
+	}
+	if started.IsZero() || completed.IsZero() {
+		return Gate{}, false, nil
+	}
+	g.Duration = completed.Sub(started)
+	return g, true, nil
+}
+
+// runnerMinutes returns the billable runner minutes of a run, as GitHub rounds each job up to the whole minute.
+func runnerMinutes(d time.Duration) int {
+	minutes := int(d / time.Minute)
+	if d%time.Minute != 0 {
+		minutes++
+	}
+	return minutes
+}
+
+// listMergedPullRequests returns the pull requests merged into the base branch since the time.
+func (t *Tracker) listMergedPullRequests(ctx context.Context, base string, since time.Time) ([]*github.PullRequest, error) {
+	var merged []*github.PullRequest
+	page := 1
+	for {
+		prs, _, err := t.client.ListPullRequests(ctx, t.owner, t.repo, &github.PullRequestListOptions{
+			State:       "closed",
+			Base:        base,
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: github.ListOptions{Page: page, PerPage: maxItemsPerPage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+		for _, pr := range prs {
+			if pr.MergedAt != nil && !pr.GetMergedAt().Time.Before(since) {
+				merged = append(merged, pr)
+			}
+		}
+		// Pull requests are sorted by the last update, which is never before the merge.
+		if len(prs) < maxItemsPerPage || prs[len(prs)-1].GetUpdatedAt().Time.Before(since) {
+			break
+		}
+		page++
+	}
+	return merged, nil
+}
+
+// Render renders the report in the format.
+func (r *Report) Render(format string) (string, error) {
+	if err := ValidateFormat(format); err != nil {
+		return "", err
+	}
+	switch format {
+	case FormatMarkdown:
+		return r.markdown(), nil
+	case FormatPrometheus:
+		return r.prometheus(), nil
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+func (r *Report) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Merge Gatekeeper SLO: %s\n\n", r.Repository)
+	fmt.Fprintf(&b, "- Generated at: %s\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Window: %s\n", r.Window)
+	fmt.Fprintf(&b, "- Objective: %s of gates complete within %s\n\n", percent(r.Objective), r.Target)
+
+	if r.Gates == 0 {
+		b.WriteString("No gates completed in the window.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Gates | p50 | p95 | Exceeding target | Burn rate | Runner minutes |\n")
+	b.WriteString("| ----: | --: | --: | ---------------: | --------: | -------------: |\n")
+	fmt.Fprintf(&b, "| %d | %s | %s | %d (%s) | %.2f | %d |\n",
+		r.Gates, r.P50.Round(time.Second), r.P95.Round(time.Second), r.Exceeded, percent(r.ExceededRatio), r.BurnRate, r.RunnerMinutes)
+	return b.String()
+}
+
+func (r *Report) prometheus() string {
+	labels := fmt.Sprintf(`repository="%s"`, r.Repository)
+
+	var b strings.Builder
+	metric := func(name, help, typ string, value float64, extra string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, typ)
+		fmt.Fprintf(&b, "%s{%s%s} %g\n", name, labels, extra, value)
+	}
+	metric("merge_gatekeeper_gates", "Number of gates completed in the window.", "gauge", float64(r.Gates), "")
+	fmt.Fprintf(&b, "# HELP merge_gatekeeper_gate_duration_seconds Duration of gates completed in the window.\n")
+	fmt.Fprintf(&b, "# TYPE merge_gatekeeper_gate_duration_seconds summary\n")
+	fmt.Fprintf(&b, "merge_gatekeeper_gate_duration_seconds{%s,quantile=\"0.5\"} %g\n", labels, r.P50.Seconds())
+	fmt.Fprintf(&b, "merge_gatekeeper_gate_duration_seconds{%s,quantile=\"0.95\"} %g\n", labels, r.P95.Seconds())
+	metric("merge_gatekeeper_gates_exceeding_target_ratio", "Ratio of gates which took longer than the target.", "gauge", r.ExceededRatio,
+		fmt.Sprintf(`,target_seconds="%g"`, r.Target.Seconds()))
+	metric("merge_gatekeeper_slo_burn_rate", "Rate at which the error budget of the objective is consumed.", "gauge", r.BurnRate,
+		fmt.Sprintf(`,objective="%g"`, r.Objective))
+	metric("merge_gatekeeper_runner_minutes", "Runner minutes consumed by the Merge Gatekeeper job waiting for gates in the window.", "gauge",
+		float64(r.RunnerMinutes), "")
+	return b.String()
+}
+
+func percent(ratio float64) string {
+	return fmt.Sprintf("%g%%", math.Round(ratio*1000)/10)
+}
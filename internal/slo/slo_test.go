@@ -0,0 +1,215 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func timestamp(t time.Time) *github.Timestamp {
+	return &github.Timestamp{Time: t}
+}
+
+var now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func pullRequest(number int, sha string, mergedAt time.Time) *github.PullRequest {
+	return &github.PullRequest{
+		Number:    &number,
+		Head:      &github.PullRequestBranch{SHA: &sha},
+		MergedAt:  timestamp(mergedAt),
+		UpdatedAt: timestamp(mergedAt),
+	}
+}
+
+func checkRun(name string, startedAt time.Time, d time.Duration) *github.CheckRun {
+	run := &github.CheckRun{Name: &name, StartedAt: timestamp(startedAt)}
+	if d != 0 {
+		run.CompletedAt = timestamp(startedAt.Add(d))
+	}
+	return run
+}
+
+func TestTracker_Gates(t *testing.T) {
+	start := now.Add(-3 * time.Hour)
+	tests := map[string]struct {
+		prs     []*github.PullRequest
+		runs    map[string][]*github.CheckRun
+		want    []Gate
+		wantErr bool
+	}{
+		"measures from the first run to the completion of the last run": {
+			prs: []*github.PullRequest{pullRequest(1, "sha-1", now.Add(-time.Hour))},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {
+					checkRun("merge-gatekeeper", start.Add(20*time.Minute), 15*time.Minute),
+					checkRun("merge-gatekeeper", start, 10*time.Minute),
+				},
+			},
+			want: []Gate{{Number: 1, SHA: "sha-1", Duration: 35 * time.Minute, RunnerMinutes: 25}},
+		},
+		"skips gates which never completed": {
+			prs: []*github.PullRequest{
+				pullRequest(2, "sha-2", now.Add(-time.Hour)),
+				pullRequest(1, "sha-1", now.Add(-2*time.Hour)),
+			},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("merge-gatekeeper", start, 4*time.Minute+time.Second)},
+				"sha-2": {checkRun("merge-gatekeeper", start, 0)},
+			},
+			want: []Gate{{Number: 1, SHA: "sha-1", Duration: 4*time.Minute + time.Second, RunnerMinutes: 5}},
+		},
+		"skips pull requests merged before the window": {
+			prs: []*github.PullRequest{pullRequest(1, "sha-1", now.Add(-48*time.Hour))},
+			runs: map[string][]*github.CheckRun{
+				"sha-1": {checkRun("merge-gatekeeper", start, 5*time.Minute)},
+			},
+		},
+		"returns error when check runs can not be listed": {
+			prs:     []*github.PullRequest{pullRequest(1, "sha-unknown", now.Add(-time.Hour))},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetRepositoryFunc: func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+					return &github.Repository{DefaultBranch: stringPtr("main")}, nil, nil
+				},
+				ListPullRequestsFunc: func(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+					return tt.prs, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					if opts.GetCheckName() != "merge-gatekeeper" || opts.GetFilter() != "all" {
+						t.Errorf("unexpected options: %+v", opts)
+					}
+					runs, ok := tt.runs[ref]
+					if !ok {
+						return nil, nil, errors.New("not found")
+					}
+					total := len(runs)
+					return &github.ListCheckRunsResults{Total: &total, CheckRuns: runs}, nil, nil
+				},
+			}
+			tr, err := New(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithSelfJob("merge-gatekeeper"),
+				WithWindow(24*time.Hour),
+				WithClock(clockmock.NewClock(now)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := tr.Gates(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Gates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Gates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracker_summarise(t *testing.T) {
+	tr := &Tracker{owner: "owner", repo: "repo", window: 24 * time.Hour, target: 10 * time.Minute, objective: 0.75, clock: clockmock.NewClock(now)}
+
+	var gates []Gate
+	for i := 1; i <= 10; i++ {
+		gates = append(gates, Gate{Number: i, Duration: time.Duration(i*2) * time.Minute, RunnerMinutes: i})
+	}
+	got := tr.summarise(gates)
+	want := &Report{
+		Repository:    "owner/repo",
+		GeneratedAt:   now,
+		Window:        24 * time.Hour,
+		Target:        10 * time.Minute,
+		Objective:     0.75,
+		Gates:         10,
+		P50:           10 * time.Minute,
+		P95:           20 * time.Minute,
+		Exceeded:      5,
+		ExceededRatio: 0.5,
+		BurnRate:      2,
+		RunnerMinutes: 55,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summarise() = %+v, want %+v", got, want)
+	}
+
+	if got := tr.summarise(nil); got.Gates != 0 || got.BurnRate != 0 {
+		t.Errorf("summarise() = %+v, want empty report", got)
+	}
+}
+
+func TestReport_Render(t *testing.T) {
+	r := &Report{
+		Repository:    "owner/repo",
+		GeneratedAt:   now,
+		Window:        24 * time.Hour,
+		Target:        10 * time.Minute,
+		Objective:     0.95,
+		Gates:         4,
+		P50:           5 * time.Minute,
+		P95:           12 * time.Minute,
+		Exceeded:      1,
+		ExceededRatio: 0.25,
+		BurnRate:      5,
+		RunnerMinutes: 30,
+	}
+
+	got, err := r.Render(FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Merge Gatekeeper SLO: owner/repo
+
+- Generated at: 2024-01-02T00:00:00Z
+- Window: 24h0m0s
+- Objective: 95% of gates complete within 10m0s
+
+| Gates | p50 | p95 | Exceeding target | Burn rate | Runner minutes |
+| ----: | --: | --: | ---------------: | --------: | -------------: |
+| 4 | 5m0s | 12m0s | 1 (25%) | 5.00 | 30 |
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, err = r.Render(FormatPrometheus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`merge_gatekeeper_gate_duration_seconds{repository="owner/repo",quantile="0.95"} 720`,
+		`merge_gatekeeper_slo_burn_rate{repository="owner/repo",objective="0.95"} 5`,
+		`merge_gatekeeper_runner_minutes{repository="owner/repo"} 30`,
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("Render() = %s, want metrics with %s", got, line)
+		}
+	}
+
+	got, err = r.Render(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `"p95_seconds": 720`) || !strings.Contains(got, `"burn_rate": 5`) {
+		t.Errorf("Render() = %s, want JSON with the durations and burn rate", got)
+	}
+
+	if _, err := r.Render("xml"); err == nil {
+		t.Error("Render() returns no error with unsupported format")
+	}
+}
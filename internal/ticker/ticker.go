@@ -3,6 +3,8 @@ package ticker
 import (
 	"sync/atomic"
 	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
 )
 
 type InstantTicker interface {
@@ -11,15 +13,22 @@ type InstantTicker interface {
 }
 
 type instantTicker struct {
-	tic        *time.Ticker
+	clk        clock.Clock
+	tic        clock.Ticker
 	tch        chan time.Time
 	instTicked int32
 	stopped    int32
 }
 
 func NewInstantTicker(d time.Duration) InstantTicker {
+	return NewInstantTickerWithClock(clock.New(), d)
+}
+
+// NewInstantTickerWithClock returns the InstantTicker driven by the given clock.
+func NewInstantTickerWithClock(clk clock.Clock, d time.Duration) InstantTicker {
 	return &instantTicker{
-		tic: time.NewTicker(d),
+		clk: clk,
+		tic: clk.NewTicker(d),
 		tch: make(chan time.Time, 1),
 	}
 }
@@ -34,9 +43,9 @@ func (it *instantTicker) Stop() {
 func (it *instantTicker) C() <-chan time.Time {
 	if atomic.CompareAndSwapInt32(&it.instTicked, 0, 1) {
 		if atomic.LoadInt32(&it.stopped) != 1 {
-			it.tch <- time.Now()
+			it.tch <- it.clk.Now()
 			return it.tch
 		}
 	}
-	return it.tic.C
+	return it.tic.C()
 }
@@ -0,0 +1,129 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// The repository whose releases are checked.
+const (
+	Owner = "aac228"
+	Repo  = "merge-gatekeeper"
+)
+
+// changedDefaultsHeading is the heading of release notes listing the changed defaults of the release, one per item.
+const changedDefaultsHeading = "changed defaults"
+
+// Notice suggests upgrading a version which is behind the latest release.
+type Notice struct {
+	Current string
+	Latest  string
+	// ChangedDefaults are the defaults changed by the releases since the current version, oldest first, each prefixed
+	// by its release, e.g) v1.3.0: skipped-as defaults to success.
+	ChangedDefaults []string
+}
+
+// Check compares the current version with the releases, and returns the notice when the current version is behind
+// the latest release by a minor or major version. Patch releases alone are not worth a notice. Nil is returned
+// when the current version is up to date, or not a release, e.g) dev builds.
+func Check(ctx context.Context, c github.Client, current string) (*Notice, error) {
+	cur, ok := parse(current)
+	if !ok {
+		return nil, nil
+	}
+	releases, _, err := c.ListReleases(ctx, Owner, Repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	type release struct {
+		tag     string
+		version version
+		body    string
+	}
+	var newer []release
+	for _, r := range releases {
+		if r.GetDraft() || r.GetPrerelease() {
+			continue
+		}
+		v, ok := parse(r.GetTagName())
+		if !ok || !cur.less(v) {
+			continue
+		}
+		newer = append(newer, release{tag: r.GetTagName(), version: v, body: r.GetBody()})
+	}
+	if len(newer) == 0 {
+		return nil, nil
+	}
+	sort.Slice(newer, func(i, j int) bool {
+		return newer[i].version.less(newer[j].version)
+	})
+	latest := newer[len(newer)-1]
+	if latest.version[0] == cur[0] && latest.version[1] == cur[1] {
+		return nil, nil
+	}
+
+	n := &Notice{Current: current, Latest: latest.tag}
+	for _, r := range newer {
+		for _, item := range changedDefaults(r.body) {
+			n.ChangedDefaults = append(n.ChangedDefaults, r.tag+": "+item)
+		}
+	}
+	return n, nil
+}
+
+// changedDefaults returns the items listed under the changed defaults heading of the release notes in markdown.
+func changedDefaults(body string) []string {
+	var items []string
+	var within bool
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			within = strings.EqualFold(strings.TrimSpace(strings.TrimLeft(line, "#")), changedDefaultsHeading)
+			continue
+		}
+		if !within {
+			continue
+		}
+		for _, bullet := range []string{"- ", "* "} {
+			if strings.HasPrefix(line, bullet) {
+				items = append(items, strings.TrimSpace(strings.TrimPrefix(line, bullet)))
+			}
+		}
+	}
+	return items
+}
+
+// version is the major, minor and patch version of a release.
+type version [3]int
+
+// parse parses the version of a release tag, e.g) v1.2.1.
+func parse(tag string) (version, bool) {
+	var v version
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(tag), "v"), ".")
+	if len(parts) != len(v) {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func (v version) less(w version) bool {
+	for i := range v {
+		if v[i] != w[i] {
+			return v[i] < w[i]
+		}
+	}
+	return false
+}
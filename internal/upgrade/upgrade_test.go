@@ -0,0 +1,82 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func release(tag, body string) *github.RepositoryRelease {
+	return &github.RepositoryRelease{TagName: &tag, Body: &body}
+}
+
+func TestCheck(t *testing.T) {
+	prerelease := true
+	releases := []*github.RepositoryRelease{
+		release("v1.4.0", "## Changed defaults\n\n- `skipped-as` defaults to `success`\n\n## Fixes\n\n- Fix typo"),
+		{TagName: stringPtr("v2.0.0-rc.1"), Prerelease: &prerelease},
+		release("v1.3.1", "## Fixes\n\n- Fix polling"),
+		release("v1.3.0", "### Changed Defaults\n* `summary` defaults to `true`\n"),
+		release("v1.2.1", "## Changed defaults\n\n- `interval` defaults to 5"),
+	}
+	tests := map[string]struct {
+		current string
+		want    *Notice
+	}{
+		"suggests the latest release with the changed defaults since the current version": {
+			current: "v1.2.1",
+			want: &Notice{
+				Current: "v1.2.1",
+				Latest:  "v1.4.0",
+				ChangedDefaults: []string{
+					"v1.3.0: `summary` defaults to `true`",
+					"v1.4.0: `skipped-as` defaults to `success`",
+				},
+			},
+		},
+		"does not notice patch releases": {
+			current: "v1.4.0",
+		},
+		"does not notice the latest release": {
+			current: "1.4.0",
+		},
+		"does not notice dev builds": {
+			current: "dev",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListReleasesFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+					return releases, nil, nil
+				},
+			}
+			got, err := Check(context.Background(), c, tt.current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck_error(t *testing.T) {
+	c := &mock.Client{
+		ListReleasesFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+			return nil, nil, errors.New("not found")
+		},
+	}
+	if _, err := Check(context.Background(), c, "v1.2.1"); err == nil {
+		t.Error("Check() error = nil, want error")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,44 @@
+package artifact
+
+import "strings"
+
+type Option func(av *artifactValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(av *artifactValidator) {
+		if len(owner) != 0 {
+			av.owner = owner
+		}
+		if len(repo) != 0 {
+			av.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(av *artifactValidator) {
+		if len(ref) != 0 {
+			av.ref = ref
+		}
+	}
+}
+
+// WithRequiredArtifacts sets the comma-separated list of artifacts which must be uploaded. Each entry is either
+// "workflow:artifact" to require the artifact from the workflow, or "artifact" to accept it from any workflow.
+func WithRequiredArtifacts(entries string) Option {
+	return func(av *artifactValidator) {
+		var required []requirement
+		for _, s := range strings.Split(entries, ",") {
+			s = strings.TrimSpace(s)
+			if len(s) == 0 {
+				continue
+			}
+			r := requirement{artifact: s}
+			if i := strings.LastIndex(s, ":"); i >= 0 {
+				r.workflow, r.artifact = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+			}
+			required = append(required, r)
+		}
+		av.required = required
+	}
+}
@@ -0,0 +1,187 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "artifacts"
+
+const workflowRunCompletedStatus = "completed"
+
+const maxItemsPerPage = 100
+
+type requirement struct {
+	workflow string // Any workflow when empty.
+	artifact string
+}
+
+func (r requirement) String() string {
+	if len(r.workflow) == 0 {
+		return r.artifact
+	}
+	return r.workflow + ":" + r.artifact
+}
+
+type artifactValidator struct {
+	owner    string
+	repo     string
+	ref      string
+	required []requirement
+	client   github.Client
+}
+
+// CreateValidator returns the validator which requires the named artifacts to be uploaded by the workflow runs of
+// the ref. This catches builds which succeed without producing anything.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	av := &artifactValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(av)
+	}
+	if err := av.validateFields(); err != nil {
+		return nil, err
+	}
+	return av, nil
+}
+
+func (av *artifactValidator) Name() string {
+	return validatorName
+}
+
+func (av *artifactValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(av.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(av.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(av.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(av.required) == 0 {
+		errs = append(errs, errors.New("required artifacts are empty"))
+	}
+	for _, r := range av.required {
+		if len(r.artifact) == 0 {
+			errs = append(errs, fmt.Errorf("artifact name of %q is empty", r.String()))
+		}
+	}
+	if av.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// run is the latest run of a workflow for the ref, and the sizes of its artifacts keyed by name.
+type run struct {
+	completed bool
+	artifacts map[string]int64
+}
+
+func (av *artifactValidator) Validate(ctx context.Context) (validators.Status, error) {
+	runs, err := av.listRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found, pending, failed []string
+	for _, r := range av.required {
+		ok, completed := av.lookup(runs, r)
+		switch {
+		case ok:
+			found = append(found, r.String())
+		case completed:
+			// The workflow can no longer upload the artifact.
+			failed = append(failed, r.String())
+		default:
+			pending = append(pending, r.String())
+		}
+	}
+	if len(failed) != 0 {
+		return nil, fmt.Errorf("artifacts were not uploaded or are empty: %s", strings.Join(failed, ", "))
+	}
+
+	st := &validators.BasicStatus{
+		Succeeded: len(pending) == 0,
+		Message:   fmt.Sprintf("%d out of %d artifacts are uploaded", len(found), len(av.required)),
+	}
+	if len(pending) != 0 {
+		st.Message += fmt.Sprintf(", waiting for: %s", strings.Join(pending, ", "))
+	}
+	return st, nil
+}
+
+// lookup reports whether the artifact of the requirement is uploaded and not empty, and otherwise whether every
+// workflow which could upload it has completed.
+func (av *artifactValidator) lookup(runs map[string]*run, r requirement) (ok bool, completed bool) {
+	completed = true
+	var matched bool
+	for workflow, wr := range runs {
+		if len(r.workflow) != 0 && workflow != r.workflow {
+			continue
+		}
+		matched = true
+		if size, exists := wr.artifacts[r.artifact]; exists && size > 0 {
+			return true, true
+		}
+		completed = completed && wr.completed
+	}
+	// Workflows which have not started yet may still upload the artifact.
+	return false, matched && completed
+}
+
+// listRuns returns the latest run of each workflow for the ref, keyed by the workflow name.
+func (av *artifactValidator) listRuns(ctx context.Context) (map[string]*run, error) {
+	workflowRuns, _, err := av.client.ListWorkflowRuns(ctx, av.owner, av.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     av.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	runs := make(map[string]*run)
+	for _, wr := range workflowRuns.WorkflowRuns {
+		// Workflow runs are listed newest first, so re-runs take precedence.
+		if _, ok := runs[wr.GetName()]; ok {
+			continue
+		}
+		r := &run{
+			completed: wr.GetStatus() == workflowRunCompletedStatus,
+			artifacts: make(map[string]int64),
+		}
+		page := 1
+		for {
+			list, _, err := av.client.ListWorkflowRunArtifacts(ctx, av.owner, av.repo, wr.GetID(), &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list artifacts of %s: %w", wr.GetName(), err)
+			}
+			for _, a := range list.Artifacts {
+				if !a.Expired {
+					r.artifacts[a.Name] = a.SizeInBytes
+				}
+			}
+			if len(list.Artifacts) < maxItemsPerPage {
+				break
+			}
+			page++
+		}
+		runs[wr.GetName()] = r
+	}
+	return runs, nil
+}
@@ -0,0 +1,105 @@
+package artifact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func workflowRun(id int64, name, status string) *github.WorkflowRun {
+	return &github.WorkflowRun{ID: &id, Name: &name, Status: &status}
+}
+
+func TestArtifactValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		required    string
+		runs        []*github.WorkflowRun
+		artifacts   map[int64][]*github.Artifact
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds when the artifact is uploaded by the workflow": {
+			required:    "CI:binary",
+			runs:        []*github.WorkflowRun{workflowRun(1, "CI", "in_progress")},
+			artifacts:   map[int64][]*github.Artifact{1: {{Name: "binary", SizeInBytes: 10}}},
+			wantSuccess: true,
+		},
+		"succeeds when the artifact is uploaded by any workflow": {
+			required:    "binary",
+			runs:        []*github.WorkflowRun{workflowRun(1, "Lint", "completed"), workflowRun(2, "CI", "completed")},
+			artifacts:   map[int64][]*github.Artifact{2: {{Name: "binary", SizeInBytes: 10}}},
+			wantSuccess: true,
+		},
+		"uses the latest run of the workflow": {
+			required:    "CI:binary",
+			runs:        []*github.WorkflowRun{workflowRun(2, "CI", "in_progress"), workflowRun(1, "CI", "completed")},
+			artifacts:   map[int64][]*github.Artifact{1: {{Name: "binary", SizeInBytes: 10}}},
+			wantSuccess: false,
+		},
+		"is pending while the workflow is running": {
+			required:    "CI:binary",
+			runs:        []*github.WorkflowRun{workflowRun(1, "CI", "in_progress")},
+			wantSuccess: false,
+		},
+		"is pending until the workflow starts": {
+			required:    "CI:binary",
+			runs:        []*github.WorkflowRun{workflowRun(1, "Lint", "completed")},
+			wantSuccess: false,
+		},
+		"returns error when the completed workflow did not upload the artifact": {
+			required: "CI:binary",
+			runs:     []*github.WorkflowRun{workflowRun(1, "CI", "completed")},
+			wantErr:  true,
+		},
+		"returns error when the artifact is empty": {
+			required:  "CI:binary",
+			runs:      []*github.WorkflowRun{workflowRun(1, "CI", "completed")},
+			artifacts: map[int64][]*github.Artifact{1: {{Name: "binary"}}},
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					return &github.WorkflowRuns{WorkflowRuns: tt.runs}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					return &github.ArtifactList{Artifacts: tt.artifacts[runID]}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithRequiredArtifacts(tt.required))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
+
+func TestWithRequiredArtifacts(t *testing.T) {
+	av := &artifactValidator{}
+	WithRequiredArtifacts("CI:binary, image ,,Release Build:dist")(av)
+
+	want := []requirement{{workflow: "CI", artifact: "binary"}, {artifact: "image"}, {workflow: "Release Build", artifact: "dist"}}
+	if len(av.required) != len(want) {
+		t.Fatalf("required = %v, want %v", av.required, want)
+	}
+	for i := range want {
+		if av.required[i] != want[i] {
+			t.Errorf("required[%d] = %v, want %v", i, av.required[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package attestation
+
+import "strings"
+
+type Option func(av *attestationValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(av *attestationValidator) {
+		if len(owner) != 0 {
+			av.owner = owner
+		}
+		if len(repo) != 0 {
+			av.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(av *attestationValidator) {
+		if len(ref) != 0 {
+			av.ref = ref
+		}
+	}
+}
+
+// WithArtifacts sets the comma-separated list of artifacts which must be attested. Every artifact of the ref
+// must be attested when empty.
+func WithArtifacts(names string) Option {
+	return func(av *attestationValidator) {
+		av.artifacts = splitList(names)
+	}
+}
+
+// WithPredicateTypes sets the comma-separated list of accepted predicate types. SLSA provenance is accepted
+// when empty.
+func WithPredicateTypes(types string) Option {
+	return func(av *attestationValidator) {
+		if ps := splitList(types); len(ps) != 0 {
+			av.predicates = ps
+		}
+	}
+}
+
+func splitList(str string) []string {
+	var ss []string
+	for _, s := range strings.Split(str, ",") {
+		if s = strings.TrimSpace(s); len(s) != 0 {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
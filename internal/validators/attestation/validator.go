@@ -0,0 +1,214 @@
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "attestations"
+
+// Predicate types of the attestations accepted by default.
+// NOTE: https://github.com/in-toto/attestation/blob/main/spec/predicates
+const (
+	PredicateSLSAProvenance = "https://slsa.dev/provenance/v1"
+	PredicateSPDX           = "https://spdx.dev/Document/v2.3"
+	PredicateCycloneDX      = "https://cyclonedx.org/bom"
+)
+
+const maxItemsPerPage = 100
+
+type attestationValidator struct {
+	owner      string
+	repo       string
+	ref        string
+	artifacts  []string
+	predicates []string
+	client     github.Client
+}
+
+// CreateValidator returns the validator which requires every build artifact of the ref to have an attestation of
+// any of the accepted predicate types. Only the presence of the attestations is checked, as their signatures are
+// verified by consumers of the artifacts, such as with `gh attestation verify`.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	av := &attestationValidator{
+		client:     c,
+		predicates: []string{PredicateSLSAProvenance},
+	}
+	for _, opt := range opts {
+		opt(av)
+	}
+	if err := av.validateFields(); err != nil {
+		return nil, err
+	}
+	return av, nil
+}
+
+func (av *attestationValidator) Name() string {
+	return validatorName
+}
+
+func (av *attestationValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(av.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(av.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(av.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(av.predicates) == 0 {
+		errs = append(errs, errors.New("accepted predicate types are empty"))
+	}
+	if av.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (av *attestationValidator) Validate(ctx context.Context) (validators.Status, error) {
+	artifacts, err := av.listArtifacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var attested, unattested, missing []string
+	for _, name := range av.artifacts {
+		if _, ok := artifacts[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	for name, artifact := range artifacts {
+		if len(artifact.Digest) == 0 {
+			return nil, fmt.Errorf("digest of artifact %s is unavailable, upload it with actions/upload-artifact v4 or later", name)
+		}
+		ok, err := av.isAttested(ctx, artifact.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attestations of artifact %s: %w", name, err)
+		}
+		if ok {
+			attested = append(attested, name)
+		} else {
+			unattested = append(unattested, name)
+		}
+	}
+	sort.Strings(attested)
+	sort.Strings(unattested)
+
+	// Attestations are usually generated by the jobs building the artifacts, so they are pending until
+	// those jobs complete, rather than failed.
+	st := &validators.BasicStatus{
+		Succeeded: len(artifacts) != 0 && len(unattested) == 0 && len(missing) == 0,
+		Message:   fmt.Sprintf("%d out of %d artifacts are attested", len(attested), len(artifacts)),
+	}
+	if len(unattested) != 0 {
+		st.Message += fmt.Sprintf(", without attestation: %s", strings.Join(unattested, ", "))
+	}
+	if len(missing) != 0 {
+		st.Message += fmt.Sprintf(", not uploaded: %s", strings.Join(missing, ", "))
+	}
+	return st, nil
+}
+
+// listArtifacts returns the artifacts uploaded by the workflow runs of the ref, keyed by their name.
+func (av *attestationValidator) listArtifacts(ctx context.Context) (map[string]*github.Artifact, error) {
+	runs, _, err := av.client.ListWorkflowRuns(ctx, av.owner, av.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     av.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	artifacts := make(map[string]*github.Artifact)
+	for _, run := range runs.WorkflowRuns {
+		page := 1
+		for {
+			list, _, err := av.client.ListWorkflowRunArtifacts(ctx, av.owner, av.repo, run.GetID(), &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list artifacts of %s: %w", run.GetName(), err)
+			}
+			for _, a := range list.Artifacts {
+				if a.Expired || !av.isTarget(a.Name) {
+					continue
+				}
+				artifacts[a.Name] = a
+			}
+			if len(list.Artifacts) < maxItemsPerPage {
+				break
+			}
+			page++
+		}
+	}
+	return artifacts, nil
+}
+
+func (av *attestationValidator) isTarget(name string) bool {
+	if len(av.artifacts) == 0 {
+		return true
+	}
+	for _, a := range av.artifacts {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (av *attestationValidator) isAttested(ctx context.Context, digest string) (bool, error) {
+	attestations, _, err := av.client.ListAttestations(ctx, av.owner, av.repo, digest, &github.ListOptions{PerPage: maxItemsPerPage})
+	if err != nil {
+		return false, err
+	}
+	for _, a := range attestations {
+		pt, err := predicateType(a)
+		if err != nil {
+			continue // Malformed bundles can not attest anything.
+		}
+		for _, p := range av.predicates {
+			if pt == p {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// predicateType reads the predicate type of the in-toto statement in the DSSE envelope of the sigstore bundle.
+func predicateType(a *github.Attestation) (string, error) {
+	var bundle struct {
+		DSSEEnvelope struct {
+			Payload string `json:"payload"`
+		} `json:"dsseEnvelope"`
+	}
+	if err := json.Unmarshal(a.Bundle, &bundle); err != nil {
+		return "", err
+	}
+	payload, err := base64.StdEncoding.DecodeString(bundle.DSSEEnvelope.Payload)
+	if err != nil {
+		return "", err
+	}
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+	}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return "", err
+	}
+	return statement.PredicateType, nil
+}
@@ -0,0 +1,110 @@
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func bundle(predicateType string) json.RawMessage {
+	payload := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"predicateType": %q}`, predicateType)))
+	return json.RawMessage(fmt.Sprintf(`{"dsseEnvelope": {"payload": %q}}`, payload))
+}
+
+func TestAttestationValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		opts         []Option
+		artifacts    []*github.Artifact
+		attestations map[string][]*github.Attestation
+		wantSuccess  bool
+		wantErr      bool
+	}{
+		"succeeds when every artifact has provenance": {
+			artifacts: []*github.Artifact{{Name: "binary", Digest: "sha256:1"}, {Name: "image", Digest: "sha256:2"}},
+			attestations: map[string][]*github.Attestation{
+				"sha256:1": {{Bundle: bundle(PredicateSLSAProvenance)}},
+				"sha256:2": {{Bundle: bundle(PredicateSLSAProvenance)}},
+			},
+			wantSuccess: true,
+		},
+		"is pending when an artifact is not attested": {
+			artifacts: []*github.Artifact{{Name: "binary", Digest: "sha256:1"}, {Name: "image", Digest: "sha256:2"}},
+			attestations: map[string][]*github.Attestation{
+				"sha256:1": {{Bundle: bundle(PredicateSLSAProvenance)}},
+			},
+			wantSuccess: false,
+		},
+		"is pending when the attestation has another predicate type": {
+			artifacts: []*github.Artifact{{Name: "binary", Digest: "sha256:1"}},
+			attestations: map[string][]*github.Attestation{
+				"sha256:1": {{Bundle: bundle(PredicateSPDX)}, {Bundle: json.RawMessage(`{}`)}},
+			},
+			wantSuccess: false,
+		},
+		"accepts configured predicate types": {
+			opts:      []Option{WithPredicateTypes(PredicateSPDX + "," + PredicateCycloneDX)},
+			artifacts: []*github.Artifact{{Name: "binary", Digest: "sha256:1"}},
+			attestations: map[string][]*github.Attestation{
+				"sha256:1": {{Bundle: bundle(PredicateSPDX)}},
+			},
+			wantSuccess: true,
+		},
+		"checks only the given artifacts": {
+			opts:      []Option{WithArtifacts("binary")},
+			artifacts: []*github.Artifact{{Name: "binary", Digest: "sha256:1"}, {Name: "logs", Digest: "sha256:2"}},
+			attestations: map[string][]*github.Attestation{
+				"sha256:1": {{Bundle: bundle(PredicateSLSAProvenance)}},
+			},
+			wantSuccess: true,
+		},
+		"is pending when the given artifact is not uploaded yet": {
+			opts:        []Option{WithArtifacts("binary")},
+			wantSuccess: false,
+		},
+		"skips expired artifacts": {
+			artifacts:   []*github.Artifact{{Name: "binary", Digest: "sha256:1", Expired: true}},
+			wantSuccess: false,
+		},
+		"returns error when the digest is unavailable": {
+			artifacts: []*github.Artifact{{Name: "binary"}},
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					id := int64(1)
+					return &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{ID: &id}}}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					return &github.ArtifactList{TotalCount: len(tt.artifacts), Artifacts: tt.artifacts}, nil, nil
+				},
+				ListAttestationsFunc: func(ctx context.Context, owner, repo, subjectDigest string, opts *github.ListOptions) ([]*github.Attestation, *github.Response, error) {
+					return tt.attestations[subjectDigest], nil, nil
+				},
+			}
+			opts := append([]Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha")}, tt.opts...)
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package benchmark
+
+type Option func(bv *benchmarkValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(bv *benchmarkValidator) {
+		if len(owner) != 0 {
+			bv.owner = owner
+		}
+		if len(repo) != 0 {
+			bv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(bv *benchmarkValidator) {
+		if len(ref) != 0 {
+			bv.ref = ref
+		}
+	}
+}
+
+// WithArtifact sets the name of the artifact containing the benchmark results, on both the ref and the base branch.
+func WithArtifact(name string) Option {
+	return func(bv *benchmarkValidator) {
+		bv.artifact = name
+	}
+}
+
+// WithBaseBranch sets the branch whose latest successful run stores the baseline.
+func WithBaseBranch(branch string) Option {
+	return func(bv *benchmarkValidator) {
+		if len(branch) != 0 {
+			bv.baseBranch = branch
+		}
+	}
+}
+
+// WithThreshold sets how many percent worse than the baseline a benchmark may get.
+func WithThreshold(pct float64) Option {
+	return func(bv *benchmarkValidator) {
+		bv.threshold = pct
+	}
+}
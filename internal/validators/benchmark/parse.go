@@ -0,0 +1,104 @@
+package benchmark
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// result is a single metric of a benchmark, such as the ns/op of a Go benchmark.
+type result struct {
+	Name  string  `json:"name"`
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+func (r *result) key() string {
+	return r.Name + " " + r.Unit
+}
+
+// higherIsBetter reports whether the metric is a throughput, such as MB/s or ops/s, rather than a cost.
+func (r *result) higherIsBetter() bool {
+	return strings.HasSuffix(r.Unit, "/s")
+}
+
+// resultsFromArchive reads the benchmark results of every file in the zip archive of an artifact. Files ending with
+// .json are read as a list of {"name", "unit", "value"} objects, as produced by github-action-benchmark, and other
+// files as the output of `go test -bench`.
+func resultsFromArchive(archive []byte) (map[string]*result, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	results := make(map[string]*result)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		var rs []*result
+		if strings.HasSuffix(strings.ToLower(f.Name), ".json") {
+			rs, err = parseJSON(rc)
+		} else {
+			rs, err = parseGo(rc)
+		}
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		for _, r := range rs {
+			results[r.key()] = r
+		}
+	}
+	if len(results) == 0 {
+		return nil, errors.New("no benchmark results are found in the artifact")
+	}
+	return results, nil
+}
+
+func parseJSON(r io.Reader) ([]*result, error) {
+	var rs []*result
+	if err := json.NewDecoder(r).Decode(&rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// parseGo reads benchmark lines such as "BenchmarkFoo-8  1000  1234 ns/op  56 B/op  2 allocs/op".
+// The GOMAXPROCS suffix is dropped, so that results of runners with different CPUs are comparable.
+func parseGo(r io.Reader) ([]*result, error) {
+	var rs []*result
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue // Not a result line, such as a log line starting with the benchmark name.
+		}
+		name := fields[0]
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i]
+			}
+		}
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s", fields[i], name)
+			}
+			rs = append(rs, &result{Name: name, Unit: fields[i+1], Value: v})
+		}
+	}
+	return rs, sc.Err()
+}
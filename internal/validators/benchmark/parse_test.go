@@ -0,0 +1,44 @@
+package benchmark
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseGo(t *testing.T) {
+	out := `goos: linux
+goarch: amd64
+pkg: example.com/pkg
+BenchmarkEncode-8        1000000      1052 ns/op     256 B/op       3 allocs/op
+BenchmarkEncode-8 logs a line
+BenchmarkDecode/small    5000000       210.5 ns/op   95.00 MB/s
+PASS
+ok      example.com/pkg 3.201s
+`
+	got, err := parseGo(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*result{
+		{Name: "BenchmarkEncode", Unit: "ns/op", Value: 1052},
+		{Name: "BenchmarkEncode", Unit: "B/op", Value: 256},
+		{Name: "BenchmarkEncode", Unit: "allocs/op", Value: 3},
+		{Name: "BenchmarkDecode/small", Unit: "ns/op", Value: 210.5},
+		{Name: "BenchmarkDecode/small", Unit: "MB/s", Value: 95},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGo() = %v, want %v", got, want)
+	}
+}
+
+func Test_parseJSON(t *testing.T) {
+	got, err := parseJSON(strings.NewReader(`[{"name": "render", "unit": "ms", "value": 12.5}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*result{{Name: "render", Unit: "ms", Value: 12.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseJSON() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "benchmarks"
+
+const (
+	workflowRunCompletedStatus = "completed"
+	workflowRunSuccessStatus   = "success"
+)
+
+const maxItemsPerPage = 100
+
+type benchmarkValidator struct {
+	owner      string
+	repo       string
+	ref        string
+	artifact   string
+	baseBranch string
+	threshold  float64 // Allowed regression in percent.
+	client     github.Client
+
+	baseline map[string]*result // Cached, as the base branch does not change while validating.
+}
+
+// CreateValidator returns the validator which compares the benchmark results uploaded as an artifact by the workflow
+// runs of the ref with the results of the latest successful run on the base branch, and fails when any benchmark
+// regressed beyond the threshold.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	bv := &benchmarkValidator{
+		client:    c,
+		threshold: 10,
+	}
+	for _, opt := range opts {
+		opt(bv)
+	}
+	if err := bv.validateFields(); err != nil {
+		return nil, err
+	}
+	return bv, nil
+}
+
+func (bv *benchmarkValidator) Name() string {
+	return validatorName
+}
+
+func (bv *benchmarkValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 6)
+
+	if len(bv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(bv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(bv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(bv.artifact) == 0 {
+		errs = append(errs, errors.New("benchmark artifact name is empty"))
+	}
+	if len(bv.baseBranch) == 0 {
+		errs = append(errs, errors.New("base branch of benchmarks is empty"))
+	}
+	if bv.threshold < 0 {
+		errs = append(errs, fmt.Errorf("regression threshold must not be negative, got %v", bv.threshold))
+	}
+	if bv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (bv *benchmarkValidator) Validate(ctx context.Context) (validators.Status, error) {
+	runs, _, err := bv.client.ListWorkflowRuns(ctx, bv.owner, bv.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     bv.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	a, completed, err := bv.findArtifact(ctx, runs.WorkflowRuns)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		if completed && len(runs.WorkflowRuns) != 0 {
+			return nil, fmt.Errorf("benchmark artifact %s was not uploaded", bv.artifact)
+		}
+		return &validators.BasicStatus{Message: fmt.Sprintf("waiting for benchmark artifact %s", bv.artifact)}, nil
+	}
+	current, err := bv.read(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline, err := bv.baselineResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseline == nil {
+		return &validators.BasicStatus{Succeeded: true, Message: fmt.Sprintf("no baseline on %s, %d benchmarks are not compared", bv.baseBranch, len(current))}, nil
+	}
+
+	var compared int
+	var regressions []string
+	for key, cur := range current {
+		base, ok := baseline[key]
+		if !ok || base.Value == 0 {
+			continue
+		}
+		compared++
+		change := (cur.Value - base.Value) / base.Value * 100
+		if cur.higherIsBetter() {
+			change = -change
+		}
+		if change > bv.threshold {
+			regressions = append(regressions, fmt.Sprintf("- %s: %g → %g %s (%.1f%% worse)", cur.Name, base.Value, cur.Value, cur.Unit, change))
+		}
+	}
+	if len(regressions) != 0 {
+		sort.Strings(regressions)
+		return nil, fmt.Errorf("%d out of %d benchmarks regressed more than %.1f%% from %s:\n%s",
+			len(regressions), compared, bv.threshold, bv.baseBranch, strings.Join(regressions, "\n"))
+	}
+	return &validators.BasicStatus{
+		Succeeded: true,
+		Message:   fmt.Sprintf("%d benchmarks are within %.1f%% of %s", compared, bv.threshold, bv.baseBranch),
+	}, nil
+}
+
+// findArtifact returns the benchmark artifact from the latest of the runs uploading it, and otherwise whether
+// every run has completed.
+func (bv *benchmarkValidator) findArtifact(ctx context.Context, runs []*github.WorkflowRun) (*github.Artifact, bool, error) {
+	completed := true
+	for _, run := range runs {
+		list, _, err := bv.client.ListWorkflowRunArtifacts(ctx, bv.owner, bv.repo, run.GetID(), &github.ListOptions{PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list artifacts of %s: %w", run.GetName(), err)
+		}
+		for _, a := range list.Artifacts {
+			if a.Name == bv.artifact && !a.Expired {
+				return a, true, nil
+			}
+		}
+		completed = completed && run.GetStatus() == workflowRunCompletedStatus
+	}
+	return nil, completed, nil
+}
+
+func (bv *benchmarkValidator) read(ctx context.Context, a *github.Artifact) (map[string]*result, error) {
+	archive, _, err := bv.client.DownloadArtifact(ctx, bv.owner, bv.repo, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download benchmark artifact %s: %w", a.Name, err)
+	}
+	return resultsFromArchive(archive)
+}
+
+// baselineResults reads the results of the latest successful run on the base branch which uploaded the artifact.
+// It returns nil when there is no such run, such as before the baseline is first stored.
+func (bv *benchmarkValidator) baselineResults(ctx context.Context) (map[string]*result, error) {
+	if bv.baseline != nil {
+		return bv.baseline, nil
+	}
+	runs, _, err := bv.client.ListWorkflowRuns(ctx, bv.owner, bv.repo, &github.ListWorkflowRunsOptions{
+		Branch:      bv.baseBranch,
+		Status:      workflowRunSuccessStatus,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs of %s: %w", bv.baseBranch, err)
+	}
+	a, _, err := bv.findArtifact(ctx, runs.WorkflowRuns)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	baseline, err := bv.read(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	bv.baseline = baseline
+	return bv.baseline, nil
+}
@@ -0,0 +1,98 @@
+package benchmark
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func archive(t *testing.T, output string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("bench.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(output)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestBenchmarkValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		current     string
+		baseline    string
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds within the threshold": {
+			current:     "BenchmarkA-8 100 105 ns/op\nBenchmarkB-8 100 95 MB/s\n",
+			baseline:    "BenchmarkA-4 100 100 ns/op\nBenchmarkB-4 100 100 MB/s\n",
+			wantSuccess: true,
+		},
+		"returns error when cost regressed": {
+			current:  "BenchmarkA-8 100 120 ns/op\n",
+			baseline: "BenchmarkA-8 100 100 ns/op\n",
+			wantErr:  true,
+		},
+		"returns error when throughput regressed": {
+			current:  "BenchmarkB-8 100 80 MB/s\n",
+			baseline: "BenchmarkB-8 100 100 MB/s\n",
+			wantErr:  true,
+		},
+		"succeeds without baseline": {
+			current:     "BenchmarkA-8 100 120 ns/op\n",
+			wantSuccess: true,
+		},
+		"is pending until the artifact is uploaded": {
+			wantSuccess: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			outputs := map[int64]string{1: tt.current, 2: tt.baseline}
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					id, status := int64(1), "in_progress"
+					if opts.Branch == "main" {
+						id, status = 2, "completed"
+					}
+					return &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{ID: &id, Status: &status}}}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					if len(outputs[runID]) == 0 {
+						return &github.ArtifactList{}, nil, nil
+					}
+					return &github.ArtifactList{Artifacts: []*github.Artifact{{ID: runID, Name: "bench"}}}, nil, nil
+				},
+				DownloadArtifactFunc: func(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+					return archive(t, outputs[artifactID]), nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithArtifact("bench"), WithBaseBranch("main"), WithThreshold(10))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
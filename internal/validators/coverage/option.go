@@ -0,0 +1,54 @@
+package coverage
+
+type Option func(cv *coverageValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(cv *coverageValidator) {
+		if len(owner) != 0 {
+			cv.owner = owner
+		}
+		if len(repo) != 0 {
+			cv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(cv *coverageValidator) {
+		if len(ref) != 0 {
+			cv.ref = ref
+		}
+	}
+}
+
+// WithArtifact sets the name of the artifact containing the coverage summary.
+func WithArtifact(name string) Option {
+	return func(cv *coverageValidator) {
+		cv.artifact = name
+	}
+}
+
+// WithFile sets the path of the coverage summary in the artifact, and its format. The first file in a known format
+// is used when the path is empty, and the format is guessed from the file name when empty.
+func WithFile(path, format string) Option {
+	return func(cv *coverageValidator) {
+		cv.file = path
+		cv.format = format
+	}
+}
+
+// WithMinimum sets the minimum line coverage in percent.
+func WithMinimum(pct float64) Option {
+	return func(cv *coverageValidator) {
+		cv.min = pct
+	}
+}
+
+// WithBaseline enables comparing the coverage with the base branch, allowing it to decrease by at most maxDecrease
+// percentage points.
+func WithBaseline(branch string, maxDecrease float64) Option {
+	return func(cv *coverageValidator) {
+		cv.baseBranch = branch
+		cv.maxDecrease = maxDecrease
+	}
+}
@@ -0,0 +1,161 @@
+package coverage
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Formats of coverage summaries.
+const (
+	FormatLCOV      = "lcov"
+	FormatCobertura = "cobertura"
+	FormatJSON      = "json"
+)
+
+// formatOf guesses the format of the file from its name. It returns empty for unknown files.
+func formatOf(name string) string {
+	base := strings.ToLower(path.Base(name))
+	switch {
+	case strings.HasSuffix(base, ".info"), strings.HasSuffix(base, ".lcov"), base == "lcov":
+		return FormatLCOV
+	case strings.HasSuffix(base, ".xml"):
+		return FormatCobertura
+	case strings.HasSuffix(base, ".json"):
+		return FormatJSON
+	default:
+		return ""
+	}
+}
+
+// fromArchive reads the line coverage in percent from the zip archive of an artifact. The file is the first one
+// in a known format when its name is empty, and the format is guessed from the file name when empty.
+func fromArchive(archive []byte, file, format string) (float64, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if len(file) != 0 && f.Name != file {
+			continue
+		}
+		ff := format
+		if len(ff) == 0 {
+			ff = formatOf(f.Name)
+		}
+		if len(ff) == 0 {
+			if len(file) != 0 {
+				return 0, fmt.Errorf("format of %s is unknown", f.Name)
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return 0, err
+		}
+		defer rc.Close()
+		pct, err := parse(rc, ff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		return pct, nil
+	}
+	if len(file) != 0 {
+		return 0, fmt.Errorf("%s is not found in the artifact", file)
+	}
+	return 0, errors.New("no coverage summary is found in the artifact")
+}
+
+func parse(r io.Reader, format string) (float64, error) {
+	switch format {
+	case FormatLCOV:
+		return parseLCOV(r)
+	case FormatCobertura:
+		return parseCobertura(r)
+	case FormatJSON:
+		return parseJSON(r)
+	default:
+		return 0, fmt.Errorf("unsupported format: %s, supported formats: [%s %s %s]", format, FormatLCOV, FormatCobertura, FormatJSON)
+	}
+}
+
+// parseLCOV sums the found and hit lines of every source file.
+func parseLCOV(r io.Reader) (float64, error) {
+	var found, hit int
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		var dst *int
+		switch {
+		case strings.HasPrefix(line, "LF:"):
+			dst = &found
+		case strings.HasPrefix(line, "LH:"):
+			dst = &hit
+		default:
+			continue
+		}
+		n, err := strconv.Atoi(line[3:])
+		if err != nil {
+			return 0, fmt.Errorf("invalid line %q: %w", line, err)
+		}
+		*dst += n
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	if found == 0 {
+		return 0, errors.New("no lines are found")
+	}
+	return float64(hit) / float64(found) * 100, nil
+}
+
+// parseCobertura reads the line rate of the root element.
+func parseCobertura(r io.Reader) (float64, error) {
+	var report struct {
+		XMLName  xml.Name `xml:"coverage"`
+		LineRate *float64 `xml:"line-rate,attr"`
+	}
+	if err := xml.NewDecoder(r).Decode(&report); err != nil {
+		return 0, err
+	}
+	if report.LineRate == nil {
+		return 0, errors.New("line-rate is not found")
+	}
+	return *report.LineRate * 100, nil
+}
+
+// parseJSON reads the line coverage of the istanbul json-summary format ({"total": {"lines": {"pct": 80}}}),
+// or the plain {"coverage": 80} format.
+func parseJSON(r io.Reader) (float64, error) {
+	var summary struct {
+		Coverage *float64 `json:"coverage"`
+		Total    *struct {
+			Lines *struct {
+				Pct *float64 `json:"pct"`
+			} `json:"lines"`
+		} `json:"total"`
+	}
+	if err := json.NewDecoder(r).Decode(&summary); err != nil {
+		return 0, err
+	}
+	switch {
+	case summary.Coverage != nil:
+		return *summary.Coverage, nil
+	case summary.Total != nil && summary.Total.Lines != nil && summary.Total.Lines.Pct != nil:
+		return *summary.Total.Lines.Pct, nil
+	default:
+		return 0, errors.New("neither coverage nor total.lines.pct is found")
+	}
+}
@@ -0,0 +1,124 @@
+package coverage
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func archive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func Test_parse(t *testing.T) {
+	tests := map[string]struct {
+		format  string
+		content string
+		want    float64
+		wantErr bool
+	}{
+		"sums lines of lcov": {
+			format:  FormatLCOV,
+			content: "SF:a.go\nLF:10\nLH:5\nend_of_record\nSF:b.go\nLF:30\nLH:25\nend_of_record\n",
+			want:    75,
+		},
+		"returns error for lcov without lines": {
+			format:  FormatLCOV,
+			content: "SF:a.go\nend_of_record\n",
+			wantErr: true,
+		},
+		"reads line rate of cobertura": {
+			format:  FormatCobertura,
+			content: `<?xml version="1.0" ?><coverage line-rate="0.825" branch-rate="0.5"><packages/></coverage>`,
+			want:    82.5,
+		},
+		"returns error for cobertura without line rate": {
+			format:  FormatCobertura,
+			content: `<coverage></coverage>`,
+			wantErr: true,
+		},
+		"reads istanbul json summary": {
+			format:  FormatJSON,
+			content: `{"total": {"lines": {"total": 10, "covered": 9, "pct": 90}}}`,
+			want:    90,
+		},
+		"reads plain json": {
+			format:  FormatJSON,
+			content: `{"coverage": 61.5}`,
+			want:    61.5,
+		},
+		"returns error for unknown json": {
+			format:  FormatJSON,
+			content: `{}`,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parse(strings.NewReader(tt.content), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fromArchive(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		file    string
+		format  string
+		want    float64
+		wantErr bool
+	}{
+		"guesses the format from the file name": {
+			files: map[string]string{"README.txt": "", "coverage/lcov.info": "LF:4\nLH:1\n"},
+			want:  25,
+		},
+		"reads the given file in the given format": {
+			files:  map[string]string{"summary.txt": `{"coverage": 50}`, "lcov.info": "LF:4\nLH:1\n"},
+			file:   "summary.txt",
+			format: FormatJSON,
+			want:   50,
+		},
+		"returns error when the given file is missing": {
+			files:   map[string]string{"lcov.info": "LF:4\nLH:1\n"},
+			file:    "coverage.xml",
+			wantErr: true,
+		},
+		"returns error without coverage summary": {
+			files:   map[string]string{"README.txt": ""},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := fromArchive(archive(t, tt.files), tt.file, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fromArchive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("fromArchive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
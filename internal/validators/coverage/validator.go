@@ -0,0 +1,191 @@
+package coverage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "coverage"
+
+const (
+	workflowRunCompletedStatus = "completed"
+	workflowRunSuccessStatus   = "success"
+)
+
+const maxItemsPerPage = 100
+
+type coverageValidator struct {
+	owner       string
+	repo        string
+	ref         string
+	artifact    string
+	file        string
+	format      string
+	min         float64
+	baseBranch  string
+	maxDecrease float64
+	client      github.Client
+
+	baseline *float64 // Cached, as the base branch does not change while validating.
+}
+
+// CreateValidator returns the validator which requires the coverage summary uploaded as an artifact by the workflow
+// runs of the ref to meet the thresholds. When the base branch is given, the coverage must also not decrease from the
+// latest successful run on the base branch by more than the allowed percentage points.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	cv := &coverageValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(cv)
+	}
+	if err := cv.validateFields(); err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
+func (cv *coverageValidator) Name() string {
+	return validatorName
+}
+
+func (cv *coverageValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 7)
+
+	if len(cv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(cv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(cv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(cv.artifact) == 0 {
+		errs = append(errs, errors.New("coverage artifact name is empty"))
+	}
+	switch cv.format {
+	case "", FormatLCOV, FormatCobertura, FormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported format: %s, supported formats: [%s %s %s]", cv.format, FormatLCOV, FormatCobertura, FormatJSON))
+	}
+	if cv.min < 0 || cv.min > 100 {
+		errs = append(errs, fmt.Errorf("minimum coverage must be between 0 and 100, got %v", cv.min))
+	}
+	if cv.maxDecrease < 0 {
+		errs = append(errs, fmt.Errorf("maximum coverage decrease must not be negative, got %v", cv.maxDecrease))
+	}
+	if cv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (cv *coverageValidator) Validate(ctx context.Context) (validators.Status, error) {
+	runs, _, err := cv.client.ListWorkflowRuns(ctx, cv.owner, cv.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     cv.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	a, completed, err := cv.findArtifact(ctx, runs.WorkflowRuns)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		if completed && len(runs.WorkflowRuns) != 0 {
+			return nil, fmt.Errorf("coverage artifact %s was not uploaded", cv.artifact)
+		}
+		return &validators.BasicStatus{Message: fmt.Sprintf("waiting for coverage artifact %s", cv.artifact)}, nil
+	}
+
+	pct, err := cv.read(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	if pct < cv.min {
+		return nil, fmt.Errorf("coverage %.2f%% is below the minimum %.2f%%", pct, cv.min)
+	}
+	msg := fmt.Sprintf("coverage %.2f%% meets the minimum %.2f%%", pct, cv.min)
+
+	if len(cv.baseBranch) != 0 {
+		base, err := cv.baselineCoverage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if base != nil {
+			delta := pct - *base
+			if -delta > cv.maxDecrease {
+				return nil, fmt.Errorf("coverage decreased by %.2f%% from %.2f%% on %s, more than the allowed %.2f%%", -delta, *base, cv.baseBranch, cv.maxDecrease)
+			}
+			msg += fmt.Sprintf(", %+.2f%% from %s", delta, cv.baseBranch)
+		} else {
+			msg += fmt.Sprintf(", no baseline on %s", cv.baseBranch)
+		}
+	}
+	return &validators.BasicStatus{Succeeded: true, Message: msg}, nil
+}
+
+// findArtifact returns the coverage artifact from the latest of the runs uploading it, and otherwise whether
+// every run has completed.
+func (cv *coverageValidator) findArtifact(ctx context.Context, runs []*github.WorkflowRun) (*github.Artifact, bool, error) {
+	completed := true
+	for _, run := range runs {
+		list, _, err := cv.client.ListWorkflowRunArtifacts(ctx, cv.owner, cv.repo, run.GetID(), &github.ListOptions{PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list artifacts of %s: %w", run.GetName(), err)
+		}
+		for _, a := range list.Artifacts {
+			if a.Name == cv.artifact && !a.Expired {
+				return a, true, nil
+			}
+		}
+		completed = completed && run.GetStatus() == workflowRunCompletedStatus
+	}
+	return nil, completed, nil
+}
+
+func (cv *coverageValidator) read(ctx context.Context, a *github.Artifact) (float64, error) {
+	archive, _, err := cv.client.DownloadArtifact(ctx, cv.owner, cv.repo, a.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download coverage artifact %s: %w", a.Name, err)
+	}
+	return fromArchive(archive, cv.file, cv.format)
+}
+
+// baselineCoverage reads the coverage of the latest successful run on the base branch which uploaded the artifact.
+// It returns nil when there is no such run, such as for the first run of the gate.
+func (cv *coverageValidator) baselineCoverage(ctx context.Context) (*float64, error) {
+	if cv.baseline != nil {
+		return cv.baseline, nil
+	}
+	runs, _, err := cv.client.ListWorkflowRuns(ctx, cv.owner, cv.repo, &github.ListWorkflowRunsOptions{
+		Branch:      cv.baseBranch,
+		Status:      workflowRunSuccessStatus,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs of %s: %w", cv.baseBranch, err)
+	}
+	a, _, err := cv.findArtifact(ctx, runs.WorkflowRuns)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	pct, err := cv.read(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	cv.baseline = &pct
+	return cv.baseline, nil
+}
@@ -0,0 +1,103 @@
+package coverage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func workflowRun(id int64, status string) *github.WorkflowRun {
+	name := "CI"
+	return &github.WorkflowRun{ID: &id, Name: &name, Status: &status}
+}
+
+func TestCoverageValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		opts        []Option
+		runs        []*github.WorkflowRun
+		baseRuns    []*github.WorkflowRun
+		coverage    map[int64]string // lcov.info content of the coverage artifact of each run.
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds when coverage meets the minimum": {
+			opts:        []Option{WithMinimum(80)},
+			runs:        []*github.WorkflowRun{workflowRun(1, "completed")},
+			coverage:    map[int64]string{1: "LF:10\nLH:9\n"},
+			wantSuccess: true,
+		},
+		"returns error when coverage is below the minimum": {
+			opts:     []Option{WithMinimum(80)},
+			runs:     []*github.WorkflowRun{workflowRun(1, "completed")},
+			coverage: map[int64]string{1: "LF:10\nLH:7\n"},
+			wantErr:  true,
+		},
+		"is pending until the artifact is uploaded": {
+			runs:        []*github.WorkflowRun{workflowRun(1, "in_progress")},
+			wantSuccess: false,
+		},
+		"returns error when the completed runs did not upload the artifact": {
+			runs:    []*github.WorkflowRun{workflowRun(1, "completed")},
+			wantErr: true,
+		},
+		"succeeds when coverage decreases within the allowance": {
+			opts:        []Option{WithBaseline("main", 1)},
+			runs:        []*github.WorkflowRun{workflowRun(1, "completed")},
+			baseRuns:    []*github.WorkflowRun{workflowRun(2, "completed")},
+			coverage:    map[int64]string{1: "LF:100\nLH:80\n", 2: "LF:100\nLH:81\n"},
+			wantSuccess: true,
+		},
+		"returns error when coverage decreases more than the allowance": {
+			opts:     []Option{WithBaseline("main", 1)},
+			runs:     []*github.WorkflowRun{workflowRun(1, "completed")},
+			baseRuns: []*github.WorkflowRun{workflowRun(2, "completed")},
+			coverage: map[int64]string{1: "LF:100\nLH:80\n", 2: "LF:100\nLH:85\n"},
+			wantErr:  true,
+		},
+		"succeeds without baseline": {
+			opts:        []Option{WithBaseline("main", 0)},
+			runs:        []*github.WorkflowRun{workflowRun(1, "completed")},
+			coverage:    map[int64]string{1: "LF:100\nLH:80\n"},
+			wantSuccess: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					if opts.Branch == "main" {
+						return &github.WorkflowRuns{WorkflowRuns: tt.baseRuns}, nil, nil
+					}
+					return &github.WorkflowRuns{WorkflowRuns: tt.runs}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					if _, ok := tt.coverage[runID]; !ok {
+						return &github.ArtifactList{}, nil, nil
+					}
+					return &github.ArtifactList{Artifacts: []*github.Artifact{{ID: runID, Name: "coverage"}}}, nil, nil
+				},
+				DownloadArtifactFunc: func(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+					return archive(t, map[string]string{"lcov.info": tt.coverage[artifactID]}), nil, nil
+				},
+			}
+			opts := append([]Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithArtifact("coverage")}, tt.opts...)
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package deployment
+
+import "strings"
+
+type Option func(dv *deploymentValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(dv *deploymentValidator) {
+		if len(owner) != 0 {
+			dv.owner = owner
+		}
+		if len(repo) != 0 {
+			dv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(dv *deploymentValidator) {
+		if len(ref) != 0 {
+			dv.ref = ref
+		}
+	}
+}
+
+// WithEnvironments sets the comma-separated list of environments which must have been deployed successfully.
+func WithEnvironments(names string) Option {
+	return func(dv *deploymentValidator) {
+		var envs []string
+		for _, s := range strings.Split(names, ",") {
+			if env := strings.TrimSpace(s); len(env) != 0 {
+				envs = append(envs, env)
+			}
+		}
+		dv.environments = envs
+	}
+}
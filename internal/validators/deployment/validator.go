@@ -0,0 +1,141 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "deployments"
+
+// NOTE: https://docs.github.com/en/rest/deployments/statuses
+const (
+	successState  = "success"
+	failureState  = "failure"
+	errorState    = "error"
+	inactiveState = "inactive"
+)
+
+const maxDeploymentsPerPage = 100
+
+type deploymentValidator struct {
+	owner        string
+	repo         string
+	ref          string
+	environments []string
+	client       github.Client
+}
+
+// CreateValidator returns the validator which requires the commit to be deployed successfully. When no environment
+// is given, every environment the commit was deployed to must have succeeded.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	dv := &deploymentValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(dv)
+	}
+	if err := dv.validateFields(); err != nil {
+		return nil, err
+	}
+	return dv, nil
+}
+
+func (dv *deploymentValidator) Name() string {
+	return validatorName
+}
+
+func (dv *deploymentValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(dv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(dv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(dv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if dv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (dv *deploymentValidator) Validate(ctx context.Context) (validators.Status, error) {
+	deployments, _, err := dv.client.ListDeployments(ctx, dv.owner, dv.repo, &github.DeploymentsListOptions{
+		SHA:         dv.ref,
+		ListOptions: github.ListOptions{PerPage: maxDeploymentsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	// Deployments are listed newest first, so only the latest deployment of each environment is considered.
+	states := make(map[string]string)
+	for _, d := range deployments {
+		env := d.GetEnvironment()
+		if _, ok := states[env]; ok {
+			continue
+		}
+		statuses, _, err := dv.client.ListDeploymentStatuses(ctx, dv.owner, dv.repo, d.GetID(), &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list statuses of deployment to %s: %w", env, err)
+		}
+		if len(statuses) == 0 {
+			states[env] = ""
+			continue
+		}
+		states[env] = statuses[0].GetState()
+	}
+
+	environments := dv.environments
+	if len(environments) == 0 {
+		for env := range states {
+			environments = append(environments, env)
+		}
+		sort.Strings(environments)
+	}
+	if len(environments) == 0 {
+		return &validators.BasicStatus{Message: fmt.Sprintf("%s has not been deployed", dv.ref)}, nil
+	}
+
+	var failed, pending, succeeded []string
+	for _, env := range environments {
+		state, ok := states[env]
+		switch {
+		case !ok:
+			pending = append(pending, env+" (not deployed)")
+		case state == successState:
+			succeeded = append(succeeded, env)
+		case state == failureState, state == errorState, state == inactiveState:
+			failed = append(failed, fmt.Sprintf("%s (%s)", env, state))
+		default:
+			pending = append(pending, env)
+		}
+	}
+	if len(failed) != 0 {
+		return nil, fmt.Errorf("deployments failed: %s", strings.Join(failed, ", "))
+	}
+
+	st := &validators.BasicStatus{
+		Succeeded: len(pending) == 0,
+		Message:   fmt.Sprintf("%d out of %d deployments succeeded", len(succeeded), len(environments)),
+	}
+	if len(pending) != 0 {
+		st.Message += fmt.Sprintf(", pending: %s", strings.Join(pending, ", "))
+	}
+	return st, nil
+}
@@ -0,0 +1,88 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func deployment(id int64, env string) *github.Deployment {
+	return &github.Deployment{ID: &id, Environment: &env}
+}
+
+func TestDeploymentValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		environments string
+		deployments  []*github.Deployment
+		states       map[int64]string
+		wantSuccess  bool
+		wantErr      bool
+	}{
+		"succeeds when every deployed environment succeeded": {
+			deployments: []*github.Deployment{deployment(2, "production"), deployment(1, "staging")},
+			states:      map[int64]string{1: "success", 2: "success"},
+			wantSuccess: true,
+		},
+		"considers only the latest deployment of each environment": {
+			deployments: []*github.Deployment{deployment(2, "production"), deployment(1, "production")},
+			states:      map[int64]string{1: "failure", 2: "success"},
+			wantSuccess: true,
+		},
+		"is pending when a required environment is not deployed": {
+			environments: "staging,production",
+			deployments:  []*github.Deployment{deployment(1, "staging")},
+			states:       map[int64]string{1: "success"},
+			wantSuccess:  false,
+		},
+		"is pending while deploying": {
+			deployments: []*github.Deployment{deployment(1, "staging")},
+			states:      map[int64]string{1: "in_progress"},
+			wantSuccess: false,
+		},
+		"is pending when nothing is deployed": {
+			wantSuccess: false,
+		},
+		"returns error when a deployment failed": {
+			deployments: []*github.Deployment{deployment(1, "staging")},
+			states:      map[int64]string{1: "failure"},
+			wantErr:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListDeploymentsFunc: func(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error) {
+					if opts.SHA != "sha" {
+						t.Errorf("sha = %s, want sha", opts.SHA)
+					}
+					return tt.deployments, nil, nil
+				},
+				ListDeploymentStatusesFunc: func(ctx context.Context, owner, repo string, id int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error) {
+					state, ok := tt.states[id]
+					if !ok {
+						return nil, nil, errors.New("not found")
+					}
+					return []*github.DeploymentStatus{{State: &state}}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithEnvironments(tt.environments))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package featureflag
+
+import (
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/flagservice"
+)
+
+type Option func(fv *featureFlagValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(fv *featureFlagValidator) {
+		if len(owner) != 0 {
+			fv.owner = owner
+		}
+		if len(repo) != 0 {
+			fv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(fv *featureFlagValidator) {
+		if len(ref) != 0 {
+			fv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to inspect, instead of looking it up by the ref.
+func WithPullRequest(number int) Option {
+	return func(fv *featureFlagValidator) {
+		if number != 0 {
+			fv.prNumber = number
+		}
+	}
+}
+
+// WithService sets the service looking up the referenced flags.
+func WithService(s flagservice.Service) Option {
+	return func(fv *featureFlagValidator) {
+		fv.service = s
+	}
+}
+
+// WithPattern sets the regular expression matching flag references, whose first group captures the flag key.
+func WithPattern(pattern string) Option {
+	return func(fv *featureFlagValidator) {
+		if len(pattern) != 0 {
+			fv.pattern = pattern
+		}
+	}
+}
+
+// WithAllowedStates sets the states referenced flags may be in (comma-separated list).
+func WithAllowedStates(states string) Option {
+	return func(fv *featureFlagValidator) {
+		if len(states) == 0 {
+			return
+		}
+		fv.allowedStates = make(map[string]bool)
+		for _, s := range strings.Split(states, ",") {
+			if s = strings.ToLower(strings.TrimSpace(s)); len(s) != 0 {
+				fv.allowedStates[s] = true
+			}
+		}
+	}
+}
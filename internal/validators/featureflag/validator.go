@@ -0,0 +1,191 @@
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/flagservice"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "feature-flags"
+
+// DefaultPattern matches the flag evaluations of the LaunchDarkly and Unleash SDKs, e.g) client.BoolVariation("key", ...)
+// and unleash.isEnabled('key').
+const DefaultPattern = `(?:[Vv]ariation|[Ii]s_?[Ee]nabled)\(\s*["'\x60]([\w.-]+)["'\x60]`
+
+// DefaultAllowedStates allows flags which exist and are not archived.
+const DefaultAllowedStates = flagservice.StateOn + "," + flagservice.StateOff
+
+const maxFilesPerPage = 100
+
+type featureFlagValidator struct {
+	owner         string
+	repo          string
+	ref           string
+	prNumber      int
+	pattern       string
+	allowedStates map[string]bool
+	service       flagservice.Service
+	client        github.Client
+
+	re *regexp.Regexp
+}
+
+// CreateValidator returns the validator which requires the feature flags referenced by the lines added in the
+// pull request to exist in the flag service, and to be in one of the allowed states.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	fv := &featureFlagValidator{
+		client:  c,
+		pattern: DefaultPattern,
+	}
+	WithAllowedStates(DefaultAllowedStates)(fv)
+	for _, opt := range opts {
+		opt(fv)
+	}
+	if err := fv.validateFields(); err != nil {
+		return nil, err
+	}
+	return fv, nil
+}
+
+func (fv *featureFlagValidator) Name() string {
+	return validatorName
+}
+
+func (fv *featureFlagValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 6)
+
+	if len(fv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(fv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(fv.ref) == 0 && fv.prNumber == 0 {
+		errs = append(errs, errors.New("reference of repository and pull request number are empty"))
+	}
+	if re, err := regexp.Compile(fv.pattern); err != nil {
+		errs = append(errs, fmt.Errorf("invalid flag reference pattern: %w", err))
+	} else if re.NumSubexp() < 1 {
+		errs = append(errs, errors.New("flag reference pattern has no group capturing the flag key"))
+	} else {
+		fv.re = re
+	}
+	if fv.service == nil {
+		errs = append(errs, errors.New("feature flag service is empty"))
+	}
+	if fv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (fv *featureFlagValidator) Validate(ctx context.Context) (validators.Status, error) {
+	number, err := fv.pullRequestNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number == 0 {
+		return &validators.BasicStatus{Message: fmt.Sprintf("no pull request is associated with %s", fv.ref)}, nil
+	}
+
+	keys, err := fv.referencedFlags(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return &validators.BasicStatus{Succeeded: true, Message: fmt.Sprintf("#%d references no feature flags", number)}, nil
+	}
+
+	var missing, disallowed []string
+	for _, key := range keys {
+		f, err := fv.service.Flag(ctx, key)
+		switch {
+		case errors.Is(err, flagservice.ErrNotFound):
+			missing = append(missing, key)
+		case err != nil:
+			return nil, fmt.Errorf("failed to look up feature flag %s: %w", key, err)
+		case !fv.allowedStates[f.State]:
+			disallowed = append(disallowed, fmt.Sprintf("%s (%s)", key, f.State))
+		}
+	}
+
+	errs := make(multierror.Errors, 0, 2)
+	if len(missing) != 0 {
+		errs = append(errs, fmt.Errorf("feature flags do not exist: %s", strings.Join(missing, ", ")))
+	}
+	if len(disallowed) != 0 {
+		errs = append(errs, fmt.Errorf("feature flags are not in allowed states: %s", strings.Join(disallowed, ", ")))
+	}
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return &validators.BasicStatus{
+		Succeeded: true,
+		Message:   fmt.Sprintf("feature flags referenced by #%d exist: %s", number, strings.Join(keys, ", ")),
+	}, nil
+}
+
+func (fv *featureFlagValidator) pullRequestNumber(ctx context.Context) (int, error) {
+	if fv.prNumber != 0 {
+		return fv.prNumber, nil
+	}
+	prs, _, err := fv.client.ListPullRequestsWithCommit(ctx, fv.owner, fv.repo, fv.ref, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up pull request of %s: %w", fv.ref, err)
+	}
+	for _, pr := range prs {
+		if pr.GetMergedAt().IsZero() && pr.GetHead().GetSHA() != fv.ref {
+			continue
+		}
+		fv.prNumber = pr.GetNumber()
+		break
+	}
+	return fv.prNumber, nil
+}
+
+// referencedFlags returns the sorted keys of the flags referenced by the lines added in the pull request. Removed
+// references are not looked up, so that merges cleaning up archived flags are not blocked.
+func (fv *featureFlagValidator) referencedFlags(ctx context.Context, number int) ([]string, error) {
+	keys := make(map[string]bool)
+	page := 1
+	for {
+		files, _, err := fv.client.ListPullRequestFiles(ctx, fv.owner, fv.repo, number, &github.ListOptions{Page: page, PerPage: maxFilesPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of #%d: %w", number, err)
+		}
+		for _, f := range files {
+			for _, line := range strings.Split(f.GetPatch(), "\n") {
+				if !strings.HasPrefix(line, "+") || strings.HasPrefix(line, "+++") {
+					continue
+				}
+				for _, m := range fv.re.FindAllStringSubmatch(line, -1) {
+					keys[m[1]] = true
+				}
+			}
+		}
+		if len(files) < maxFilesPerPage {
+			break
+		}
+		page++
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
@@ -0,0 +1,116 @@
+package featureflag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/flagservice"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+type service map[string]string
+
+func (s service) Flag(ctx context.Context, key string) (*flagservice.Flag, error) {
+	state, ok := s[key]
+	if !ok {
+		return nil, flagservice.ErrNotFound
+	}
+	return &flagservice.Flag{Key: key, State: state}, nil
+}
+
+func TestFeatureFlagValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		patch       string
+		flags       service
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds when referenced flags exist": {
+			patch: "@@ -1,2 +1,3 @@\n" +
+				" func checkout() {\n" +
+				"+\tif client.BoolVariation(\"new-checkout\", ctx, false) {\n" +
+				"+\tif unleash.isEnabled('fast-search') {\n",
+			flags:       service{"new-checkout": flagservice.StateOn, "fast-search": flagservice.StateOff},
+			wantSuccess: true,
+		},
+		"succeeds without references": {
+			patch:       "+func main() {}\n",
+			wantSuccess: true,
+		},
+		"ignores removed references": {
+			patch:       "-\tif client.BoolVariation(\"old-checkout\", ctx, false) {\n",
+			wantSuccess: true,
+		},
+		"returns error when referenced flag does not exist": {
+			patch:   "+\tif client.BoolVariation(\"new-checkout\", ctx, false) {\n",
+			flags:   service{},
+			wantErr: true,
+		},
+		"returns error when referenced flag is archived": {
+			patch:   "+\tif client.BoolVariation(\"new-checkout\", ctx, false) {\n",
+			flags:   service{"new-checkout": flagservice.StateArchived},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+					return []*github.CommitFile{{Filename: stringPtr("main.go"), Patch: stringPtr(tt.patch)}}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithGitHubRef("sha"),
+				WithPullRequest(1),
+				WithService(tt.flags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns validator": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithService(service{})},
+		},
+		"returns error without service": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha")},
+			wantErr: true,
+		},
+		"returns error when pattern captures no key": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithService(service{}), WithPattern(`isEnabled\(`)},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(&mock.Client{}, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package junit
+
+type Option func(jv *junitValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(jv *junitValidator) {
+		if len(owner) != 0 {
+			jv.owner = owner
+		}
+		if len(repo) != 0 {
+			jv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(jv *junitValidator) {
+		if len(ref) != 0 {
+			jv.ref = ref
+		}
+	}
+}
+
+// WithArtifactPattern sets the glob pattern of the names of artifacts containing JUnit XML reports, e.g) test-results-*.
+func WithArtifactPattern(pattern string) Option {
+	return func(jv *junitValidator) {
+		jv.pattern = pattern
+	}
+}
@@ -0,0 +1,131 @@
+package junit
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// testCase is a test case of a JUnit XML report. Both <testsuites> and <testsuite> roots are supported.
+type testCase struct {
+	Name      string     `xml:"name,attr"`
+	ClassName string     `xml:"classname,attr"`
+	Failures  []struct{} `xml:"failure"`
+	Errors    []struct{} `xml:"error"`
+	Skipped   *struct{}  `xml:"skipped"`
+	// Surefire records failed attempts of tests which passed on rerun.
+	FlakyFailures []struct{} `xml:"flakyFailure"`
+	FlakyErrors   []struct{} `xml:"flakyError"`
+}
+
+func (tc *testCase) id() string {
+	if len(tc.ClassName) == 0 {
+		return tc.Name
+	}
+	return tc.ClassName + "." + tc.Name
+}
+
+func (tc *testCase) failed() bool {
+	return len(tc.Failures) != 0 || len(tc.Errors) != 0
+}
+
+func (tc *testCase) flaky() bool {
+	return len(tc.FlakyFailures) != 0 || len(tc.FlakyErrors) != 0
+}
+
+type testSuite struct {
+	Cases  []testCase  `xml:"testcase"`
+	Suites []testSuite `xml:"testsuite"`
+}
+
+func (ts *testSuite) collect(cases []testCase) []testCase {
+	cases = append(cases, ts.Cases...)
+	for i := range ts.Suites {
+		cases = ts.Suites[i].collect(cases)
+	}
+	return cases
+}
+
+func parseReport(r io.Reader) ([]testCase, error) {
+	var root testSuite
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, err
+	}
+	return root.collect(nil), nil
+}
+
+// casesFromArchive returns the test cases of every XML file in the zip archive of an artifact.
+func casesFromArchive(archive []byte) ([]testCase, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	var cases []testCase
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(f.Name), ".xml") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		cs, err := parseReport(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		cases = append(cases, cs...)
+	}
+	return cases, nil
+}
+
+// results aggregates the test cases across shards and attempts. A test is failed when it never passed, and flaky
+// when it failed in some attempts but passed in others.
+type results struct {
+	total   int
+	skipped int
+	failed  []string
+	flaky   []string
+}
+
+func aggregate(cases []testCase) *results {
+	passed := make(map[string]bool)
+	failed := make(map[string]bool)
+	flaky := make(map[string]bool)
+	var order []string
+	skipped := make(map[string]bool)
+	for i := range cases {
+		tc := &cases[i]
+		id := tc.id()
+		if !passed[id] && !failed[id] && !skipped[id] {
+			order = append(order, id)
+		}
+		switch {
+		case tc.failed():
+			failed[id] = true
+		case tc.Skipped != nil:
+			skipped[id] = true
+		default:
+			passed[id] = true
+			if tc.flaky() {
+				flaky[id] = true
+			}
+		}
+	}
+
+	r := &results{total: len(order)}
+	for _, id := range order {
+		switch {
+		case failed[id] && passed[id], flaky[id]:
+			r.flaky = append(r.flaky, id)
+		case failed[id]:
+			r.failed = append(r.failed, id)
+		case skipped[id] && !passed[id]:
+			r.skipped++
+		}
+	}
+	return r
+}
@@ -0,0 +1,57 @@
+package junit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_aggregate(t *testing.T) {
+	shard1 := `<testsuites>
+  <testsuite name="a">
+    <testcase classname="pkg.A" name="passes"/>
+    <testcase classname="pkg.A" name="fails"><failure message="boom">trace</failure></testcase>
+    <testcase classname="pkg.A" name="retried"><failure/></testcase>
+    <testcase classname="pkg.A" name="skipped"><skipped/></testcase>
+  </testsuite>
+</testsuites>`
+	shard2 := `<testsuite name="b">
+  <testcase classname="pkg.A" name="retried"/>
+  <testcase classname="pkg.B" name="errors"><error/></testcase>
+  <testcase classname="pkg.B" name="rerun"><flakyFailure/></testcase>
+</testsuite>`
+
+	var cases []testCase
+	for _, report := range []string{shard1, shard2} {
+		cs, err := parseReport(strings.NewReader(report))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases = append(cases, cs...)
+	}
+
+	got := aggregate(cases)
+	want := &results{
+		total:   6,
+		skipped: 1,
+		failed:  []string{"pkg.A.fails", "pkg.B.errors"},
+		flaky:   []string{"pkg.A.retried", "pkg.B.rerun"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("aggregate() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_listTests(t *testing.T) {
+	ids := make([]string, maxListedTests+2)
+	for i := range ids {
+		ids[i] = "test"
+	}
+	got := listTests(ids)
+	if !strings.HasSuffix(got, "\n- ... and 2 more") {
+		t.Errorf("listTests() = %q, want the rest summarised", got)
+	}
+	if n := strings.Count(got, "\n"); n != maxListedTests {
+		t.Errorf("listTests() has %d lines, want %d", n+1, maxListedTests+1)
+	}
+}
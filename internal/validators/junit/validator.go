@@ -0,0 +1,166 @@
+package junit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "tests"
+
+const (
+	maxItemsPerPage = 100
+	maxListedTests  = 20
+)
+
+type junitValidator struct {
+	owner   string
+	repo    string
+	ref     string
+	pattern string
+	client  github.Client
+
+	downloaded map[int64][]testCase // Cached, as artifacts do not change once uploaded.
+}
+
+// CreateValidator returns the validator which aggregates the JUnit XML reports uploaded as artifacts by the workflow
+// runs of the ref, and fails with the names of the failed tests. Completion of the jobs is left to the status
+// validator, so this validator succeeds as soon as the uploaded reports have no failures.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	jv := &junitValidator{
+		client:     c,
+		downloaded: make(map[int64][]testCase),
+	}
+	for _, opt := range opts {
+		opt(jv)
+	}
+	if err := jv.validateFields(); err != nil {
+		return nil, err
+	}
+	return jv, nil
+}
+
+func (jv *junitValidator) Name() string {
+	return validatorName
+}
+
+func (jv *junitValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(jv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(jv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(jv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(jv.pattern) == 0 {
+		errs = append(errs, errors.New("artifact pattern of test reports is empty"))
+	} else if _, err := path.Match(jv.pattern, ""); err != nil {
+		errs = append(errs, fmt.Errorf("invalid artifact pattern %s: %w", jv.pattern, err))
+	}
+	if jv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (jv *junitValidator) Validate(ctx context.Context) (validators.Status, error) {
+	runs, _, err := jv.client.ListWorkflowRuns(ctx, jv.owner, jv.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     jv.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	var (
+		cases   []testCase
+		reports int
+		seen    = make(map[string]bool)
+	)
+	for _, run := range runs.WorkflowRuns {
+		// Workflow runs are listed newest first, so re-runs take precedence.
+		if seen[run.GetName()] {
+			continue
+		}
+		seen[run.GetName()] = true
+
+		list, _, err := jv.client.ListWorkflowRunArtifacts(ctx, jv.owner, jv.repo, run.GetID(), &github.ListOptions{PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts of %s: %w", run.GetName(), err)
+		}
+		for _, a := range list.Artifacts {
+			if ok, _ := path.Match(jv.pattern, a.Name); !ok || a.Expired {
+				continue
+			}
+			cs, err := jv.download(ctx, a)
+			if err != nil {
+				return nil, err
+			}
+			cases = append(cases, cs...)
+			reports++
+		}
+	}
+	if reports == 0 {
+		return &validators.BasicStatus{Message: fmt.Sprintf("waiting for test reports matching %s", jv.pattern)}, nil
+	}
+
+	r := aggregate(cases)
+	if len(r.failed) != 0 {
+		return nil, fmt.Errorf("%d out of %d tests failed:\n%s", len(r.failed), r.total, listTests(r.failed))
+	}
+
+	msg := fmt.Sprintf("%d tests passed in %d reports", r.total-r.skipped, reports)
+	if r.skipped != 0 {
+		msg += fmt.Sprintf(", %d skipped", r.skipped)
+	}
+	if len(r.flaky) != 0 {
+		msg += fmt.Sprintf(", %d flaky:\n%s", len(r.flaky), listTests(r.flaky))
+	}
+	return &validators.BasicStatus{Succeeded: true, Message: msg}, nil
+}
+
+func (jv *junitValidator) download(ctx context.Context, a *github.Artifact) ([]testCase, error) {
+	if cs, ok := jv.downloaded[a.ID]; ok {
+		return cs, nil
+	}
+	archive, _, err := jv.client.DownloadArtifact(ctx, jv.owner, jv.repo, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download test report %s: %w", a.Name, err)
+	}
+	cs, err := casesFromArchive(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test report %s: %w", a.Name, err)
+	}
+	jv.downloaded[a.ID] = cs
+	return cs, nil
+}
+
+func listTests(ids []string) string {
+	var b strings.Builder
+	for i, id := range ids {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		if i == maxListedTests {
+			fmt.Fprintf(&b, "- ... and %d more", len(ids)-maxListedTests)
+			break
+		}
+		fmt.Fprintf(&b, "- %s", id)
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package junit
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func archive(t *testing.T, report string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("report.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(report)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestJUnitValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		artifacts   []*github.Artifact
+		reports     map[int64]string
+		wantSuccess bool
+		wantErr     string
+	}{
+		"succeeds when every test passed across shards": {
+			artifacts: []*github.Artifact{{ID: 1, Name: "junit-1"}, {ID: 2, Name: "junit-2"}, {ID: 3, Name: "coverage"}},
+			reports: map[int64]string{
+				1: `<testsuite><testcase name="a"/></testsuite>`,
+				2: `<testsuite><testcase name="b"/></testsuite>`,
+			},
+			wantSuccess: true,
+		},
+		"returns error with the names of failed tests": {
+			artifacts: []*github.Artifact{{ID: 1, Name: "junit-1"}},
+			reports: map[int64]string{
+				1: `<testsuite><testcase classname="pkg" name="a"><failure/></testcase></testsuite>`,
+			},
+			wantErr: "- pkg.a",
+		},
+		"is pending until reports are uploaded": {
+			artifacts:   []*github.Artifact{{ID: 3, Name: "coverage"}},
+			wantSuccess: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					id, name := int64(1), "CI"
+					return &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{ID: &id, Name: &name}}}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					return &github.ArtifactList{Artifacts: tt.artifacts}, nil, nil
+				},
+				DownloadArtifactFunc: func(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+					return archive(t, tt.reports[artifactID]), nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithArtifactPattern("junit-*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if len(tt.wantErr) != 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
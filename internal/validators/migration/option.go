@@ -0,0 +1,67 @@
+package migration
+
+import "strings"
+
+type Option func(mv *migrationValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(mv *migrationValidator) {
+		if len(owner) != 0 {
+			mv.owner = owner
+		}
+		if len(repo) != 0 {
+			mv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(mv *migrationValidator) {
+		if len(ref) != 0 {
+			mv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to inspect, instead of looking it up by the ref.
+func WithPullRequest(number int) Option {
+	return func(mv *migrationValidator) {
+		if number != 0 {
+			mv.prNumber = number
+		}
+	}
+}
+
+// WithPaths sets the patterns of migration files (comma-separated list), e.g) **/migrations/**.
+func WithPaths(paths string) Option {
+	return func(mv *migrationValidator) {
+		if len(paths) == 0 {
+			return
+		}
+		mv.paths = mv.paths[:0]
+		for _, p := range strings.Split(paths, ",") {
+			if p = strings.TrimSpace(p); len(p) != 0 {
+				mv.paths = append(mv.paths, p)
+			}
+		}
+	}
+}
+
+// WithTestJob sets the job which must have succeeded when migrations are changed.
+func WithTestJob(name string) Option {
+	return func(mv *migrationValidator) {
+		mv.testJob = name
+	}
+}
+
+// WithTeam sets the team which must approve the pull request when migrations are changed, given as either
+// org/team-slug or team-slug of the repository owner.
+func WithTeam(team string) Option {
+	return func(mv *migrationValidator) {
+		if org, slug, ok := strings.Cut(team, "/"); ok {
+			mv.teamOrg, mv.teamSlug = org, slug
+			return
+		}
+		mv.teamSlug = team
+	}
+}
@@ -0,0 +1,261 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/pathfilter"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "migrations"
+
+// DefaultPaths are the patterns of migration files used by common frameworks, such as Rails, Django and golang-migrate.
+const DefaultPaths = "**/migrations/**,**/migrate/**"
+
+// NOTE: https://docs.github.com/en/rest/pulls/reviews
+const (
+	approvedState         = "APPROVED"
+	changesRequestedState = "CHANGES_REQUESTED"
+	dismissedState        = "DISMISSED"
+)
+
+const (
+	checkRunCompletedStatus   = "completed"
+	checkRunSuccessConclusion = "success"
+)
+
+const maxItemsPerPage = 100
+
+type migrationValidator struct {
+	owner    string
+	repo     string
+	ref      string
+	prNumber int
+	paths    []string
+	testJob  string
+	teamOrg  string
+	teamSlug string
+	members  map[string]bool
+	client   github.Client
+}
+
+// CreateValidator returns the validator which requires pull requests changing database migrations to pass the
+// migration test job, and to be approved by a member of the team owning the database.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	mv := &migrationValidator{
+		client: c,
+	}
+	WithPaths(DefaultPaths)(mv)
+	for _, opt := range opts {
+		opt(mv)
+	}
+	if len(mv.teamOrg) == 0 {
+		mv.teamOrg = mv.owner
+	}
+	if err := mv.validateFields(); err != nil {
+		return nil, err
+	}
+	return mv, nil
+}
+
+func (mv *migrationValidator) Name() string {
+	return validatorName
+}
+
+func (mv *migrationValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 6)
+
+	if len(mv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(mv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(mv.ref) == 0 && mv.prNumber == 0 {
+		errs = append(errs, errors.New("reference of repository and pull request number are empty"))
+	}
+	if len(mv.testJob) == 0 {
+		errs = append(errs, errors.New("migration test job is empty"))
+	}
+	if len(mv.teamSlug) == 0 {
+		errs = append(errs, errors.New("migration approval team is empty"))
+	}
+	if mv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (mv *migrationValidator) Validate(ctx context.Context) (validators.Status, error) {
+	number, err := mv.pullRequestNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number == 0 {
+		return &validators.BasicStatus{Message: fmt.Sprintf("no pull request is associated with %s", mv.ref)}, nil
+	}
+
+	migrations, err := mv.changedMigrations(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return &validators.BasicStatus{Succeeded: true, Message: fmt.Sprintf("#%d changes no migrations", number)}, nil
+	}
+
+	tested, err := mv.tested(ctx)
+	if err != nil {
+		return nil, err
+	}
+	approvers, err := mv.teamApprovers(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var waiting []string
+	if !tested {
+		waiting = append(waiting, fmt.Sprintf("job %s to succeed", mv.testJob))
+	}
+	if len(approvers) == 0 {
+		waiting = append(waiting, fmt.Sprintf("approval from %s/%s", mv.teamOrg, mv.teamSlug))
+	}
+	msg := fmt.Sprintf("#%d changes %d migrations: %s", number, len(migrations), strings.Join(migrations, ", "))
+	if len(waiting) != 0 {
+		return &validators.BasicStatus{Message: msg + "\nwaiting for " + strings.Join(waiting, " and ")}, nil
+	}
+	return &validators.BasicStatus{
+		Succeeded: true,
+		Message:   msg + fmt.Sprintf("\ntested by %s and approved by %s", mv.testJob, strings.Join(approvers, ", ")),
+	}, nil
+}
+
+func (mv *migrationValidator) pullRequestNumber(ctx context.Context) (int, error) {
+	if mv.prNumber != 0 {
+		return mv.prNumber, nil
+	}
+	prs, _, err := mv.client.ListPullRequestsWithCommit(ctx, mv.owner, mv.repo, mv.ref, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up pull request of %s: %w", mv.ref, err)
+	}
+	for _, pr := range prs {
+		if pr.GetMergedAt().IsZero() && pr.GetHead().GetSHA() != mv.ref {
+			continue
+		}
+		mv.prNumber = pr.GetNumber()
+		break
+	}
+	return mv.prNumber, nil
+}
+
+// changedMigrations returns the files of the pull request matching the migration paths, including removed ones.
+func (mv *migrationValidator) changedMigrations(ctx context.Context, number int) ([]string, error) {
+	var migrations []string
+	page := 1
+	for {
+		files, _, err := mv.client.ListPullRequestFiles(ctx, mv.owner, mv.repo, number, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of #%d: %w", number, err)
+		}
+		for _, f := range files {
+			if mv.isMigration(f.GetFilename()) || mv.isMigration(f.GetPreviousFilename()) {
+				migrations = append(migrations, f.GetFilename())
+			}
+		}
+		if len(files) < maxItemsPerPage {
+			break
+		}
+		page++
+	}
+	return migrations, nil
+}
+
+func (mv *migrationValidator) isMigration(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	return pathfilter.MatchAny(mv.paths, name)
+}
+
+// tested reports whether the latest run of the test job succeeded, and fails when it concluded otherwise.
+func (mv *migrationValidator) tested(ctx context.Context) (bool, error) {
+	cr, _, err := mv.client.ListCheckRunsForRef(ctx, mv.owner, mv.repo, mv.ref, &github.ListCheckRunsOptions{
+		CheckName:   &mv.testJob,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list check runs: %w", err)
+	}
+	if len(cr.CheckRuns) == 0 || cr.CheckRuns[0].GetStatus() != checkRunCompletedStatus {
+		return false, nil
+	}
+	if conclusion := cr.CheckRuns[0].GetConclusion(); conclusion != checkRunSuccessConclusion {
+		return false, fmt.Errorf("migration test job %s concluded with %s", mv.testJob, conclusion)
+	}
+	return true, nil
+}
+
+// teamApprovers returns the members of the team whose latest review approves the pull request.
+func (mv *migrationValidator) teamApprovers(ctx context.Context, number int) ([]string, error) {
+	if mv.members == nil {
+		members := make(map[string]bool)
+		page := 1
+		for {
+			users, _, err := mv.client.ListTeamMembersBySlug(ctx, mv.teamOrg, mv.teamSlug, &github.TeamListTeamMembersOptions{
+				ListOptions: github.ListOptions{Page: page, PerPage: maxItemsPerPage},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list members of %s/%s: %w", mv.teamOrg, mv.teamSlug, err)
+			}
+			for _, u := range users {
+				members[u.GetLogin()] = true
+			}
+			if len(users) < maxItemsPerPage {
+				break
+			}
+			page++
+		}
+		mv.members = members
+	}
+
+	var reviews []*github.PullRequestReview
+	page := 1
+	for {
+		rs, _, err := mv.client.ListReviews(ctx, mv.owner, mv.repo, number, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews of #%d: %w", number, err)
+		}
+		reviews = append(reviews, rs...)
+		if len(rs) < maxItemsPerPage {
+			break
+		}
+		page++
+	}
+
+	// Reviews are listed oldest first, so the last review of each reviewer wins.
+	latest := make(map[string]string)
+	for _, r := range reviews {
+		switch state := r.GetState(); state {
+		case approvedState, changesRequestedState, dismissedState:
+			latest[r.GetUser().GetLogin()] = state
+		}
+	}
+	var approvers []string
+	for login, state := range latest {
+		if state == approvedState && mv.members[login] {
+			approvers = append(approvers, login)
+		}
+	}
+	sort.Strings(approvers)
+	return approvers, nil
+}
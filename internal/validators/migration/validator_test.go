@@ -0,0 +1,126 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func review(login, state string) *github.PullRequestReview {
+	return &github.PullRequestReview{User: &github.User{Login: &login}, State: &state}
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns validator": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithTestJob("migration-test"), WithTeam("dba")},
+		},
+		"returns error without test job": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithTeam("dba")},
+			wantErr: true,
+		},
+		"returns error without team": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithTestJob("migration-test")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(&mock.Client{}, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMigrationValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		files       []string
+		runs        []*github.CheckRun
+		reviews     []*github.PullRequestReview
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds without migrations": {
+			files:       []string{"main.go"},
+			wantSuccess: true,
+		},
+		"succeeds when tested and approved by the team": {
+			files:       []string{"main.go", "db/migrations/0002_add_index.sql"},
+			runs:        []*github.CheckRun{{Status: stringPtr("completed"), Conclusion: stringPtr("success")}},
+			reviews:     []*github.PullRequestReview{review("dba-01", "APPROVED")},
+			wantSuccess: true,
+		},
+		"is pending until approved by the team": {
+			files:   []string{"db/migrations/0002_add_index.sql"},
+			runs:    []*github.CheckRun{{Status: stringPtr("completed"), Conclusion: stringPtr("success")}},
+			reviews: []*github.PullRequestReview{review("dev-01", "APPROVED"), review("dba-01", "APPROVED"), review("dba-01", "DISMISSED")},
+		},
+		"is pending until the test job completes": {
+			files:   []string{"db/migrations/0002_add_index.sql"},
+			runs:    []*github.CheckRun{{Status: stringPtr("in_progress")}},
+			reviews: []*github.PullRequestReview{review("dba-01", "APPROVED")},
+		},
+		"returns error when the test job failed": {
+			files:   []string{"db/migrations/0002_add_index.sql"},
+			runs:    []*github.CheckRun{{Status: stringPtr("completed"), Conclusion: stringPtr("failure")}},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+					var files []*github.CommitFile
+					for _, f := range tt.files {
+						files = append(files, &github.CommitFile{Filename: stringPtr(f)})
+					}
+					return files, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: tt.runs}, nil, nil
+				},
+				ListTeamMembersBySlugFunc: func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+					if org != "owner" || slug != "dba" {
+						t.Errorf("team = %s/%s, want owner/dba", org, slug)
+					}
+					return []*github.User{{Login: stringPtr("dba-01")}}, nil, nil
+				},
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return tt.reviews, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithGitHubRef("sha"),
+				WithPullRequest(1),
+				WithTestJob("migration-test"),
+				WithTeam("dba"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package milestone
+
+type Option func(mv *milestoneValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(mv *milestoneValidator) {
+		if len(owner) != 0 {
+			mv.owner = owner
+		}
+		if len(repo) != 0 {
+			mv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(mv *milestoneValidator) {
+		if len(ref) != 0 {
+			mv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to validate, instead of looking it up by the ref.
+func WithPullRequest(number int) Option {
+	return func(mv *milestoneValidator) {
+		if number != 0 {
+			mv.prNumber = number
+		}
+	}
+}
+
+// WithMilestone sets the milestone the pull request must be assigned to, either Current for the open milestone
+// due next or a regular expression matching titles of open milestones.
+func WithMilestone(milestone string) Option {
+	return func(mv *milestoneValidator) {
+		if len(milestone) != 0 {
+			mv.milestone = milestone
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package milestone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "milestone"
+
+// Current requires the pull request to be assigned to the current milestone of the release train, which is the
+// open milestone due next, or the first open milestone when none is due.
+const Current = "current"
+
+// NOTE: https://docs.github.com/en/rest/issues/milestones
+const (
+	milestoneOpenState = "open"
+	milestoneSortDueOn = "due_on"
+	sortAscending      = "asc"
+)
+
+const maxMilestonesPerPage = 100
+
+type milestoneValidator struct {
+	owner     string
+	repo      string
+	ref       string
+	prNumber  int
+	milestone string
+	pattern   *regexp.Regexp
+	client    github.Client
+}
+
+// CreateValidator returns the validator which holds the gate pending until the pull request is assigned to the
+// current milestone, or to an open milestone matching the pattern. The pull request is looked up by the ref when
+// not given.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	mv := &milestoneValidator{
+		client:    c,
+		milestone: Current,
+	}
+	for _, opt := range opts {
+		opt(mv)
+	}
+	if err := mv.validateFields(); err != nil {
+		return nil, err
+	}
+	return mv, nil
+}
+
+func (mv *milestoneValidator) Name() string {
+	return validatorName
+}
+
+func (mv *milestoneValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(mv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(mv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(mv.ref) == 0 && mv.prNumber == 0 {
+		errs = append(errs, errors.New("reference of repository and pull request number are empty"))
+	}
+	if mv.milestone != Current {
+		pattern, err := regexp.Compile(mv.milestone)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("milestone pattern is invalid: %w", err))
+		}
+		mv.pattern = pattern
+	}
+	if mv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (mv *milestoneValidator) Validate(ctx context.Context) (validators.Status, error) {
+	number, err := mv.pullRequestNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number == 0 {
+		return &validators.BasicStatus{Message: fmt.Sprintf("no pull request is associated with %s", mv.ref)}, nil
+	}
+
+	// The pull request is fetched on every validation, as the milestone may be assigned while the gate is pending.
+	pr, _, err := mv.client.GetPullRequest(ctx, mv.owner, mv.repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", number, err)
+	}
+	assigned := pr.GetMilestone()
+
+	if mv.pattern != nil {
+		if assigned != nil && assigned.GetState() == milestoneOpenState && mv.pattern.MatchString(assigned.GetTitle()) {
+			return &validators.BasicStatus{
+				Succeeded: true,
+				Message:   fmt.Sprintf("#%d is assigned to milestone %s", number, assigned.GetTitle()),
+			}, nil
+		}
+		return &validators.BasicStatus{
+			Message: fmt.Sprintf("#%d is %s. Assign it to an open milestone matching %s", number, describe(assigned), mv.pattern),
+		}, nil
+	}
+
+	current, err := mv.currentMilestone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return &validators.BasicStatus{
+			Message: fmt.Sprintf("#%d is %s, but no milestone is open. Open the milestone of the next release and assign it", number, describe(assigned)),
+		}, nil
+	}
+	if assigned != nil && assigned.GetNumber() == current.GetNumber() {
+		return &validators.BasicStatus{
+			Succeeded: true,
+			Message:   fmt.Sprintf("#%d is assigned to the current milestone %s", number, current.GetTitle()),
+		}, nil
+	}
+	return &validators.BasicStatus{
+		Message: fmt.Sprintf("#%d is %s. Assign it to the current milestone %s", number, describe(assigned), current.GetTitle()),
+	}, nil
+}
+
+// currentMilestone returns the open milestone due next, or the first open milestone when none is due.
+func (mv *milestoneValidator) currentMilestone(ctx context.Context) (*github.Milestone, error) {
+	var first *github.Milestone
+	page := 1
+	for {
+		ms, _, err := mv.client.ListMilestones(ctx, mv.owner, mv.repo, &github.MilestoneListOptions{
+			State:       milestoneOpenState,
+			Sort:        milestoneSortDueOn,
+			Direction:   sortAscending,
+			ListOptions: github.ListOptions{Page: page, PerPage: maxMilestonesPerPage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		for _, m := range ms {
+			// Milestones without due dates are only current when no milestone is due.
+			if m.DueOn != nil {
+				return m, nil
+			}
+			if first == nil {
+				first = m
+			}
+		}
+		if len(ms) < maxMilestonesPerPage {
+			return first, nil
+		}
+		page++
+	}
+}
+
+func (mv *milestoneValidator) pullRequestNumber(ctx context.Context) (int, error) {
+	if mv.prNumber != 0 {
+		return mv.prNumber, nil
+	}
+	prs, _, err := mv.client.ListPullRequestsWithCommit(ctx, mv.owner, mv.repo, mv.ref, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up pull request of %s: %w", mv.ref, err)
+	}
+	for _, pr := range prs {
+		if pr.GetMergedAt().IsZero() && pr.GetHead().GetSHA() != mv.ref {
+			continue
+		}
+		mv.prNumber = pr.GetNumber()
+		break
+	}
+	return mv.prNumber, nil
+}
+
+func describe(m *github.Milestone) string {
+	switch {
+	case m == nil:
+		return "not assigned to any milestone"
+	case m.GetState() != milestoneOpenState:
+		return fmt.Sprintf("assigned to the closed milestone %s", m.GetTitle())
+	default:
+		return fmt.Sprintf("assigned to milestone %s", m.GetTitle())
+	}
+}
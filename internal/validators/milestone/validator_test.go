@@ -0,0 +1,98 @@
+package milestone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func milestone(number int, title, state string, dueOn *time.Time) *github.Milestone {
+	m := &github.Milestone{Number: &number, Title: &title, State: &state}
+	if dueOn != nil {
+		m.DueOn = &github.Timestamp{Time: *dueOn}
+	}
+	return m
+}
+
+var due = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func TestMilestoneValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		milestone   string
+		assigned    *github.Milestone
+		open        []*github.Milestone
+		wantSuccess bool
+		wantMessage string
+	}{
+		"succeeds when assigned to the milestone due next": {
+			assigned:    milestone(2, "v1.2", "open", &due),
+			open:        []*github.Milestone{milestone(3, "backlog", "open", nil), milestone(2, "v1.2", "open", &due)},
+			wantSuccess: true,
+			wantMessage: "#1 is assigned to the current milestone v1.2",
+		},
+		"is pending when assigned to a later milestone": {
+			assigned:    milestone(3, "backlog", "open", nil),
+			open:        []*github.Milestone{milestone(3, "backlog", "open", nil), milestone(2, "v1.2", "open", &due)},
+			wantMessage: "#1 is assigned to milestone backlog. Assign it to the current milestone v1.2",
+		},
+		"is pending when not assigned": {
+			open:        []*github.Milestone{milestone(3, "backlog", "open", nil)},
+			wantMessage: "#1 is not assigned to any milestone. Assign it to the current milestone backlog",
+		},
+		"is pending when no milestone is open": {
+			assigned:    milestone(1, "v1.1", "closed", &due),
+			wantMessage: "#1 is assigned to the closed milestone v1.1, but no milestone is open. Open the milestone of the next release and assign it",
+		},
+		"succeeds when assigned to a matching milestone": {
+			milestone:   `^v1\.\d+$`,
+			assigned:    milestone(2, "v1.3", "open", nil),
+			wantSuccess: true,
+			wantMessage: "#1 is assigned to milestone v1.3",
+		},
+		"is pending when the matching milestone is closed": {
+			milestone:   `^v1\.\d+$`,
+			assigned:    milestone(1, "v1.1", "closed", &due),
+			wantMessage: `#1 is assigned to the closed milestone v1.1. Assign it to an open milestone matching ^v1\.\d+$`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{Number: &number, Milestone: tt.assigned}, nil, nil
+				},
+				ListMilestonesFunc: func(ctx context.Context, owner, repo string, opts *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error) {
+					if opts.State != "open" {
+						t.Errorf("ListMilestones() state = %s, want open", opts.State)
+					}
+					return tt.open, nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithPullRequest(1), WithMilestone(tt.milestone))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("Validate() success = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+			if got.Detail() != tt.wantMessage {
+				t.Errorf("Validate() message = %s, want %s", got.Detail(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCreateValidator(t *testing.T) {
+	_, err := CreateValidator(&mock.Client{}, WithGitHubOwnerAndRepo("owner", "repo"), WithPullRequest(1), WithMilestone("v1.("))
+	if err == nil {
+		t.Error("CreateValidator() error = nil, want error of the invalid pattern")
+	}
+}
@@ -0,0 +1,31 @@
+package pause
+
+type Option func(pv *pauseValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(pv *pauseValidator) {
+		if len(owner) != 0 {
+			pv.owner = owner
+		}
+		if len(repo) != 0 {
+			pv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(pv *pauseValidator) {
+		if len(ref) != 0 {
+			pv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to check, instead of looking it up by the ref.
+func WithPullRequest(number int) Option {
+	return func(pv *pauseValidator) {
+		if number != 0 {
+			pv.prNumber = number
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// IssueTitle is the title of the issue opened to pause gating of the whole repository.
+const IssueTitle = "Merge Gatekeeper is paused"
+
+// ErrNotPaused is returned by Resume when gating is not paused.
+var ErrNotPaused = errors.New("gating is not paused")
+
+// Pause pauses gating of the pull request, or of the whole repository when number is zero, until Resume is called.
+// The repository is paused by opening a labelled issue, and the pull request by labelling it. Both record who paused
+// gating and why, so that the issue and pull request timelines serve as the log of pauses.
+func Pause(ctx context.Context, c github.Client, owner, repo string, number int, actor, why string) error {
+	by := byActor(actor)
+	if number != 0 {
+		if _, _, err := c.AddLabelsToIssue(ctx, owner, repo, number, []string{Label}); err != nil {
+			return fmt.Errorf("failed to label #%d: %w", number, err)
+		}
+		return comment(ctx, c, owner, repo, number, fmt.Sprintf("Merge Gatekeeper is paused on this pull request%s: %s", by, why))
+	}
+
+	issues, err := listPausedIssues(ctx, c, owner, repo)
+	if err != nil {
+		return err
+	}
+	if len(issues) != 0 {
+		return comment(ctx, c, owner, repo, issues[0].GetNumber(), fmt.Sprintf("Paused again%s: %s", by, why))
+	}
+
+	title := IssueTitle
+	body := fmt.Sprintf("%s\n\nPaused%s. Gates of all pull requests report pending until this issue is closed.\n", why, by)
+	if _, _, err := c.CreateIssue(ctx, owner, repo, &github.IssueRequest{
+		Title:  &title,
+		Body:   &body,
+		Labels: &[]string{Label},
+	}); err != nil {
+		return fmt.Errorf("failed to open pause issue: %w", err)
+	}
+	return nil
+}
+
+// Resume resumes gating of the pull request, or of the whole repository when number is zero, recording who resumed it.
+// It returns ErrNotPaused when there is no pause to lift.
+func Resume(ctx context.Context, c github.Client, owner, repo string, number int, actor string) error {
+	msg := fmt.Sprintf("Merge Gatekeeper is resumed%s.", byActor(actor))
+	if number != 0 {
+		res, err := c.RemoveLabelForIssue(ctx, owner, repo, number, Label)
+		if err != nil {
+			if res != nil && res.StatusCode == http.StatusNotFound {
+				return ErrNotPaused
+			}
+			return fmt.Errorf("failed to unlabel #%d: %w", number, err)
+		}
+		return comment(ctx, c, owner, repo, number, msg)
+	}
+
+	issues, err := listPausedIssues(ctx, c, owner, repo)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return ErrNotPaused
+	}
+	closed := "closed"
+	for _, issue := range issues {
+		if err := comment(ctx, c, owner, repo, issue.GetNumber(), msg); err != nil {
+			return err
+		}
+		if _, _, err := c.EditIssue(ctx, owner, repo, issue.GetNumber(), &github.IssueRequest{State: &closed}); err != nil {
+			return fmt.Errorf("failed to close pause issue #%d: %w", issue.GetNumber(), err)
+		}
+	}
+	return nil
+}
+
+// listPausedIssues returns the open issues pausing the whole repository, excluding paused pull requests.
+func listPausedIssues(ctx context.Context, c github.Client, owner, repo string) ([]*github.Issue, error) {
+	paused, err := listPaused(ctx, c, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	var issues []*github.Issue
+	for _, issue := range paused {
+		if !issue.IsPullRequest() {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+func comment(ctx context.Context, c github.Client, owner, repo string, number int, body string) error {
+	if _, _, err := c.CreateIssueComment(ctx, owner, repo, number, &github.IssueComment{Body: &body}); err != nil {
+		return fmt.Errorf("failed to comment on #%d: %w", number, err)
+	}
+	return nil
+}
+
+func byActor(actor string) string {
+	if len(actor) == 0 {
+		return ""
+	}
+	return " by @" + actor
+}
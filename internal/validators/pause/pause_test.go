@@ -0,0 +1,127 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+// recorder records the changes made to issues by Pause and Resume.
+type recorder struct {
+	created  []string
+	comments map[int][]string
+	labelled []int
+	closed   []int
+}
+
+func (r *recorder) client(open []*github.Issue, labelled map[int]bool) *mock.Client {
+	r.comments = make(map[int][]string)
+	return &mock.Client{
+		ListIssuesFunc: func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+			return open, nil, nil
+		},
+		CreateIssueFunc: func(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+			r.created = append(r.created, issue.GetBody())
+			return &github.Issue{}, nil, nil
+		},
+		CreateIssueCommentFunc: func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+			r.comments[number] = append(r.comments[number], comment.GetBody())
+			return comment, nil, nil
+		},
+		AddLabelsToIssueFunc: func(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+			r.labelled = append(r.labelled, number)
+			return nil, nil, nil
+		},
+		RemoveLabelForIssueFunc: func(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error) {
+			if !labelled[number] {
+				return &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+			}
+			return nil, nil
+		},
+		EditIssueFunc: func(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+			if issue.GetState() == "closed" {
+				r.closed = append(r.closed, number)
+			}
+			return &github.Issue{}, nil, nil
+		},
+	}
+}
+
+func TestPause(t *testing.T) {
+	t.Run("opens issue to pause the repository", func(t *testing.T) {
+		r := &recorder{}
+		if err := Pause(context.Background(), r.client(nil, nil), "owner", "repo", 0, "alice", "incident"); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"incident\n\nPaused by @alice. Gates of all pull requests report pending until this issue is closed.\n"}
+		if !reflect.DeepEqual(r.created, want) {
+			t.Errorf("created = %q, want %q", r.created, want)
+		}
+	})
+	t.Run("comments on the open issue when the repository is already paused", func(t *testing.T) {
+		r := &recorder{}
+		if err := Pause(context.Background(), r.client([]*github.Issue{issue(3, "incident")}, nil), "owner", "repo", 0, "bob", "still broken"); err != nil {
+			t.Fatal(err)
+		}
+		if len(r.created) != 0 {
+			t.Errorf("created = %q, want none", r.created)
+		}
+		if want := []string{"Paused again by @bob: still broken"}; !reflect.DeepEqual(r.comments[3], want) {
+			t.Errorf("comments = %q, want %q", r.comments[3], want)
+		}
+	})
+	t.Run("labels the pull request", func(t *testing.T) {
+		r := &recorder{}
+		if err := Pause(context.Background(), r.client(nil, nil), "owner", "repo", 1, "", "flaky"); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.labelled, []int{1}) {
+			t.Errorf("labelled = %v, want [1]", r.labelled)
+		}
+		if want := []string{"Merge Gatekeeper is paused on this pull request: flaky"}; !reflect.DeepEqual(r.comments[1], want) {
+			t.Errorf("comments = %q, want %q", r.comments[1], want)
+		}
+	})
+}
+
+func TestResume(t *testing.T) {
+	t.Run("closes issues pausing the repository", func(t *testing.T) {
+		r := &recorder{}
+		c := r.client([]*github.Issue{pullRequest(1), issue(3, "incident")}, nil)
+		if err := Resume(context.Background(), c, "owner", "repo", 0, "alice"); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.closed, []int{3}) {
+			t.Errorf("closed = %v, want [3]", r.closed)
+		}
+		if want := []string{"Merge Gatekeeper is resumed by @alice."}; !reflect.DeepEqual(r.comments[3], want) {
+			t.Errorf("comments = %q, want %q", r.comments[3], want)
+		}
+	})
+	t.Run("returns ErrNotPaused when the repository is not paused", func(t *testing.T) {
+		r := &recorder{}
+		if err := Resume(context.Background(), r.client([]*github.Issue{pullRequest(1)}, nil), "owner", "repo", 0, ""); !errors.Is(err, ErrNotPaused) {
+			t.Errorf("Resume() error = %v, want %v", err, ErrNotPaused)
+		}
+	})
+	t.Run("unlabels the pull request", func(t *testing.T) {
+		r := &recorder{}
+		if err := Resume(context.Background(), r.client(nil, map[int]bool{1: true}), "owner", "repo", 1, ""); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"Merge Gatekeeper is resumed."}; !reflect.DeepEqual(r.comments[1], want) {
+			t.Errorf("comments = %q, want %q", r.comments[1], want)
+		}
+	})
+	t.Run("returns ErrNotPaused when the pull request is not paused", func(t *testing.T) {
+		r := &recorder{}
+		if err := Resume(context.Background(), r.client(nil, nil), "owner", "repo", 2, ""); !errors.Is(err, ErrNotPaused) {
+			t.Errorf("Resume() error = %v, want %v", err, ErrNotPaused)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "pause"
+
+// Label pauses gating while it is on an open issue, for the whole repository, or on a pull request, for the pull
+// request only.
+const Label = "merge-gatekeeper-paused"
+
+const maxIssuesPerPage = 100
+
+type pauseValidator struct {
+	owner    string
+	repo     string
+	ref      string
+	prNumber int
+	client   github.Client
+}
+
+// CreateValidator returns the validator which keeps the gate pending while gating is paused, e.g. during an incident.
+// Gating resumes as soon as the pause is lifted.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	pv := &pauseValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(pv)
+	}
+	if err := pv.validateFields(); err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
+
+func (pv *pauseValidator) Name() string {
+	return validatorName
+}
+
+func (pv *pauseValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(pv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(pv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(pv.ref) == 0 && pv.prNumber == 0 {
+		errs = append(errs, errors.New("reference of repository and pull request number are empty"))
+	}
+	if pv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (pv *pauseValidator) Validate(ctx context.Context) (validators.Status, error) {
+	issues, err := listPaused(ctx, pv.client, pv.owner, pv.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pausedPRs []int
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			return &validators.BasicStatus{
+				Message: fmt.Sprintf("gating of %s/%s is paused: %s (%s)", pv.owner, pv.repo, reason(issue), issue.GetHTMLURL()),
+			}, nil
+		}
+		pausedPRs = append(pausedPRs, issue.GetNumber())
+	}
+
+	// The pull request is only looked up when any pull request is paused, so that no request is spent otherwise.
+	if len(pausedPRs) != 0 {
+		number, err := pv.pullRequestNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range pausedPRs {
+			if n == number {
+				return &validators.BasicStatus{
+					Message: fmt.Sprintf("gating of #%d is paused by the %s label", number, Label),
+				}, nil
+			}
+		}
+	}
+	return &validators.BasicStatus{Succeeded: true, Message: "gating is not paused"}, nil
+}
+
+func (pv *pauseValidator) pullRequestNumber(ctx context.Context) (int, error) {
+	if pv.prNumber != 0 {
+		return pv.prNumber, nil
+	}
+	prs, _, err := pv.client.ListPullRequestsWithCommit(ctx, pv.owner, pv.repo, pv.ref, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up pull request of %s: %w", pv.ref, err)
+	}
+	for _, pr := range prs {
+		if pr.GetMergedAt().IsZero() && pr.GetHead().GetSHA() != pv.ref {
+			continue
+		}
+		pv.prNumber = pr.GetNumber()
+		break
+	}
+	return pv.prNumber, nil
+}
+
+// listPaused returns the open issues and pull requests carrying the label.
+func listPaused(ctx context.Context, c github.Client, owner, repo string) ([]*github.Issue, error) {
+	var paused []*github.Issue
+	page := 1
+	for {
+		issues, _, err := c.ListIssues(ctx, owner, repo, &github.IssueListByRepoOptions{
+			State:       "open",
+			Labels:      []string{Label},
+			ListOptions: github.ListOptions{Page: page, PerPage: maxIssuesPerPage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list paused issues: %w", err)
+		}
+		paused = append(paused, issues...)
+		if len(issues) < maxIssuesPerPage {
+			break
+		}
+		page++
+	}
+	return paused, nil
+}
+
+// reason returns the first line of the issue body, where Pause records why gating was paused.
+func reason(issue *github.Issue) string {
+	body := strings.TrimSpace(issue.GetBody())
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = strings.TrimSpace(body[:i])
+	}
+	if len(body) == 0 {
+		return issue.GetTitle()
+	}
+	return body
+}
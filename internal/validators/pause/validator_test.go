@@ -0,0 +1,89 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func issue(number int, body string) *github.Issue {
+	url := "https://github.com/owner/repo/issues/1"
+	return &github.Issue{Number: &number, Title: stringPtr(IssueTitle), Body: &body, HTMLURL: &url}
+}
+
+func pullRequest(number int) *github.Issue {
+	return &github.Issue{Number: &number, PullRequestLinks: &github.PullRequestLinks{}}
+}
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func TestPauseValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		issues      []*github.Issue
+		listErr     error
+		wantSuccess bool
+		wantDetail  string
+		wantErr     bool
+	}{
+		"succeeds when not paused": {
+			wantSuccess: true,
+			wantDetail:  "gating is not paused",
+		},
+		"succeeds when other pull requests are paused": {
+			issues:      []*github.Issue{pullRequest(2)},
+			wantSuccess: true,
+			wantDetail:  "gating is not paused",
+		},
+		"is pending when the repository is paused": {
+			issues:     []*github.Issue{pullRequest(2), issue(3, "incident INC-1\n\nPaused by @alice.")},
+			wantDetail: "gating of owner/repo is paused: incident INC-1 (https://github.com/owner/repo/issues/1)",
+		},
+		"is pending when the pull request is paused": {
+			issues:     []*github.Issue{pullRequest(1)},
+			wantDetail: "gating of #1 is paused by the merge-gatekeeper-paused label",
+		},
+		"returns error when issues can not be listed": {
+			listErr: errors.New("err"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListIssuesFunc: func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+					if opts.State != "open" || len(opts.Labels) != 1 || opts.Labels[0] != Label {
+						t.Errorf("unexpected options: %+v", opts)
+					}
+					return tt.issues, nil, tt.listErr
+				},
+				ListPullRequestsWithCommitFunc: func(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+					number := 1
+					return []*github.PullRequest{{Number: &number, Head: &github.PullRequestBranch{SHA: &sha}}}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSuccess)
+			}
+			if st.Detail() != tt.wantDetail {
+				t.Errorf("Detail() = %s, want %s", st.Detail(), tt.wantDetail)
+			}
+		})
+	}
+}
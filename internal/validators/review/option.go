@@ -0,0 +1,84 @@
+package review
+
+import (
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+)
+
+type Option func(rv *reviewValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(rv *reviewValidator) {
+		if len(owner) != 0 {
+			rv.owner = owner
+		}
+		if len(repo) != 0 {
+			rv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(rv *reviewValidator) {
+		if len(ref) != 0 {
+			rv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to validate, instead of looking it up by the ref.
+func WithPullRequest(number int) Option {
+	return func(rv *reviewValidator) {
+		if number != 0 {
+			rv.prNumber = number
+		}
+	}
+}
+
+// WithMinApprovals sets how many reviewers must have approved the pull request. Zero only enforces the other rules,
+// such as outstanding change requests and the two-person rule.
+func WithMinApprovals(n int) Option {
+	return func(rv *reviewValidator) {
+		if n >= 0 {
+			rv.minApprovals = n
+		}
+	}
+}
+
+// WithTwoPersonPaths sets patterns of sensitive paths (comma-separated list). Pull requests changing any of them must
+// be approved by two reviewers who are neither the author nor committers of the pull request.
+func WithTwoPersonPaths(patterns string) Option {
+	return func(rv *reviewValidator) {
+		for _, pattern := range strings.Split(patterns, ",") {
+			if pattern = strings.TrimSpace(pattern); len(pattern) != 0 {
+				rv.twoPersonPaths = append(rv.twoPersonPaths, pattern)
+			}
+		}
+	}
+}
+
+// WithDiscountPushers discounts approvals of reviewers who authored or committed commits dated after their approval,
+// which the stale review dismissal of branch protection does not cover when it is disabled or bypassed.
+func WithDiscountPushers(discount bool) Option {
+	return func(rv *reviewValidator) {
+		rv.discountPushers = discount
+	}
+}
+
+// WithFreshness requires at least one approval of the latest commit (FreshnessLatestCommit), or submitted within the
+// duration, e.g) 24h. The gate is pending until a fresh approval is given.
+func WithFreshness(freshness string) Option {
+	return func(rv *reviewValidator) {
+		rv.freshness = freshness
+	}
+}
+
+// WithClock sets the clock used to decide whether approvals are fresh.
+func WithClock(c clock.Clock) Option {
+	return func(rv *reviewValidator) {
+		if c != nil {
+			rv.clock = c
+		}
+	}
+}
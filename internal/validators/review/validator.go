@@ -0,0 +1,333 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/pathfilter"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "approvals"
+
+// NOTE: https://docs.github.com/en/rest/pulls/reviews
+const (
+	approvedState         = "APPROVED"
+	changesRequestedState = "CHANGES_REQUESTED"
+	dismissedState        = "DISMISSED"
+)
+
+const maxItemsPerPage = 100
+
+// twoPersonApprovals is how many reviewers other than the author and committers must approve changes of sensitive paths.
+const twoPersonApprovals = 2
+
+// FreshnessLatestCommit requires an approval of the latest commit of the pull request.
+const FreshnessLatestCommit = "latest-commit"
+
+type reviewValidator struct {
+	owner           string
+	repo            string
+	ref             string
+	prNumber        int
+	minApprovals    int
+	twoPersonPaths  []string
+	discountPushers bool
+	freshness       string
+	freshWithin     time.Duration
+	clock           clock.Clock
+	client          github.Client
+}
+
+// CreateValidator returns the validator which requires the pull request to be approved by enough reviewers,
+// and not to have outstanding change requests. Changes of sensitive paths additionally require the approvals of two
+// reviewers who are neither the author nor committers. The pull request is looked up by the ref when not given.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	rv := &reviewValidator{
+		client:       c,
+		minApprovals: 1,
+		clock:        clock.New(),
+	}
+	for _, opt := range opts {
+		opt(rv)
+	}
+	if err := rv.validateFields(); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (rv *reviewValidator) Name() string {
+	return validatorName
+}
+
+func (rv *reviewValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4+len(rv.twoPersonPaths))
+
+	if len(rv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(rv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(rv.ref) == 0 && rv.prNumber == 0 {
+		errs = append(errs, errors.New("reference of repository and pull request number are empty"))
+	}
+	if len(rv.freshness) != 0 && rv.freshness != FreshnessLatestCommit {
+		d, err := time.ParseDuration(rv.freshness)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("approval freshness %s is invalid. must be %s or a positive duration", rv.freshness, FreshnessLatestCommit))
+		}
+		rv.freshWithin = d
+	}
+	for _, pattern := range rv.twoPersonPaths {
+		if err := pathfilter.Validate(pattern); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if rv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (rv *reviewValidator) Validate(ctx context.Context) (validators.Status, error) {
+	number, err := rv.pullRequestNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number == 0 {
+		return &validators.BasicStatus{Message: fmt.Sprintf("no pull request is associated with %s", rv.ref)}, nil
+	}
+
+	var reviews []*github.PullRequestReview
+	page := 1
+	for {
+		rs, _, err := rv.client.ListReviews(ctx, rv.owner, rv.repo, number, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews of #%d: %w", number, err)
+		}
+		reviews = append(reviews, rs...)
+		if len(rs) < maxItemsPerPage {
+			break
+		}
+		page++
+	}
+
+	// Reviews are listed oldest first, so the last review of each reviewer wins. Comments neither approve
+	// nor request changes, so they do not override earlier reviews.
+	latest := make(map[string]*github.PullRequestReview)
+	for _, r := range reviews {
+		switch r.GetState() {
+		case approvedState, changesRequestedState, dismissedState:
+			latest[r.GetUser().GetLogin()] = r
+		}
+	}
+
+	var approvers, requesters []string
+	for login, r := range latest {
+		switch r.GetState() {
+		case approvedState:
+			approvers = append(approvers, login)
+		case changesRequestedState:
+			requesters = append(requesters, login)
+		}
+	}
+	sort.Strings(approvers)
+	sort.Strings(requesters)
+
+	if len(requesters) != 0 {
+		return nil, fmt.Errorf("#%d has changes requested by %s", number, strings.Join(requesters, ", "))
+	}
+
+	var commits []*github.RepositoryCommit
+	var discounted []string
+	if rv.discountPushers && len(approvers) != 0 {
+		if commits, err = rv.listCommits(ctx, number); err != nil {
+			return nil, err
+		}
+		approvers, discounted = discountPushers(approvers, latest, commits)
+	}
+	if rv.freshness == FreshnessLatestCommit && commits == nil {
+		if commits, err = rv.listCommits(ctx, number); err != nil {
+			return nil, err
+		}
+	}
+
+	st := &validators.BasicStatus{
+		Succeeded: len(approvers) >= rv.minApprovals,
+		Message:   fmt.Sprintf("#%d is approved by %d out of %d required reviewers", number, len(approvers), rv.minApprovals),
+	}
+	if len(approvers) != 0 {
+		st.Message += fmt.Sprintf(": %s", strings.Join(approvers, ", "))
+	}
+	if len(discounted) != 0 {
+		st.Message += fmt.Sprintf(". Approvals of %s are discounted, as they pushed commits after approving", strings.Join(discounted, ", "))
+	}
+	if len(rv.freshness) != 0 && !rv.hasFreshApproval(approvers, latest, commits) {
+		st.Succeeded = false
+		if rv.freshness == FreshnessLatestCommit {
+			st.Message += ". No approval is of the latest commit. Ask a reviewer to approve it again"
+		} else {
+			st.Message += fmt.Sprintf(". No approval was given within the last %s. Ask a reviewer to approve it again", rv.freshWithin)
+		}
+	}
+	if len(rv.twoPersonPaths) == 0 {
+		return st, nil
+	}
+
+	sensitive, err := rv.changesSensitivePaths(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if !sensitive {
+		return st, nil
+	}
+	if commits == nil {
+		if commits, err = rv.listCommits(ctx, number); err != nil {
+			return nil, err
+		}
+	}
+	excluded, err := rv.authorAndCommitters(ctx, number, commits)
+	if err != nil {
+		return nil, err
+	}
+	var independent []string
+	for _, login := range approvers {
+		if !excluded[login] {
+			independent = append(independent, login)
+		}
+	}
+	st.Succeeded = st.Succeeded && len(independent) >= twoPersonApprovals
+	st.Message += fmt.Sprintf(". #%d changes sensitive paths, which are approved by %d out of %d required reviewers other than the author and committers", number, len(independent), twoPersonApprovals)
+	return st, nil
+}
+
+// changesSensitivePaths reports whether the pull request changes any of the sensitive paths. Renamed files are
+// matched by both names, so that moving files out of a sensitive path is also a sensitive change.
+func (rv *reviewValidator) changesSensitivePaths(ctx context.Context, number int) (bool, error) {
+	page := 1
+	for {
+		files, _, err := rv.client.ListPullRequestFiles(ctx, rv.owner, rv.repo, number, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return false, fmt.Errorf("failed to list files of #%d: %w", number, err)
+		}
+		for _, f := range files {
+			if pathfilter.MatchAny(rv.twoPersonPaths, f.GetFilename()) {
+				return true, nil
+			}
+			if name := f.GetPreviousFilename(); len(name) != 0 && pathfilter.MatchAny(rv.twoPersonPaths, name) {
+				return true, nil
+			}
+		}
+		if len(files) < maxItemsPerPage {
+			return false, nil
+		}
+		page++
+	}
+}
+
+// authorAndCommitters returns the logins of the author of the pull request, and of the authors and committers of
+// its commits, whose approvals do not count for the two-person rule.
+func (rv *reviewValidator) authorAndCommitters(ctx context.Context, number int, commits []*github.RepositoryCommit) (map[string]bool, error) {
+	pr, _, err := rv.client.GetPullRequest(ctx, rv.owner, rv.repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", number, err)
+	}
+	logins := map[string]bool{pr.GetUser().GetLogin(): true}
+	for _, c := range commits {
+		if login := c.GetAuthor().GetLogin(); len(login) != 0 {
+			logins[login] = true
+		}
+		if login := c.GetCommitter().GetLogin(); len(login) != 0 {
+			logins[login] = true
+		}
+	}
+	return logins, nil
+}
+
+func (rv *reviewValidator) listCommits(ctx context.Context, number int) ([]*github.RepositoryCommit, error) {
+	var commits []*github.RepositoryCommit
+	page := 1
+	for {
+		cs, _, err := rv.client.ListPullRequestCommits(ctx, rv.owner, rv.repo, number, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of #%d: %w", number, err)
+		}
+		commits = append(commits, cs...)
+		if len(cs) < maxItemsPerPage {
+			return commits, nil
+		}
+		page++
+	}
+}
+
+// hasFreshApproval reports whether any approval counted is of the latest commit, or submitted within the freshness
+// window.
+func (rv *reviewValidator) hasFreshApproval(approvers []string, latest map[string]*github.PullRequestReview, commits []*github.RepositoryCommit) bool {
+	for _, login := range approvers {
+		r := latest[login]
+		if rv.freshness == FreshnessLatestCommit {
+			if len(commits) != 0 && r.GetCommitID() == commits[len(commits)-1].GetSHA() {
+				return true
+			}
+			continue
+		}
+		if rv.clock.Now().Sub(r.GetSubmittedAt().Time) <= rv.freshWithin {
+			return true
+		}
+	}
+	return false
+}
+
+// discountPushers splits the approvers into those whose approvals count, and those who authored or committed any
+// commit dated after their approval. Their approvals do not cover the changes they made after approving.
+func discountPushers(approvers []string, latest map[string]*github.PullRequestReview, commits []*github.RepositoryCommit) (counted, discounted []string) {
+	pushed := make(map[string]bool)
+	for _, c := range commits {
+		date := c.GetCommit().GetCommitter().GetDate().Time
+		for _, login := range []string{c.GetAuthor().GetLogin(), c.GetCommitter().GetLogin()} {
+			if r, ok := latest[login]; ok && date.After(r.GetSubmittedAt().Time) {
+				pushed[login] = true
+			}
+		}
+	}
+	for _, login := range approvers {
+		if pushed[login] {
+			discounted = append(discounted, login)
+		} else {
+			counted = append(counted, login)
+		}
+	}
+	return counted, discounted
+}
+
+func (rv *reviewValidator) pullRequestNumber(ctx context.Context) (int, error) {
+	if rv.prNumber != 0 {
+		return rv.prNumber, nil
+	}
+	prs, _, err := rv.client.ListPullRequestsWithCommit(ctx, rv.owner, rv.repo, rv.ref, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up pull request of %s: %w", rv.ref, err)
+	}
+	for _, pr := range prs {
+		if pr.GetMergedAt().IsZero() && pr.GetHead().GetSHA() != rv.ref {
+			continue
+		}
+		rv.prNumber = pr.GetNumber()
+		break
+	}
+	return rv.prNumber, nil
+}
@@ -0,0 +1,310 @@
+package review
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func review(login, state string) *github.PullRequestReview {
+	return &github.PullRequestReview{User: &github.User{Login: &login}, State: &state}
+}
+
+func TestReviewValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		minApprovals int
+		prs          []*github.PullRequest
+		reviews      []*github.PullRequestReview
+		wantSuccess  bool
+		wantErr      bool
+	}{
+		"succeeds when approved": {
+			reviews:     []*github.PullRequestReview{review("alice", "APPROVED")},
+			wantSuccess: true,
+		},
+		"keeps approval after comments": {
+			reviews:     []*github.PullRequestReview{review("alice", "APPROVED"), review("alice", "COMMENTED")},
+			wantSuccess: true,
+		},
+		"is pending without enough approvals": {
+			minApprovals: 2,
+			reviews:      []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "DISMISSED")},
+			wantSuccess:  false,
+		},
+		"is pending without pull request": {
+			prs:         []*github.PullRequest{},
+			wantSuccess: false,
+		},
+		"returns error when changes are requested": {
+			reviews: []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "CHANGES_REQUESTED")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListPullRequestsWithCommitFunc: func(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+					if tt.prs != nil {
+						return tt.prs, nil, nil
+					}
+					number := 1
+					return []*github.PullRequest{{Number: &number, Head: &github.PullRequestBranch{SHA: &sha}}}, nil, nil
+				},
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return tt.reviews, nil, nil
+				},
+			}
+			opts := []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha")}
+			if tt.minApprovals != 0 {
+				opts = append(opts, WithMinApprovals(tt.minApprovals))
+			}
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
+
+func commit(author, committer string) *github.RepositoryCommit {
+	return &github.RepositoryCommit{Author: &github.User{Login: &author}, Committer: &github.User{Login: &committer}}
+}
+
+func TestReviewValidator_Validate_twoPersonRule(t *testing.T) {
+	tests := map[string]struct {
+		minApprovals int
+		files        []string
+		reviews      []*github.PullRequestReview
+		wantSuccess  bool
+	}{
+		"succeeds with two independent approvals of sensitive paths": {
+			files:       []string{"README.md", "infra/prod/main.tf"},
+			reviews:     []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "APPROVED")},
+			wantSuccess: true,
+		},
+		"does not count approvals of committers": {
+			files:   []string{"infra/prod/main.tf"},
+			reviews: []*github.PullRequestReview{review("alice", "APPROVED"), review("carol", "APPROVED")},
+		},
+		"does not count approvals of the author": {
+			files:   []string{"infra/prod/main.tf"},
+			reviews: []*github.PullRequestReview{review("alice", "APPROVED"), review("author", "APPROVED")},
+		},
+		"requires no approval when sensitive paths are not changed": {
+			files:       []string{"README.md"},
+			wantSuccess: true,
+		},
+		"keeps requiring minimum approvals": {
+			minApprovals: 3,
+			files:        []string{"infra/prod/main.tf"},
+			reviews:      []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "APPROVED")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return tt.reviews, nil, nil
+				},
+				ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+					files := make([]*github.CommitFile, 0, len(tt.files))
+					for _, name := range tt.files {
+						name := name
+						files = append(files, &github.CommitFile{Filename: &name})
+					}
+					return files, nil, nil
+				},
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					login := "author"
+					return &github.PullRequest{User: &github.User{Login: &login}}, nil, nil
+				},
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					return []*github.RepositoryCommit{commit("author", "web-flow"), commit("author", "carol")}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithMinApprovals(tt.minApprovals),
+				WithTwoPersonPaths("infra/**, .github/workflows/**"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
+
+func TestReviewValidator_Validate_discountPushers(t *testing.T) {
+	approvedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	approval := func(login string) *github.PullRequestReview {
+		r := review(login, "APPROVED")
+		r.SubmittedAt = &github.Timestamp{Time: approvedAt}
+		return r
+	}
+	pushed := func(author string, at time.Time) *github.RepositoryCommit {
+		c := commit(author, "web-flow")
+		c.Commit = &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: at}}}
+		return c
+	}
+
+	tests := map[string]struct {
+		discount    bool
+		commits     []*github.RepositoryCommit
+		wantSuccess bool
+		wantMessage string
+	}{
+		"discounts approval of reviewer who pushed after approving": {
+			discount:    true,
+			commits:     []*github.RepositoryCommit{pushed("author", approvedAt.Add(-time.Hour)), pushed("alice", approvedAt.Add(time.Hour))},
+			wantMessage: "#1 is approved by 1 out of 2 required reviewers: bob. Approvals of alice are discounted, as they pushed commits after approving",
+		},
+		"counts approval of reviewer who pushed before approving": {
+			discount:    true,
+			commits:     []*github.RepositoryCommit{pushed("alice", approvedAt.Add(-time.Hour))},
+			wantSuccess: true,
+			wantMessage: "#1 is approved by 2 out of 2 required reviewers: alice, bob",
+		},
+		"counts every approval when disabled": {
+			commits:     []*github.RepositoryCommit{pushed("alice", approvedAt.Add(time.Hour))},
+			wantSuccess: true,
+			wantMessage: "#1 is approved by 2 out of 2 required reviewers: alice, bob",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return []*github.PullRequestReview{approval("alice"), approval("bob")}, nil, nil
+				},
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					return tt.commits, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithMinApprovals(2),
+				WithDiscountPushers(tt.discount),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSuccess)
+			}
+			if st.Detail() != tt.wantMessage {
+				t.Errorf("Detail() = %s, want %s", st.Detail(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestReviewValidator_Validate_freshness(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	approval := func(login, commitID string, submittedAt time.Time) *github.PullRequestReview {
+		r := review(login, "APPROVED")
+		r.CommitID = &commitID
+		r.SubmittedAt = &github.Timestamp{Time: submittedAt}
+		return r
+	}
+
+	tests := map[string]struct {
+		freshness   string
+		reviews     []*github.PullRequestReview
+		wantSuccess bool
+		wantMessage string
+	}{
+		"succeeds with approval of the latest commit": {
+			freshness:   FreshnessLatestCommit,
+			reviews:     []*github.PullRequestReview{approval("alice", "sha-1", now.Add(-48*time.Hour)), approval("bob", "sha-2", now.Add(-48*time.Hour))},
+			wantSuccess: true,
+			wantMessage: "#1 is approved by 2 out of 1 required reviewers: alice, bob",
+		},
+		"is pending without approval of the latest commit": {
+			freshness:   FreshnessLatestCommit,
+			reviews:     []*github.PullRequestReview{approval("alice", "sha-1", now)},
+			wantMessage: "#1 is approved by 1 out of 1 required reviewers: alice. No approval is of the latest commit. Ask a reviewer to approve it again",
+		},
+		"succeeds with approval within the window": {
+			freshness:   "24h",
+			reviews:     []*github.PullRequestReview{approval("alice", "sha-1", now.Add(-48*time.Hour)), approval("bob", "sha-1", now.Add(-time.Hour))},
+			wantSuccess: true,
+			wantMessage: "#1 is approved by 2 out of 1 required reviewers: alice, bob",
+		},
+		"is pending without approval within the window": {
+			freshness:   "24h",
+			reviews:     []*github.PullRequestReview{approval("alice", "sha-2", now.Add(-48*time.Hour))},
+			wantMessage: "#1 is approved by 1 out of 1 required reviewers: alice. No approval was given within the last 24h0m0s. Ask a reviewer to approve it again",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return tt.reviews, nil, nil
+				},
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					sha1, sha2 := "sha-1", "sha-2"
+					return []*github.RepositoryCommit{{SHA: &sha1}, {SHA: &sha2}}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithFreshness(tt.freshness),
+				WithClock(clockmock.NewClock(now)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSuccess)
+			}
+			if st.Detail() != tt.wantMessage {
+				t.Errorf("Detail() = %s, want %s", st.Detail(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCreateValidator_freshness(t *testing.T) {
+	for _, freshness := range []string{"yesterday", "-1h"} {
+		if _, err := CreateValidator(&mock.Client{}, WithGitHubOwnerAndRepo("owner", "repo"), WithPullRequest(1), WithFreshness(freshness)); err == nil {
+			t.Errorf("CreateValidator() with freshness %s error = nil, want error", freshness)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package stale
+
+import (
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+)
+
+type Option func(sv *staleValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(sv *staleValidator) {
+		if len(owner) != 0 {
+			sv.owner = owner
+		}
+		if len(repo) != 0 {
+			sv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(sv *staleValidator) {
+		if len(ref) != 0 {
+			sv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request to validate, instead of looking it up by the ref.
+func WithPullRequest(number int) Option {
+	return func(sv *staleValidator) {
+		if number != 0 {
+			sv.prNumber = number
+		}
+	}
+}
+
+// WithMaxAge sets how long the pull request may go without commits before it is stale.
+func WithMaxAge(d time.Duration) Option {
+	return func(sv *staleValidator) {
+		sv.maxAge = d
+	}
+}
+
+// WithResult sets the result of stale pull requests, either ResultFailure or ResultNeutral.
+func WithResult(result string) Option {
+	return func(sv *staleValidator) {
+		if len(result) != 0 {
+			sv.result = result
+		}
+	}
+}
+
+// WithComment enables commenting the guidance on stale pull requests, once per validation.
+func WithComment(enabled bool) Option {
+	return func(sv *staleValidator) {
+		sv.comment = enabled
+	}
+}
+
+// WithTitle sets the title heading the guidance comments, which identifies the gate posting them.
+func WithTitle(title string) Option {
+	return func(sv *staleValidator) {
+		sv.title = title
+	}
+}
+
+// WithClock sets the clock used to measure how long the pull request has gone without commits.
+func WithClock(c clock.Clock) Option {
+	return func(sv *staleValidator) {
+		if c != nil {
+			sv.clock = c
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package stale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/fingerprint"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "stale"
+
+// Results of stale pull requests.
+const (
+	// ResultFailure fails the gate.
+	ResultFailure = "failure"
+	// ResultNeutral only reports the guidance, and leaves the decision to the other validators.
+	ResultNeutral = "neutral"
+)
+
+const maxItemsPerPage = 100
+
+type staleValidator struct {
+	owner     string
+	repo      string
+	ref       string
+	prNumber  int
+	maxAge    time.Duration
+	result    string
+	comment   bool
+	commented bool
+	title     string
+	clock     clock.Clock
+	client    github.Client
+}
+
+// CreateValidator returns the validator which reports pull requests without commits for longer than the max age as
+// stale, with guidance to rebase them and push to re-trigger the gate. Results of old runs of the required jobs may
+// no longer hold on the current base branch, so they must not imply the pull request is ready.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	sv := &staleValidator{
+		client: c,
+		result: ResultFailure,
+		clock:  clock.New(),
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if err := sv.validateFields(); err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+func (sv *staleValidator) Name() string {
+	return validatorName
+}
+
+func (sv *staleValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 6)
+
+	if len(sv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(sv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(sv.ref) == 0 && sv.prNumber == 0 {
+		errs = append(errs, errors.New("reference of repository and pull request number are empty"))
+	}
+	if sv.maxAge <= 0 {
+		errs = append(errs, errors.New("max age is not positive"))
+	}
+	if sv.result != ResultFailure && sv.result != ResultNeutral {
+		errs = append(errs, fmt.Errorf("result %s is invalid. must be %s or %s", sv.result, ResultFailure, ResultNeutral))
+	}
+	if sv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (sv *staleValidator) Validate(ctx context.Context) (validators.Status, error) {
+	number, err := sv.pullRequestNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number == 0 {
+		return &validators.BasicStatus{Succeeded: true, Message: fmt.Sprintf("no pull request is associated with %s", sv.ref)}, nil
+	}
+
+	lastCommit, err := sv.lastCommitDate(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	age := sv.clock.Now().Sub(lastCommit)
+	if age <= sv.maxAge {
+		return &validators.BasicStatus{
+			Succeeded: true,
+			Message:   fmt.Sprintf("#%d was last committed to %s ago", number, humanize(age)),
+		}, nil
+	}
+
+	pr, _, err := sv.client.GetPullRequest(ctx, sv.owner, sv.repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", number, err)
+	}
+	guidance := fmt.Sprintf("#%d is stale, as it has had no commits for %s, longer than %s. Rebase it onto %s and push to re-trigger the gate",
+		number, humanize(age), humanize(sv.maxAge), pr.GetBase().GetRef())
+
+	if sv.comment && !sv.commented {
+		err := sv.commentGuidance(ctx, number, guidance, lastCommit, pr.GetBase().GetRef())
+		switch {
+		case github.IsForbidden(err):
+			// Read-only tokens of GitHub Apps are only found by their writes, so the guidance is only reported rather
+			// than failing on every poll.
+			sv.comment = false
+		case err != nil:
+			// The comment is only a side effect of the validation, so failing to post it is noted along with the
+			// guidance rather than changing the result, and retried by the next poll.
+			guidance = fmt.Sprintf("%s\nWARNING: %v", guidance, err)
+		default:
+			sv.commented = true
+		}
+	}
+
+	if sv.result == ResultNeutral {
+		return &validators.BasicStatus{Succeeded: true, Message: guidance}, nil
+	}
+	return nil, errors.New(guidance)
+}
+
+// commentGuidance comments the guidance, unless it has already been commented for the same commits and base branch
+// by an earlier run. The age in the guidance grows every day, so it is not part of the fingerprint.
+func (sv *staleValidator) commentGuidance(ctx context.Context, number int, guidance string, lastCommit time.Time, base string) error {
+	fp := fingerprint.Of(validatorName, lastCommit.UTC().Format(time.RFC3339), base, sv.title)
+	commented, err := fingerprint.Commented(ctx, sv.client, sv.owner, sv.repo, number, fp)
+	if err != nil {
+		return err
+	}
+	if commented {
+		return nil
+	}
+
+	body := guidance + ".\n\nResults of the required jobs this old may no longer hold on the current base branch, so the gate does not treat this pull request as ready.\n\n" + fingerprint.Marker(fp)
+	if len(sv.title) != 0 {
+		body = fmt.Sprintf("**%s**\n\n%s", sv.title, body)
+	}
+	if _, _, err := sv.client.CreateIssueComment(ctx, sv.owner, sv.repo, number, &github.IssueComment{Body: &body}); err != nil {
+		return fmt.Errorf("failed to comment on #%d: %w", number, err)
+	}
+	return nil
+}
+
+// lastCommitDate returns the latest committer date of the commits of the pull request.
+func (sv *staleValidator) lastCommitDate(ctx context.Context, number int) (time.Time, error) {
+	var last time.Time
+	page := 1
+	for {
+		commits, _, err := sv.client.ListPullRequestCommits(ctx, sv.owner, sv.repo, number, &github.ListOptions{Page: page, PerPage: maxItemsPerPage})
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to list commits of #%d: %w", number, err)
+		}
+		for _, c := range commits {
+			if date := c.GetCommit().GetCommitter().GetDate().Time; date.After(last) {
+				last = date
+			}
+		}
+		if len(commits) < maxItemsPerPage {
+			return last, nil
+		}
+		page++
+	}
+}
+
+func (sv *staleValidator) pullRequestNumber(ctx context.Context) (int, error) {
+	if sv.prNumber != 0 {
+		return sv.prNumber, nil
+	}
+	prs, _, err := sv.client.ListPullRequestsWithCommit(ctx, sv.owner, sv.repo, sv.ref, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up pull request of %s: %w", sv.ref, err)
+	}
+	for _, pr := range prs {
+		if pr.GetMergedAt().IsZero() && pr.GetHead().GetSHA() != sv.ref {
+			continue
+		}
+		sv.prNumber = pr.GetNumber()
+		break
+	}
+	return sv.prNumber, nil
+}
+
+// humanize formats the duration in whole days, or in hours when shorter than a day.
+func humanize(d time.Duration) string {
+	const day = 24 * time.Hour
+	n, unit := int(d/day), "day"
+	if d < day {
+		n, unit = int(d/time.Hour), "hour"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
@@ -0,0 +1,141 @@
+package stale
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	clockmock "github.com/aac228/merge-gatekeeper/internal/clock/mock"
+	"github.com/aac228/merge-gatekeeper/internal/fingerprint"
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+var now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func commit(date time.Time) *github.RepositoryCommit {
+	return &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}}}}
+}
+
+func TestStaleValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		result      string
+		comment     bool
+		commits     []*github.RepositoryCommit
+		wantSuccess bool
+		wantErr     bool
+		wantMessage string
+		wantComment bool
+		comments    []*github.IssueComment
+		commentErr  error
+	}{
+		"succeeds with recent commits": {
+			commits:     []*github.RepositoryCommit{commit(now.Add(-40 * 24 * time.Hour)), commit(now.Add(-25 * time.Hour))},
+			wantSuccess: true,
+			wantMessage: "#1 was last committed to 1 day ago",
+		},
+		"fails stale pull request with guidance": {
+			comment:     true,
+			commits:     []*github.RepositoryCommit{commit(now.Add(-45 * 24 * time.Hour))},
+			wantErr:     true,
+			wantMessage: "#1 is stale, as it has had no commits for 45 days, longer than 30 days. Rebase it onto main and push to re-trigger the gate",
+			wantComment: true,
+		},
+		"does not comment the same guidance again": {
+			comment:     true,
+			commits:     []*github.RepositoryCommit{commit(now.Add(-45 * 24 * time.Hour))},
+			comments:    []*github.IssueComment{{Body: stringPtr("#1 is stale, as it has had no commits for 44 days\n\n" + fingerprint.Marker(fingerprint.Of("stale", now.Add(-45*24*time.Hour).Format(time.RFC3339), "main", "")))}},
+			wantErr:     true,
+			wantMessage: "#1 is stale, as it has had no commits for 45 days, longer than 30 days. Rebase it onto main and push to re-trigger the gate",
+		},
+		"reports guidance when the token is forbidden to comment": {
+			comment:     true,
+			commits:     []*github.RepositoryCommit{commit(now.Add(-45 * 24 * time.Hour))},
+			commentErr:  &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}},
+			wantErr:     true,
+			wantMessage: "#1 is stale, as it has had no commits for 45 days, longer than 30 days. Rebase it onto main and push to re-trigger the gate",
+			wantComment: true,
+		},
+		"notes the failure to comment along with the guidance": {
+			comment:     true,
+			commits:     []*github.RepositoryCommit{commit(now.Add(-45 * 24 * time.Hour))},
+			commentErr:  errors.New("502 Bad Gateway"),
+			wantErr:     true,
+			wantMessage: "#1 is stale, as it has had no commits for 45 days, longer than 30 days. Rebase it onto main and push to re-trigger the gate\nWARNING: failed to comment on #1: 502 Bad Gateway",
+			wantComment: true,
+		},
+		"keeps neutral result when failing to comment": {
+			result:      ResultNeutral,
+			comment:     true,
+			commits:     []*github.RepositoryCommit{commit(now.Add(-45 * 24 * time.Hour))},
+			commentErr:  errors.New("rate limited"),
+			wantSuccess: true,
+			wantMessage: "#1 is stale, as it has had no commits for 45 days, longer than 30 days. Rebase it onto main and push to re-trigger the gate\nWARNING: failed to comment on #1: rate limited",
+			wantComment: true,
+		},
+		"only reports stale pull request when neutral": {
+			result:      ResultNeutral,
+			commits:     []*github.RepositoryCommit{commit(now.Add(-45 * 24 * time.Hour))},
+			wantSuccess: true,
+			wantMessage: "#1 is stale, as it has had no commits for 45 days, longer than 30 days. Rebase it onto main and push to re-trigger the gate",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var commented bool
+			c := &mock.Client{
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					return tt.commits, nil, nil
+				},
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					base := "main"
+					return &github.PullRequest{Base: &github.PullRequestBranch{Ref: &base}}, nil, nil
+				},
+				ListIssueCommentsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+					return tt.comments, nil, nil
+				},
+				CreateIssueCommentFunc: func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+					commented = true
+					return comment, nil, tt.commentErr
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithPullRequest(1),
+				WithMaxAge(30*24*time.Hour),
+				WithResult(tt.result),
+				WithComment(tt.comment),
+				WithClock(clockmock.NewClock(now)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if err.Error() != tt.wantMessage {
+					t.Errorf("Validate() error = %s, want %s", err, tt.wantMessage)
+				}
+			} else {
+				if st.IsSuccess() != tt.wantSuccess {
+					t.Errorf("IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSuccess)
+				}
+				if st.Detail() != tt.wantMessage {
+					t.Errorf("Detail() = %s, want %s", st.Detail(), tt.wantMessage)
+				}
+			}
+			if commented != tt.wantComment {
+				t.Errorf("commented = %v, want %v", commented, tt.wantComment)
+			}
+		})
+	}
+}
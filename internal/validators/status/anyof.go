@@ -0,0 +1,73 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ruleAnyOf decides the jobs of any-of groups by the outcome of their groups rather than their own states.
+const ruleAnyOf = "any-of"
+
+// parseAnyOf parses groups of jobs, at least one of which must succeed, separated by semicolons, each of whose jobs
+// are separated by |, e.g) "build-linux|build-linux-arm;Test / unit|Test / unit-legacy". Jobs are named as in the
+// ignored jobs, optionally qualified by their workflows.
+func parseAnyOf(spec string) ([][]string, error) {
+	var groups [][]string
+	for _, g := range strings.Split(spec, ";") {
+		if len(strings.TrimSpace(g)) == 0 {
+			continue
+		}
+		var group []string
+		for _, job := range strings.Split(g, "|") {
+			if job = strings.TrimSpace(job); len(job) != 0 {
+				group = append(group, job)
+			}
+		}
+		if len(group) < 2 {
+			return nil, fmt.Errorf("any-of group %q must have at least two jobs", strings.TrimSpace(g))
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// anyOfGroup returns the index of the first any-of group the job belongs to, or -1 when none.
+func (sv *statusValidator) anyOfGroup(gs *ghaStatus) int {
+	for i, group := range sv.anyOf {
+		for _, job := range group {
+			if gs.Job == job || gs.String() == job {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// anyOfOutcomes returns the outcome of every any-of group by its jobs other than this job and the ignored jobs: it
+// succeeds once any of them succeeds, fails only when all of them failed, and is pending otherwise. Jobs which never
+// appear, e.g) skipped by conditions, are not waited for. Groups without any job have no outcome.
+func (sv *statusValidator) anyOfOutcomes(statuses []*ghaStatus) []string {
+	if len(sv.anyOf) == 0 {
+		return nil
+	}
+	outcomes := make([]string, len(sv.anyOf))
+	for _, gs := range statuses {
+		g := sv.anyOfGroup(gs)
+		if g < 0 || sv.isSelf(gs) || gs.Sibling || gs.Unresolved {
+			continue
+		}
+		if _, ignored := sv.ignoredBy(gs); ignored {
+			continue
+		}
+		switch {
+		case gs.State == successState:
+			outcomes[g] = successState
+		case outcomes[g] == successState:
+		case gs.State == pendingState:
+			outcomes[g] = pendingState
+		case outcomes[g] != pendingState:
+			outcomes[g] = errorState
+		}
+	}
+	return outcomes
+}
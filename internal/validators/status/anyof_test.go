@@ -0,0 +1,149 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+func Test_parseAnyOf(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		want    [][]string
+		wantErr bool
+	}{
+		"parses groups": {
+			spec: "build-linux | build-linux-arm; Test / unit|Test / unit-legacy;",
+			want: [][]string{{"build-linux", "build-linux-arm"}, {"Test / unit", "Test / unit-legacy"}},
+		},
+		"parses nothing": {},
+		"returns error with a single job": {
+			spec:    "build-linux|",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseAnyOf(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAnyOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAnyOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_anyOf(t *testing.T) {
+	run := func(name, conclusion string) *github.CheckRun {
+		cr := &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus)}
+		if len(conclusion) != 0 {
+			cr.Status, cr.Conclusion = stringPtr(checkRunCompletedStatus), stringPtr(conclusion)
+		}
+		return cr
+	}
+	tests := map[string]struct {
+		checkRuns []*github.CheckRun
+		wantState string
+	}{
+		"succeeds once any job of the group succeeds": {
+			checkRuns: []*github.CheckRun{run("build-linux", checkRunFailedConclusion), run("build-linux-arm", checkRunSuccessConclusion)},
+			wantState: validators.StateSuccess,
+		},
+		"succeeds while other jobs of the succeeded group are running": {
+			checkRuns: []*github.CheckRun{run("build-linux", checkRunSuccessConclusion), run("build-linux-arm", "")},
+			wantState: validators.StateSuccess,
+		},
+		"waits for the other jobs of the group after a failure": {
+			checkRuns: []*github.CheckRun{run("build-linux", checkRunFailedConclusion), run("build-linux-arm", "")},
+			wantState: validators.StatePending,
+		},
+		"fails when every job of the group failed": {
+			checkRuns: []*github.CheckRun{run("build-linux", checkRunFailedConclusion), run("build-linux-arm", checkRunFailedConclusion)},
+			wantState: validators.StateFailure,
+		},
+		"does not wait for jobs which never appear": {
+			checkRuns: []*github.CheckRun{run("build-linux", checkRunFailedConclusion)},
+			wantState: validators.StateFailure,
+		},
+		"fails on other jobs regardless of the group": {
+			checkRuns: []*github.CheckRun{run("build-linux", checkRunSuccessConclusion), run("test", checkRunFailedConclusion)},
+			wantState: validators.StateFailure,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				anyOf:       [][]string{{"build-linux", "build-linux-arm"}},
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						return &github.WorkflowRuns{}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			state := validators.StatePending
+			var fcErr *validators.FailedChecksError
+			switch {
+			case errors.As(err, &fcErr):
+				state = validators.StateFailure
+			case err != nil:
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			case got.IsSuccess():
+				state = validators.StateSuccess
+			}
+			if state != tt.wantState {
+				t.Errorf("statusValidator.Validate() state = %s, want %s", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_anyOfPolls(t *testing.T) {
+	run := func(name, conclusion string) *github.CheckRun {
+		cr := &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus)}
+		if len(conclusion) != 0 {
+			cr.Status, cr.Conclusion = stringPtr(checkRunCompletedStatus), stringPtr(conclusion)
+		}
+		return cr
+	}
+	polls := [][]*github.CheckRun{
+		{run("build-linux", ""), run("build-linux-arm", "")},
+		{run("build-linux", checkRunSuccessConclusion), run("build-linux-arm", "")},
+	}
+	var poll int
+	sv := &statusValidator{
+		selfJobName: "self-job",
+		anyOf:       [][]string{{"build-linux", "build-linux-arm"}},
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: polls[poll]}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				return &github.WorkflowRuns{}, nil, nil
+			},
+		},
+	}
+
+	// Jobs observed pending have not vanished once they are ignored along with their groups which succeeded.
+	wantSucceeded := []bool{false, true}
+	for poll = range polls {
+		got, err := sv.Validate(context.Background())
+		if err != nil {
+			t.Fatalf("poll %d: statusValidator.Validate() error = %v", poll, err)
+		}
+		if st := got.(*status); st.succeeded != wantSucceeded[poll] || len(st.notes) != 0 {
+			t.Errorf("poll %d: succeeded = %v, notes = %v, want %v without notes", poll, st.succeeded, st.notes, wantSucceeded[poll])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package status
+
+import (
+	"sort"
+	"time"
+)
+
+// estimates holds the estimated completion times of the pending workflows.
+type estimates struct {
+	now       time.Time
+	workflows map[string]time.Time
+	overall   time.Time
+}
+
+// estimateCompletion estimates when each pending job finishes, based on the durations of the jobs which
+// already completed for the same ref. Durations of the same workflow are preferred, as jobs of a workflow
+// tend to take similar time. It returns nil when there is nothing to estimate from.
+func estimateCompletion(now time.Time, statuses []*ghaStatus) *estimates {
+	byWorkflow := make(map[string][]time.Duration)
+	var all []time.Duration
+	for _, gs := range statuses {
+		if gs.State == pendingState || gs.StartedAt.IsZero() || gs.CompletedAt.IsZero() {
+			continue
+		}
+		d := gs.CompletedAt.Sub(gs.StartedAt)
+		byWorkflow[gs.Workflow] = append(byWorkflow[gs.Workflow], d)
+		all = append(all, d)
+	}
+
+	es := &estimates{
+		now:       now,
+		workflows: make(map[string]time.Time),
+	}
+	for _, gs := range statuses {
+		if gs.State != pendingState {
+			continue
+		}
+		durations, ok := byWorkflow[gs.Workflow]
+		if !ok {
+			durations = all
+		}
+		if len(durations) == 0 {
+			continue
+		}
+
+		start := gs.StartedAt
+		if start.IsZero() {
+			start = now // Still queued.
+		}
+		eta := start.Add(median(durations))
+		if eta.Before(now) {
+			eta = now // Overdue, the best we can say is that it should finish anytime soon.
+		}
+		if eta.After(es.workflows[gs.Workflow]) {
+			es.workflows[gs.Workflow] = eta
+		}
+		if eta.After(es.overall) {
+			es.overall = eta
+		}
+	}
+
+	if len(es.workflows) == 0 {
+		return nil
+	}
+	return es
+}
+
+// remaining returns the time left until the eta, rounded to seconds.
+func (es *estimates) remaining(eta time.Time) time.Duration {
+	return eta.Sub(es.now).Round(time.Second)
+}
+
+func median(ds []time.Duration) time.Duration {
+	sorted := make([]time.Duration, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,65 @@
+package status
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_estimateCompletion(t *testing.T) {
+	now := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	minutesAgo := func(m int) time.Time { return now.Add(-time.Duration(m) * time.Minute) }
+
+	tests := map[string]struct {
+		statuses []*ghaStatus
+		want     *estimates
+	}{
+		"returns nil when nothing has completed yet": {
+			statuses: []*ghaStatus{
+				{Job: "job-01", Workflow: "CI", State: pendingState, StartedAt: minutesAgo(1)},
+			},
+			want: nil,
+		},
+		"returns nil when nothing is pending": {
+			statuses: []*ghaStatus{
+				{Job: "job-01", Workflow: "CI", State: successState, StartedAt: minutesAgo(10), CompletedAt: minutesAgo(5)},
+			},
+			want: nil,
+		},
+		"estimates from the durations of the same workflow": {
+			statuses: []*ghaStatus{
+				{Job: "job-01", Workflow: "CI", State: successState, StartedAt: minutesAgo(20), CompletedAt: minutesAgo(10)},
+				{Job: "job-02", Workflow: "Lint", State: successState, StartedAt: minutesAgo(20), CompletedAt: minutesAgo(19)},
+				{Job: "job-03", Workflow: "CI", State: pendingState, StartedAt: minutesAgo(4)},
+			},
+			want: &estimates{
+				now:       now,
+				workflows: map[string]time.Time{"CI": now.Add(6 * time.Minute)},
+				overall:   now.Add(6 * time.Minute),
+			},
+		},
+		"falls back to the durations of all workflows, and starts queued jobs now": {
+			statuses: []*ghaStatus{
+				{Job: "job-01", Workflow: "CI", State: errorState, StartedAt: minutesAgo(20), CompletedAt: minutesAgo(18)},
+				{Job: "job-02", Workflow: "E2E", State: pendingState},
+				{Job: "job-03", Workflow: "Lint", State: pendingState, StartedAt: minutesAgo(5)},
+			},
+			want: &estimates{
+				now: now,
+				workflows: map[string]time.Time{
+					"E2E":  now.Add(2 * time.Minute),
+					"Lint": now, // Overdue
+				},
+				overall: now.Add(2 * time.Minute),
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := estimateCompletion(now, tt.statuses)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("estimateCompletion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+// gateActionRepo is the name of the repository of the action, by which the jobs of other instances of the gate are
+// recognized in workflow definitions, whichever fork or version they use.
+const gateActionRepo = "merge-gatekeeper"
+
+// loadGateJobs finds the jobs running the action in the definitions of the workflows of the checks, at the ref, so
+// that the other instances of the gate are ignored without naming them. Definitions are cached by their paths, as
+// they do not change while validating.
+func (sv *statusValidator) loadGateJobs(ctx context.Context, statuses []*ghaStatus) error {
+	if sv.gateJobs == nil {
+		sv.gateJobs = make(map[string][]gateJobName)
+	}
+	for _, gs := range statuses {
+		if len(gs.Path) == 0 {
+			continue
+		}
+		if _, ok := sv.gateJobs[gs.Path]; ok {
+			continue
+		}
+		content, _, _, err := sv.client.GetContents(ctx, sv.owner, sv.repo, gs.Path, &github.RepositoryContentGetOptions{Ref: sv.ref})
+		if err != nil {
+			return fmt.Errorf("failed to get workflow definition %s: %w", gs.Path, err)
+		}
+		str, err := content.GetContent()
+		if err != nil {
+			return fmt.Errorf("failed to decode workflow definition %s: %w", gs.Path, err)
+		}
+		jobs, err := parseGateJobs([]byte(str))
+		if err != nil {
+			return fmt.Errorf("failed to parse workflow definition %s: %w", gs.Path, err)
+		}
+		sv.gateJobs[gs.Path] = jobs
+	}
+	return nil
+}
+
+// isOtherGateRun reports whether the check is a job running the action, as found by loadGateJobs. The jobs of a
+// matrix are named after the job, e.g) merge-gatekeeper (pull_request).
+func (sv *statusValidator) isOtherGateRun(gs *ghaStatus) bool {
+	for _, job := range sv.gateJobs[gs.Path] {
+		if gs.Job == job.name || strings.HasPrefix(gs.Job, job.name+" (") || (job.prefix && strings.HasPrefix(gs.Job, job.name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// gateJobName is the name of a job running the action, or its part before an expression when it is a prefix.
+type gateJobName struct {
+	name   string
+	prefix bool
+}
+
+// parseGateJobs returns the names of the jobs of the workflow definition with a step using the action. Names given
+// by expressions, e.g) gate-${{ matrix.component }}, are matched by their parts before the expressions.
+func parseGateJobs(definition []byte) ([]gateJobName, error) {
+	var wf struct {
+		Jobs map[string]struct {
+			Name  string `yaml:"name"`
+			Steps []struct {
+				Uses string `yaml:"uses"`
+			} `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(definition, &wf); err != nil {
+		return nil, err
+	}
+
+	var names []gateJobName
+	for key, job := range wf.Jobs {
+		var gate bool
+		for _, step := range job.Steps {
+			gate = gate || isGateAction(step.Uses)
+		}
+		if !gate {
+			continue
+		}
+		name := gateJobName{name: key}
+		if len(job.Name) != 0 {
+			name.name = job.Name
+		}
+		if i := strings.Index(name.name, "${{"); i >= 0 {
+			name = gateJobName{name: name.name[:i], prefix: true}
+		}
+		if len(strings.TrimSpace(name.name)) != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].name < names[j].name
+	})
+	return names, nil
+}
+
+// isGateAction reports whether the step uses the action, e.g) aac228/merge-gatekeeper@v1.
+func isGateAction(uses string) bool {
+	ref, _, _ := strings.Cut(uses, "@")
+	parts := strings.Split(ref, "/")
+	return len(parts) >= 2 && !strings.HasPrefix(ref, ".") && parts[1] == gateActionRepo
+}
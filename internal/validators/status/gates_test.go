@@ -0,0 +1,161 @@
+package status
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+)
+
+func Test_parseGateJobs(t *testing.T) {
+	tests := map[string]struct {
+		definition string
+		want       []gateJobName
+	}{
+		"finds jobs using the action by their names or keys": {
+			definition: `
+jobs:
+  merge-gatekeeper:
+    steps:
+      - uses: aac228/merge-gatekeeper@main
+  backend:
+    name: Backend gate
+    steps:
+      - uses: actions/checkout@v4
+      - uses: upsidr/merge-gatekeeper@v1
+  test:
+    steps:
+      - uses: actions/checkout@v4
+      - run: go test ./...
+`,
+			want: []gateJobName{{name: "Backend gate"}, {name: "merge-gatekeeper"}},
+		},
+		"matches names given by expressions by their prefixes": {
+			definition: `
+jobs:
+  gate:
+    name: gate-${{ matrix.component }}
+    steps:
+      - uses: someone/merge-gatekeeper@v1
+`,
+			want: []gateJobName{{name: "gate-", prefix: true}},
+		},
+		"ignores local and similarly named actions": {
+			definition: `
+jobs:
+  local:
+    steps:
+      - uses: ./.github/actions/merge-gatekeeper
+  other:
+    steps:
+      - uses: someone/merge-gatekeeper-lite@v1
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseGateJobs([]byte(tt.definition))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGateJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_ignoreOtherGates(t *testing.T) {
+	const definition = `
+jobs:
+  gate:
+    name: gate-${{ matrix.component }}
+    steps:
+      - uses: aac228/merge-gatekeeper@v1
+  test:
+    steps:
+      - run: go test ./...
+`
+	run := func(name string) *github.CheckRun {
+		return &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(1)}}
+	}
+	tests := map[string]struct {
+		ignoreOtherGates bool
+		checkRuns        []*github.CheckRun
+		contentsErr      error
+		wantSucceeded    bool
+		wantNotes        []string
+	}{
+		"waits for other instances of the gate by default": {
+			checkRuns:     []*github.CheckRun{run("gate-backend")},
+			wantSucceeded: false,
+		},
+		"ignores other instances of the gate": {
+			ignoreOtherGates: true,
+			checkRuns:        []*github.CheckRun{run("gate-backend"), run("gate-frontend")},
+			wantSucceeded:    true,
+		},
+		"waits for the other jobs of the workflows of the gates": {
+			ignoreOtherGates: true,
+			checkRuns:        []*github.CheckRun{run("gate-backend"), run("test")},
+			wantSucceeded:    false,
+		},
+		"notes unavailable workflow definitions": {
+			ignoreOtherGates: true,
+			checkRuns:        []*github.CheckRun{run("gate-backend")},
+			contentsErr:      errors.New("not found"),
+			wantSucceeded:    false,
+			wantNotes: []string{i18n.Default().Sprintf(i18n.DetailNoGates,
+				errors.New("failed to get workflow definition .github/workflows/gates.yml: not found"))},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotRef string
+			sv := &statusValidator{
+				selfJobName:      "self-job",
+				ref:              "sha",
+				ignoreOtherGates: tt.ignoreOtherGates,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 1
+						return &github.WorkflowRuns{
+							TotalCount:   &total,
+							WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Gates"), Path: stringPtr(".github/workflows/gates.yml"), CheckSuiteID: intPtr(1)}},
+						}, nil, nil
+					},
+					GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+						if tt.contentsErr != nil {
+							return nil, nil, nil, tt.contentsErr
+						}
+						gotRef = opts.Ref
+						encoding := "base64"
+						content := base64.StdEncoding.EncodeToString([]byte(definition))
+						return &github.RepositoryContent{Encoding: &encoding, Content: &content}, nil, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSucceeded {
+				t.Errorf("statusValidator.Validate() succeeded = %v, want %v", got.IsSuccess(), tt.wantSucceeded)
+			}
+			if notes := got.(*status).notes; !reflect.DeepEqual(notes, tt.wantNotes) {
+				t.Errorf("statusValidator.Validate() notes = %v, want %v", notes, tt.wantNotes)
+			}
+			if tt.ignoreOtherGates && tt.contentsErr == nil && gotRef != "sha" {
+				t.Errorf("workflow definitions are read at %q, want sha", gotRef)
+			}
+		})
+	}
+}
@@ -1,17 +1,69 @@
 package status
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/clock"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/owners"
+)
 
 type Option func(s *statusValidator)
 
-func WithSelfJob(name string) Option {
+// WithSelfJob sets the name of this job, optionally qualified by its workflow, e.g) Gate / merge-gatekeeper, so that
+// jobs of the same name in other workflows are not ignored as this job. It may be followed by the names of the other
+// instances of this job, e.g) in the workflows of other components of a monorepo, which are ignored along with this
+// job (comma-separated list).
+func WithSelfJob(names string) Option {
 	return func(s *statusValidator) {
-		if len(name) != 0 {
-			s.selfJobName = name
+		var gates []gateJob
+		for _, name := range strings.Split(names, ",") {
+			g := gateJob{job: strings.TrimSpace(name)}
+			if workflow, job, ok := strings.Cut(g.job, " / "); ok {
+				g.workflow, g.job = strings.TrimSpace(workflow), strings.TrimSpace(job)
+			}
+			if len(g.job) != 0 {
+				gates = append(gates, g)
+			}
+		}
+		if len(gates) == 0 {
+			return
+		}
+		s.selfWorkflow, s.selfJobName = gates[0].workflow, gates[0].job
+		s.otherGates = nil
+		if len(gates) > 1 {
+			s.otherGates = gates[1:]
 		}
 	}
 }
 
+// WithSelfRun sets the ID of the workflow run of this job, so that the jobs of its matrix, e.g) self (pull_request),
+// are ignored along with this job itself rather than blocking each other, and jobs of the same name are only ignored
+// as this job in the workflow of the run.
+func WithSelfRun(id int64) Option {
+	return func(s *statusValidator) {
+		s.selfRunID = id
+	}
+}
+
+// WithIgnoredSelfRun sets whether every job of the workflow run of this job is ignored along with this job, so that
+// this job is found by its workflow run rather than by its name, which waits forever when misspelled. The jobs of
+// the workflow run are never gated, so this job must run in a workflow of its own. It requires the workflow run of
+// this job, as given by WithSelfRun.
+func WithIgnoredSelfRun(b bool) Option {
+	return func(s *statusValidator) {
+		s.ignoreSelfRun = b
+	}
+}
+
+// WithIgnoredOtherGates sets whether the jobs running this action in the workflows of the ref, i.e) the other
+// instances of the gate, are ignored along with this job, so that they never wait for each other without naming them.
+func WithIgnoredOtherGates(b bool) Option {
+	return func(s *statusValidator) {
+		s.ignoreOtherGates = b
+	}
+}
+
 func WithGitHubOwnerAndRepo(owner, repo string) Option {
 	return func(s *statusValidator) {
 		if len(owner) != 0 {
@@ -31,6 +83,9 @@ func WithGitHubRef(ref string) Option {
 	}
 }
 
+// WithIgnoredJobs sets the jobs to ignore (comma-separated list). Entries starting with ^ or ending with $ are
+// regular expressions matching job names, e.g) ^lint-.*$, and the others are exact job names. Both may qualify
+// the job by its workflow, e.g) Nightly / build.
 func WithIgnoredJobs(names string) Option {
 	return func(s *statusValidator) {
 		// TODO: Add more input validation, such as "," should not be a valid input.
@@ -50,3 +105,207 @@ func WithIgnoredJobs(names string) Option {
 		s.ignoredJobs = jobs
 	}
 }
+
+// WithIgnoredWorkflows sets the workflows whose jobs are all ignored (comma-separated list of workflow names).
+func WithIgnoredWorkflows(names string) Option {
+	return func(s *statusValidator) {
+		workflows := []string{}
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); len(name) != 0 {
+				workflows = append(workflows, name)
+			}
+		}
+		s.ignoredWorkflows = workflows
+	}
+}
+
+// WithIgnoredApps sets the GitHub Apps whose check runs are all ignored (comma-separated list of app slugs), e.g) checks
+// of external services such as codecov, which have no workflow run.
+func WithIgnoredApps(slugs string) Option {
+	return func(s *statusValidator) {
+		apps := []string{}
+		for _, slug := range strings.Split(slugs, ",") {
+			if slug = strings.TrimSpace(slug); len(slug) != 0 {
+				apps = append(apps, slug)
+			}
+		}
+		s.ignoredApps = apps
+	}
+}
+
+// WithRules sets the ordered rules evaluated before the ignored jobs, workflows and apps, one per line or separated
+// by semicolons, e.g) "require job:lint-security; ignore job:^lint-.*$". The first rule matching a check captures it.
+func WithRules(spec string) Option {
+	return func(s *statusValidator) {
+		s.ruleSpec = spec
+	}
+}
+
+// WithAnyOf sets the groups of jobs, at least one of which must succeed, e.g) "build-linux|build-linux-arm", separated
+// by semicolons. A group succeeds once any of its jobs succeeds, and only fails when all of them failed.
+func WithAnyOf(spec string) Option {
+	return func(s *statusValidator) {
+		s.anyOfSpec = spec
+	}
+}
+
+// WithQuorums sets the jobs a percent of which must succeed, e.g) "^integration-=95%", separated by semicolons. A
+// quorum succeeds once the percent of the jobs it matches succeeded, and fails once too few of them are left to.
+func WithQuorums(spec string) Option {
+	return func(s *statusValidator) {
+		s.quorumSpec = spec
+	}
+}
+
+// WithExplain enables recording how every rule is evaluated against each check, so that the checks can be explained
+// in full by the status.
+func WithExplain(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.explain = enabled
+	}
+}
+
+// WithMessageCatalog sets the catalog used to render user facing messages.
+func WithMessageCatalog(c *i18n.Catalog) Option {
+	return func(s *statusValidator) {
+		if c != nil {
+			s.catalog = c
+		}
+	}
+}
+
+// WithClock sets the clock used for time dependent logic, such as the completion estimates.
+func WithClock(c clock.Clock) Option {
+	return func(s *statusValidator) {
+		if c != nil {
+			s.clock = c
+		}
+	}
+}
+
+// WithCriticalPath enables resolving the workflow_run trigger graph to report the chain of workflows
+// which keeps the gate open.
+func WithCriticalPath(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.criticalPath = enabled
+	}
+}
+
+// WithOwners sets the resolver of the owners of jobs, which are reported along with failed jobs.
+func WithOwners(r *owners.Resolver) Option {
+	return func(s *statusValidator) {
+		s.owners = r
+	}
+}
+
+// WithMinChecks sets how many jobs, other than this job and the ignored jobs, must be observed before the validation
+// can succeed, so that workflows which failed to trigger do not let an empty set of jobs pass.
+func WithMinChecks(n int) Option {
+	return func(s *statusValidator) {
+		s.minChecks = n
+	}
+}
+
+// WithFailOnNoJobs sets whether the validation fails when no jobs, other than this job and the ignored jobs, are
+// found by consecutive polls, as CI never started for the ref, instead of passing without anything to wait for.
+func WithFailOnNoJobs(b bool) Option {
+	return func(s *statusValidator) {
+		s.failOnNoJobs = b
+	}
+}
+
+// WithStatusContexts sets whether the contexts of the commit statuses of the ref are validated as jobs along with
+// the check runs, so that CIs reporting commit statuses, e.g) Jenkins, are gated too. The statuses published by this
+// job are named so that they are not waited for.
+func WithStatusContexts(b bool, published ...string) Option {
+	return func(s *statusValidator) {
+		s.statusContexts = b
+		s.published = published
+	}
+}
+
+// WithStatusPrefixes sets the prefixes of the contexts of commit statuses validated as jobs, e.g) ci/, and of those
+// ignored, e.g) license/ (comma-separated lists). Contexts are validated under any prefix when none is set, and the
+// others are ignored by the ignored-statuses rule.
+func WithStatusPrefixes(validated, ignored string) Option {
+	return func(s *statusValidator) {
+		s.statusPrefixes = splitPrefixes(validated)
+		s.ignoredStatusPrefixes = splitPrefixes(ignored)
+	}
+}
+
+func splitPrefixes(prefixes string) []string {
+	var list []string
+	for _, prefix := range strings.Split(prefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); len(prefix) != 0 {
+			list = append(list, prefix)
+		}
+	}
+	return list
+}
+
+// WithRequiredChecks sets whether only the required checks are validated, ignoring every other check by the
+// not-required rule, e.g) to gate on the required status checks of the protected branch. No check is validated when
+// none is required.
+func WithRequiredChecks(b bool, checks ...string) Option {
+	return func(s *statusValidator) {
+		s.requiredOnly = b
+		s.required = checks
+	}
+}
+
+// WithSkippedAs sets how skipped jobs are treated, one of SkippedIgnore, SkippedSuccess or SkippedFailure. Skipped jobs
+// are ignored by default.
+func WithSkippedAs(treatment string) Option {
+	return func(s *statusValidator) {
+		s.skippedAs = treatment
+	}
+}
+
+// WithNeutralAs sets how neutral conclusions are treated, one of NeutralSuccess, NeutralPending or NeutralFailure.
+// Neutral check runs are counted as succeeded by default.
+func WithNeutralAs(treatment string) Option {
+	return func(s *statusValidator) {
+		s.neutralAs = treatment
+	}
+}
+
+// WithCancelledAs sets how cancelled jobs are treated, one of CancelledFailure, CancelledIgnore or CancelledPending.
+// Cancelled jobs fail the validation by default.
+func WithCancelledAs(treatment string) Option {
+	return func(s *statusValidator) {
+		s.cancelledAs = treatment
+	}
+}
+
+// WithActionRequiredAs sets how jobs concluding action_required, e.g) workflow runs awaiting approval, are treated,
+// one of AttentionFailure, AttentionPending or AttentionIgnore. They fail the validation by default.
+func WithActionRequiredAs(treatment string) Option {
+	return func(s *statusValidator) {
+		s.actionRequiredAs = treatment
+	}
+}
+
+// WithStaleAs sets how jobs concluding stale, as they did not complete in time, are treated, one of AttentionFailure,
+// AttentionPending or AttentionIgnore. They fail the validation by default.
+func WithStaleAs(treatment string) Option {
+	return func(s *statusValidator) {
+		s.staleAs = treatment
+	}
+}
+
+// WithChecksAPI sets the API listing the check runs of the ref, one of ChecksAPIREST, ChecksAPIGraphQL or
+// ChecksAPIAuto. The check runs are listed from the REST API by default.
+func WithChecksAPI(api string) Option {
+	return func(s *statusValidator) {
+		s.checksAPI = api
+	}
+}
+
+// WithRollupCache enables reusing the status of the previous poll once every check of the ref succeeded, while the
+// rollup of the checks is unchanged, so that waiting on other validators does not list every job on each poll.
+func WithRollupCache(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.rollupCache = enabled
+	}
+}
@@ -0,0 +1,116 @@
+package status
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ruleQuorum decides the jobs of quorums by the outcome of their quorums rather than their own states.
+const ruleQuorum = "quorum"
+
+// quorum is a logical unit of the jobs it matches, e.g) the jobs of a large test matrix, which succeeds once the
+// percent of its jobs succeeded.
+type quorum struct {
+	text    string
+	percent float64
+	match   func(gs *ghaStatus) bool
+}
+
+// quorumOutcome is the outcome of a quorum by the counts of its jobs.
+type quorumOutcome struct {
+	state     string
+	jobs      int
+	succeeded int
+	required  int
+}
+
+// parseQuorums parses quorums separated by semicolons, each of which is a job followed by the percent of the jobs it
+// matches which must succeed, e.g) "^integration-=95%;^Test / e2e =80". Jobs are named as in the ignored jobs,
+// optionally qualified by their workflows, or regular expressions when starting with ^ or ending with $.
+func parseQuorums(spec string) ([]*quorum, error) {
+	var quorums []*quorum
+	for _, q := range strings.Split(spec, ";") {
+		text := strings.TrimSpace(q)
+		if len(text) == 0 {
+			continue
+		}
+		i := strings.LastIndex(text, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid quorum %q: no percent of jobs", text)
+		}
+		job, value := strings.TrimSpace(text[:i]), strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text[i+1:]), "%"))
+		percent, err := strconv.ParseFloat(value, 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("invalid quorum %q: percent must be greater than 0 and at most 100", text)
+		}
+		if len(job) == 0 {
+			return nil, fmt.Errorf("invalid quorum %q: no job to match", text)
+		}
+		match, err := matcher(selectorJob, job)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quorum %q: %w", text, err)
+		}
+		quorums = append(quorums, &quorum{text: job, percent: percent, match: match})
+	}
+	return quorums, nil
+}
+
+// quorumOf returns the index of the first quorum the job belongs to, or -1 when none. Jobs of any-of groups are
+// decided by their groups instead.
+func (sv *statusValidator) quorumOf(gs *ghaStatus) int {
+	if sv.anyOfGroup(gs) >= 0 {
+		return -1
+	}
+	for i, q := range sv.quorums {
+		if q.match(gs) {
+			return i
+		}
+	}
+	return -1
+}
+
+// quorumOutcomes returns the outcome of every quorum by its jobs other than this job and the ignored jobs: it
+// succeeds once the percent of its jobs succeeded, fails once too few of them are left to succeed, and is pending
+// otherwise. Quorums without any job have no outcome.
+func (sv *statusValidator) quorumOutcomes(statuses []*ghaStatus) []quorumOutcome {
+	if len(sv.quorums) == 0 {
+		return nil
+	}
+	outcomes := make([]quorumOutcome, len(sv.quorums))
+	pending := make([]int, len(sv.quorums))
+	for _, gs := range statuses {
+		q := sv.quorumOf(gs)
+		if q < 0 || sv.isSelf(gs) || gs.Sibling || gs.Unresolved {
+			continue
+		}
+		if _, ignored := sv.ignoredBy(gs); ignored {
+			continue
+		}
+		outcomes[q].jobs++
+		switch gs.State {
+		case successState:
+			outcomes[q].succeeded++
+		case pendingState:
+			pending[q]++
+		}
+	}
+	for i, q := range sv.quorums {
+		o := &outcomes[i]
+		if o.jobs == 0 {
+			continue
+		}
+		// The epsilon keeps e.g) 95% of 20 jobs from requiring 20 by rounding errors.
+		o.required = int(math.Ceil(float64(o.jobs)*q.percent/100 - 1e-9))
+		switch {
+		case o.succeeded >= o.required:
+			o.state = successState
+		case o.succeeded+pending[i] >= o.required:
+			o.state = pendingState
+		default:
+			o.state = errorState
+		}
+	}
+	return outcomes
+}
@@ -0,0 +1,223 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+func Test_parseQuorums(t *testing.T) {
+	tests := map[string]struct {
+		spec        string
+		wantTexts   []string
+		wantPercent []float64
+		wantErr     bool
+	}{
+		"parses quorums": {
+			spec:        "^integration-=95%; Test / e2e = 80 ;",
+			wantTexts:   []string{"^integration-", "Test / e2e"},
+			wantPercent: []float64{95, 80},
+		},
+		"parses nothing": {},
+		"returns error without percent": {
+			spec:    "^integration-",
+			wantErr: true,
+		},
+		"returns error with percent over 100": {
+			spec:    "^integration-=150%",
+			wantErr: true,
+		},
+		"returns error with zero percent": {
+			spec:    "^integration-=0",
+			wantErr: true,
+		},
+		"returns error without job": {
+			spec:    "=95%",
+			wantErr: true,
+		},
+		"returns error with invalid regular expression": {
+			spec:    "^integration-(=95%",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseQuorums(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseQuorums() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var texts []string
+			var percent []float64
+			for _, q := range got {
+				texts = append(texts, q.text)
+				percent = append(percent, q.percent)
+			}
+			if !reflect.DeepEqual(texts, tt.wantTexts) || !reflect.DeepEqual(percent, tt.wantPercent) {
+				t.Errorf("parseQuorums() = %v %v, want %v %v", texts, percent, tt.wantTexts, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_quorums(t *testing.T) {
+	run := func(name, conclusion string) *github.CheckRun {
+		cr := &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus)}
+		if len(conclusion) != 0 {
+			cr.Status, cr.Conclusion = stringPtr(checkRunCompletedStatus), stringPtr(conclusion)
+		}
+		return cr
+	}
+	// matrix returns the jobs of the integration matrix, the first of which failed and the next of which are running.
+	matrix := func(failed, running int) []*github.CheckRun {
+		runs := make([]*github.CheckRun, 0, 10)
+		for i := 0; i < 10; i++ {
+			conclusion := checkRunSuccessConclusion
+			switch {
+			case i < failed:
+				conclusion = checkRunFailedConclusion
+			case i < failed+running:
+				conclusion = ""
+			}
+			runs = append(runs, run(fmt.Sprintf("integration-%d", i), conclusion))
+		}
+		return runs
+	}
+	note := func(succeeded, jobs, required int) string {
+		return i18n.Default().Sprintf(i18n.DetailQuorum, "^integration-", succeeded, jobs, required)
+	}
+	tests := map[string]struct {
+		checkRuns []*github.CheckRun
+		wantState string
+		wantNote  string
+	}{
+		"succeeds once the percent of the jobs succeeded": {
+			checkRuns: matrix(1, 0),
+			wantState: validators.StateSuccess,
+			wantNote:  note(9, 10, 8),
+		},
+		"succeeds while the other jobs of the quorum are running": {
+			checkRuns: matrix(0, 2),
+			wantState: validators.StateSuccess,
+			wantNote:  note(8, 10, 8),
+		},
+		"waits while enough jobs of the quorum can still succeed": {
+			checkRuns: matrix(2, 1),
+			wantState: validators.StatePending,
+			wantNote:  note(7, 10, 8),
+		},
+		"fails once too few jobs of the quorum are left to succeed": {
+			checkRuns: matrix(3, 1),
+			wantState: validators.StateFailure,
+			wantNote:  note(6, 10, 8),
+		},
+		"fails on other jobs regardless of the quorum": {
+			checkRuns: append(matrix(0, 0), run("lint", checkRunFailedConclusion)),
+			wantState: validators.StateFailure,
+			wantNote:  note(10, 10, 8),
+		},
+		"does not note quorums without jobs": {
+			checkRuns: []*github.CheckRun{run("lint", checkRunSuccessConclusion)},
+			wantState: validators.StateSuccess,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			quorums, err := parseQuorums("^integration-=75%")
+			if err != nil {
+				t.Fatal(err)
+			}
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				quorums:     quorums,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						return &github.WorkflowRuns{}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			state := validators.StatePending
+			var fcErr *validators.FailedChecksError
+			switch {
+			case errors.As(err, &fcErr):
+				state = validators.StateFailure
+				got = fcErr.Status
+			case err != nil:
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			case got.IsSuccess():
+				state = validators.StateSuccess
+			}
+			if state != tt.wantState {
+				t.Errorf("statusValidator.Validate() state = %s, want %s", state, tt.wantState)
+			}
+			var wantNotes []string
+			if len(tt.wantNote) != 0 {
+				wantNotes = []string{tt.wantNote}
+			}
+			if notes := got.(*status).notes; !reflect.DeepEqual(notes, wantNotes) {
+				t.Errorf("statusValidator.Validate() notes = %v, want %v", notes, wantNotes)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_quorumPolls(t *testing.T) {
+	run := func(name, conclusion string) *github.CheckRun {
+		cr := &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus)}
+		if len(conclusion) != 0 {
+			cr.Status, cr.Conclusion = stringPtr(checkRunCompletedStatus), stringPtr(conclusion)
+		}
+		return cr
+	}
+	polls := [][]*github.CheckRun{
+		{run("integration-0", ""), run("integration-1", ""), run("integration-2", "")},
+		{run("integration-0", checkRunSuccessConclusion), run("integration-1", checkRunSuccessConclusion), run("integration-2", "")},
+	}
+	quorums, err := parseQuorums("^integration-=60%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var poll int
+	sv := &statusValidator{
+		selfJobName: "self-job",
+		quorums:     quorums,
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: polls[poll]}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				return &github.WorkflowRuns{}, nil, nil
+			},
+		},
+	}
+
+	// Jobs observed pending have not vanished once they are ignored along with their quorums which succeeded.
+	wantSucceeded := []bool{false, true}
+	wantNotes := [][]string{
+		{i18n.Default().Sprintf(i18n.DetailQuorum, "^integration-", 0, 3, 2)},
+		{i18n.Default().Sprintf(i18n.DetailQuorum, "^integration-", 2, 3, 2)},
+	}
+	for poll = range polls {
+		got, err := sv.Validate(context.Background())
+		if err != nil {
+			t.Fatalf("poll %d: statusValidator.Validate() error = %v", poll, err)
+		}
+		st := got.(*status)
+		if st.succeeded != wantSucceeded[poll] {
+			t.Errorf("poll %d: succeeded = %v, want %v", poll, st.succeeded, wantSucceeded[poll])
+		}
+		if !reflect.DeepEqual(st.notes, wantNotes[poll]) {
+			t.Errorf("poll %d: notes = %v, want %v", poll, st.notes, wantNotes[poll])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package status
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Actions of rules.
+const (
+	actionIgnore  = "ignore"
+	actionRequire = "require"
+)
+
+// Selectors of rules, choosing what the value of a rule is matched against.
+const (
+	selectorJob      = "job"
+	selectorWorkflow = "workflow"
+	selectorApp      = "app"
+)
+
+// rule classifies the checks it matches by its action. Rules are evaluated in order and the first rule matching a
+// check captures it, so that e.g. a require rule listed before an ignore rule exempts a check from the latter.
+type rule struct {
+	name   string // Name of the rule in decisions, e.g) rules[1] or ignored-jobs.
+	text   string // How the rule was given, shown by explanations.
+	action string
+	match  func(gs *ghaStatus) bool
+}
+
+// describe returns the name of the rule along with how it was given, unless they are the same.
+func (r *rule) describe() string {
+	if len(r.text) == 0 || r.text == r.name {
+		return r.name
+	}
+	return fmt.Sprintf("%s (%s)", r.name, r.text)
+}
+
+// parseRules parses the ordered rules, one per line or separated by semicolons. Each rule is an action followed by
+// a selector and its value, e.g) "require job:lint-security" or "ignore workflow:Nightly". Values of the job
+// selector, which is the default, are job names optionally qualified by their workflows, or regular expressions
+// when starting with ^ or ending with $, like the ignored jobs.
+func parseRules(spec string) ([]*rule, error) {
+	var rules []*rule
+	for _, line := range strings.FieldsFunc(spec, func(r rune) bool { return r == '\n' || r == ';' }) {
+		text := strings.TrimSpace(line)
+		if len(text) == 0 {
+			continue
+		}
+		action, target, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule %q: no check to match", text)
+		}
+		if action != actionIgnore && action != actionRequire {
+			return nil, fmt.Errorf("invalid rule %q: unknown action %q", text, action)
+		}
+		target = strings.TrimSpace(target)
+		selector, value, ok := strings.Cut(target, ":")
+		switch selector {
+		case selectorJob, selectorWorkflow, selectorApp:
+		default:
+			// Job names may contain colons, so only the known selectors are taken as such.
+			selector, value, ok = selectorJob, target, true
+		}
+		if value = strings.TrimSpace(value); !ok || len(value) == 0 {
+			return nil, fmt.Errorf("invalid rule %q: no check to match", text)
+		}
+		match, err := matcher(selector, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", text, err)
+		}
+		rules = append(rules, &rule{
+			name:   fmt.Sprintf("rules[%d]", len(rules)+1),
+			text:   fmt.Sprintf("%s %s:%s", action, selector, value),
+			action: action,
+			match:  match,
+		})
+	}
+	return rules, nil
+}
+
+func matcher(selector, value string) (func(gs *ghaStatus) bool, error) {
+	switch selector {
+	case selectorWorkflow:
+		return func(gs *ghaStatus) bool { return len(gs.Workflow) != 0 && gs.Workflow == value }, nil
+	case selectorApp:
+		return func(gs *ghaStatus) bool { return len(gs.App) != 0 && gs.App == value }, nil
+	}
+	matchName := func(name string) bool { return name == value }
+	if isPattern(value) {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, err
+		}
+		matchName = re.MatchString
+	}
+	return func(gs *ghaStatus) bool {
+		if matchName(gs.Job) {
+			return true
+		}
+		return len(gs.Workflow) != 0 && matchName(gs.String())
+	}, nil
+}
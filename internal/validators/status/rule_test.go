@@ -0,0 +1,86 @@
+package status
+
+import (
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		"parses rules separated by lines and semicolons": {
+			spec: "require job:lint-security\nignore ^lint-.*$; ignore workflow:Nightly\n\nignore app:codecov",
+			want: []string{"require job:lint-security", "ignore job:^lint-.*$", "ignore workflow:Nightly", "ignore app:codecov"},
+		},
+		"takes values of unknown selectors as job names": {
+			spec: "require deploy: production",
+			want: []string{"require job:deploy: production"},
+		},
+		"returns nothing when empty": {
+			spec: " ; ",
+		},
+		"returns error of unknown actions": {
+			spec:    "skip job:lint",
+			wantErr: true,
+		},
+		"returns error of rules without checks": {
+			spec:    "ignore workflow:",
+			wantErr: true,
+		},
+		"returns error of invalid patterns": {
+			spec:    "ignore ^lint-($",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules, err := parseRules(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rules) != len(tt.want) {
+				t.Fatalf("parseRules() returned %d rules, want %d", len(rules), len(tt.want))
+			}
+			for i, r := range rules {
+				if r.text != tt.want[i] {
+					t.Errorf("rule %d = %q, want %q", i+1, r.text, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRule_match(t *testing.T) {
+	rules, err := parseRules("require job:CI / lint-security; ignore job:^lint-; ignore workflow:Nightly; ignore app:codecov")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		check *ghaStatus
+		want  []bool
+	}{
+		"matches the job qualified by its workflow and patterns": {
+			check: &ghaStatus{Workflow: "CI", Job: "lint-security"},
+			want:  []bool{true, true, false, false},
+		},
+		"matches the job of the pattern only in other workflows": {
+			check: &ghaStatus{Workflow: "Nightly", Job: "lint-security"},
+			want:  []bool{false, true, true, false},
+		},
+		"matches check runs of the app": {
+			check: &ghaStatus{Workflow: externalWorkflow, Job: "codecov/patch", App: "codecov"},
+			want:  []bool{false, false, false, true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i, r := range rules {
+				if got := r.match(tt.check); got != tt.want[i] {
+					t.Errorf("rule %q matches %s = %v, want %v", r.text, tt.check, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
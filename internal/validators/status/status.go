@@ -1,13 +1,27 @@
 package status
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
 
 type status struct {
 	totalJobs    []string
 	completeJobs []string
 	errJobs      []string
 	ignoredJobs  []string
+	decisions    []validators.Decision
+	matched      map[string][]string // Rules matching the checks in the order of their evaluation, to be explained.
+	evaluations  map[string]*evaluation
 	succeeded    bool
+	estimates    *estimates
+	notes        []string
+	unresolved   bool // Whether jobs are kept pending until their workflows are known.
+	catalog      *i18n.Catalog
 }
 
 func prettyPrintJobList(jobs []string) string {
@@ -26,60 +40,279 @@ func prettyPrintJobList(jobs []string) string {
 }
 
 func (s *status) Detail() string {
+	msgs := s.messages()
 	result := fmt.Sprintf(
-		`%d out of %d
+		`%s
 
-Total job count:       %d
-Completed job count:   %d
-Incompleted job count: %d
-Failed job count:      %d
-Ignored job count:     %d
+%s
+%s
+%s
+%s
+%s
 `,
-		len(s.completeJobs), len(s.totalJobs),
-		len(s.totalJobs),
-		len(s.completeJobs),
-		len(s.getIncompleteJobs()),
-		len(s.errJobs),
-		len(s.ignoredJobs),
+		msgs.Sprintf(i18n.DetailSummary, len(s.completeJobs), len(s.totalJobs)),
+		msgs.Sprintf(i18n.DetailTotalCount, len(s.totalJobs)),
+		msgs.Sprintf(i18n.DetailCompletedCount, len(s.completeJobs)),
+		msgs.Sprintf(i18n.DetailIncompleteCount, len(s.getIncompleteJobs())),
+		msgs.Sprintf(i18n.DetailFailedCount, len(s.errJobs)),
+		msgs.Sprintf(i18n.DetailIgnoredCount, len(s.ignoredJobs)),
 	)
+	if eta, ok := s.ETA(); ok {
+		result += msgs.Sprintf(i18n.DetailETA, s.estimates.remaining(eta)) + "\n"
+	}
+	for _, note := range s.notes {
+		result += note + "\n"
+	}
 
 	result = fmt.Sprintf(`%s
-::group::Failed jobs
+::group::%s
+%s
+::endgroup::
+
+::group::%s
 %s
 ::endgroup::
 
-::group::Completed jobs
+::group::%s
 %s
 ::endgroup::
 
-::group::Incomplete jobs
+::group::%s
 %s
 ::endgroup::
 
-::group::Ignored jobs
+::group::%s
 %s
 ::endgroup::
 
-::group::All jobs
+::group::%s
 %s
 ::endgroup::
 `,
 		result,
-		prettyPrintJobList(s.errJobs),
-		prettyPrintJobList(s.completeJobs),
-		prettyPrintJobList(s.getIncompleteJobs()),
-		prettyPrintJobList(s.ignoredJobs),
-		prettyPrintJobList(s.totalJobs),
+		msgs.Get(i18n.DetailWorkflows), s.prettyPrintGroups(),
+		msgs.Get(i18n.DetailFailedJobs), prettyPrintJobList(s.errJobs),
+		msgs.Get(i18n.DetailCompletedJobs), prettyPrintJobList(s.completeJobs),
+		msgs.Get(i18n.DetailIncompleteJobs), prettyPrintJobList(s.getIncompleteJobs()),
+		msgs.Get(i18n.DetailIgnoredJobs), prettyPrintJobList(s.ignoredJobs),
+		msgs.Get(i18n.DetailAllJobs), prettyPrintJobList(s.totalJobs),
 	)
 
 	return result
 }
 
+// prettyPrintGroups lists the workflows with their counts. Jobs are only listed for workflows which are not green,
+// so that the output stays readable for repositories with many workflows.
+func (s *status) prettyPrintGroups() string {
+	msgs := s.messages()
+	groups := s.Groups()
+	if len(groups) == 0 {
+		return "[]"
+	}
+
+	lines := make([]string, 0, len(groups))
+	for _, g := range groups {
+		line := "- " + msgs.Sprintf(i18n.DetailWorkflowCounts, g.Name, g.Succeeded, g.Failed, g.Pending, g.Ignored)
+		if g.ETA != nil {
+			line += " " + msgs.Sprintf(i18n.DetailWorkflowETA, s.estimates.remaining(*g.ETA))
+		}
+		lines = append(lines, line)
+		if g.IsGreen() {
+			continue
+		}
+		for _, d := range g.Checks {
+			lines = append(lines, "    - "+msgs.Sprintf(i18n.DetailJobState, strings.TrimPrefix(d.Check, g.Name+" / "), msgs.State(d.Verdict)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *status) IsSuccess() bool {
 	// TDOO: Add test case
 	return s.succeeded
 }
 
+func (s *status) Summary() string {
+	return s.messages().Sprintf(i18n.DetailSummary, len(s.completeJobs), len(s.totalJobs))
+}
+
+func (s *status) Sections() []validators.Section {
+	msgs := s.messages()
+	return []validators.Section{
+		{Title: msgs.Get(i18n.DetailFailedJobs), State: validators.StateFailure, Items: s.errJobs},
+		{Title: msgs.Get(i18n.DetailCompletedJobs), State: validators.StateSuccess, Items: s.completeJobs},
+		{Title: msgs.Get(i18n.DetailIncompleteJobs), State: validators.StatePending, Items: s.getIncompleteJobs()},
+		{Title: msgs.Get(i18n.DetailIgnoredJobs), State: validators.StateIgnored, Items: s.ignoredJobs},
+	}
+}
+
+// Groups groups the checks by their workflow, in the order of their first appearance.
+// This job itself is left out as it is never a check to wait for.
+func (s *status) Groups() []validators.Group {
+	groups := make([]validators.Group, 0)
+	index := make(map[string]int)
+	for _, d := range s.decisions {
+		if d.Rule == ruleSelf {
+			continue
+		}
+		i, ok := index[d.Group]
+		if !ok {
+			i = len(groups)
+			index[d.Group] = i
+			groups = append(groups, validators.Group{Name: d.Group})
+		}
+		g := &groups[i]
+		switch d.Verdict {
+		case validators.StateSuccess:
+			g.Succeeded++
+		case validators.StateFailure:
+			g.Failed++
+		case validators.StatePending:
+			g.Pending++
+		case validators.StateIgnored:
+			g.Ignored++
+		}
+		g.Checks = append(g.Checks, d)
+	}
+
+	if s.estimates != nil {
+		for i := range groups {
+			if eta, ok := s.estimates.workflows[groups[i].Name]; ok {
+				groups[i].ETA = &eta
+			}
+		}
+	}
+	return groups
+}
+
+func (s *status) Notes() []string {
+	return s.notes
+}
+
+// ETA returns the estimated completion time of the slowest pending workflow.
+func (s *status) ETA() (time.Time, bool) {
+	if s.estimates == nil {
+		return time.Time{}, false
+	}
+	return s.estimates.overall, true
+}
+
+func (s *status) messages() *i18n.Catalog {
+	if s.catalog == nil {
+		return i18n.Default()
+	}
+	return s.catalog
+}
+
+func (s *status) Decisions() []validators.Decision {
+	return s.decisions
+}
+
+// Results of rules evaluated against a check.
+const (
+	resultCaptured  = "captured"
+	resultShadowed  = "shadowed"
+	resultUnmatched = "unmatched"
+)
+
+// evaluation is how every rule was evaluated against a check, recorded only when explaining checks.
+type evaluation struct {
+	state string
+	rules []ruleEvaluation
+}
+
+type ruleEvaluation struct {
+	rule   string
+	result string
+}
+
+// Explain describes which rule captured the check, and which other rules it matches, as the first matching rule wins.
+// When the evaluations of the rules were recorded, the state of the check and every rule evaluated are described too.
+func (s *status) Explain(check string) (string, bool) {
+	msgs := s.messages()
+	var lines []string
+	for _, d := range s.decisions {
+		if d.Check != check && !strings.HasSuffix(d.Check, " / "+check) {
+			continue
+		}
+		matched := s.matched[d.Check]
+		captured := d.Rule
+		if len(matched) != 0 {
+			captured = matched[0]
+		}
+		lines = append(lines, msgs.Sprintf(i18n.DetailExplainCaptured, d.Check, captured, d.Verdict))
+		if len(matched) > 1 {
+			lines = append(lines, msgs.Sprintf(i18n.DetailExplainShadowed, strings.Join(matched[1:], ", ")))
+		}
+		if ev, ok := s.evaluations[d.Check]; ok {
+			lines = append(lines, msgs.Sprintf(i18n.DetailExplainState, verdictOf(ev.state)))
+			lines = append(lines, msgs.Get(i18n.DetailExplainRules))
+			for _, re := range ev.rules {
+				switch re.result {
+				case resultCaptured:
+					lines = append(lines, msgs.Sprintf(i18n.DetailExplainCaptures, re.rule))
+				case resultShadowed:
+					lines = append(lines, msgs.Sprintf(i18n.DetailExplainShadows, re.rule))
+				default:
+					lines = append(lines, msgs.Sprintf(i18n.DetailExplainNoMatch, re.rule))
+				}
+			}
+		}
+		switch {
+		case d.Rule == ruleSelf:
+			lines = append(lines, msgs.Get(i18n.DetailExplainSelf))
+		case d.Rule == ruleVanished:
+			lines = append(lines, msgs.Get(i18n.DetailExplainVanished))
+		case d.Rule == ruleUnresolved:
+			lines = append(lines, msgs.Get(i18n.DetailExplainNoRun))
+		case d.Rule == ruleAnyOf:
+			lines = append(lines, msgs.Get(i18n.DetailExplainAnyOf))
+		case d.Rule == ruleQuorum:
+			lines = append(lines, msgs.Get(i18n.DetailExplainQuorum))
+		case d.Verdict == validators.StateIgnored:
+			lines = append(lines, msgs.Sprintf(i18n.DetailExplainIgnored, d.State))
+		default:
+			lines = append(lines, msgs.Get(i18n.DetailExplainDecided))
+		}
+	}
+	return strings.Join(lines, "\n"), len(lines) != 0
+}
+
+// record records how every rule was evaluated against the check, to be explained.
+func (s *status) record(gs *ghaStatus, rules []ruleEvaluation) {
+	if s.evaluations == nil {
+		s.evaluations = make(map[string]*evaluation)
+	}
+	s.evaluations[gs.String()] = &evaluation{state: gs.State, rules: rules}
+}
+
+// match records the rules matching the check, unless the name of the rule in its decision tells them all.
+func (s *status) match(gs *ghaStatus, matched []*rule) {
+	if len(matched) == 0 || (len(matched) == 1 && matched[0].describe() == matched[0].name) {
+		return
+	}
+	if s.matched == nil {
+		s.matched = make(map[string][]string)
+	}
+	for _, r := range matched {
+		s.matched[gs.String()] = append(s.matched[gs.String()], r.describe())
+	}
+}
+
+func (s *status) decide(gs *ghaStatus, rule, verdict string) {
+	d := validators.Decision{
+		Check:   gs.String(),
+		Group:   gs.Workflow,
+		Rule:    rule,
+		Verdict: verdict,
+	}
+	// Ignored jobs keep their observed state, so that they can be reported without blocking the gate.
+	if verdict == validators.StateIgnored && rule != ruleSelf {
+		d.State = verdictOf(gs.State)
+	}
+	s.decisions = append(s.decisions, d)
+}
+
 func (s *status) getIncompleteJobs() []string {
 	var incomplete []string
 
@@ -111,3 +344,12 @@ func (s *status) getIncompleteJobs() []string {
 	}
 	return incomplete
 }
+
+var (
+	_ validators.Status    = &status{}
+	_ validators.Tracer    = &status{}
+	_ validators.Reporter  = &status{}
+	_ validators.Grouper   = &status{}
+	_ validators.Estimator = &status{}
+	_ validators.Noter     = &status{}
+)
@@ -2,9 +2,19 @@ package status
 
 import (
 	"testing"
+	"time"
+
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
 )
 
 func Test_status_Detail(t *testing.T) {
+	japanese, err := i18n.New(i18n.Japanese)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+
 	tests := map[string]struct {
 		s    *status
 		want string
@@ -31,6 +41,10 @@ Incompleted job count: 1
 Failed job count:      1
 Ignored job count:     0
 
+::group::Workflows
+[]
+::endgroup::
+
 ::group::Failed jobs
 - job-3
 ::endgroup::
@@ -81,6 +95,10 @@ Incompleted job count: 1
 Failed job count:      1
 Ignored job count:     1
 
+::group::Workflows
+[]
+::endgroup::
+
 ::group::Failed jobs
 - job-3
 ::endgroup::
@@ -119,6 +137,10 @@ Incompleted job count: 0
 Failed job count:      0
 Ignored job count:     0
 
+::group::Workflows
+[]
+::endgroup::
+
 ::group::Failed jobs
 []
 ::endgroup::
@@ -138,6 +160,147 @@ Ignored job count:     0
 ::group::All jobs
 []
 ::endgroup::
+`,
+		},
+		"return detail grouped by workflow": {
+			s: &status{
+				totalJobs:    []string{"CI / build", "CI / test", "Lint / lint"},
+				completeJobs: []string{"CI / build", "Lint / lint"},
+				errJobs:      []string{"CI / test"},
+				decisions: []validators.Decision{
+					{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "CI / test", Group: "CI", Rule: ruleState, Verdict: validators.StateFailure},
+					{Check: "Lint / lint", Group: "Lint", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Merge / merge-gatekeeper", Group: "Merge", Rule: ruleSelf, Verdict: validators.StateIgnored},
+				},
+			},
+			want: `2 out of 3
+
+Total job count:       3
+Completed job count:   2
+Incompleted job count: 0
+Failed job count:      1
+Ignored job count:     0
+
+::group::Workflows
+- CI: 1 succeeded, 1 failed, 0 pending, 0 ignored
+    - build: succeeded
+    - test: failed
+- Lint: 1 succeeded, 0 failed, 0 pending, 0 ignored
+::endgroup::
+
+::group::Failed jobs
+- CI / test
+::endgroup::
+
+::group::Completed jobs
+- CI / build
+- Lint / lint
+::endgroup::
+
+::group::Incomplete jobs
+[]
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+- CI / build
+- CI / test
+- Lint / lint
+::endgroup::
+`,
+		},
+		"return detail with completion estimates": {
+			s: &status{
+				totalJobs:    []string{"CI / build", "CI / test"},
+				completeJobs: []string{"CI / build"},
+				decisions: []validators.Decision{
+					{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "CI / test", Group: "CI", Rule: ruleState, Verdict: validators.StatePending},
+				},
+				estimates: &estimates{
+					now:       now,
+					workflows: map[string]time.Time{"CI": now.Add(90 * time.Second)},
+					overall:   now.Add(90 * time.Second),
+				},
+			},
+			want: `1 out of 2
+
+Total job count:       2
+Completed job count:   1
+Incompleted job count: 1
+Failed job count:      0
+Ignored job count:     0
+Estimated time to completion: 1m30s
+
+::group::Workflows
+- CI: 1 succeeded, 0 failed, 1 pending, 0 ignored (ETA: 1m30s)
+    - build: succeeded
+    - test: pending
+::endgroup::
+
+::group::Failed jobs
+[]
+::endgroup::
+
+::group::Completed jobs
+- CI / build
+::endgroup::
+
+::group::Incomplete jobs
+- CI / test
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+- CI / build
+- CI / test
+::endgroup::
+`,
+		},
+		"return detail in the locale of the catalog": {
+			s: &status{
+				totalJobs:    []string{"job-1"},
+				completeJobs: []string{"job-1"},
+				catalog:      japanese,
+			},
+			want: `1 / 1 完了
+
+ジョブ総数:       1
+完了したジョブ数: 1
+未完了のジョブ数: 0
+失敗したジョブ数: 0
+無視したジョブ数: 0
+
+::group::ワークフロー
+[]
+::endgroup::
+
+::group::失敗したジョブ
+[]
+::endgroup::
+
+::group::完了したジョブ
+- job-1
+::endgroup::
+
+::group::未完了のジョブ
+[]
+::endgroup::
+
+::group::無視したジョブ
+[]
+::endgroup::
+
+::group::すべてのジョブ
+- job-1
+::endgroup::
 `,
 		},
 	}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/aac228/merge-gatekeeper/internal/clock"
 	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
 	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/owners"
 	"github.com/aac228/merge-gatekeeper/internal/validators"
 )
 
@@ -17,6 +23,84 @@ const (
 	pendingState = "pending"
 )
 
+// Rules and verdicts recorded in the decision trace.
+const (
+	ruleSelf            = "self"
+	ruleIgnored         = "ignored-jobs"
+	ruleIgnoredWorkflow = "ignored-workflows"
+	ruleIgnoredApp      = "ignored-apps"
+	ruleIgnoredStatus   = "ignored-statuses"
+	ruleNotRequired     = "not-required"
+	ruleState           = "state"
+	ruleVanished        = "vanished"
+	ruleUnresolved      = "unresolved"
+)
+
+// Treatments of skipped jobs.
+const (
+	// SkippedIgnore drops skipped jobs, as if they never ran.
+	SkippedIgnore = "ignore"
+	// SkippedSuccess counts skipped jobs as succeeded.
+	SkippedSuccess = "success"
+	// SkippedFailure fails the validation on skipped jobs, e.g) required jobs skipped by paths filters.
+	SkippedFailure = "failure"
+)
+
+// Treatments of neutral conclusions.
+const (
+	// NeutralSuccess counts neutral check runs as succeeded.
+	NeutralSuccess = "success"
+	// NeutralPending keeps neutral check runs pending, e.g) apps concluding neutral when a human needs to look at them.
+	NeutralPending = "pending"
+	// NeutralFailure fails the validation on neutral check runs.
+	NeutralFailure = "failure"
+)
+
+// Treatments of cancelled check runs.
+const (
+	// CancelledFailure fails the validation on cancelled check runs.
+	CancelledFailure = "failure"
+	// CancelledIgnore drops cancelled check runs, e.g) jobs superseded by newer runs of concurrency groups.
+	CancelledIgnore = "ignore"
+	// CancelledPending keeps cancelled check runs pending until they are re-run, as re-runs supersede them.
+	CancelledPending = "pending"
+)
+
+// Treatments of check runs concluding action_required or stale, which need someone to act on them.
+const (
+	// AttentionFailure fails the validation on the check runs.
+	AttentionFailure = "failure"
+	// AttentionPending keeps the check runs pending until someone acts on them, e.g) approves or re-runs them.
+	AttentionPending = "pending"
+	// AttentionIgnore drops the check runs, as if they never ran.
+	AttentionIgnore = "ignore"
+)
+
+// APIs listing the check runs of the ref.
+const (
+	// ChecksAPIREST lists the check runs from the REST API, page by page.
+	ChecksAPIREST = "rest"
+	// ChecksAPIGraphQL lists the check runs from the GraphQL API in a single request.
+	ChecksAPIGraphQL = "graphql"
+	// ChecksAPIAuto lists the check runs from the GraphQL API, falling back to the REST API for the rest of the
+	// validation once it fails, e.g) during partial outages of the GraphQL API.
+	ChecksAPIAuto = "auto"
+)
+
+// actionsApp is the slug of the app creating the check runs of GitHub Actions, which all have workflow runs.
+const actionsApp = "github-actions"
+
+// maxSuiteLookups is how many polls the check runs of GitHub Actions are kept pending while their workflow runs are
+// not listed, which happens right after a push, before they are validated as external checks.
+const maxSuiteLookups = 3
+
+// maxEmptyPolls is how many consecutive polls must find no jobs before WithFailOnNoJobs fails the validation, as
+// the check runs of the other workflows are created a while after a push, possibly after this job started.
+const maxEmptyPolls = 3
+
+// externalWorkflow groups the check runs which have no workflow run, such as the checks of external apps.
+const externalWorkflow = "external"
+
 // NOTE: https://docs.github.com/en/rest/reference/checks
 const (
 	checkRunCompletedStatus  = "completed"
@@ -24,11 +108,14 @@ const (
 	checkRunInProgressStatus = "in_progress"
 )
 const (
-	checkRunNeutralConclusion  = "neutral"
-	checkRunSuccessConclusion  = "success"
-	checkRunSkipConclusion     = "skipped"
-	checkRunFailedConclusion   = "failure"
-	checkRunTimedOutConclusion = "timed_out"
+	checkRunNeutralConclusion        = "neutral"
+	checkRunSuccessConclusion        = "success"
+	checkRunSkipConclusion           = "skipped"
+	checkRunFailedConclusion         = "failure"
+	checkRunTimedOutConclusion       = "timed_out"
+	checkRunCancelledConclusion      = "cancelled"
+	checkRunActionRequiredConclusion = "action_required"
+	checkRunStaleConclusion          = "stale"
 )
 
 const (
@@ -42,22 +129,93 @@ var (
 )
 
 type ghaStatus struct {
-	Job      string
-	Workflow string
-	State    string
+	Job         string
+	Workflow    string
+	App         string // Slug of the GitHub App which created the check run.
+	Path        string // Path of the workflow file, used to resolve the owners of the job.
+	Sibling     bool   // Whether the job is of the matrix, or with ignoreSelfRun of any job, of the workflow run of this job.
+	Unresolved  bool   // Whether the job is of GitHub Actions, but its workflow run is not listed yet.
+	Context     bool   // Whether the job is the context of a commit status rather than a check run.
+	State       string
+	Attention   string // Conclusion of the job when someone needs to act on it, i.e) action_required or stale.
+	StartedAt   time.Time
+	CompletedAt time.Time
 }
 
 func (gs *ghaStatus) String() string {
+	if len(gs.Workflow) == 0 {
+		return gs.Job
+	}
 	return fmt.Sprintf("%s / %s", gs.Workflow, gs.Job)
 }
 
+// gateJob is the name of a job of another instance of the gate, optionally qualified by its workflow.
+type gateJob struct {
+	workflow string
+	job      string
+}
+
 type statusValidator struct {
-	repo        string
-	owner       string
-	ref         string
-	selfJobName string
-	ignoredJobs []string
-	client      github.Client
+	repo             string
+	owner            string
+	ref              string
+	selfJobName      string
+	selfWorkflow     string // Workflow of this job, when the self job name is qualified by it.
+	selfRunID        int64
+	selfRunWorkflow  string // Workflow of the workflow run of this job, once found.
+	otherGates       []gateJob
+	ignoreOtherGates bool
+	gateJobs         map[string][]gateJobName // Jobs running the action, keyed by the paths of their workflows.
+	ignoreSelfRun    bool                     // Whether every job of the workflow run of this job is ignored, whatever its name.
+	ignoredJobs      []string
+	ignoredPatterns  []*regexp.Regexp // Compiled from the regular expressions of ignoredJobs.
+	ignoredWorkflows []string
+	ignoredApps      []string
+	ruleSpec         string
+	rules            []*rule // Parsed from ruleSpec, evaluated before the ignored jobs, workflows and apps.
+	anyOfSpec        string
+	anyOf            [][]string // Parsed from anyOfSpec, groups of jobs at least one of which must succeed.
+	quorumSpec       string
+	quorums          []*quorum // Parsed from quorumSpec, jobs a percent of which must succeed.
+	skippedAs        string
+	neutralAs        string
+	cancelledAs      string
+	actionRequiredAs string
+	staleAs          string
+	checksAPI        string
+	explain          bool // Whether the evaluations of every rule are recorded, to explain checks.
+	catalog          *i18n.Catalog
+	clock            clock.Clock
+	client           github.Client
+
+	minChecks    int
+	failOnNoJobs bool
+	// Whether the contexts of the commit statuses of the ref are validated as jobs, other than the published ones.
+	statusContexts bool
+	published      []string
+	// Prefixes of the contexts of commit statuses validated as jobs, every context when none, and of those ignored.
+	statusPrefixes        []string
+	ignoredStatusPrefixes []string
+	// Whether only the required checks are validated, e.g) the required status checks of the protected branch.
+	requiredOnly bool
+	required     []string
+	criticalPath bool
+	rollupCache  bool
+	cached       *cachedStatus // Status of the previous poll, along with the key of the rollup it was evaluated at.
+	graph        workflowGraph // Cached, as workflow definitions do not change while validating.
+
+	// observed are the checks observed by the previous polls, keyed by their names, in the order of their first
+	// observation, so that checks vanishing from the API are noticed.
+	observed     map[string]*ghaStatus
+	observedKeys []string
+	// suiteLookups counts the polls by the check suites of GitHub Actions whose workflow runs were not listed.
+	suiteLookups map[int64]int
+	// emptyPolls counts the consecutive polls which found no jobs, with WithFailOnNoJobs.
+	emptyPolls int
+	// graphQLErr is the error of the GraphQL API with ChecksAPIAuto, after which the REST API lists the checks.
+	graphQLErr error
+
+	owners *owners.Resolver
 }
 
 func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
@@ -77,6 +235,13 @@ func (sv *statusValidator) Name() string {
 	return sv.selfJobName
 }
 
+func (sv *statusValidator) now() time.Time {
+	if sv.clock == nil {
+		return time.Now()
+	}
+	return sv.clock.Now()
+}
+
 func (sv *statusValidator) validateFields() error {
 	errs := make(multierror.Errors, 0, 6)
 
@@ -95,6 +260,68 @@ func (sv *statusValidator) validateFields() error {
 	if sv.client == nil {
 		errs = append(errs, errors.New("github client is empty"))
 	}
+	if sv.ignoreSelfRun && sv.selfRunID == 0 {
+		errs = append(errs, errors.New("workflow run of this job is unknown, which is required to ignore its jobs"))
+	}
+	if sv.minChecks < 0 {
+		errs = append(errs, errors.New("minimum number of jobs is negative"))
+	}
+	for _, job := range sv.ignoredJobs {
+		if !isPattern(job) {
+			continue
+		}
+		re, err := regexp.Compile(job)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern of ignored jobs %q: %w", job, err))
+			continue
+		}
+		sv.ignoredPatterns = append(sv.ignoredPatterns, re)
+	}
+	switch sv.skippedAs {
+	case "", SkippedIgnore, SkippedSuccess, SkippedFailure:
+	default:
+		errs = append(errs, fmt.Errorf("treatment of skipped jobs %s is invalid. must be %s, %s or %s", sv.skippedAs, SkippedIgnore, SkippedSuccess, SkippedFailure))
+	}
+	switch sv.neutralAs {
+	case "", NeutralSuccess, NeutralPending, NeutralFailure:
+	default:
+		errs = append(errs, fmt.Errorf("treatment of neutral conclusions %s is invalid. must be %s, %s or %s", sv.neutralAs, NeutralSuccess, NeutralPending, NeutralFailure))
+	}
+	switch sv.cancelledAs {
+	case "", CancelledFailure, CancelledIgnore, CancelledPending:
+	default:
+		errs = append(errs, fmt.Errorf("treatment of cancelled jobs %s is invalid. must be %s, %s or %s", sv.cancelledAs, CancelledFailure, CancelledIgnore, CancelledPending))
+	}
+	switch sv.actionRequiredAs {
+	case "", AttentionFailure, AttentionPending, AttentionIgnore:
+	default:
+		errs = append(errs, fmt.Errorf("treatment of jobs requiring action %s is invalid. must be %s, %s or %s", sv.actionRequiredAs, AttentionFailure, AttentionPending, AttentionIgnore))
+	}
+	switch sv.staleAs {
+	case "", AttentionFailure, AttentionPending, AttentionIgnore:
+	default:
+		errs = append(errs, fmt.Errorf("treatment of stale jobs %s is invalid. must be %s, %s or %s", sv.staleAs, AttentionFailure, AttentionPending, AttentionIgnore))
+	}
+	switch sv.checksAPI {
+	case "", ChecksAPIREST, ChecksAPIGraphQL, ChecksAPIAuto:
+	default:
+		errs = append(errs, fmt.Errorf("api of check runs %s is invalid. must be %s, %s or %s", sv.checksAPI, ChecksAPIREST, ChecksAPIGraphQL, ChecksAPIAuto))
+	}
+	rules, err := parseRules(sv.ruleSpec)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	sv.rules = rules
+	anyOf, err := parseAnyOf(sv.anyOfSpec)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	sv.anyOf = anyOf
+	quorums, err := parseQuorums(sv.quorumSpec)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	sv.quorums = quorums
 
 	if len(errs) != 0 {
 		return errs
@@ -103,8 +330,217 @@ func (sv *statusValidator) validateFields() error {
 	return nil
 }
 
+// isSelf reports whether the check is this job. Jobs of the same name are only this job in the workflow of this job,
+// as given by the self job name or found by the workflow run of this job, so that they are not silently ignored in
+// other workflows. Runs of the same workflow, e.g) on other events, are all this job, so that they never wait for
+// each other. Without knowing either workflow, jobs are matched by their names only.
+func (sv *statusValidator) isSelf(gs *ghaStatus) bool {
+	if gs.Job == sv.selfJobName {
+		workflow := sv.selfWorkflow
+		if len(workflow) == 0 {
+			workflow = sv.selfRunWorkflow
+		}
+		if len(workflow) == 0 || len(gs.Workflow) == 0 || gs.Workflow == workflow {
+			return true
+		}
+	}
+	return sv.isOtherGate(gs)
+}
+
+// isOtherGate reports whether the check is a job of another instance of the gate, including the jobs of its matrix,
+// so that the instances never wait for each other.
+func (sv *statusValidator) isOtherGate(gs *ghaStatus) bool {
+	for _, g := range sv.otherGates {
+		if gs.Job != g.job && !strings.HasPrefix(gs.Job, g.job+" (") {
+			continue
+		}
+		if len(g.workflow) == 0 || gs.Workflow == g.workflow {
+			return true
+		}
+	}
+	return false
+}
+
+// isMatrixOfSelf reports whether the job is named as a job of the matrix of this job, e.g) self (pull_request).
+func (sv *statusValidator) isMatrixOfSelf(job string) bool {
+	return job == sv.selfJobName || strings.HasPrefix(job, sv.selfJobName+" (")
+}
+
+func (sv *statusValidator) isIgnoredWorkflow(workflow string) bool {
+	if len(workflow) == 0 {
+		return false
+	}
+	for _, ignored := range sv.ignoredWorkflows {
+		if workflow == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+func (sv *statusValidator) isIgnoredApp(slug string) bool {
+	if len(slug) == 0 {
+		return false
+	}
+	for _, ignored := range sv.ignoredApps {
+		if slug == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// isPattern reports whether the entry of ignored jobs is a regular expression rather than a job name.
+func isPattern(job string) bool {
+	return strings.HasPrefix(job, "^") || strings.HasSuffix(job, "$")
+}
+
+// isIgnored reports whether the job is ignored, either by its name or by its name qualified by its workflow,
+// e.g) Nightly / build, so that a job can be ignored without ignoring the jobs of the same name in other workflows.
+func (sv *statusValidator) isIgnored(gs *ghaStatus) bool {
+	names := []string{gs.Job}
+	if len(gs.Workflow) != 0 {
+		names = append(names, gs.String())
+	}
+	for _, name := range names {
+		for _, ignored := range sv.ignoredJobs {
+			if name == ignored {
+				return true
+			}
+		}
+		for _, re := range sv.ignoredPatterns {
+			if re.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orderedRules returns the rules in the order of their evaluation: the rules given explicitly, followed by the
+// ignored apps, jobs and workflows, then with status contexts the ignored statuses, and with the required checks the
+// checks which are not required.
+func (sv *statusValidator) orderedRules() []*rule {
+	rules := append(append([]*rule{}, sv.rules...),
+		&rule{name: ruleIgnoredApp, action: actionIgnore, match: func(gs *ghaStatus) bool { return sv.isIgnoredApp(gs.App) }},
+		&rule{name: ruleIgnored, action: actionIgnore, match: sv.isIgnored},
+		&rule{name: ruleIgnoredWorkflow, action: actionIgnore, match: func(gs *ghaStatus) bool { return sv.isIgnoredWorkflow(gs.Workflow) }},
+	)
+	if sv.statusContexts {
+		rules = append(rules, &rule{name: ruleIgnoredStatus, action: actionIgnore, match: sv.isIgnoredStatus})
+	}
+	if sv.requiredOnly {
+		rules = append(rules, &rule{name: ruleNotRequired, action: actionIgnore, match: func(gs *ghaStatus) bool { return !sv.isRequired(gs) }})
+	}
+	return rules
+}
+
+// isIgnoredStatus reports whether the job is the context of a commit status which is ignored by its prefix, either as
+// it is not under any of the validated prefixes, or under one of the ignored prefixes, e.g) license/.
+func (sv *statusValidator) isIgnoredStatus(gs *ghaStatus) bool {
+	if !gs.Context {
+		return false
+	}
+	if len(sv.statusPrefixes) != 0 && !hasAnyPrefix(gs.Job, sv.statusPrefixes) {
+		return true
+	}
+	return hasAnyPrefix(gs.Job, sv.ignoredStatusPrefixes)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired reports whether the check is one of the required checks, by its name optionally qualified by its
+// workflow, e.g) Nightly / build.
+func (sv *statusValidator) isRequired(gs *ghaStatus) bool {
+	for _, required := range sv.required {
+		if gs.Job == required || gs.String() == required {
+			return true
+		}
+	}
+	return false
+}
+
+// Ignores reports which rule ignores a check of the name, optionally qualified by its workflow, e.g) Nightly / build.
+// Only the name is known, so the rules selecting apps never match, and neither do those selecting workflows unless the
+// name is qualified.
+func (sv *statusValidator) Ignores(check string) (string, bool) {
+	gs := &ghaStatus{Job: check}
+	if workflow, job, ok := strings.Cut(check, " / "); ok {
+		gs = &ghaStatus{Job: job, Workflow: workflow}
+	}
+	if sv.isSelf(gs) || (gs.Job != sv.selfJobName && sv.isMatrixOfSelf(gs.Job)) {
+		return "", false
+	}
+	return sv.ignoredBy(gs)
+}
+
+// IgnoresGroup reports which rule ignores every job of the workflow, such as the ignored workflows, or job patterns
+// matching the workflow, e.g) ^Nightly / .
+func (sv *statusValidator) IgnoresGroup(workflow string) (string, bool) {
+	return sv.ignoredBy(&ghaStatus{Workflow: workflow})
+}
+
+// ignoredBy returns the rule capturing the job when it is an ignore rule.
+func (sv *statusValidator) ignoredBy(gs *ghaStatus) (string, bool) {
+	for _, r := range sv.orderedRules() {
+		if !r.match(gs) {
+			continue
+		}
+		if r.action != actionIgnore {
+			return "", false
+		}
+		return r.describe(), true
+	}
+	return "", false
+}
+
+type cachedStatus struct {
+	rollup string
+	status *status
+}
+
+// Validate evaluates the checks of the ref. With the rollup cache, the status of the previous poll is reused once
+// every check succeeded, as long as the counts of checks by their states are unchanged, as the rollup is far cheaper
+// to get than the checks, e.g) while waiting on reviews.
 func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, error) {
-	ghaStatuses, err := sv.listGhaStatuses(ctx)
+	if !sv.rollupCache {
+		return sv.evaluate(ctx)
+	}
+
+	// The rollup is only an optimization, so the checks are listed whenever it is unavailable. Pending rollups are
+	// never reused, as their counts do not tell which checks they are, and pending statuses are refreshed by every
+	// poll, e.g) vanished checks and the estimates of their completion.
+	var key string
+	if rollup, _, err := sv.client.GetCheckRollup(ctx, sv.owner, sv.repo, sv.ref); err == nil && rollup.State == github.RollupSuccess {
+		key = rollup.Key()
+	}
+	if len(key) != 0 && sv.cached != nil && sv.cached.rollup == key {
+		github.DefaultStats.CacheHit()
+		return sv.cached.status, nil
+	}
+
+	sv.cached = nil
+	st, err := sv.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Only succeeded statuses are reused, e.g) not the ones with too few jobs, nor with unresolved jobs whose
+	// workflows are looked up by every poll.
+	if s, ok := st.(*status); ok && len(key) != 0 && s.succeeded {
+		sv.cached = &cachedStatus{rollup: key, status: s}
+	}
+	return st, nil
+}
+
+func (sv *statusValidator) evaluate(ctx context.Context) (validators.Status, error) {
+	ghaStatuses, listed, degraded, err := sv.listGhaStatuses(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -114,42 +550,187 @@ func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, err
 		completeJobs: make([]string, 0, len(ghaStatuses)),
 		errJobs:      make([]string, 0, len(ghaStatuses)/2),
 		ignoredJobs:  make([]string, 0, len(ghaStatuses)),
+		decisions:    make([]validators.Decision, 0, len(ghaStatuses)),
 		succeeded:    true,
+		catalog:      sv.catalog,
 	}
+	rules := sv.orderedRules()
 
 	st.ignoredJobs = append(st.ignoredJobs, sv.ignoredJobs...)
+	if degraded != nil {
+		st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailNoWorkflows, degraded))
+	}
+	if sv.graphQLErr != nil {
+		st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailChecksFallback, sv.graphQLErr))
+	}
+	// Other instances of the gate are only ignored by their names when their workflows are unavailable.
+	if sv.ignoreOtherGates && degraded == nil {
+		if err := sv.loadGateJobs(ctx, ghaStatuses); err != nil {
+			st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailNoGates, err))
+		}
+	}
 
 	var successCnt int
+	var unresolved []string
+	outcomes := sv.anyOfOutcomes(ghaStatuses)
+	quorums := sv.quorumOutcomes(ghaStatuses)
+	considered := make([]*ghaStatus, 0, len(ghaStatuses))
 	for _, ghaStatus := range ghaStatuses {
-		var toIgnore bool
-		for _, ignored := range sv.ignoredJobs {
-			if ghaStatus.Job == ignored {
-				toIgnore = true
-				break
+		// Jobs whose workflows are unknown yet are not classified, nor observed, as their names change once known.
+		if ghaStatus.Unresolved {
+			st.decide(ghaStatus, ruleUnresolved, validators.StatePending)
+			st.totalJobs = append(st.totalJobs, ghaStatus.String())
+			unresolved = append(unresolved, ghaStatus.Job)
+			continue
+		}
+		// Ignored jobs and this job itself should be considered as success regardless of their statuses.
+		if sv.isSelf(ghaStatus) || ghaStatus.Sibling || sv.isOtherGateRun(ghaStatus) {
+			st.decide(ghaStatus, ruleSelf, validators.StateIgnored)
+			if sv.explain {
+				st.record(ghaStatus, []ruleEvaluation{{rule: ruleSelf, result: resultCaptured}})
 			}
+			successCnt++
+			continue
 		}
 
-		// Ignored jobs and this job itself should be considered as success regardless of their statuses.
-		if toIgnore || ghaStatus.Job == sv.selfJobName {
+		// The first rule matching the check captures it, while the others are only recorded to be explained.
+		var captured *rule
+		var matched []*rule
+		evaluated := []ruleEvaluation{{rule: ruleSelf, result: resultUnmatched}}
+		for _, r := range rules {
+			result := resultUnmatched
+			if r.match(ghaStatus) {
+				matched = append(matched, r)
+				result = resultShadowed
+				if len(matched) == 1 {
+					result = resultCaptured
+				}
+			}
+			evaluated = append(evaluated, ruleEvaluation{rule: r.describe(), result: result})
+		}
+		if len(matched) != 0 {
+			captured = matched[0]
+		}
+		st.match(ghaStatus, matched)
+		if sv.explain {
+			st.record(ghaStatus, evaluated)
+		}
+		if captured != nil && captured.action == actionIgnore {
+			st.decide(ghaStatus, captured.name, validators.StateIgnored)
+			// The ignored jobs are listed as they were given, and the others by the checks they captured.
+			if captured.name != ruleIgnored {
+				st.ignoredJobs = append(st.ignoredJobs, ghaStatus.String())
+			}
 			successCnt++
 			continue
 		}
+		// Jobs of any-of groups which have not succeeded are decided by their groups: they pass along with the
+		// groups which succeeded, and only fail once every job of their groups has failed.
+		if g := sv.anyOfGroup(ghaStatus); g >= 0 && ghaStatus.State != successState {
+			switch outcomes[g] {
+			case successState:
+				st.decide(ghaStatus, ruleAnyOf, validators.StateIgnored)
+				st.ignoredJobs = append(st.ignoredJobs, ghaStatus.String())
+				successCnt++
+				continue
+			case pendingState:
+				st.decide(ghaStatus, ruleAnyOf, validators.StatePending)
+				st.totalJobs = append(st.totalJobs, ghaStatus.String())
+				considered = append(considered, ghaStatus)
+				continue
+			}
+		}
+		// Likewise, jobs of quorums which have not succeeded pass along with the quorums which succeeded, and only
+		// fail once too few jobs of their quorums are left to succeed.
+		if q := sv.quorumOf(ghaStatus); q >= 0 && ghaStatus.State != successState {
+			switch quorums[q].state {
+			case successState:
+				st.decide(ghaStatus, ruleQuorum, validators.StateIgnored)
+				st.ignoredJobs = append(st.ignoredJobs, ghaStatus.String())
+				successCnt++
+				continue
+			case pendingState:
+				st.decide(ghaStatus, ruleQuorum, validators.StatePending)
+				st.totalJobs = append(st.totalJobs, ghaStatus.String())
+				considered = append(considered, ghaStatus)
+				continue
+			}
+		}
 
+		decidedBy := ruleState
+		if captured != nil {
+			decidedBy = captured.name
+		}
+		st.decide(ghaStatus, decidedBy, verdictOf(ghaStatus.State))
 		st.totalJobs = append(st.totalJobs, ghaStatus.String())
+		considered = append(considered, ghaStatus)
+		if note := attentionNote(st.messages(), ghaStatus); len(note) != 0 {
+			st.notes = append(st.notes, note)
+		}
 
 		switch ghaStatus.State {
 		case successState:
 			st.completeJobs = append(st.completeJobs, ghaStatus.String())
 			successCnt++
 		case errorState, failureState:
-			st.errJobs = append(st.errJobs, ghaStatus.String())
+			st.errJobs = append(st.errJobs, sv.describeFailure(ctx, st, ghaStatus))
+		}
+	}
+	// Checks which vanished, e.g) as their suite was deleted or reset by a rerun, are kept pending, as the gate
+	// would otherwise turn green only because fewer checks are left to wait for.
+	var vanished []string
+	if degraded == nil {
+		for _, gs := range sv.observe(considered, listed) {
+			st.decide(gs, ruleVanished, validators.StatePending)
+			st.totalJobs = append(st.totalJobs, gs.String())
+			vanished = append(vanished, gs.String())
 		}
 	}
+	if len(vanished) != 0 {
+		st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailVanished, strings.Join(vanished, ", ")))
+	}
+	for i, q := range sv.quorums {
+		if o := quorums[i]; o.jobs != 0 {
+			st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailQuorum, q.text, o.succeeded, o.jobs, o.required))
+		}
+	}
+	if len(unresolved) != 0 {
+		st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailUnresolved, maxSuiteLookups, strings.Join(unresolved, ", ")))
+		st.unresolved = true
+	}
+	// Too few jobs keep the validation pending rather than passing, as their workflows may have failed to trigger.
+	tooFew := len(st.totalJobs) < sv.minChecks
+	if tooFew {
+		st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailTooFewJobs, sv.minChecks, len(st.totalJobs)))
+	}
+	// Without any job to wait for, CI never started for the ref, which fails the validation rather than passing it.
+	// The validation is kept pending by the first polls, as the check runs may not be created yet.
+	noJobs := sv.failOnNoJobs && len(st.totalJobs) == 0
+	if noJobs {
+		sv.emptyPolls++
+		if sv.emptyPolls >= maxEmptyPolls {
+			st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailNoJobs))
+			st.succeeded = false
+			return nil, &validators.FailedChecksError{Status: st}
+		}
+		st.notes = append(st.notes, st.messages().Sprintf(i18n.DetailNoJobsYet, maxEmptyPolls))
+	} else {
+		sv.emptyPolls = 0
+	}
+
 	if len(st.errJobs) != 0 {
-		return nil, errors.New(st.Detail())
+		st.succeeded = false
+		return nil, &validators.FailedChecksError{Status: st}
 	}
 
-	if len(ghaStatuses) != successCnt {
+	st.estimates = estimateCompletion(sv.now(), considered)
+	if sv.criticalPath {
+		if note := sv.criticalPathNote(ctx, considered); len(note) != 0 {
+			st.notes = append(st.notes, note)
+		}
+	}
+
+	if len(ghaStatuses) != successCnt || len(vanished) != 0 || tooFew || noJobs {
 		st.succeeded = false
 		return st, nil
 	}
@@ -157,7 +738,141 @@ func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, err
 	return st, nil
 }
 
+// observe records the checks of the poll, and returns the checks observed by the previous polls which are no longer
+// listed, as they were last observed. Checks still listed but decided otherwise, e.g) ignored once their any-of
+// groups succeeded, have not vanished.
+func (sv *statusValidator) observe(checks []*ghaStatus, listed map[string]bool) []*ghaStatus {
+	if sv.observed == nil {
+		sv.observed = make(map[string]*ghaStatus)
+	}
+	for _, gs := range checks {
+		key := gs.String()
+		if _, ok := sv.observed[key]; !ok {
+			sv.observedKeys = append(sv.observedKeys, key)
+		}
+		sv.observed[key] = gs
+	}
+
+	var vanished []*ghaStatus
+	for _, key := range sv.observedKeys {
+		if !listed[key] {
+			vanished = append(vanished, sv.observed[key])
+		}
+	}
+	return vanished
+}
+
+// moveObserved moves the check observed under the key from to the key to, in the order of its first observation.
+func (sv *statusValidator) moveObserved(from, to string) {
+	gs, ok := sv.observed[from]
+	if !ok {
+		return
+	}
+	delete(sv.observed, from)
+	_, exists := sv.observed[to]
+	for i, key := range sv.observedKeys {
+		if key != from {
+			continue
+		}
+		if exists {
+			sv.observedKeys = append(sv.observedKeys[:i], sv.observedKeys[i+1:]...)
+		} else {
+			sv.observedKeys[i] = to
+			sv.observed[to] = gs
+		}
+		break
+	}
+}
+
+// describeFailure names the failed job along with its owners, so that they get pinged by reports and notifications.
+// As owners are only informational, failing to resolve them is noted once rather than failing the validation.
+func (sv *statusValidator) describeFailure(ctx context.Context, st *status, gs *ghaStatus) string {
+	if sv.owners == nil {
+		return gs.String()
+	}
+	names, err := sv.owners.Owners(ctx, gs.Job, gs.Path)
+	if err != nil {
+		note := st.messages().Sprintf(i18n.DetailNoOwners, err)
+		if len(st.notes) == 0 || st.notes[len(st.notes)-1] != note {
+			st.notes = append(st.notes, note)
+		}
+		return gs.String()
+	}
+	if len(names) == 0 {
+		return gs.String()
+	}
+	return st.messages().Sprintf(i18n.DetailJobOwners, gs.String(), strings.Join(names, " "))
+}
+
+// criticalPathNote describes the chain of workflows which keeps the gate open. As this is only informational,
+// failing to resolve it is reported in the note rather than failing the validation.
+func (sv *statusValidator) criticalPathNote(ctx context.Context, statuses []*ghaStatus) string {
+	msgs := sv.catalog
+	if msgs == nil {
+		msgs = i18n.Default()
+	}
+	if sv.graph == nil {
+		graph, err := sv.loadWorkflowGraph(ctx)
+		if err != nil {
+			return msgs.Sprintf(i18n.DetailNoCriticalPath, err)
+		}
+		sv.graph = graph
+	}
+
+	present := make(map[string]bool)
+	pending := make(map[string]bool)
+	for _, gs := range statuses {
+		present[gs.Workflow] = true
+		if gs.State == pendingState {
+			pending[gs.Workflow] = true
+		}
+	}
+	path := sv.graph.criticalPath(present, pending)
+	if len(path) == 0 {
+		return ""
+	}
+	return msgs.Sprintf(i18n.DetailCriticalPath, strings.Join(path, " → "))
+}
+
+// attentionNote explains what the job needs, so that it is not mistaken for an ordinary failure.
+func attentionNote(msgs *i18n.Catalog, gs *ghaStatus) string {
+	switch gs.Attention {
+	case checkRunActionRequiredConclusion:
+		return msgs.Sprintf(i18n.DetailActionRequired, gs.String())
+	case checkRunStaleConclusion:
+		return msgs.Sprintf(i18n.DetailStale, gs.String())
+	}
+	return ""
+}
+
+// verdictOf maps the state of a job to the state reported by validators.
+func verdictOf(state string) string {
+	switch state {
+	case successState:
+		return validators.StateSuccess
+	case errorState, failureState:
+		return validators.StateFailure
+	default:
+		return validators.StatePending
+	}
+}
+
 func (sv *statusValidator) listCheckRunsForRef(ctx context.Context) ([]*github.CheckRun, error) {
+	switch sv.checksAPI {
+	case ChecksAPIGraphQL:
+		runs, _, err := sv.client.ListCheckRunsByGraphQL(ctx, sv.owner, sv.repo, sv.ref)
+		return runs, err
+	case ChecksAPIAuto:
+		if sv.graphQLErr != nil {
+			break
+		}
+		runs, _, err := sv.client.ListCheckRunsByGraphQL(ctx, sv.owner, sv.repo, sv.ref)
+		if err == nil {
+			return runs, nil
+		}
+		sv.graphQLErr = err
+	}
+
 	var runResults []*github.CheckRun
 	page := 1
 	for {
@@ -177,13 +892,16 @@ func (sv *statusValidator) listCheckRunsForRef(ctx context.Context) ([]*github.C
 	return runResults, nil
 }
 
-func (sv *statusValidator) listGhaStatuses(ctx context.Context) ([]*ghaStatus, error) {
-	currentJobs := make(map[string]struct{})
-
+// listGhaStatuses returns the statuses of the jobs of the ref, along with the keys of every check listed by the API,
+// including those left out by their conclusions, e.g) ignored cancellations, which have not vanished. When the
+// workflow runs are unavailable, such as during partial outages of the Actions API, jobs are keyed by their names
+// only rather than failing the validation, and the error of the workflow runs is returned as degraded. Jobs of the
+// same name in different workflows then collapse into the worst of their check runs.
+func (sv *statusValidator) listGhaStatuses(ctx context.Context) (statuses []*ghaStatus, listed map[string]bool, degraded error, err error) {
 	// Get all the checks related to this reference
 	runResults, err := sv.listCheckRunsForRef(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	ghaStatuses := make([]*ghaStatus, 0, len(runResults))
@@ -192,56 +910,281 @@ func (sv *statusValidator) listGhaStatuses(ctx context.Context) ([]*ghaStatus, e
 	workflowRuns, _, err := sv.client.ListWorkflowRuns(ctx, sv.owner, sv.repo, &github.ListWorkflowRunsOptions{
 		HeadSHA: sv.ref,
 	})
-
 	if err != nil {
-		return nil, err
+		degraded = fmt.Errorf("failed to list workflow runs: %w", err)
+		workflowRuns = &github.WorkflowRuns{}
 	}
 
 	// Map check suite ID to workflow name
 	suiteToWorkflow := make(map[int64]string)
+	suiteToPath := make(map[int64]string)
+	var selfSuite int64
 	fmt.Println("Found workflows:")
 	for _, wf := range workflowRuns.WorkflowRuns {
 		fmt.Println("-", wf.GetName())
 		suiteToWorkflow[wf.GetCheckSuiteID()] = wf.GetName()
+		suiteToPath[wf.GetCheckSuiteID()] = wf.GetPath()
+		if sv.selfRunID != 0 && wf.GetID() == sv.selfRunID {
+			selfSuite = wf.GetCheckSuiteID()
+			sv.selfRunWorkflow = wf.GetName()
+		}
 	}
 
-	for _, run := range runResults {
-		checkKey, wfName, err := CreateCheckKey(run, suiteToWorkflow)
-		if err != nil {
-			return nil, err
+	// Right after a push, check runs of GitHub Actions may refer to check suites whose workflow runs are yet to be
+	// listed. Their workflows are looked up again by the next polls, rather than validating them as external checks.
+	unresolved := make(map[int64]bool)
+	if degraded == nil {
+		for _, run := range runResults {
+			id := run.GetCheckSuite().GetID()
+			if _, ok := suiteToWorkflow[id]; ok || id == 0 || unresolved[id] || run.GetApp().GetSlug() != actionsApp {
+				continue
+			}
+			if sv.suiteLookups == nil {
+				sv.suiteLookups = make(map[int64]int)
+			}
+			sv.suiteLookups[id]++
+			unresolved[id] = sv.suiteLookups[id] <= maxSuiteLookups
+		}
+		// Jobs validated as external checks once their lookups were exhausted move to their workflows once their
+		// workflow runs are listed, rather than vanishing from the external checks.
+		var resolved []int64
+		for _, run := range runResults {
+			id := run.GetCheckSuite().GetID()
+			if wf, ok := suiteToWorkflow[id]; ok && sv.suiteLookups[id] > maxSuiteLookups {
+				sv.moveObserved(fmt.Sprintf("%s / %s", externalWorkflow, run.GetName()), fmt.Sprintf("%s / %s", wf, run.GetName()))
+				resolved = append(resolved, id)
+			}
 		}
+		for _, id := range resolved {
+			delete(sv.suiteLookups, id)
+		}
+	}
 
+	// Re-runs of workflows leave the check runs of their earlier attempts behind, so only the latest attempt of each
+	// check is validated, rather than the first one listed, which may be a stale failure.
+	latest := make(map[string]*github.CheckRun, len(runResults))
+	workflows := make(map[string]string, len(runResults))
+	keys := make([]string, 0, len(runResults))
+	for _, run := range runResults {
 		if run.Name == nil || run.Status == nil {
-			return nil, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, run.Name, run.Status)
+			return nil, nil, nil, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, run.Name, run.Status)
 		}
-		if _, ok := currentJobs[checkKey]; ok {
-			continue
+
+		// Check runs of ignored apps have no workflow run, so they are keyed by their apps instead.
+		checkKey, wfName := run.GetName(), ""
+		if app := run.GetApp().GetSlug(); sv.isIgnoredApp(app) {
+			checkKey = fmt.Sprintf("%s / %s", app, run.GetName())
+		} else if degraded == nil {
+			checkKey, wfName = CreateCheckKey(run, suiteToWorkflow)
+		}
+		prev, ok := latest[checkKey]
+		if !ok {
+			keys = append(keys, checkKey)
+			workflows[checkKey] = wfName
+		}
+		if !ok || sv.supersedes(run, prev) {
+			latest[checkKey] = run
 		}
-		currentJobs[checkKey] = struct{}{}
+	}
 
-		ghaStatus := &ghaStatus{Job: *run.Name, Workflow: wfName}
+	listed = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		run, wfName, app := latest[key], workflows[key], latest[key].GetApp().GetSlug()
+		ghaStatus := &ghaStatus{
+			Job:         *run.Name,
+			Workflow:    wfName,
+			App:         app,
+			Path:        suiteToPath[run.GetCheckSuite().GetID()],
+			Sibling:     selfSuite != 0 && run.GetCheckSuite().GetID() == selfSuite && (sv.ignoreSelfRun || sv.isMatrixOfSelf(run.GetName())),
+			StartedAt:   run.GetStartedAt().Time,
+			CompletedAt: run.GetCompletedAt().Time,
+		}
+		listed[ghaStatus.String()] = true
 
-		if *run.Status != checkRunCompletedStatus {
+		if unresolved[run.GetCheckSuite().GetID()] {
+			ghaStatus.Workflow = ""
 			ghaStatus.State = pendingState
+			ghaStatus.Unresolved = true
 			ghaStatuses = append(ghaStatuses, ghaStatus)
 			continue
 		}
-
-		switch *run.Conclusion {
-		case checkRunNeutralConclusion, checkRunSuccessConclusion:
-			ghaStatus.State = successState
-		case checkRunSkipConclusion:
+		if !sv.conclude(ghaStatus, run) {
 			continue
-		default:
-			ghaStatus.State = errorState
 		}
 		ghaStatuses = append(ghaStatuses, ghaStatus)
 	}
 
-	return ghaStatuses, nil
+	if sv.statusContexts {
+		contexts, err := sv.listStatusContexts(ctx, ghaStatuses)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, gs := range contexts {
+			listed[gs.String()] = true
+		}
+		ghaStatuses = append(ghaStatuses, contexts...)
+	}
+
+	return ghaStatuses, listed, degraded, nil
+}
+
+// conclude sets the state of the job by its check run, and reports whether the job is validated, as the jobs of some
+// conclusions are dropped, e.g) ignored cancellations.
+func (sv *statusValidator) conclude(gs *ghaStatus, run *github.CheckRun) bool {
+	if run.GetStatus() != checkRunCompletedStatus {
+		gs.State = pendingState
+		return true
+	}
+	switch run.GetConclusion() {
+	case checkRunSuccessConclusion:
+		gs.State = successState
+	case checkRunNeutralConclusion:
+		switch sv.neutralAs {
+		case NeutralPending:
+			gs.State = pendingState
+		case NeutralFailure:
+			gs.State = errorState
+		default:
+			gs.State = successState
+		}
+	case checkRunCancelledConclusion:
+		switch sv.cancelledAs {
+		case CancelledIgnore:
+			return false
+		case CancelledPending:
+			gs.State = pendingState
+		default:
+			gs.State = errorState
+		}
+	case checkRunActionRequiredConclusion, checkRunStaleConclusion:
+		treatment := sv.actionRequiredAs
+		if run.GetConclusion() == checkRunStaleConclusion {
+			treatment = sv.staleAs
+		}
+		switch treatment {
+		case AttentionIgnore:
+			return false
+		case AttentionPending:
+			gs.State = pendingState
+		default:
+			gs.State = errorState
+		}
+		gs.Attention = run.GetConclusion()
+	case checkRunSkipConclusion:
+		switch sv.skippedAs {
+		case SkippedSuccess:
+			gs.State = successState
+		case SkippedFailure:
+			gs.State = errorState
+		default:
+			return false
+		}
+	default:
+		gs.State = errorState
+	}
+	return true
+}
+
+// listStatusContexts returns the contexts of the commit statuses of the ref as jobs, as reported by CIs outside
+// GitHub Actions, e.g) Jenkins. Contexts named after the check runs already listed, which some CIs report both
+// ways, and the statuses published by this job are left out.
+func (sv *statusValidator) listStatusContexts(ctx context.Context, checks []*ghaStatus) ([]*ghaStatus, error) {
+	known := make(map[string]bool, len(checks)+len(sv.published))
+	for _, gs := range checks {
+		known[gs.Job] = true
+	}
+	for _, name := range sv.published {
+		known[name] = true
+	}
+
+	var contexts []*ghaStatus
+	var listed int
+	for page := 1; ; page++ {
+		combined, _, err := sv.client.GetCombinedStatus(ctx, sv.owner, sv.repo, sv.ref, &github.ListOptions{
+			Page:    page,
+			PerPage: maxStatusesPerPage,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status: %w", err)
+		}
+		for _, st := range combined.Statuses {
+			if st.Context == nil || st.State == nil {
+				return nil, fmt.Errorf("%w context: %v, state: %v", ErrInvalidCombinedStatusResponse, st.Context, st.State)
+			}
+			if known[st.GetContext()] {
+				continue
+			}
+			known[st.GetContext()] = true
+
+			gs := &ghaStatus{
+				Job:       st.GetContext(),
+				Context:   true,
+				StartedAt: st.GetCreatedAt().Time,
+			}
+			switch st.GetState() {
+			case successState:
+				gs.State = successState
+				gs.CompletedAt = st.GetUpdatedAt().Time
+			case pendingState:
+				gs.State = pendingState
+			default:
+				gs.State = errorState
+				gs.CompletedAt = st.GetUpdatedAt().Time
+			}
+			contexts = append(contexts, gs)
+		}
+		listed += len(combined.Statuses)
+		if len(combined.Statuses) < maxStatusesPerPage || combined.GetTotalCount() <= listed {
+			return contexts, nil
+		}
+	}
+}
+
+// supersedes reports whether the check run is validated rather than the other one of the same key. Attempts of the
+// same check suite supersede each other by isNewerAttempt, while check runs of different suites, which share their
+// keys e.g) when jobs are keyed by their names only, keep the worst of them, so that a failure is not hidden by a
+// job of another workflow which succeeded later.
+func (sv *statusValidator) supersedes(run, than *github.CheckRun) bool {
+	if run.GetCheckSuite().GetID() == than.GetCheckSuite().GetID() {
+		return isNewerAttempt(run, than)
+	}
+	return sv.severity(run) > sv.severity(than)
+}
+
+// severity ranks the check run by how it keeps the validation from succeeding: dropped, succeeded, pending, failed.
+func (sv *statusValidator) severity(run *github.CheckRun) int {
+	gs := &ghaStatus{}
+	if !sv.conclude(gs, run) {
+		return 0
+	}
+	switch gs.State {
+	case successState:
+		return 1
+	case pendingState:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// isNewerAttempt reports whether the check run is of a later attempt than the other one of the same check. Attempts
+// are ordered by when they started, where attempts yet to start, such as queued re-runs, are the latest, and by their
+// IDs, which only increase, when they started at the same time.
+func isNewerAttempt(run, than *github.CheckRun) bool {
+	started, thanStarted := run.GetStartedAt().Time, than.GetStartedAt().Time
+	switch {
+	case started.Equal(thanStarted):
+		return run.GetID() > than.GetID()
+	case started.IsZero():
+		return true
+	case thanStarted.IsZero():
+		return false
+	default:
+		return started.After(thanStarted)
+	}
 }
 
-func CreateCheckKey(run *github.CheckRun, suiteToWorkflow map[int64]string) (string, string, error) {
+func CreateCheckKey(run *github.CheckRun, suiteToWorkflow map[int64]string) (string, string) {
 	checkSuiteID := run.GetCheckSuite().GetID()
 	wfName, ok := suiteToWorkflow[checkSuiteID]
 
@@ -250,9 +1193,10 @@ func CreateCheckKey(run *github.CheckRun, suiteToWorkflow map[int64]string) (str
 		fmt.Println("-", v)
 	}
 
+	// Check runs of external apps, such as Codecov, have no workflow run, and are validated as standalone checks.
 	if !ok {
-		return "", "", fmt.Errorf("workflow name not found for check suite ID: %v of run %v", checkSuiteID, *run.Name)
+		wfName = externalWorkflow
 	}
 
-	return fmt.Sprintf("%v / %v", wfName, *run.Name), wfName, nil
+	return fmt.Sprintf("%v / %v", wfName, *run.Name), wfName
 }
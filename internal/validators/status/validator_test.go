@@ -2,13 +2,19 @@ package status
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/aac228/merge-gatekeeper/internal/github"
 	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+	"github.com/aac228/merge-gatekeeper/internal/i18n"
+	"github.com/aac228/merge-gatekeeper/internal/owners"
 	"github.com/aac228/merge-gatekeeper/internal/validators"
 )
 
@@ -91,11 +97,118 @@ func TestCreateValidator(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"returns Validator with compiled patterns of ignored jobs": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithIgnoredJobs("job-01,^lint-.*$"),
+			},
+			want: &statusValidator{
+				client:          &mock.Client{},
+				owner:           "test-owner",
+				repo:            "test-repo",
+				ref:             "sha",
+				selfJobName:     "job",
+				ignoredJobs:     []string{"job-01", "^lint-.*$"},
+				ignoredPatterns: []*regexp.Regexp{regexp.MustCompile("^lint-.*$")},
+			},
+			wantErr: false,
+		},
+		"returns error when a pattern of ignored jobs is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithIgnoredJobs("^lint-(.*$"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		"returns error when option is empty": {
 			c:       &mock.Client{},
 			want:    nil,
 			wantErr: true,
 		},
+		"returns error when the minimum number of jobs is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMinChecks(-1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the jobs of the unknown workflow run of this job are ignored": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithIgnoredSelfRun(true),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the treatment of skipped jobs is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithSkippedAs("neutral"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the treatment of neutral conclusions is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithNeutralAs("ignore"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the api of check runs is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithChecksAPI("graphql-v4"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the treatment of cancelled jobs is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithCancelledAs("success"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the treatment of stale jobs is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithStaleAs("success"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		"returns error when client is nil": {
 			c: nil,
 			opts: []Option{
@@ -123,6 +236,160 @@ func TestCreateValidator(t *testing.T) {
 	}
 }
 
+func TestIsIgnored(t *testing.T) {
+	v, err := CreateValidator(&mock.Client{},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+		WithSelfJob("job"),
+		WithIgnoredJobs("build (ubuntu),^lint-,e2e$,Nightly / build,^Release / "),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sv := v.(*statusValidator)
+	tests := map[string]struct {
+		job  *ghaStatus
+		want bool
+	}{
+		"ignores the job of the exact name": {
+			job:  &ghaStatus{Workflow: "CI", Job: "build (ubuntu)"},
+			want: true,
+		},
+		"does not match names as patterns": {
+			job:  &ghaStatus{Workflow: "CI", Job: "build ubuntu"},
+			want: false,
+		},
+		"ignores jobs matching a pattern anchored at the start": {
+			job:  &ghaStatus{Workflow: "CI", Job: "lint-go"},
+			want: true,
+		},
+		"ignores jobs matching a pattern anchored at the end": {
+			job:  &ghaStatus{Workflow: "CI", Job: "test-e2e"},
+			want: true,
+		},
+		"ignores the job qualified by its workflow": {
+			job:  &ghaStatus{Workflow: "Nightly", Job: "build"},
+			want: true,
+		},
+		"does not ignore the job of the same name in other workflows": {
+			job:  &ghaStatus{Workflow: "CI", Job: "build"},
+			want: false,
+		},
+		"ignores jobs of the workflow matching a pattern": {
+			job:  &ghaStatus{Workflow: "Release", Job: "publish"},
+			want: true,
+		},
+		"ignores jobs by their names when workflows are unavailable": {
+			job:  &ghaStatus{Job: "lint-go"},
+			want: true,
+		},
+		"does not ignore other jobs": {
+			job:  &ghaStatus{Workflow: "CI", Job: "golint-go"},
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := sv.isIgnored(tt.job); got != tt.want {
+				t.Errorf("isIgnored(%s) = %v, want %v", tt.job, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Ignores(t *testing.T) {
+	v, err := CreateValidator(&mock.Client{},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("job"),
+		WithIgnoredJobs("^lint-,job"),
+		WithIgnoredWorkflows("Nightly"),
+		WithRules("require job:lint-security"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		check    string
+		wantRule string
+		want     bool
+	}{
+		"ignores the check by the ignored jobs": {
+			check:    "lint-go",
+			wantRule: ruleIgnored,
+			want:     true,
+		},
+		"does not ignore the check required by a rule": {
+			check: "lint-security",
+		},
+		"ignores the check qualified by an ignored workflow": {
+			check:    "Nightly / build",
+			wantRule: ruleIgnoredWorkflow,
+			want:     true,
+		},
+		"does not ignore the check unqualified by its workflow": {
+			check: "build",
+		},
+		"does not ignore itself": {
+			check: "job",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, got := v.(validators.Classifier).Ignores(tt.check)
+			if rule != tt.wantRule || got != tt.want {
+				t.Errorf("Ignores(%q) = %q, %v, want %q, %v", tt.check, rule, got, tt.wantRule, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_IgnoresGroup(t *testing.T) {
+	v, err := CreateValidator(&mock.Client{},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("job"),
+		WithIgnoredJobs("^Release / ,^lint-"),
+		WithIgnoredWorkflows("Nightly"),
+		WithRules("ignore workflow:Docs"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		workflow string
+		wantRule string
+		want     bool
+	}{
+		"ignores the workflow by the ignored workflows": {
+			workflow: "Nightly",
+			wantRule: ruleIgnoredWorkflow,
+			want:     true,
+		},
+		"ignores the workflow by a rule": {
+			workflow: "Docs",
+			wantRule: "rules[1] (ignore workflow:Docs)",
+			want:     true,
+		},
+		"ignores the workflow by a job pattern matching the workflow": {
+			workflow: "Release",
+			wantRule: ruleIgnored,
+			want:     true,
+		},
+		"does not ignore other workflows": {
+			workflow: "CI",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, got := v.(validators.Classifier).IgnoresGroup(tt.workflow)
+			if rule != tt.wantRule || got != tt.want {
+				t.Errorf("IgnoresGroup(%q) = %q, %v, want %q, %v", tt.workflow, rule, got, tt.wantRule, tt.want)
+			}
+		})
+	}
+}
+
 func TestName(t *testing.T) {
 	tests := map[string]struct {
 		c    github.Client
@@ -181,6 +448,7 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
 				errJobs:      []string{},
+				decisions:    []validators.Decision{},
 			},
 		},
 		"returns succeeded status and nil when there is one job, which is itself": {
@@ -221,6 +489,9 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
 				errJobs:      []string{},
+				decisions: []validators.Decision{
+					{Check: "Merge Workflow / self-job", Group: "Merge Workflow", Rule: ruleSelf, Verdict: validators.StateIgnored},
+				},
 			},
 		},
 		"returns failed status and nil when there is one job": {
@@ -258,6 +529,9 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
 				errJobs:      []string{},
+				decisions: []validators.Decision{
+					{Check: "Workflow / job", Group: "Workflow", Rule: ruleState, Verdict: validators.StatePending},
+				},
 			},
 		},
 		"returns error when there is a failed job": {
@@ -321,6 +595,10 @@ func Test_statusValidator_Validate(t *testing.T) {
 					"Workflow / job-02",
 				},
 				ignoredJobs: []string{},
+				decisions: []validators.Decision{
+					{Check: "Workflow / job-01", Group: "Workflow", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Workflow / job-02", Group: "Workflow", Rule: ruleState, Verdict: validators.StateFailure},
+				},
 			}).Detail(),
 		},
 		"returns error when there is a failed job with failure state": {
@@ -384,6 +662,10 @@ func Test_statusValidator_Validate(t *testing.T) {
 					"Workflow / job-02",
 				},
 				ignoredJobs: []string{},
+				decisions: []validators.Decision{
+					{Check: "Workflow / job-01", Group: "Workflow", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Workflow / job-02", Group: "Workflow", Rule: ruleState, Verdict: validators.StateFailure},
+				},
 			}).Detail(),
 		},
 		"returns failed status and nil when successful job count is less than total": {
@@ -446,6 +728,11 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				errJobs:     []string{},
 				ignoredJobs: []string{},
+				decisions: []validators.Decision{
+					{Check: "Workflow / job-01", Group: "Workflow", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Workflow / job-02", Group: "Workflow", Rule: ruleState, Verdict: validators.StatePending},
+					{Check: "Merge Workflow / self-job", Group: "Merge Workflow", Rule: ruleSelf, Verdict: validators.StateIgnored},
+				},
 			},
 		},
 		"returns succeeded status and nil when validation is success": {
@@ -514,6 +801,11 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				errJobs:     []string{},
 				ignoredJobs: []string{},
+				decisions: []validators.Decision{
+					{Check: "Workflow 1 / job-01", Group: "Workflow 1", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Workflow 2 / job-02", Group: "Workflow 2", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Merge Workflow / self-job", Group: "Merge Workflow", Rule: ruleSelf, Verdict: validators.StateIgnored},
+				},
 			},
 		},
 		"returns succeeded status and nil when only an ignored job is failing": {
@@ -573,6 +865,53 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{"Workflow / job-01"},
 				errJobs:      []string{},
 				ignoredJobs:  []string{"job-02", "job-03"},
+				decisions: []validators.Decision{
+					{Check: "Workflow / job-01", Group: "Workflow", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "Workflow / job-02", Group: "Workflow", Rule: ruleIgnored, Verdict: validators.StateIgnored, State: validators.StateFailure},
+					{Check: "Merge Workflow / self-job", Group: "Merge Workflow", Rule: ruleSelf, Verdict: validators.StateIgnored},
+				},
+			},
+		},
+		"returns succeeded status keyed by job names when workflow runs are unavailable": {
+			selfJobName: "self-job",
+			client: &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								CheckSuite: &github.CheckSuite{
+									ID: intPtr(1),
+								},
+							},
+							{
+								Name:   stringPtr("self-job"),
+								Status: stringPtr(checkRunInProgressStatus),
+								CheckSuite: &github.CheckSuite{
+									ID: intPtr(2),
+								},
+							},
+						},
+					}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				decisions: []validators.Decision{
+					{Check: "job-01", Rule: ruleState, Verdict: validators.StateSuccess},
+					{Check: "self-job", Rule: ruleSelf, Verdict: validators.StateIgnored},
+				},
+				notes: []string{i18n.Default().Sprintf(i18n.DetailNoWorkflows, "failed to list workflow runs: err")},
 			},
 		},
 	}
@@ -592,6 +931,10 @@ func Test_statusValidator_Validate(t *testing.T) {
 				if err.Error() != tt.wantErrStr {
 					t.Errorf("statusValidator.Validate() error.Error() = %s, wantErrStr %s", err.Error(), tt.wantErrStr)
 				}
+				var failed *validators.FailedChecksError
+				if !errors.As(err, &failed) || failed.Status.IsSuccess() {
+					t.Errorf("statusValidator.Validate() error = %#v, want failed checks", err)
+				}
 			}
 			if !reflect.DeepEqual(got, tt.wantStatus) {
 				t.Errorf("statusValidator.Validate() status = %v, want %v", got, tt.wantStatus)
@@ -600,73 +943,1503 @@ func Test_statusValidator_Validate(t *testing.T) {
 	}
 }
 
-func Test_statusValidator_listStatuses(t *testing.T) {
-	type fields struct {
-		repo        string
-		owner       string
-		ref         string
-		selfJobName string
-		client      github.Client
+func Test_statusValidator_Validate_vanished(t *testing.T) {
+	run := func(name, status, conclusion string) *github.CheckRun {
+		r := &github.CheckRun{Name: stringPtr(name), Status: stringPtr(status), CheckSuite: &github.CheckSuite{ID: intPtr(1)}}
+		if len(conclusion) != 0 {
+			r.Conclusion = stringPtr(conclusion)
+		}
+		return r
 	}
-	type test struct {
-		fields  fields
-		ctx     context.Context
-		wantErr bool
-		want    []*ghaStatus
+	polls := [][]*github.CheckRun{
+		{run("job-01", checkRunInProgressStatus, ""), run("job-02", checkRunInProgressStatus, "")},
+		{run("job-01", checkRunCompletedStatus, checkRunSuccessConclusion)},
+		{run("job-01", checkRunCompletedStatus, checkRunSuccessConclusion), run("job-02", checkRunCompletedStatus, checkRunSuccessConclusion)},
 	}
-	tests := map[string]test{
-		"succeeds to get job statuses": func() test {
-			c := &mock.Client{
-				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					return &github.ListCheckRunsResults{
-						CheckRuns: []*github.CheckRun{
-							// The first element here is the latest state.
-							{
-								Name:       stringPtr("job-02"),
-								Status:     stringPtr(checkRunInProgressStatus),
-								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
-							},
-							{
-								Name:       stringPtr("job-03"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunNeutralConclusion),
-								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
-							},
-							{
-								Name:       stringPtr("job-04"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunSuccessConclusion),
-								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
-							},
-							{
-								Name:       stringPtr("job-05"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunFailedConclusion),
-								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
-							},
-							{
-								Name:       stringPtr("job-06"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunSkipConclusion),
-								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
-							},
-						},
-					}, nil, nil
-				},
-				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
-					total := 1
-					return &github.WorkflowRuns{
-						TotalCount: &total,
-						WorkflowRuns: []*github.WorkflowRun{
-							{
-								Name:         stringPtr("Workflow"),
-								CheckSuiteID: intPtr(1),
-							},
-						},
-					}, nil, nil
-				},
+	var poll int
+	sv := &statusValidator{
+		selfJobName: "self-job",
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: polls[poll]}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				total := 1
+				return &github.WorkflowRuns{
+					TotalCount:   &total,
+					WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)}},
+				}, nil, nil
+			},
+		},
+	}
+
+	wantSucceeded := []bool{false, false, true}
+	wantNotes := [][]string{
+		nil,
+		{i18n.Default().Sprintf(i18n.DetailVanished, "Workflow / job-02")},
+		nil,
+	}
+	for poll = range polls {
+		got, err := sv.Validate(context.Background())
+		if err != nil {
+			t.Fatalf("poll %d: statusValidator.Validate() error = %v", poll, err)
+		}
+		st := got.(*status)
+		if st.succeeded != wantSucceeded[poll] {
+			t.Errorf("poll %d: succeeded = %v, want %v", poll, st.succeeded, wantSucceeded[poll])
+		}
+		if !reflect.DeepEqual(st.notes, wantNotes[poll]) {
+			t.Errorf("poll %d: notes = %v, want %v", poll, st.notes, wantNotes[poll])
+		}
+		if len(st.totalJobs) != 2 {
+			t.Errorf("poll %d: totalJobs = %v, want 2 jobs", poll, st.totalJobs)
+		}
+	}
+}
+
+func Test_statusValidator_observe(t *testing.T) {
+	job := func(name string) *ghaStatus {
+		return &ghaStatus{Job: name, Workflow: "Workflow", State: pendingState}
+	}
+	tests := map[string]struct {
+		listed []string
+		want   []string
+	}{
+		"returns checks no longer listed": {
+			listed: []string{"Workflow / job-01"},
+			want:   []string{"Workflow / job-02"},
+		},
+		"does not return checks listed but decided otherwise": {
+			listed: []string{"Workflow / job-01", "Workflow / job-02"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{}
+			all := map[string]bool{"Workflow / job-01": true, "Workflow / job-02": true}
+			if got := sv.observe([]*ghaStatus{job("job-01"), job("job-02")}, all); len(got) != 0 {
+				t.Fatalf("statusValidator.observe() = %v, want nothing on the first poll", got)
 			}
-			return test{
+
+			listed := make(map[string]bool, len(tt.listed))
+			for _, key := range tt.listed {
+				listed[key] = true
+			}
+			var got []string
+			for _, gs := range sv.observe([]*ghaStatus{job("job-01")}, listed) {
+				got = append(got, gs.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statusValidator.observe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_minChecks(t *testing.T) {
+	success := func(name string) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       stringPtr(name),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunSuccessConclusion),
+			CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+		}
+	}
+	tests := map[string]struct {
+		minChecks     int
+		checkRuns     []*github.CheckRun
+		wantSucceeded bool
+		wantNotes     []string
+	}{
+		"succeeds without jobs when no minimum is set": {
+			checkRuns:     []*github.CheckRun{success("self-job")},
+			wantSucceeded: true,
+		},
+		"is pending when fewer jobs than the minimum are observed": {
+			minChecks:     2,
+			checkRuns:     []*github.CheckRun{success("self-job"), success("job-01"), success("job-02")},
+			wantSucceeded: false,
+			wantNotes:     []string{i18n.Default().Sprintf(i18n.DetailTooFewJobs, 2, 1)},
+		},
+		"succeeds when the minimum is met": {
+			minChecks:     2,
+			checkRuns:     []*github.CheckRun{success("self-job"), success("job-01"), success("job-03")},
+			wantSucceeded: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				ignoredJobs: []string{"job-02"},
+				minChecks:   tt.minChecks,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 1
+						return &github.WorkflowRuns{
+							TotalCount:   &total,
+							WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)}},
+						}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			st := got.(*status)
+			if st.succeeded != tt.wantSucceeded {
+				t.Errorf("statusValidator.Validate() succeeded = %v, want %v", st.succeeded, tt.wantSucceeded)
+			}
+			if !reflect.DeepEqual(st.notes, tt.wantNotes) {
+				t.Errorf("statusValidator.Validate() notes = %v, want %v", st.notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_failOnNoJobs(t *testing.T) {
+	success := func(name string) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       stringPtr(name),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunSuccessConclusion),
+			CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+		}
+	}
+	tests := map[string]struct {
+		failOnNoJobs bool
+		checkRuns    []*github.CheckRun
+		createdAt    int // The poll from which job-01 is listed, never when zero.
+		wantErr      bool
+	}{
+		"succeeds without jobs by default": {
+			checkRuns: []*github.CheckRun{success("self-job")},
+		},
+		"fails without jobs": {
+			failOnNoJobs: true,
+			checkRuns:    []*github.CheckRun{success("self-job")},
+			wantErr:      true,
+		},
+		"fails with only ignored jobs": {
+			failOnNoJobs: true,
+			checkRuns:    []*github.CheckRun{success("self-job"), success("job-02")},
+			wantErr:      true,
+		},
+		"succeeds with jobs": {
+			failOnNoJobs: true,
+			checkRuns:    []*github.CheckRun{success("self-job")},
+			createdAt:    1,
+		},
+		"succeeds with jobs created after the first polls": {
+			failOnNoJobs: true,
+			checkRuns:    []*github.CheckRun{success("self-job")},
+			createdAt:    maxEmptyPolls,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			polls := 0
+			sv := &statusValidator{
+				selfJobName:  "self-job",
+				ignoredJobs:  []string{"job-02"},
+				failOnNoJobs: tt.failOnNoJobs,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						polls++
+						if tt.createdAt != 0 && polls >= tt.createdAt {
+							return &github.ListCheckRunsResults{CheckRuns: append(tt.checkRuns, success("job-01"))}, nil, nil
+						}
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 1
+						return &github.WorkflowRuns{
+							TotalCount:   &total,
+							WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)}},
+						}, nil, nil
+					},
+				},
+			}
+			// The first polls without jobs are kept pending, as the check runs may not be created yet.
+			for i := 1; tt.failOnNoJobs && i < maxEmptyPolls && (tt.createdAt == 0 || i < tt.createdAt); i++ {
+				got, err := sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() error = %v at poll %d", err, i)
+				}
+				if got.IsSuccess() {
+					t.Fatalf("statusValidator.Validate() succeeded = true at poll %d, want false", i)
+				}
+				want := []string{i18n.Default().Sprintf(i18n.DetailNoJobsYet, maxEmptyPolls)}
+				if notes := got.(*status).notes; !reflect.DeepEqual(notes, want) {
+					t.Errorf("statusValidator.Validate() notes = %v at poll %d, want %v", notes, i, want)
+				}
+			}
+			got, err := sv.Validate(context.Background())
+			if tt.wantErr {
+				var fcErr *validators.FailedChecksError
+				if !errors.As(err, &fcErr) {
+					t.Fatalf("statusValidator.Validate() error = %v, want FailedChecksError", err)
+				}
+				want := []string{i18n.Default().Sprintf(i18n.DetailNoJobs)}
+				if notes := fcErr.Status.(*status).notes; !reflect.DeepEqual(notes, want) {
+					t.Errorf("statusValidator.Validate() notes = %v, want %v", notes, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			if !got.IsSuccess() {
+				t.Error("statusValidator.Validate() succeeded = false, want true")
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_siblings(t *testing.T) {
+	run := func(name string, suite int) *github.CheckRun {
+		return &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(suite)}}
+	}
+	tests := map[string]struct {
+		selfRunID     int64
+		ignoreSelfRun bool
+		checkRuns     []*github.CheckRun
+		wantSucceeded bool
+	}{
+		"ignores the jobs of the matrix of this job in its workflow run": {
+			selfRunID: 20,
+			checkRuns: []*github.CheckRun{
+				{Name: stringPtr("job-01"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				run("self-job (pull_request)", 2),
+				run("self-job (pull_request_review)", 2),
+			},
+			wantSucceeded: true,
+		},
+		"does not ignore jobs of the same name in other workflow runs": {
+			selfRunID: 20,
+			checkRuns: []*github.CheckRun{
+				run("self-job (pull_request)", 2),
+				run("self-job (push)", 1),
+			},
+			wantSucceeded: false,
+		},
+		"does not ignore the jobs of the matrix without the workflow run of this job": {
+			checkRuns: []*github.CheckRun{
+				run("self-job (pull_request)", 2),
+			},
+			wantSucceeded: false,
+		},
+		"ignores every job of the workflow run of this job whatever its name": {
+			selfRunID:     20,
+			ignoreSelfRun: true,
+			checkRuns: []*github.CheckRun{
+				{Name: stringPtr("job-01"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				run("Merge Gatekeeper", 2),
+			},
+			wantSucceeded: true,
+		},
+		"does not ignore the jobs of other workflow runs when every job of the workflow run of this job is": {
+			selfRunID:     20,
+			ignoreSelfRun: true,
+			checkRuns: []*github.CheckRun{
+				run("Merge Gatekeeper", 2),
+				run("job-01", 1),
+			},
+			wantSucceeded: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:   "self-job",
+				selfRunID:     tt.selfRunID,
+				ignoreSelfRun: tt.ignoreSelfRun,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 2
+						return &github.WorkflowRuns{
+							TotalCount: &total,
+							WorkflowRuns: []*github.WorkflowRun{
+								{ID: intPtr(10), Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)},
+								{ID: intPtr(20), Name: stringPtr("Merge Workflow"), CheckSuiteID: intPtr(2)},
+							},
+						}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSucceeded {
+				t.Errorf("statusValidator.Validate() succeeded = %v, want %v", got.IsSuccess(), tt.wantSucceeded)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_selfWorkflow(t *testing.T) {
+	run := func(name string, suite int) *github.CheckRun {
+		return &github.CheckRun{Name: stringPtr(name), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(suite)}}
+	}
+	tests := map[string]struct {
+		self          string
+		selfRunID     int64
+		checkRuns     []*github.CheckRun
+		wantSucceeded bool
+	}{
+		"ignores jobs of the name in every workflow without knowing the workflow of this job": {
+			self:          "self-job",
+			checkRuns:     []*github.CheckRun{run("self-job", 1), run("self-job", 2)},
+			wantSucceeded: true,
+		},
+		"does not ignore jobs of the name in other workflows than that of the run of this job": {
+			self:          "self-job",
+			selfRunID:     20,
+			checkRuns:     []*github.CheckRun{run("self-job", 1), run("self-job", 2)},
+			wantSucceeded: false,
+		},
+		"ignores jobs of the name in other runs of the workflow of this job": {
+			self:          "self-job",
+			selfRunID:     20,
+			checkRuns:     []*github.CheckRun{run("self-job", 2), run("self-job", 3)},
+			wantSucceeded: true,
+		},
+		"does not ignore jobs of the name in other workflows than the qualified one": {
+			self:          "Merge Workflow / self-job",
+			checkRuns:     []*github.CheckRun{run("self-job", 1), run("self-job", 2)},
+			wantSucceeded: false,
+		},
+		"ignores the job of the qualified workflow": {
+			self:          "Merge Workflow / self-job",
+			checkRuns:     []*github.CheckRun{run("self-job", 2)},
+			wantSucceeded: true,
+		},
+		"ignores the other instances of the gate": {
+			self:          "self-job, other-gate",
+			selfRunID:     20,
+			checkRuns:     []*github.CheckRun{run("self-job", 2), run("other-gate", 1), run("other-gate (push)", 1)},
+			wantSucceeded: true,
+		},
+		"ignores the other instances of the gate only in their qualified workflows": {
+			self:          "self-job,Workflow / other-gate",
+			checkRuns:     []*github.CheckRun{run("other-gate", 1), run("other-gate", 2)},
+			wantSucceeded: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfRunID: tt.selfRunID,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 3
+						return &github.WorkflowRuns{
+							TotalCount: &total,
+							WorkflowRuns: []*github.WorkflowRun{
+								{ID: intPtr(10), Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)},
+								{ID: intPtr(20), Name: stringPtr("Merge Workflow"), CheckSuiteID: intPtr(2)},
+								{ID: intPtr(30), Name: stringPtr("Merge Workflow"), CheckSuiteID: intPtr(3)},
+							},
+						}, nil, nil
+					},
+				},
+			}
+			WithSelfJob(tt.self)(sv)
+			if sv.selfJobName != "self-job" {
+				t.Fatalf("WithSelfJob() self job name = %s, want self-job", sv.selfJobName)
+			}
+			got, err := sv.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSucceeded {
+				t.Errorf("statusValidator.Validate() succeeded = %v, want %v", got.IsSuccess(), tt.wantSucceeded)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_ignoredWorkflows(t *testing.T) {
+	sv := &statusValidator{
+		selfJobName:      "self-job",
+		ignoredWorkflows: []string{"Nightly"},
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+					{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+					{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(2)}},
+					{Name: stringPtr("e2e"), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(2)}},
+				}}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				total := 2
+				return &github.WorkflowRuns{
+					TotalCount: &total,
+					WorkflowRuns: []*github.WorkflowRun{
+						{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)},
+						{Name: stringPtr("Nightly"), CheckSuiteID: intPtr(2)},
+					},
+				}, nil, nil
+			},
+		},
+	}
+	got, err := sv.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.Validate() error = %v", err)
+	}
+	want := &status{
+		succeeded:    true,
+		totalJobs:    []string{"CI / build"},
+		completeJobs: []string{"CI / build"},
+		errJobs:      []string{},
+		ignoredJobs:  []string{"Nightly / build", "Nightly / e2e"},
+		decisions: []validators.Decision{
+			{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateSuccess},
+			{Check: "Nightly / build", Group: "Nightly", Rule: ruleIgnoredWorkflow, Verdict: validators.StateIgnored, State: validators.StateFailure},
+			{Check: "Nightly / e2e", Group: "Nightly", Rule: ruleIgnoredWorkflow, Verdict: validators.StateIgnored, State: validators.StatePending},
+		},
+	}
+	st := got.(*status)
+	st.estimates = nil
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("statusValidator.Validate() = %+v, want %+v", st, want)
+	}
+}
+
+func Test_statusValidator_Validate_rules(t *testing.T) {
+	v, err := CreateValidator(&mock.Client{
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+				{Name: stringPtr("lint-go"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				{Name: stringPtr("lint-security"), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				{Name: stringPtr("e2e"), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(2)}},
+			}}, nil, nil
+		},
+		ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+			total := 2
+			return &github.WorkflowRuns{
+				TotalCount: &total,
+				WorkflowRuns: []*github.WorkflowRun{
+					{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)},
+					{Name: stringPtr("Nightly"), CheckSuiteID: intPtr(2)},
+				},
+			}, nil, nil
+		},
+	},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+		WithSelfJob("self-job"),
+		WithRules("require lint-security\nignore workflow:Nightly"),
+		WithIgnoredJobs("^lint-"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := v.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.Validate() error = %v", err)
+	}
+	wantDecisions := []validators.Decision{
+		{Check: "CI / lint-go", Group: "CI", Rule: ruleIgnored, Verdict: validators.StateIgnored, State: validators.StateFailure},
+		{Check: "CI / lint-security", Group: "CI", Rule: "rules[1]", Verdict: validators.StatePending},
+		{Check: "Nightly / e2e", Group: "Nightly", Rule: "rules[2]", Verdict: validators.StateIgnored, State: validators.StatePending},
+	}
+	if st := got.(*status); !reflect.DeepEqual(st.decisions, wantDecisions) {
+		t.Errorf("statusValidator.Validate() decisions = %+v, want %+v", st.decisions, wantDecisions)
+	}
+	if got.IsSuccess() {
+		t.Errorf("statusValidator.Validate() succeeded, want pending on the required job")
+	}
+
+	tests := map[string]struct {
+		check  string
+		want   string
+		wantOK bool
+	}{
+		"explains the rule shadowing later rules": {
+			check:  "lint-security",
+			want:   "CI / lint-security is captured by the rule rules[1] (require job:lint-security), whose verdict is pending.\n  It also matches ignored-jobs, shadowed by the first match.\n  Its state decides the verdict, as no rule ignores it first.",
+			wantOK: true,
+		},
+		"explains the check qualified by its workflow": {
+			check:  "Nightly / e2e",
+			want:   "Nightly / e2e is captured by the rule rules[2] (ignore workflow:Nightly), whose verdict is ignored.\n  It is ignored regardless of its state, which is pending.",
+			wantOK: true,
+		},
+		"explains checks captured by the ignored jobs": {
+			check:  "lint-go",
+			want:   "CI / lint-go is captured by the rule ignored-jobs, whose verdict is ignored.\n  It is ignored regardless of its state, which is failure.",
+			wantOK: true,
+		},
+		"reports checks which were not observed": {
+			check: "build",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := got.(validators.Explainer).Explain(tt.check)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Explain(%q) = %q, %v, want %q, %v", tt.check, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_explain(t *testing.T) {
+	v, err := CreateValidator(&mock.Client{
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+				{Name: stringPtr("lint-security"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				{Name: stringPtr("self-job"), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+			}}, nil, nil
+		},
+		ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+			total := 1
+			return &github.WorkflowRuns{
+				TotalCount:   &total,
+				WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+			}, nil, nil
+		},
+	},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+		WithSelfJob("self-job"),
+		WithRules("require lint-security"),
+		WithIgnoredJobs("^lint-"),
+		WithExplain(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Failed checks are explained as well, by the status of the error.
+	_, err = v.Validate(context.Background())
+	var failed *validators.FailedChecksError
+	if !errors.As(err, &failed) {
+		t.Fatalf("statusValidator.Validate() error = %v, want failed checks", err)
+	}
+	tests := map[string]struct {
+		check string
+		want  string
+	}{
+		"explains every rule evaluated against the check": {
+			check: "CI / lint-security",
+			want: `CI / lint-security is captured by the rule rules[1] (require job:lint-security), whose verdict is failure.
+  It also matches ignored-jobs, shadowed by the first match.
+  State: failure
+  Rules in the order of evaluation:
+  - self: does not match
+  - rules[1] (require job:lint-security): captures the check
+  - ignored-apps: does not match
+  - ignored-jobs: matches, but is shadowed
+  - ignored-workflows: does not match
+  Its state decides the verdict, as no rule ignores it first.`,
+		},
+		"explains this job": {
+			check: "self-job",
+			want: `CI / self-job is captured by the rule self, whose verdict is ignored.
+  State: pending
+  Rules in the order of evaluation:
+  - self: captures the check
+  It is this job or a job of its matrix, which never gates itself.`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := failed.Status.(validators.Explainer).Explain(tt.check)
+			if !ok || got != tt.want {
+				t.Errorf("Explain(%q) = %q, %v, want %q", tt.check, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_skippedAs(t *testing.T) {
+	tests := map[string]struct {
+		skippedAs   string
+		wasQueued   bool
+		wantErr     bool
+		wantChecks  []string
+		wantSuccess bool
+	}{
+		"drops skipped jobs by default": {
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"drops skipped jobs when ignored": {
+			skippedAs:   SkippedIgnore,
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"drops skipped jobs observed queued earlier": {
+			wasQueued:   true,
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"counts skipped jobs as succeeded": {
+			skippedAs:   SkippedSuccess,
+			wantChecks:  []string{"CI / build", "CI / deploy"},
+			wantSuccess: true,
+		},
+		"fails on skipped jobs": {
+			skippedAs: SkippedFailure,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			deploy := &github.CheckRun{Name: stringPtr("deploy"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSkipConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}}
+			v, err := CreateValidator(&mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+						{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+						deploy,
+					}}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					total := 1
+					return &github.WorkflowRuns{
+						TotalCount:   &total,
+						WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+					}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				WithSkippedAs(tt.skippedAs),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The job is observed queued by the previous poll, rather than vanishing once skipped.
+			if tt.wasQueued {
+				skipped := *deploy
+				deploy.Status, deploy.Conclusion = stringPtr("queued"), nil
+				if _, err := v.Validate(context.Background()); err != nil {
+					t.Fatalf("statusValidator.Validate() error = %v", err)
+				}
+				*deploy = skipped
+			}
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st := got.(*status); !reflect.DeepEqual(st.totalJobs, tt.wantChecks) {
+				t.Errorf("statusValidator.Validate() jobs = %v, want %v", st.totalJobs, tt.wantChecks)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("statusValidator.Validate() IsSuccess = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_neutralAs(t *testing.T) {
+	tests := map[string]struct {
+		neutralAs   string
+		wantErr     bool
+		wantSuccess bool
+	}{
+		"counts neutral check runs as succeeded by default": {
+			wantSuccess: true,
+		},
+		"counts neutral check runs as succeeded": {
+			neutralAs:   NeutralSuccess,
+			wantSuccess: true,
+		},
+		"keeps neutral check runs pending": {
+			neutralAs:   NeutralPending,
+			wantSuccess: false,
+		},
+		"fails on neutral check runs": {
+			neutralAs: NeutralFailure,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+						{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+						{Name: stringPtr("scan"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunNeutralConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+					}}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					total := 1
+					return &github.WorkflowRuns{
+						TotalCount:   &total,
+						WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+					}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				WithNeutralAs(tt.neutralAs),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("statusValidator.Validate() IsSuccess = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_cancelledAs(t *testing.T) {
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		cancelledAs string
+		rerun       bool
+		wasRunning  bool
+		wantErr     bool
+		wantChecks  []string
+		wantSuccess bool
+	}{
+		"fails on cancelled jobs by default": {
+			wantErr: true,
+		},
+		"fails on cancelled jobs": {
+			cancelledAs: CancelledFailure,
+			wantErr:     true,
+		},
+		"drops cancelled jobs when ignored": {
+			cancelledAs: CancelledIgnore,
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"drops cancelled jobs observed running earlier when ignored": {
+			cancelledAs: CancelledIgnore,
+			wasRunning:  true,
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"keeps cancelled jobs pending": {
+			cancelledAs: CancelledPending,
+			wantChecks:  []string{"CI / build", "CI / deploy"},
+			wantSuccess: false,
+		},
+		"validates the re-run of cancelled jobs": {
+			cancelledAs: CancelledPending,
+			rerun:       true,
+			wantChecks:  []string{"CI / build", "CI / deploy"},
+			wantSuccess: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			runs := []*github.CheckRun{
+				{ID: intPtr(1), Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				{ID: intPtr(2), Name: stringPtr("deploy"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunCancelledConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}, StartedAt: &github.Timestamp{Time: started}},
+			}
+			if tt.rerun {
+				runs = append(runs, &github.CheckRun{ID: intPtr(3), Name: stringPtr("deploy"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}, StartedAt: &github.Timestamp{Time: started.Add(time.Minute)}})
+			}
+			v, err := CreateValidator(&mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: runs}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					total := 1
+					return &github.WorkflowRuns{
+						TotalCount:   &total,
+						WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+					}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				WithCancelledAs(tt.cancelledAs),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The job is observed running by the previous poll, rather than vanishing once cancelled.
+			if tt.wasRunning {
+				cancelled := runs[1]
+				runs[1] = &github.CheckRun{ID: intPtr(2), Name: stringPtr("deploy"), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(1)}, StartedAt: &github.Timestamp{Time: started}}
+				if _, err := v.Validate(context.Background()); err != nil {
+					t.Fatalf("statusValidator.Validate() error = %v", err)
+				}
+				runs[1] = cancelled
+			}
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st := got.(*status); !reflect.DeepEqual(st.totalJobs, tt.wantChecks) {
+				t.Errorf("statusValidator.Validate() jobs = %v, want %v", st.totalJobs, tt.wantChecks)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("statusValidator.Validate() IsSuccess = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_attention(t *testing.T) {
+	actionRequired := "WARNING: CI / deploy requires action, such as approving its workflow run, before it can complete."
+	stale := "WARNING: CI / deploy is stale, as it did not complete in time. Re-run it."
+	tests := map[string]struct {
+		conclusion  string
+		opt         Option
+		wasRunning  bool
+		wantErr     bool
+		wantChecks  []string
+		wantNotes   []string
+		wantSuccess bool
+	}{
+		"fails on jobs requiring action by default": {
+			conclusion: checkRunActionRequiredConclusion,
+			opt:        WithActionRequiredAs(""),
+			wantErr:    true,
+			wantChecks: []string{"CI / build", "CI / deploy"},
+			wantNotes:  []string{actionRequired},
+		},
+		"keeps jobs requiring action pending": {
+			conclusion: checkRunActionRequiredConclusion,
+			opt:        WithActionRequiredAs(AttentionPending),
+			wantChecks: []string{"CI / build", "CI / deploy"},
+			wantNotes:  []string{actionRequired},
+		},
+		"drops jobs requiring action when ignored": {
+			conclusion:  checkRunActionRequiredConclusion,
+			opt:         WithActionRequiredAs(AttentionIgnore),
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"drops jobs requiring action observed running earlier when ignored": {
+			conclusion:  checkRunActionRequiredConclusion,
+			opt:         WithActionRequiredAs(AttentionIgnore),
+			wasRunning:  true,
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"drops stale jobs observed running earlier when ignored": {
+			conclusion:  checkRunStaleConclusion,
+			opt:         WithStaleAs(AttentionIgnore),
+			wasRunning:  true,
+			wantChecks:  []string{"CI / build"},
+			wantSuccess: true,
+		},
+		"fails on stale jobs": {
+			conclusion: checkRunStaleConclusion,
+			opt:        WithStaleAs(AttentionFailure),
+			wantErr:    true,
+			wantChecks: []string{"CI / build", "CI / deploy"},
+			wantNotes:  []string{stale},
+		},
+		"keeps stale jobs pending": {
+			conclusion: checkRunStaleConclusion,
+			opt:        WithStaleAs(AttentionPending),
+			wantChecks: []string{"CI / build", "CI / deploy"},
+			wantNotes:  []string{stale},
+		},
+		"treats stale jobs apart from jobs requiring action": {
+			conclusion: checkRunStaleConclusion,
+			opt:        WithActionRequiredAs(AttentionIgnore),
+			wantErr:    true,
+			wantChecks: []string{"CI / build", "CI / deploy"},
+			wantNotes:  []string{stale},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			deploy := &github.CheckRun{Name: stringPtr("deploy"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(tt.conclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}}
+			v, err := CreateValidator(&mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+						{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+						deploy,
+					}}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					total := 1
+					return &github.WorkflowRuns{
+						TotalCount:   &total,
+						WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+					}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				tt.opt,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The job is observed running by the previous poll, rather than vanishing once it needs attention.
+			if tt.wasRunning {
+				completed := *deploy
+				deploy.Status, deploy.Conclusion = stringPtr(checkRunInProgressStatus), nil
+				if _, err := v.Validate(context.Background()); err != nil {
+					t.Fatalf("statusValidator.Validate() error = %v", err)
+				}
+				*deploy = completed
+			}
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var failed *validators.FailedChecksError
+				if !errors.As(err, &failed) {
+					t.Fatalf("statusValidator.Validate() error = %v, want FailedChecksError", err)
+				}
+				got = failed.Status
+			}
+			st := got.(*status)
+			if !reflect.DeepEqual(st.totalJobs, tt.wantChecks) {
+				t.Errorf("statusValidator.Validate() jobs = %v, want %v", st.totalJobs, tt.wantChecks)
+			}
+			if !reflect.DeepEqual(st.notes, tt.wantNotes) {
+				t.Errorf("statusValidator.Validate() notes = %v, want %v", st.notes, tt.wantNotes)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("statusValidator.Validate() IsSuccess = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_rollupCache(t *testing.T) {
+	pending := &github.CheckRollup{State: "PENDING", Total: 2, Counts: map[string]int{"SUCCESS": 1, "IN_PROGRESS": 1}}
+	succeeded := &github.CheckRollup{State: github.RollupSuccess, Total: 2, Counts: map[string]int{"SUCCESS": 2}}
+	tests := map[string]struct {
+		rollups     []*github.CheckRollup
+		rollupErr   error
+		minChecks   int
+		wantLists   int
+		wantSuccess bool
+	}{
+		"lists the checks on every poll while the rollup is pending": {
+			rollups:   []*github.CheckRollup{pending, pending, pending},
+			wantLists: 3,
+		},
+		"reuses the status once every check succeeded": {
+			rollups:     []*github.CheckRollup{pending, succeeded, succeeded, succeeded},
+			wantLists:   2,
+			wantSuccess: true,
+		},
+		"lists the checks on every poll while the status has not succeeded": {
+			rollups:   []*github.CheckRollup{succeeded, succeeded, succeeded},
+			minChecks: 3,
+			wantLists: 3,
+		},
+		"lists the checks on every poll when the rollup is unavailable": {
+			rollups:   []*github.CheckRollup{nil, nil, nil},
+			rollupErr: errors.New("err"),
+			wantLists: 3,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var poll, lists int
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				rollupCache: true,
+				minChecks:   tt.minChecks,
+				client: &mock.Client{
+					GetCheckRollupFunc: func(ctx context.Context, owner, repo, ref string) (*github.CheckRollup, *github.Response, error) {
+						return tt.rollups[poll], nil, tt.rollupErr
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						lists++
+						job := &github.CheckRun{Name: stringPtr("job-01"), Status: stringPtr(checkRunInProgressStatus), CheckSuite: &github.CheckSuite{ID: intPtr(1)}}
+						if tt.rollups[poll] == succeeded {
+							job.Status, job.Conclusion = stringPtr(checkRunCompletedStatus), stringPtr(checkRunSuccessConclusion)
+						}
+						return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{job}}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 1
+						return &github.WorkflowRuns{
+							TotalCount:   &total,
+							WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)}},
+						}, nil, nil
+					},
+				},
+			}
+			var got validators.Status
+			for poll = range tt.rollups {
+				var err error
+				got, err = sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("poll %d: statusValidator.Validate() error = %v", poll, err)
+				}
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("statusValidator.Validate() IsSuccess = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+			if lists != tt.wantLists {
+				t.Errorf("statusValidator.Validate() listed the checks %d times, want %d", lists, tt.wantLists)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_checksAPI(t *testing.T) {
+	tests := map[string]struct {
+		checksAPI   string
+		graphQLErr  error
+		wantGraphQL int
+		wantREST    int
+		wantNotes   []string
+		wantErr     bool
+	}{
+		"lists the check runs from the REST API by default": {
+			wantREST: 2,
+		},
+		"lists the check runs from the GraphQL API": {
+			checksAPI:   ChecksAPIGraphQL,
+			wantGraphQL: 2,
+		},
+		"returns error of the GraphQL API": {
+			checksAPI:   ChecksAPIGraphQL,
+			graphQLErr:  errors.New("err"),
+			wantGraphQL: 1,
+			wantErr:     true,
+		},
+		"lists the check runs from the GraphQL API when auto": {
+			checksAPI:   ChecksAPIAuto,
+			wantGraphQL: 2,
+		},
+		"falls back to the REST API once the GraphQL API fails": {
+			checksAPI:   ChecksAPIAuto,
+			graphQLErr:  github.ErrTooManyCheckRuns,
+			wantGraphQL: 1,
+			wantREST:    2,
+			wantNotes:   []string{i18n.Default().Sprintf(i18n.DetailChecksFallback, github.ErrTooManyCheckRuns)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			runs := []*github.CheckRun{
+				{Name: stringPtr("job-01"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+			}
+			var graphQL, rest int
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				checksAPI:   tt.checksAPI,
+				client: &mock.Client{
+					ListCheckRunsByGraphQLFunc: func(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, *github.Response, error) {
+						graphQL++
+						if tt.graphQLErr != nil {
+							return nil, nil, tt.graphQLErr
+						}
+						return runs, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						rest++
+						return &github.ListCheckRunsResults{CheckRuns: runs}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 1
+						return &github.WorkflowRuns{
+							TotalCount:   &total,
+							WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)}},
+						}, nil, nil
+					},
+				},
+			}
+			// Check runs are listed by two polls, unless the first fails.
+			for poll := 0; poll < 2; poll++ {
+				got, err := sv.Validate(context.Background())
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("poll %d: statusValidator.Validate() error = %v, wantErr %v", poll, err, tt.wantErr)
+				}
+				if err != nil {
+					break
+				}
+				if !got.IsSuccess() {
+					t.Errorf("poll %d: statusValidator.Validate() = %v, want success", poll, got)
+				}
+				if notes := got.(*status).notes; !reflect.DeepEqual(notes, tt.wantNotes) {
+					t.Errorf("poll %d: statusValidator.Validate() notes = %v, want %v", poll, notes, tt.wantNotes)
+				}
+			}
+			if graphQL != tt.wantGraphQL || rest != tt.wantREST {
+				t.Errorf("statusValidator.Validate() listed the check runs %d times from the GraphQL API and %d times from the REST API, want %d and %d", graphQL, rest, tt.wantGraphQL, tt.wantREST)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_ignoredApps(t *testing.T) {
+	sv := &statusValidator{
+		selfJobName: "self-job",
+		ignoredApps: []string{"codecov"},
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+					{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}, App: &github.App{Slug: stringPtr("github-actions")}},
+					{Name: stringPtr("codecov/patch"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(2)}, App: &github.App{Slug: stringPtr("codecov")}},
+				}}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				total := 1
+				return &github.WorkflowRuns{
+					TotalCount:   &total,
+					WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+				}, nil, nil
+			},
+		},
+	}
+	got, err := sv.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.Validate() error = %v", err)
+	}
+	st := got.(*status)
+	if !st.succeeded {
+		t.Errorf("statusValidator.Validate() succeeded = false, want true")
+	}
+	want := []validators.Decision{
+		{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateSuccess},
+		{Check: "codecov/patch", Rule: ruleIgnoredApp, Verdict: validators.StateIgnored, State: validators.StateFailure},
+	}
+	if !reflect.DeepEqual(st.decisions, want) {
+		t.Errorf("statusValidator.Validate() decisions = %+v, want %+v", st.decisions, want)
+	}
+}
+
+func TestCreateCheckKey(t *testing.T) {
+	suiteToWorkflow := map[int64]string{1: "CI"}
+	tests := map[string]struct {
+		run          *github.CheckRun
+		wantKey      string
+		wantWorkflow string
+	}{
+		"returns the key qualified by the workflow of the check suite": {
+			run:          &github.CheckRun{Name: stringPtr("build"), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+			wantKey:      "CI / build",
+			wantWorkflow: "CI",
+		},
+		"returns the key of a standalone check when the check suite has no workflow run": {
+			run:          &github.CheckRun{Name: stringPtr("codecov/patch"), CheckSuite: &github.CheckSuite{ID: intPtr(2)}},
+			wantKey:      "external / codecov/patch",
+			wantWorkflow: externalWorkflow,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, workflow := CreateCheckKey(tt.run, suiteToWorkflow)
+			if key != tt.wantKey {
+				t.Errorf("CreateCheckKey() key = %s, want %s", key, tt.wantKey)
+			}
+			if workflow != tt.wantWorkflow {
+				t.Errorf("CreateCheckKey() workflow = %s, want %s", workflow, tt.wantWorkflow)
+			}
+		})
+	}
+}
+
+func TestIsNewerAttempt(t *testing.T) {
+	now := time.Now()
+	run := func(id int, started time.Time) *github.CheckRun {
+		r := &github.CheckRun{ID: intPtr(id)}
+		if !started.IsZero() {
+			r.StartedAt = &github.Timestamp{Time: started}
+		}
+		return r
+	}
+	tests := map[string]struct {
+		run  *github.CheckRun
+		than *github.CheckRun
+		want bool
+	}{
+		"returns true when started later": {
+			run:  run(1, now),
+			than: run(2, now.Add(-time.Minute)),
+			want: true,
+		},
+		"returns false when started earlier": {
+			run:  run(2, now.Add(-time.Minute)),
+			than: run(1, now),
+			want: false,
+		},
+		"returns true when yet to start, as a queued re-run": {
+			run:  run(2, time.Time{}),
+			than: run(1, now),
+			want: true,
+		},
+		"returns false when the other is yet to start": {
+			run:  run(1, now),
+			than: run(2, time.Time{}),
+			want: false,
+		},
+		"returns true of the larger ID when started at the same time": {
+			run:  run(2, now),
+			than: run(1, now),
+			want: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isNewerAttempt(tt.run, tt.than); got != tt.want {
+				t.Errorf("isNewerAttempt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_degradedSuites(t *testing.T) {
+	now := time.Now()
+	run := func(id int, conclusion string, started time.Time) *github.CheckRun {
+		r := &github.CheckRun{ID: intPtr(id), Name: stringPtr("build"), Status: stringPtr(checkRunInProgressStatus), StartedAt: &github.Timestamp{Time: started}, CheckSuite: &github.CheckSuite{ID: intPtr(id)}}
+		if len(conclusion) != 0 {
+			r.Status, r.Conclusion = stringPtr(checkRunCompletedStatus), stringPtr(conclusion)
+		}
+		return r
+	}
+	tests := map[string]struct {
+		checkRuns []*github.CheckRun
+		wantState string
+	}{
+		"keeps the failure of a workflow which succeeded later in another": {
+			checkRuns: []*github.CheckRun{run(1, checkRunFailedConclusion, now.Add(-time.Hour)), run(2, checkRunSuccessConclusion, now)},
+			wantState: validators.StateFailure,
+		},
+		"keeps the failure of a workflow which succeeded earlier in another": {
+			checkRuns: []*github.CheckRun{run(2, checkRunSuccessConclusion, now.Add(-time.Hour)), run(1, checkRunFailedConclusion, now)},
+			wantState: validators.StateFailure,
+		},
+		"keeps a workflow pending which succeeded in another": {
+			checkRuns: []*github.CheckRun{run(1, "", now.Add(-time.Hour)), run(2, checkRunSuccessConclusion, now)},
+			wantState: validators.StatePending,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+					},
+					// Jobs of the workflows are keyed by their names only, as the workflow runs are unavailable.
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						return nil, nil, errors.New("err")
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			state := validators.StatePending
+			var fcErr *validators.FailedChecksError
+			switch {
+			case errors.As(err, &fcErr):
+				state = validators.StateFailure
+			case err != nil:
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			case got.IsSuccess():
+				state = validators.StateSuccess
+			}
+			if state != tt.wantState {
+				t.Errorf("statusValidator.Validate() state = %s, want %s", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_reruns(t *testing.T) {
+	now := time.Now()
+	sv := &statusValidator{
+		selfJobName: "self-job",
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+					// The failed first attempt is listed before the re-run.
+					{ID: intPtr(1), Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), StartedAt: &github.Timestamp{Time: now.Add(-time.Hour)}, CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+					{ID: intPtr(3), Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: &github.Timestamp{Time: now.Add(-time.Minute)}, CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+					{ID: intPtr(2), Name: stringPtr("test"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), StartedAt: &github.Timestamp{Time: now.Add(-time.Hour)}, CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+					{ID: intPtr(4), Name: stringPtr("test"), Status: stringPtr(checkRunQueuedStatus), CheckSuite: &github.CheckSuite{ID: intPtr(1)}},
+				}}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				total := 1
+				return &github.WorkflowRuns{
+					TotalCount:   &total,
+					WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}},
+				}, nil, nil
+			},
+		},
+	}
+	got, err := sv.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.Validate() error = %v, want the stale failures to be superseded", err)
+	}
+	want := []validators.Decision{
+		{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateSuccess},
+		{Check: "CI / test", Group: "CI", Rule: ruleState, Verdict: validators.StatePending},
+	}
+	if st := got.(*status); !reflect.DeepEqual(st.decisions, want) {
+		t.Errorf("statusValidator.Validate() decisions = %+v, want %+v", st.decisions, want)
+	}
+}
+
+func Test_statusValidator_describeFailure(t *testing.T) {
+	files := map[string]string{
+		owners.DefaultPath:   "e2e @org/qa\n",
+		".github/CODEOWNERS": ".github/workflows/ @org/platform\n",
+	}
+	newClient := func(err error) github.Client {
+		return &mock.Client{
+			GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				str, ok := files[path]
+				if !ok {
+					return nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+				}
+				content := base64.StdEncoding.EncodeToString([]byte(str))
+				encoding := "base64"
+				return &github.RepositoryContent{Content: &content, Encoding: &encoding}, nil, nil, nil
+			},
+		}
+	}
+	tests := map[string]struct {
+		client    github.Client
+		gs        *ghaStatus
+		want      string
+		wantNotes []string
+	}{
+		"names owners of the job": {
+			client: newClient(nil),
+			gs:     &ghaStatus{Job: "e2e", Workflow: "CI", Path: ".github/workflows/ci.yml"},
+			want:   "CI / e2e (owners: @org/qa)",
+		},
+		"names code owners of the workflow": {
+			client: newClient(nil),
+			gs:     &ghaStatus{Job: "build", Workflow: "CI", Path: ".github/workflows/ci.yml"},
+			want:   "CI / build (owners: @org/platform)",
+		},
+		"names only the job when owners are unknown": {
+			client: newClient(nil),
+			gs:     &ghaStatus{Job: "build", Workflow: "CI"},
+			want:   "CI / build",
+		},
+		"notes the error when owners can not be resolved": {
+			client:    newClient(errors.New("err")),
+			gs:        &ghaStatus{Job: "e2e", Workflow: "CI"},
+			want:      "CI / e2e",
+			wantNotes: []string{i18n.Default().Sprintf(i18n.DetailNoOwners, "failed to get .github/JOBOWNERS: err")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{owners: owners.NewResolver(tt.client, "owner", "repo", "sha", "")}
+			st := &status{}
+			if got := sv.describeFailure(context.Background(), st, tt.gs); got != tt.want {
+				t.Errorf("describeFailure() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(st.notes, tt.wantNotes) {
+				t.Errorf("notes = %v, want %v", st.notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listStatuses(t *testing.T) {
+	type fields struct {
+		repo        string
+		owner       string
+		ref         string
+		selfJobName string
+		client      github.Client
+	}
+	type test struct {
+		fields  fields
+		ctx     context.Context
+		wantErr bool
+		want    []*ghaStatus
+	}
+	tests := map[string]test{
+		"succeeds to get job statuses": func() test {
+			c := &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							// The first element here is the latest state.
+							{
+								Name:       stringPtr("job-02"),
+								Status:     stringPtr(checkRunInProgressStatus),
+								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+							},
+							{
+								Name:       stringPtr("job-04"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+							},
+							{
+								Name:       stringPtr("job-05"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunFailedConclusion),
+								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+							},
+							{
+								Name:       stringPtr("job-06"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSkipConclusion),
+								CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+							},
+						},
+					}, nil, nil
+				},
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					total := 1
+					return &github.WorkflowRuns{
+						TotalCount: &total,
+						WorkflowRuns: []*github.WorkflowRun{
+							{
+								Name:         stringPtr("Workflow"),
+								CheckSuiteID: intPtr(1),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
 				fields: fields{
 					client:      c,
 					selfJobName: "self-job",
@@ -998,7 +2771,7 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 				selfJobName: tt.fields.selfJobName,
 				client:      tt.fields.client,
 			}
-			got, err := sv.listGhaStatuses(tt.ctx)
+			got, _, _, err := sv.listGhaStatuses(tt.ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("statusValidator.listStatuses() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -1013,3 +2786,274 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 		})
 	}
 }
+
+func Test_statusValidator_listGhaStatuses_statusContexts(t *testing.T) {
+	repoStatus := func(context, state string) *github.RepoStatus {
+		return &github.RepoStatus{Context: stringPtr(context), State: stringPtr(state)}
+	}
+	pages := [][]*github.RepoStatus{
+		make([]*github.RepoStatus, 0, maxStatusesPerPage),
+		{repoStatus("jenkins/lint", "failure"), repoStatus("merge-gatekeeper/full-gate", "pending")},
+	}
+	for i := 0; i < maxStatusesPerPage; i++ {
+		pages[0] = append(pages[0], repoStatus(fmt.Sprintf("buildkite/%d", i), "success"))
+	}
+	pages[0][0] = repoStatus("test", "pending")
+
+	sv := &statusValidator{
+		selfJobName:    "self-job",
+		statusContexts: true,
+		published:      []string{"merge-gatekeeper/full-gate"},
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+					{Name: stringPtr("test"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+				}}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				return &github.WorkflowRuns{}, nil, nil
+			},
+			GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+				total := maxStatusesPerPage + 2
+				return &github.CombinedStatus{TotalCount: &total, Statuses: pages[opts.Page-1]}, nil, nil
+			},
+		},
+	}
+	got, _, _, err := sv.listGhaStatuses(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.listGhaStatuses() error = %v", err)
+	}
+
+	states := make(map[string]string, len(got))
+	for _, gs := range got {
+		if _, ok := states[gs.Job]; ok {
+			t.Errorf("statusValidator.listGhaStatuses() lists %s twice", gs.Job)
+		}
+		states[gs.Job] = gs.State
+	}
+	want := map[string]string{"test": successState, "jenkins/lint": errorState}
+	for i := 1; i < maxStatusesPerPage; i++ {
+		want[fmt.Sprintf("buildkite/%d", i)] = successState
+	}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("statusValidator.listGhaStatuses() states = %v, want %v", states, want)
+	}
+}
+
+func Test_statusValidator_listGhaStatuses_invalidStatusContext(t *testing.T) {
+	sv := &statusValidator{
+		selfJobName:    "self-job",
+		statusContexts: true,
+		client: &mock.Client{
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{}, nil, nil
+			},
+			ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				return &github.WorkflowRuns{}, nil, nil
+			},
+			GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+				return &github.CombinedStatus{Statuses: []*github.RepoStatus{{Context: stringPtr("jenkins")}}}, nil, nil
+			},
+		},
+	}
+	if _, _, _, err := sv.listGhaStatuses(context.Background()); !errors.Is(err, ErrInvalidCombinedStatusResponse) {
+		t.Errorf("statusValidator.listGhaStatuses() error = %v, want %v", err, ErrInvalidCombinedStatusResponse)
+	}
+}
+
+func Test_statusValidator_Validate_requiredChecks(t *testing.T) {
+	run := func(name, conclusion string) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       stringPtr(name),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(conclusion),
+			CheckSuite: &github.CheckSuite{ID: intPtr(1)},
+		}
+	}
+	tests := map[string]struct {
+		requiredOnly  bool
+		required      []string
+		wantSucceeded bool
+	}{
+		"validates every job by default": {
+			wantSucceeded: false,
+		},
+		"ignores the jobs which are not required": {
+			requiredOnly:  true,
+			required:      []string{"test", "Workflow / lint"},
+			wantSucceeded: true,
+		},
+		"validates the required jobs": {
+			requiredOnly:  true,
+			required:      []string{"test", "flaky"},
+			wantSucceeded: false,
+		},
+		"validates no jobs when none is required": {
+			requiredOnly:  true,
+			wantSucceeded: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:  "self-job",
+				requiredOnly: tt.requiredOnly,
+				required:     tt.required,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+							run("test", checkRunSuccessConclusion), run("lint", checkRunSuccessConclusion), run("flaky", checkRunFailedConclusion),
+						}}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						total := 1
+						return &github.WorkflowRuns{
+							TotalCount:   &total,
+							WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("Workflow"), CheckSuiteID: intPtr(1)}},
+						}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			var fcErr *validators.FailedChecksError
+			if err != nil && !errors.As(err, &fcErr) {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			if succeeded := err == nil && got.IsSuccess(); succeeded != tt.wantSucceeded {
+				t.Errorf("statusValidator.Validate() succeeded = %v, want %v", succeeded, tt.wantSucceeded)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_unresolvedSuites(t *testing.T) {
+	tests := map[string]struct {
+		listedAfter   int // Polls after which the workflow run is listed, never when zero.
+		wantDecisions [][]validators.Decision
+	}{
+		"keeps jobs pending until their workflow runs are listed": {
+			listedAfter: 2,
+			wantDecisions: [][]validators.Decision{
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateFailure}},
+			},
+		},
+		"validates jobs as external checks once the lookups are exhausted": {
+			wantDecisions: [][]validators.Decision{
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "external / build", Group: externalWorkflow, Rule: ruleState, Verdict: validators.StateFailure}},
+			},
+		},
+		"moves jobs validated as external checks to their workflows once listed": {
+			listedAfter: 4,
+			wantDecisions: [][]validators.Decision{
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "build", Rule: ruleUnresolved, Verdict: validators.StatePending}},
+				{{Check: "external / build", Group: externalWorkflow, Rule: ruleState, Verdict: validators.StateFailure}},
+				{{Check: "CI / build", Group: "CI", Rule: ruleState, Verdict: validators.StateFailure}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var polls int
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						polls++
+						return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+							{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailedConclusion), CheckSuite: &github.CheckSuite{ID: intPtr(1)}, App: &github.App{Slug: stringPtr(actionsApp)}},
+						}}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						if tt.listedAfter == 0 || polls <= tt.listedAfter {
+							return &github.WorkflowRuns{}, nil, nil
+						}
+						return &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{Name: stringPtr("CI"), CheckSuiteID: intPtr(1)}}}, nil, nil
+					},
+				},
+			}
+			for i, want := range tt.wantDecisions {
+				got, err := sv.Validate(context.Background())
+				var fcErr *validators.FailedChecksError
+				if errors.As(err, &fcErr) {
+					got = fcErr.Status
+				} else if err != nil {
+					t.Fatalf("statusValidator.Validate() error = %v", err)
+				}
+				st := got.(*status)
+				if !reflect.DeepEqual(st.decisions, want) {
+					t.Errorf("poll %d: statusValidator.Validate() decisions = %+v, want %+v", i+1, st.decisions, want)
+				}
+				if wantNote := want[0].Rule == ruleUnresolved; (len(st.notes) != 0) != wantNote {
+					t.Errorf("poll %d: statusValidator.Validate() notes = %v, want note %v", i+1, st.notes, wantNote)
+				}
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_statusPrefixes(t *testing.T) {
+	tests := map[string]struct {
+		statusPrefixes        []string
+		ignoredStatusPrefixes []string
+		want                  []validators.Decision
+	}{
+		"validates every context by default": {
+			want: []validators.Decision{
+				{Check: "ci/jenkins", Rule: ruleState, Verdict: validators.StateSuccess},
+				{Check: "license/cla", Rule: ruleState, Verdict: validators.StatePending},
+			},
+		},
+		"ignores contexts under the ignored prefixes": {
+			ignoredStatusPrefixes: []string{"license/"},
+			want: []validators.Decision{
+				{Check: "ci/jenkins", Rule: ruleState, Verdict: validators.StateSuccess},
+				{Check: "license/cla", Rule: ruleIgnoredStatus, Verdict: validators.StateIgnored, State: validators.StatePending},
+			},
+		},
+		"ignores contexts under none of the validated prefixes": {
+			statusPrefixes: []string{"ci/"},
+			want: []validators.Decision{
+				{Check: "ci/jenkins", Rule: ruleState, Verdict: validators.StateSuccess},
+				{Check: "license/cla", Rule: ruleIgnoredStatus, Verdict: validators.StateIgnored, State: validators.StatePending},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:           "self-job",
+				statusContexts:        true,
+				statusPrefixes:        tt.statusPrefixes,
+				ignoredStatusPrefixes: tt.ignoredStatusPrefixes,
+				client: &mock.Client{
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+					ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+						return &github.WorkflowRuns{}, nil, nil
+					},
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						return &github.CombinedStatus{Statuses: []*github.RepoStatus{
+							{Context: stringPtr("ci/jenkins"), State: stringPtr(successState)},
+							{Context: stringPtr("license/cla"), State: stringPtr(pendingState)},
+						}}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() error = %v", err)
+			}
+			if decisions := got.(*status).decisions; !reflect.DeepEqual(decisions, tt.want) {
+				t.Errorf("statusValidator.Validate() decisions = %+v, want %+v", decisions, tt.want)
+			}
+		})
+	}
+}
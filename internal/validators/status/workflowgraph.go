@@ -0,0 +1,164 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+)
+
+const maxWorkflowsPerPage = 100
+
+// workflowGraph maps each workflow name to the names of the workflows which trigger it via workflow_run.
+type workflowGraph map[string][]string
+
+// loadWorkflowGraph reads the workflow definitions of the repository. Workflows triggered by workflow_run
+// are always read from the default branch, which is why no ref is given when fetching their contents.
+func (sv *statusValidator) loadWorkflowGraph(ctx context.Context) (workflowGraph, error) {
+	var workflows []*github.Workflow
+	page := 1
+	for {
+		wfs, _, err := sv.client.ListWorkflows(ctx, sv.owner, sv.repo, &github.ListOptions{
+			Page:    page,
+			PerPage: maxWorkflowsPerPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, wfs.Workflows...)
+		if wfs.GetTotalCount() <= len(workflows) || len(wfs.Workflows) == 0 {
+			break
+		}
+		page++
+	}
+
+	graph := make(workflowGraph, len(workflows))
+	for _, wf := range workflows {
+		content, _, _, err := sv.client.GetContents(ctx, sv.owner, sv.repo, wf.GetPath(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow definition %s: %w", wf.GetPath(), err)
+		}
+		str, err := content.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode workflow definition %s: %w", wf.GetPath(), err)
+		}
+		upstreams, err := parseWorkflowRunTriggers([]byte(str))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workflow definition %s: %w", wf.GetPath(), err)
+		}
+		graph[wf.GetName()] = upstreams
+	}
+	return graph, nil
+}
+
+// parseWorkflowRunTriggers returns the workflows listed under on.workflow_run.workflows.
+func parseWorkflowRunTriggers(definition []byte) ([]string, error) {
+	var wf struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(definition, &wf); err != nil {
+		return nil, err
+	}
+	if wf.On.Kind != yaml.MappingNode {
+		return nil, nil // Triggers given as a string or a list cannot have workflow_run filters.
+	}
+
+	var trigger struct {
+		WorkflowRun struct {
+			Workflows yaml.Node `yaml:"workflows"`
+		} `yaml:"workflow_run"`
+	}
+	if err := wf.On.Decode(&trigger); err != nil {
+		return nil, err
+	}
+
+	switch n := trigger.WorkflowRun.Workflows; n.Kind {
+	case yaml.ScalarNode:
+		return []string{n.Value}, nil
+	case yaml.SequenceNode:
+		var names []string
+		if err := n.Decode(&names); err != nil {
+			return nil, err
+		}
+		return names, nil
+	default:
+		return nil, nil
+	}
+}
+
+// criticalPath returns the longest chain of workflows which keeps the gate open. The chain starts from a
+// pending workflow, and follows the workflows which are yet to be triggered by it.
+func (g workflowGraph) criticalPath(present, pending map[string]bool) []string {
+	downstreams := make(map[string][]string)
+	for wf, upstreams := range g {
+		for _, up := range upstreams {
+			downstreams[up] = append(downstreams[up], wf)
+		}
+	}
+	for _, ds := range downstreams {
+		sort.Strings(ds)
+	}
+
+	// A workflow which has not run yet holds the gate open when one of its upstreams does.
+	openMemo := make(map[string]bool)
+	visiting := make(map[string]bool)
+	var isOpen func(wf string) bool
+	isOpen = func(wf string) bool {
+		if v, ok := openMemo[wf]; ok {
+			return v
+		}
+		if pending[wf] {
+			return true
+		}
+		if present[wf] || visiting[wf] {
+			return false
+		}
+		visiting[wf] = true
+		defer delete(visiting, wf)
+		for _, up := range g[wf] {
+			if isOpen(up) {
+				openMemo[wf] = true
+				return true
+			}
+		}
+		openMemo[wf] = false
+		return false
+	}
+
+	var longest func(wf string, seen map[string]bool) []string
+	longest = func(wf string, seen map[string]bool) []string {
+		seen[wf] = true
+		defer delete(seen, wf)
+
+		var best []string
+		for _, ds := range downstreams[wf] {
+			if seen[ds] || pending[ds] || present[ds] || !isOpen(ds) {
+				continue
+			}
+			if p := longest(ds, seen); len(p) > len(best) {
+				best = p
+			}
+		}
+		return append([]string{wf}, best...)
+	}
+
+	roots := make([]string, 0, len(pending))
+	for wf := range pending {
+		roots = append(roots, wf)
+	}
+	sort.Strings(roots)
+
+	var path []string
+	for _, root := range roots {
+		if p := longest(root, make(map[string]bool)); len(p) > len(path) {
+			path = p
+		}
+	}
+	if len(path) < 2 {
+		return nil // A single pending workflow is not a chain worth reporting.
+	}
+	return path
+}
@@ -0,0 +1,126 @@
+package status
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_parseWorkflowRunTriggers(t *testing.T) {
+	tests := map[string]struct {
+		definition string
+		want       []string
+		wantErr    bool
+	}{
+		"returns nil when triggers are a list": {
+			definition: "on: [push, pull_request]\n",
+			want:       nil,
+		},
+		"returns nil when there is no workflow_run trigger": {
+			definition: "on:\n  pull_request:\n    branches: [main]\n",
+			want:       nil,
+		},
+		"returns the workflows of the workflow_run trigger": {
+			definition: "on:\n  workflow_run:\n    workflows: [Build, Lint]\n    types: [completed]\n",
+			want:       []string{"Build", "Lint"},
+		},
+		"returns the workflow given as a string": {
+			definition: "on:\n  workflow_run:\n    workflows: Build\n",
+			want:       []string{"Build"},
+		},
+		"returns error when the definition is malformed": {
+			definition: "on: [",
+			wantErr:    true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseWorkflowRunTriggers([]byte(tt.definition))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWorkflowRunTriggers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorkflowRunTriggers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_workflowGraph_criticalPath(t *testing.T) {
+	graph := workflowGraph{
+		"Build":       nil,
+		"Lint":        nil,
+		"Integration": {"Build"},
+		"Deploy":      {"Integration"},
+		"Notify":      {"Build"},
+	}
+	tests := map[string]struct {
+		present map[string]bool
+		pending map[string]bool
+		want    []string
+	}{
+		"returns the longest chain of workflows yet to be triggered": {
+			present: map[string]bool{"Build": true, "Lint": true},
+			pending: map[string]bool{"Build": true},
+			want:    []string{"Build", "Integration", "Deploy"},
+		},
+		"starts from the pending downstream when upstream has completed": {
+			present: map[string]bool{"Build": true, "Integration": true, "Notify": true},
+			pending: map[string]bool{"Integration": true},
+			want:    []string{"Integration", "Deploy"},
+		},
+		"returns nil when there is no chain": {
+			present: map[string]bool{"Build": true, "Lint": true},
+			pending: map[string]bool{"Lint": true},
+			want:    nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := graph.criticalPath(tt.present, tt.pending); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("criticalPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_loadWorkflowGraph(t *testing.T) {
+	definitions := map[string]string{
+		".github/workflows/build.yml":  "name: Build\non: [pull_request]\n",
+		".github/workflows/deploy.yml": "name: Deploy\non:\n  workflow_run:\n    workflows: [Build]\n",
+	}
+	sv := &statusValidator{
+		owner: "test-owner",
+		repo:  "test-repo",
+		client: &mock.Client{
+			ListWorkflowsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+				total := 2
+				return &github.Workflows{
+					TotalCount: &total,
+					Workflows: []*github.Workflow{
+						{Name: stringPtr("Build"), Path: stringPtr(".github/workflows/build.yml")},
+						{Name: stringPtr("Deploy"), Path: stringPtr(".github/workflows/deploy.yml")},
+					},
+				}, nil, nil
+			},
+			GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+				return &github.RepositoryContent{
+					Encoding: stringPtr("base64"),
+					Content:  stringPtr(base64.StdEncoding.EncodeToString([]byte(definitions[path]))),
+				}, nil, nil, nil
+			},
+		},
+	}
+	got, err := sv.loadWorkflowGraph(context.Background())
+	if err != nil {
+		t.Fatalf("loadWorkflowGraph() error = %v", err)
+	}
+	want := workflowGraph{"Build": nil, "Deploy": {"Build"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadWorkflowGraph() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package terraform
+
+type Option func(tv *terraformValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(tv *terraformValidator) {
+		if len(owner) != 0 {
+			tv.owner = owner
+		}
+		if len(repo) != 0 {
+			tv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(tv *terraformValidator) {
+		if len(ref) != 0 {
+			tv.ref = ref
+		}
+	}
+}
+
+// WithPullRequest sets the number of the pull request carrying the approval label.
+func WithPullRequest(number int) Option {
+	return func(tv *terraformValidator) {
+		tv.prNumber = number
+	}
+}
+
+// WithPlanArtifact reads the plan from the artifact, containing either `terraform show -json` output as .json files
+// or human readable plans.
+func WithPlanArtifact(name string) Option {
+	return func(tv *terraformValidator) {
+		tv.artifact = name
+	}
+}
+
+// WithPlanCheckRun reads the plan from the output of the check run, such as the one posted by plan actions.
+func WithPlanCheckRun(name string) Option {
+	return func(tv *terraformValidator) {
+		tv.checkRun = name
+	}
+}
+
+// WithApprovalLabel sets the label approving destructive changes.
+func WithApprovalLabel(label string) Option {
+	return func(tv *terraformValidator) {
+		if len(label) != 0 {
+			tv.approvalLabel = label
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package terraform
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// plan summarises the destructive changes of a terraform plan.
+type plan struct {
+	deleted  []string // Addresses of resources to be destroyed.
+	replaced []string // Addresses of resources to be destroyed and recreated.
+	destroy  int      // Number of destroyed resources, which is the only information in plain text output.
+}
+
+func (p *plan) destructive() bool {
+	return p.destroy != 0 || len(p.deleted) != 0 || len(p.replaced) != 0
+}
+
+func (p *plan) String() string {
+	if len(p.deleted) == 0 && len(p.replaced) == 0 {
+		return fmt.Sprintf("%d to destroy", p.destroy)
+	}
+	var parts []string
+	if len(p.deleted) != 0 {
+		parts = append(parts, "delete "+strings.Join(p.deleted, ", "))
+	}
+	if len(p.replaced) != 0 {
+		parts = append(parts, "replace "+strings.Join(p.replaced, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (p *plan) merge(o *plan) {
+	p.deleted = append(p.deleted, o.deleted...)
+	p.replaced = append(p.replaced, o.replaced...)
+	p.destroy += o.destroy
+}
+
+// parseJSON reads the output of `terraform show -json PLANFILE`.
+func parseJSON(r io.Reader) (*plan, error) {
+	var out struct {
+		ResourceChanges []struct {
+			Address string `json:"address"`
+			Change  struct {
+				Actions []string `json:"actions"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return nil, err
+	}
+	p := &plan{}
+	for _, rc := range out.ResourceChanges {
+		var del, create bool
+		for _, a := range rc.Change.Actions {
+			switch a {
+			case "delete":
+				del = true
+			case "create":
+				create = true
+			}
+		}
+		switch {
+		case del && create:
+			p.replaced = append(p.replaced, rc.Address)
+			p.destroy++
+		case del:
+			p.deleted = append(p.deleted, rc.Address)
+			p.destroy++
+		}
+	}
+	return p, nil
+}
+
+var planSummary = regexp.MustCompile(`Plan: \d+ to add, \d+ to change, (\d+) to destroy`)
+
+// parseText reads the summary line of the human readable plan, such as the output of plan check runs.
+func parseText(text string) (*plan, bool) {
+	p := &plan{}
+	var found bool
+	for _, m := range planSummary.FindAllStringSubmatch(text, -1) {
+		n, _ := strconv.Atoi(m[1])
+		p.destroy += n
+		found = true
+	}
+	if !found && strings.Contains(text, "No changes.") {
+		found = true
+	}
+	return p, found
+}
+
+// planFromArchive reads every plan in the zip archive of an artifact. Files ending with .json are read as JSON plans,
+// and other files as human readable plans.
+func planFromArchive(archive []byte) (*plan, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	p := &plan{}
+	var found bool
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(strings.ToLower(f.Name), ".json") {
+			fp, err := parseJSON(rc)
+			rc.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+			}
+			p.merge(fp)
+			found = true
+			continue
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if fp, ok := parseText(string(b)); ok {
+			p.merge(fp)
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.New("no terraform plan is found in the artifact")
+	}
+	return p, nil
+}
@@ -0,0 +1,68 @@
+package terraform
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseJSON(t *testing.T) {
+	out := `{"resource_changes": [
+		{"address": "aws_s3_bucket.logs", "change": {"actions": ["delete"]}},
+		{"address": "aws_instance.web", "change": {"actions": ["delete", "create"]}},
+		{"address": "aws_instance.api", "change": {"actions": ["create", "delete"]}},
+		{"address": "aws_iam_role.ci", "change": {"actions": ["update"]}},
+		{"address": "aws_sqs_queue.jobs", "change": {"actions": ["no-op"]}}
+	]}`
+	got, err := parseJSON(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &plan{
+		deleted:  []string{"aws_s3_bucket.logs"},
+		replaced: []string{"aws_instance.web", "aws_instance.api"},
+		destroy:  3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseJSON() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_parseText(t *testing.T) {
+	tests := map[string]struct {
+		text      string
+		wantFound bool
+		wantPlan  *plan
+	}{
+		"reads destroyed resources from the summary": {
+			text:      "Terraform will perform the following actions:\n...\nPlan: 1 to add, 2 to change, 3 to destroy.\n",
+			wantFound: true,
+			wantPlan:  &plan{destroy: 3},
+		},
+		"sums summaries of multiple plans": {
+			text:      "Plan: 0 to add, 0 to change, 1 to destroy.\nPlan: 2 to add, 0 to change, 0 to destroy.\n",
+			wantFound: true,
+			wantPlan:  &plan{destroy: 1},
+		},
+		"reads plan without changes": {
+			text:      "No changes. Your infrastructure matches the configuration.",
+			wantFound: true,
+			wantPlan:  &plan{},
+		},
+		"returns not found without plan": {
+			text:     "Error: Invalid provider configuration",
+			wantPlan: &plan{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, found := parseText(tt.text)
+			if found != tt.wantFound {
+				t.Fatalf("parseText() found = %v, want %v", found, tt.wantFound)
+			}
+			if !reflect.DeepEqual(got, tt.wantPlan) {
+				t.Errorf("parseText() = %+v, want %+v", got, tt.wantPlan)
+			}
+		})
+	}
+}
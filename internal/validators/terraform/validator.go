@@ -0,0 +1,180 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "terraform"
+
+const checkRunCompletedStatus = "completed"
+
+// DefaultApprovalLabel is the label approving destructive changes when none is given.
+const DefaultApprovalLabel = "terraform-destroy-approved"
+
+const maxItemsPerPage = 100
+
+type terraformValidator struct {
+	owner         string
+	repo          string
+	ref           string
+	prNumber      int
+	artifact      string
+	checkRun      string
+	approvalLabel string
+	client        github.Client
+}
+
+// CreateValidator returns the validator which inspects the terraform plan of the ref, read from either an artifact or
+// the output of a check run, and requires the approval label on the pull request when it deletes or replaces
+// any resource.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	tv := &terraformValidator{
+		client:        c,
+		approvalLabel: DefaultApprovalLabel,
+	}
+	for _, opt := range opts {
+		opt(tv)
+	}
+	if err := tv.validateFields(); err != nil {
+		return nil, err
+	}
+	return tv, nil
+}
+
+func (tv *terraformValidator) Name() string {
+	return validatorName
+}
+
+func (tv *terraformValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(tv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(tv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(tv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(tv.artifact) == 0 && len(tv.checkRun) == 0 {
+		errs = append(errs, errors.New("either plan artifact or plan check run must be set"))
+	}
+	if tv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (tv *terraformValidator) Validate(ctx context.Context) (validators.Status, error) {
+	var (
+		p   *plan
+		err error
+	)
+	if len(tv.artifact) != 0 {
+		p, err = tv.planFromArtifact(ctx)
+	} else {
+		p, err = tv.planFromCheckRun(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return &validators.BasicStatus{Message: "waiting for terraform plan"}, nil
+	}
+	if !p.destructive() {
+		return &validators.BasicStatus{Succeeded: true, Message: "terraform plan has no destructive changes"}, nil
+	}
+
+	if tv.prNumber == 0 {
+		return nil, fmt.Errorf("terraform plan has destructive changes (%s), which can only be approved on pull requests", p)
+	}
+	approved, err := tv.hasApprovalLabel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		return &validators.BasicStatus{
+			Message: fmt.Sprintf("terraform plan has destructive changes (%s), waiting for label %s on #%d", p, tv.approvalLabel, tv.prNumber),
+		}, nil
+	}
+	return &validators.BasicStatus{
+		Succeeded: true,
+		Message:   fmt.Sprintf("destructive changes of terraform plan (%s) are approved by label %s", p, tv.approvalLabel),
+	}, nil
+}
+
+// planFromArtifact returns the plan uploaded by the latest run uploading the artifact, or nil when not uploaded yet.
+func (tv *terraformValidator) planFromArtifact(ctx context.Context) (*plan, error) {
+	runs, _, err := tv.client.ListWorkflowRuns(ctx, tv.owner, tv.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     tv.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	for _, run := range runs.WorkflowRuns {
+		list, _, err := tv.client.ListWorkflowRunArtifacts(ctx, tv.owner, tv.repo, run.GetID(), &github.ListOptions{PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts of %s: %w", run.GetName(), err)
+		}
+		for _, a := range list.Artifacts {
+			if a.Name != tv.artifact || a.Expired {
+				continue
+			}
+			archive, _, err := tv.client.DownloadArtifact(ctx, tv.owner, tv.repo, a.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download plan artifact %s: %w", a.Name, err)
+			}
+			return planFromArchive(archive)
+		}
+	}
+	return nil, nil
+}
+
+// planFromCheckRun returns the plan in the output of the latest completed check run, or nil when not completed yet.
+func (tv *terraformValidator) planFromCheckRun(ctx context.Context) (*plan, error) {
+	cr, _, err := tv.client.ListCheckRunsForRef(ctx, tv.owner, tv.repo, tv.ref, &github.ListCheckRunsOptions{
+		CheckName:   &tv.checkRun,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs: %w", err)
+	}
+	for _, run := range cr.CheckRuns {
+		if run.GetStatus() != checkRunCompletedStatus {
+			continue
+		}
+		output := run.GetOutput().GetSummary() + "\n" + run.GetOutput().GetText()
+		p, ok := parseText(output)
+		if !ok {
+			return nil, fmt.Errorf("terraform plan is not found in the output of check run %s", tv.checkRun)
+		}
+		return p, nil
+	}
+	return nil, nil
+}
+
+func (tv *terraformValidator) hasApprovalLabel(ctx context.Context) (bool, error) {
+	labels, _, err := tv.client.ListLabelsByIssue(ctx, tv.owner, tv.repo, tv.prNumber, &github.ListOptions{PerPage: maxItemsPerPage})
+	if err != nil {
+		return false, fmt.Errorf("failed to list labels of #%d: %w", tv.prNumber, err)
+	}
+	for _, l := range labels {
+		if l.GetName() == tv.approvalLabel {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,212 @@
+package terraform
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func archive(t *testing.T, name, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const destructivePlan = `{"resource_changes": [{"address": "aws_s3_bucket.logs", "change": {"actions": ["delete"]}}]}`
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns validator with artifact": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithPlanArtifact("plan")},
+		},
+		"returns validator with check run": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithPlanCheckRun("terraform plan")},
+		},
+		"returns error without plan source": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha")},
+			wantErr: true,
+		},
+		"returns error without ref": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithPlanArtifact("plan")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(&mock.Client{}, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTerraformValidator_Validate_artifact(t *testing.T) {
+	tests := map[string]struct {
+		file        string
+		content     string
+		labels      []string
+		prNumber    int
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds without destructive changes": {
+			file:        "plan.json",
+			content:     `{"resource_changes": [{"address": "aws_instance.web", "change": {"actions": ["update"]}}]}`,
+			prNumber:    1,
+			wantSuccess: true,
+		},
+		"is pending until destructive changes are approved": {
+			file:     "plan.json",
+			content:  destructivePlan,
+			labels:   []string{"bug"},
+			prNumber: 1,
+		},
+		"succeeds when destructive changes are approved": {
+			file:        "plan.txt",
+			content:     "Plan: 0 to add, 0 to change, 1 to destroy.",
+			labels:      []string{DefaultApprovalLabel},
+			prNumber:    1,
+			wantSuccess: true,
+		},
+		"returns error on destructive changes without pull request": {
+			file:    "plan.json",
+			content: destructivePlan,
+			wantErr: true,
+		},
+		"is pending until the artifact is uploaded": {
+			prNumber: 1,
+		},
+		"returns error when the artifact has no plan": {
+			file:     "README.md",
+			content:  "terraform plan",
+			prNumber: 1,
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					id := int64(1)
+					return &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{ID: &id}}}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					if len(tt.file) == 0 {
+						return &github.ArtifactList{}, nil, nil
+					}
+					return &github.ArtifactList{Artifacts: []*github.Artifact{{ID: 2, Name: "plan"}}}, nil, nil
+				},
+				DownloadArtifactFunc: func(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+					return archive(t, tt.file, tt.content), nil, nil
+				},
+				ListLabelsByIssueFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+					var labels []*github.Label
+					for _, l := range tt.labels {
+						labels = append(labels, &github.Label{Name: stringPtr(l)})
+					}
+					return labels, nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithPlanArtifact("plan"), WithPullRequest(tt.prNumber))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
+
+func TestTerraformValidator_Validate_checkRun(t *testing.T) {
+	tests := map[string]struct {
+		runs        []*github.CheckRun
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds when the plan has no changes": {
+			runs: []*github.CheckRun{{
+				Status: stringPtr("completed"),
+				Output: &github.CheckRunOutput{Summary: stringPtr("No changes. Your infrastructure matches the configuration.")},
+			}},
+			wantSuccess: true,
+		},
+		"is pending on destructive changes": {
+			runs: []*github.CheckRun{{
+				Status: stringPtr("completed"),
+				Output: &github.CheckRunOutput{Text: stringPtr("Plan: 1 to add, 0 to change, 1 to destroy.")},
+			}},
+		},
+		"is pending until the check run completes": {
+			runs: []*github.CheckRun{{Status: stringPtr("in_progress")}},
+		},
+		"returns error when the output has no plan": {
+			runs: []*github.CheckRun{{
+				Status: stringPtr("completed"),
+				Output: &github.CheckRunOutput{Summary: stringPtr("Error: Unsupported argument")},
+			}},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					if opts.GetCheckName() != "terraform plan" {
+						t.Errorf("check name = %s, want terraform plan", opts.GetCheckName())
+					}
+					return &github.ListCheckRunsResults{CheckRuns: tt.runs}, nil, nil
+				},
+				ListLabelsByIssueFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+					return nil, nil, nil
+				},
+			}
+			v, err := CreateValidator(c, WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithPlanCheckRun("terraform plan"), WithPullRequest(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validators
 
 import (
 	"context"
+	"time"
 )
 
 type Status interface {
@@ -13,3 +14,109 @@ type Validator interface {
 	Name() string
 	Validate(ctx context.Context) (Status, error)
 }
+
+// Decision records how a validator classified a single check, and which rule led to it.
+type Decision struct {
+	Check   string `json:"check"`
+	Group   string `json:"group,omitempty"`
+	Rule    string `json:"rule"`
+	Verdict string `json:"verdict"`
+	// State is the observed state of checks whose verdict does not follow it, such as ignored checks.
+	State string `json:"state,omitempty"`
+}
+
+// Tracer is implemented by statuses which can explain how their result was reached.
+type Tracer interface {
+	Decisions() []Decision
+}
+
+// States of the sections reported by statuses.
+const (
+	StateSuccess = "success"
+	StateFailure = "failure"
+	StatePending = "pending"
+	StateIgnored = "ignored"
+)
+
+// Section is a titled list of checks sharing the same state.
+type Section struct {
+	Title string
+	State string
+	Items []string
+}
+
+// Reporter is implemented by statuses which can describe their checks as structured sections,
+// so that they can be rendered in output modes other than Detail.
+type Reporter interface {
+	Summary() string
+	Sections() []Section
+}
+
+// Group summarises the checks belonging to the same group, such as a workflow.
+type Group struct {
+	Name      string     `json:"name"`
+	Succeeded int        `json:"succeeded"`
+	Failed    int        `json:"failed"`
+	Pending   int        `json:"pending"`
+	Ignored   int        `json:"ignored"`
+	ETA       *time.Time `json:"eta,omitempty"`
+	Checks    []Decision `json:"-"`
+}
+
+// IsGreen reports whether no check in the group is failed or pending.
+func (g *Group) IsGreen() bool {
+	return g.Failed == 0 && g.Pending == 0
+}
+
+// Grouper is implemented by statuses which can group their checks.
+type Grouper interface {
+	Groups() []Group
+}
+
+// Explainer is implemented by statuses which can explain which of their rules captured a check, given by its name
+// either qualified by its group or not. It reports false when no such check was observed.
+type Explainer interface {
+	Explain(check string) (string, bool)
+}
+
+// Classifier is implemented by validators which can tell whether their rules ignore a check, given by its name
+// either qualified by its group or not, or every check of a group, without observing them. It reports which rule
+// ignores the check or the group, if any.
+type Classifier interface {
+	Ignores(check string) (string, bool)
+	IgnoresGroup(group string) (string, bool)
+}
+
+// Estimator is implemented by statuses which can estimate when they are likely to complete.
+type Estimator interface {
+	ETA() (time.Time, bool)
+}
+
+// Noter is implemented by statuses which carry additional notes for users, such as analysis results or warnings.
+type Noter interface {
+	Notes() []string
+}
+
+// FailedChecksError is returned by validators whose checks failed. It carries the status describing the checks,
+// so that failures can be rendered in any output mode, and its message is the detail of the status.
+type FailedChecksError struct {
+	Status Status
+}
+
+func (e *FailedChecksError) Error() string {
+	return e.Status.Detail()
+}
+
+// BasicStatus is the Status of validators whose result is fully described by a single message.
+type BasicStatus struct {
+	Succeeded bool
+	Message   string
+}
+
+func (s *BasicStatus) Detail() string {
+	return s.Message
+}
+
+func (s *BasicStatus) IsSuccess() bool {
+	return s.Succeeded
+}
@@ -0,0 +1,53 @@
+package vulnerability
+
+import "strings"
+
+type Option func(vv *vulnerabilityValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(vv *vulnerabilityValidator) {
+		if len(owner) != 0 {
+			vv.owner = owner
+		}
+		if len(repo) != 0 {
+			vv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(vv *vulnerabilityValidator) {
+		if len(ref) != 0 {
+			vv.ref = ref
+		}
+	}
+}
+
+// WithArtifactPattern sets the glob pattern of the names of artifacts containing SARIF scan results, e.g) trivy-*.
+func WithArtifactPattern(pattern string) Option {
+	return func(vv *vulnerabilityValidator) {
+		vv.pattern = pattern
+	}
+}
+
+// WithSeverity sets the lowest severity which blocks the merge.
+func WithSeverity(severity string) Option {
+	return func(vv *vulnerabilityValidator) {
+		if len(severity) != 0 {
+			vv.severity = strings.ToLower(severity)
+		}
+	}
+}
+
+// WithIgnoredRules sets the comma-separated list of rules, such as CVE IDs, which never block the merge.
+func WithIgnoredRules(ids string) Option {
+	return func(vv *vulnerabilityValidator) {
+		ignored := make(map[string]bool)
+		for _, s := range strings.Split(ids, ",") {
+			if id := strings.TrimSpace(s); len(id) != 0 {
+				ignored[id] = true
+			}
+		}
+		vv.ignoredRules = ignored
+	}
+}
@@ -0,0 +1,154 @@
+package vulnerability
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Severities of vulnerabilities, in ascending order.
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+var severityRanks = map[string]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// finding is a vulnerability reported by a scanner.
+type finding struct {
+	RuleID   string
+	Severity string
+	Location string
+}
+
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Rules []sarifRule `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			Level     string `json:"level"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+type sarifRule struct {
+	ID         string `json:"id"`
+	Properties struct {
+		SecuritySeverity string   `json:"security-severity"`
+		Tags             []string `json:"tags"`
+	} `json:"properties"`
+}
+
+// severity derives the severity of the rule from its CVSS score, as GitHub code scanning does, and otherwise from
+// its tags, such as the ones Trivy adds.
+func (r *sarifRule) severity() string {
+	if score, err := strconv.ParseFloat(r.Properties.SecuritySeverity, 64); err == nil {
+		switch {
+		case score >= 9.0:
+			return SeverityCritical
+		case score >= 7.0:
+			return SeverityHigh
+		case score >= 4.0:
+			return SeverityMedium
+		default:
+			return SeverityLow
+		}
+	}
+	for _, tag := range r.Properties.Tags {
+		if _, ok := severityRanks[strings.ToLower(tag)]; ok {
+			return strings.ToLower(tag)
+		}
+	}
+	return ""
+}
+
+// severityOfLevel is the fallback for results whose rule carries no severity.
+func severityOfLevel(level string) string {
+	switch level {
+	case "error":
+		return SeverityHigh
+	case "warning":
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+func parseSARIF(r io.Reader) ([]finding, error) {
+	var log sarifLog
+	if err := json.NewDecoder(r).Decode(&log); err != nil {
+		return nil, err
+	}
+	var findings []finding
+	for _, run := range log.Runs {
+		severities := make(map[string]string)
+		for i := range run.Tool.Driver.Rules {
+			rule := &run.Tool.Driver.Rules[i]
+			severities[rule.ID] = rule.severity()
+		}
+		for _, res := range run.Results {
+			f := finding{RuleID: res.RuleID, Severity: severities[res.RuleID]}
+			if len(f.Severity) == 0 {
+				f.Severity = severityOfLevel(res.Level)
+			}
+			if len(res.Locations) != 0 {
+				f.Location = res.Locations[0].PhysicalLocation.ArtifactLocation.URI
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+// findingsFromArchive returns the findings of every SARIF file in the zip archive of an artifact.
+func findingsFromArchive(archive []byte) ([]finding, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	var findings []finding
+	var found bool
+	for _, f := range zr.File {
+		name := strings.ToLower(f.Name)
+		if f.FileInfo().IsDir() || !(strings.HasSuffix(name, ".sarif") || strings.HasSuffix(name, ".sarif.json")) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		fs, err := parseSARIF(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		findings = append(findings, fs...)
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("no SARIF file is found in the artifact")
+	}
+	return findings, nil
+}
@@ -0,0 +1,36 @@
+package vulnerability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseSARIF(t *testing.T) {
+	sarif := `{
+  "runs": [{
+    "tool": {"driver": {"name": "Trivy", "rules": [
+      {"id": "CVE-1", "properties": {"security-severity": "9.8", "tags": ["vulnerability", "CRITICAL"]}},
+      {"id": "CVE-2", "properties": {"tags": ["vulnerability", "HIGH"]}},
+      {"id": "CVE-3", "properties": {}}
+    ]}},
+    "results": [
+      {"ruleId": "CVE-1", "level": "error", "locations": [{"physicalLocation": {"artifactLocation": {"uri": "library/alpine"}}}]},
+      {"ruleId": "CVE-2", "level": "error"},
+      {"ruleId": "CVE-3", "level": "warning"}
+    ]
+  }]
+}`
+	got, err := parseSARIF(strings.NewReader(sarif))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []finding{
+		{RuleID: "CVE-1", Severity: SeverityCritical, Location: "library/alpine"},
+		{RuleID: "CVE-2", Severity: SeverityHigh},
+		{RuleID: "CVE-3", Severity: SeverityMedium},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSARIF() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+package vulnerability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "vulnerabilities"
+
+const workflowRunCompletedStatus = "completed"
+
+const (
+	maxItemsPerPage   = 100
+	maxListedFindings = 20
+)
+
+type vulnerabilityValidator struct {
+	owner        string
+	repo         string
+	ref          string
+	pattern      string
+	severity     string
+	ignoredRules map[string]bool
+	client       github.Client
+
+	downloaded map[int64][]finding // Cached, as artifacts do not change once uploaded.
+}
+
+// CreateValidator returns the validator which waits for the SARIF results of image scanners, such as Trivy, uploaded
+// as artifacts by the workflow runs of the ref, and fails when any vulnerability is at or above the severity.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	vv := &vulnerabilityValidator{
+		client:     c,
+		severity:   SeverityCritical,
+		downloaded: make(map[int64][]finding),
+	}
+	for _, opt := range opts {
+		opt(vv)
+	}
+	if err := vv.validateFields(); err != nil {
+		return nil, err
+	}
+	return vv, nil
+}
+
+func (vv *vulnerabilityValidator) Name() string {
+	return validatorName
+}
+
+func (vv *vulnerabilityValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 6)
+
+	if len(vv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(vv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(vv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(vv.pattern) == 0 {
+		errs = append(errs, errors.New("artifact pattern of scan results is empty"))
+	} else if _, err := path.Match(vv.pattern, ""); err != nil {
+		errs = append(errs, fmt.Errorf("invalid artifact pattern %s: %w", vv.pattern, err))
+	}
+	if _, ok := severityRanks[vv.severity]; !ok {
+		errs = append(errs, fmt.Errorf("unsupported severity: %s, supported severities: [%s %s %s %s]", vv.severity, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical))
+	}
+	if vv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (vv *vulnerabilityValidator) Validate(ctx context.Context) (validators.Status, error) {
+	runs, _, err := vv.client.ListWorkflowRuns(ctx, vv.owner, vv.repo, &github.ListWorkflowRunsOptions{
+		HeadSHA:     vv.ref,
+		ListOptions: github.ListOptions{PerPage: maxItemsPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	var (
+		findings  []finding
+		scans     int
+		completed = true
+		seen      = make(map[string]bool)
+	)
+	for _, run := range runs.WorkflowRuns {
+		// Workflow runs are listed newest first, so re-runs take precedence.
+		if seen[run.GetName()] {
+			continue
+		}
+		seen[run.GetName()] = true
+		completed = completed && run.GetStatus() == workflowRunCompletedStatus
+
+		list, _, err := vv.client.ListWorkflowRunArtifacts(ctx, vv.owner, vv.repo, run.GetID(), &github.ListOptions{PerPage: maxItemsPerPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts of %s: %w", run.GetName(), err)
+		}
+		for _, a := range list.Artifacts {
+			if ok, _ := path.Match(vv.pattern, a.Name); !ok || a.Expired {
+				continue
+			}
+			fs, err := vv.download(ctx, a)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, fs...)
+			scans++
+		}
+	}
+	if scans == 0 {
+		if completed && len(runs.WorkflowRuns) != 0 {
+			return nil, fmt.Errorf("no scan results matching %s were uploaded", vv.pattern)
+		}
+		return &validators.BasicStatus{Message: fmt.Sprintf("waiting for scan results matching %s", vv.pattern)}, nil
+	}
+
+	var blocking []string
+	counts := make(map[string]int)
+	for _, f := range findings {
+		if vv.ignoredRules[f.RuleID] {
+			continue
+		}
+		counts[f.Severity]++
+		if severityRanks[f.Severity] >= severityRanks[vv.severity] {
+			blocking = append(blocking, fmt.Sprintf("- %s (%s) in %s", f.RuleID, f.Severity, f.Location))
+		}
+	}
+	summary := fmt.Sprintf("%d critical, %d high, %d medium, %d low in %d scans",
+		counts[SeverityCritical], counts[SeverityHigh], counts[SeverityMedium], counts[SeverityLow], scans)
+	if len(blocking) != 0 {
+		sort.Strings(blocking)
+		if len(blocking) > maxListedFindings {
+			blocking = append(blocking[:maxListedFindings], fmt.Sprintf("- ... and %d more", len(blocking)-maxListedFindings))
+		}
+		return nil, fmt.Errorf("vulnerabilities at or above %s severity were found (%s):\n%s", vv.severity, summary, strings.Join(blocking, "\n"))
+	}
+	return &validators.BasicStatus{Succeeded: true, Message: fmt.Sprintf("no vulnerabilities at or above %s severity (%s)", vv.severity, summary)}, nil
+}
+
+func (vv *vulnerabilityValidator) download(ctx context.Context, a *github.Artifact) ([]finding, error) {
+	if fs, ok := vv.downloaded[a.ID]; ok {
+		return fs, nil
+	}
+	archive, _, err := vv.client.DownloadArtifact(ctx, vv.owner, vv.repo, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download scan results %s: %w", a.Name, err)
+	}
+	fs, err := findingsFromArchive(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan results %s: %w", a.Name, err)
+	}
+	vv.downloaded[a.ID] = fs
+	return fs, nil
+}
@@ -0,0 +1,116 @@
+package vulnerability
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func archive(t *testing.T, severities ...string) []byte {
+	t.Helper()
+	var rules, results string
+	for i, s := range severities {
+		if i != 0 {
+			rules += ","
+			results += ","
+		}
+		rules += fmt.Sprintf(`{"id": "CVE-%d", "properties": {"tags": [%q]}}`, i, s)
+		results += fmt.Sprintf(`{"ruleId": "CVE-%d"}`, i)
+	}
+	sarif := fmt.Sprintf(`{"runs": [{"tool": {"driver": {"rules": [%s]}}, "results": [%s]}]}`, rules, results)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("trivy.sarif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(sarif)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestVulnerabilityValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		opts        []Option
+		status      string
+		severities  []string // Severities of the uploaded scan, nil when not uploaded.
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds without critical vulnerabilities": {
+			status:      "completed",
+			severities:  []string{"HIGH", "LOW"},
+			wantSuccess: true,
+		},
+		"returns error with critical vulnerabilities": {
+			status:     "completed",
+			severities: []string{"CRITICAL"},
+			wantErr:    true,
+		},
+		"returns error at the configured severity": {
+			opts:       []Option{WithSeverity("high")},
+			status:     "completed",
+			severities: []string{"HIGH"},
+			wantErr:    true,
+		},
+		"skips ignored rules": {
+			opts:        []Option{WithIgnoredRules("CVE-0")},
+			status:      "completed",
+			severities:  []string{"CRITICAL"},
+			wantSuccess: true,
+		},
+		"is pending until the scan is uploaded": {
+			status:      "in_progress",
+			wantSuccess: false,
+		},
+		"returns error when the completed runs did not upload the scan": {
+			status:  "completed",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListWorkflowRunsFunc: func(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+					id, name := int64(1), "Build"
+					return &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{ID: &id, Name: &name, Status: &tt.status}}}, nil, nil
+				},
+				ListWorkflowRunArtifactsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+					if tt.severities == nil {
+						return &github.ArtifactList{}, nil, nil
+					}
+					return &github.ArtifactList{Artifacts: []*github.Artifact{{ID: 1, Name: "trivy-app"}}}, nil, nil
+				},
+				DownloadArtifactFunc: func(ctx context.Context, owner, repo string, artifactID int64) ([]byte, *github.Response, error) {
+					return archive(t, tt.severities...), nil, nil
+				},
+			}
+			opts := append([]Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithArtifactPattern("trivy-*")}, tt.opts...)
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package waitfor
+
+import "strings"
+
+type Option func(wv *waitForValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(wv *waitForValidator) {
+		if len(owner) != 0 {
+			wv.owner = owner
+		}
+		if len(repo) != 0 {
+			wv.repo = repo
+		}
+	}
+}
+
+func WithGitHubRef(ref string) Option {
+	return func(wv *waitForValidator) {
+		if len(ref) != 0 {
+			wv.ref = ref
+		}
+	}
+}
+
+// WithChecks sets the comma-separated list of check runs or status contexts which must appear and succeed.
+func WithChecks(names string) Option {
+	return func(wv *waitForValidator) {
+		var checks []string
+		for _, s := range strings.Split(names, ",") {
+			if name := strings.TrimSpace(s); len(name) != 0 {
+				checks = append(checks, name)
+			}
+		}
+		wv.checks = checks
+	}
+}
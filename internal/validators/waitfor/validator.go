@@ -0,0 +1,170 @@
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/multierror"
+	"github.com/aac228/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "wait-for"
+
+// NOTE: https://docs.github.com/en/rest/checks/runs
+const (
+	checkRunCompletedStatus = "completed"
+
+	checkRunSuccessConclusion = "success"
+	checkRunNeutralConclusion = "neutral"
+	checkRunSkipConclusion    = "skipped"
+)
+
+// NOTE: https://docs.github.com/en/rest/commits/statuses
+const (
+	successState = "success"
+	failureState = "failure"
+	errorState   = "error"
+)
+
+const maxItemsPerPage = 100
+
+type waitForValidator struct {
+	owner  string
+	repo   string
+	ref    string
+	checks []string
+	client github.Client
+}
+
+// CreateValidator returns the validator which waits for the given check runs or status contexts of the ref to
+// appear and then succeed, regardless of any other check of the ref.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	wv := &waitForValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(wv)
+	}
+	if err := wv.validateFields(); err != nil {
+		return nil, err
+	}
+	return wv, nil
+}
+
+func (wv *waitForValidator) Name() string {
+	return validatorName
+}
+
+func (wv *waitForValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(wv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(wv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if len(wv.ref) == 0 {
+		errs = append(errs, errors.New("reference of repository is empty"))
+	}
+	if len(wv.checks) == 0 {
+		errs = append(errs, errors.New("no check to wait for"))
+	}
+	if wv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (wv *waitForValidator) Validate(ctx context.Context) (validators.Status, error) {
+	contexts, err := wv.statusContexts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed, pending, succeeded []string
+	for _, name := range wv.checks {
+		state, err := wv.checkState(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if len(state) == 0 {
+			state = contexts[name]
+		}
+		switch state {
+		case "":
+			pending = append(pending, name+" (not found)")
+		case successState:
+			succeeded = append(succeeded, name)
+		case failureState, errorState:
+			failed = append(failed, name)
+		default:
+			pending = append(pending, name)
+		}
+	}
+	if len(failed) != 0 {
+		return nil, fmt.Errorf("checks waited for failed: %s", strings.Join(failed, ", "))
+	}
+
+	st := &validators.BasicStatus{
+		Succeeded: len(pending) == 0,
+		Message:   fmt.Sprintf("%d out of %d checks waited for succeeded", len(succeeded), len(wv.checks)),
+	}
+	if len(pending) != 0 {
+		st.Message += fmt.Sprintf(", pending: %s", strings.Join(pending, ", "))
+	}
+	return st, nil
+}
+
+// checkState returns the state of the latest check run of the name, in the terms of commit statuses, or empty
+// when the ref has no check run of the name.
+func (wv *waitForValidator) checkState(ctx context.Context, name string) (string, error) {
+	cr, _, err := wv.client.ListCheckRunsForRef(ctx, wv.owner, wv.repo, wv.ref, &github.ListCheckRunsOptions{
+		CheckName:   &name,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list check runs of %s: %w", name, err)
+	}
+	if len(cr.CheckRuns) == 0 {
+		return "", nil
+	}
+	run := cr.CheckRuns[0]
+	if run.GetStatus() != checkRunCompletedStatus {
+		return run.GetStatus(), nil
+	}
+	switch run.GetConclusion() {
+	case checkRunSuccessConclusion, checkRunNeutralConclusion, checkRunSkipConclusion:
+		return successState, nil
+	default:
+		return failureState, nil
+	}
+}
+
+// statusContexts returns the latest state of every status context of the ref.
+func (wv *waitForValidator) statusContexts(ctx context.Context) (map[string]string, error) {
+	contexts := make(map[string]string)
+	for page := 1; ; page++ {
+		cs, _, err := wv.client.GetCombinedStatus(ctx, wv.owner, wv.repo, wv.ref, &github.ListOptions{
+			Page:    page,
+			PerPage: maxItemsPerPage,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status: %w", err)
+		}
+		for _, s := range cs.Statuses {
+			contexts[s.GetContext()] = s.GetState()
+		}
+		if len(cs.Statuses) < maxItemsPerPage || cs.GetTotalCount() <= len(contexts) {
+			return contexts, nil
+		}
+	}
+}
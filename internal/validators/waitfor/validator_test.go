@@ -0,0 +1,132 @@
+package waitfor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aac228/merge-gatekeeper/internal/github"
+	"github.com/aac228/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+	}{
+		"returns validator": {
+			opts: []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithChecks("compliance-scan")},
+		},
+		"returns error without checks": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithGitHubRef("sha"), WithChecks(" , ")},
+			wantErr: true,
+		},
+		"returns error without ref": {
+			opts:    []Option{WithGitHubOwnerAndRepo("owner", "repo"), WithChecks("compliance-scan")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(&mock.Client{}, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitForValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		checks      string
+		runs        map[string]*github.CheckRun
+		statuses    []*github.RepoStatus
+		wantSuccess bool
+		wantErr     bool
+	}{
+		"succeeds when the check run succeeded": {
+			checks: "compliance-scan",
+			runs: map[string]*github.CheckRun{
+				"compliance-scan": {Status: stringPtr("completed"), Conclusion: stringPtr("success")},
+			},
+			wantSuccess: true,
+		},
+		"succeeds when the status context succeeded": {
+			checks:      "ci/compliance",
+			statuses:    []*github.RepoStatus{{Context: stringPtr("ci/compliance"), State: stringPtr("success")}},
+			wantSuccess: true,
+		},
+		"succeeds when every check succeeded": {
+			checks: "compliance-scan, ci/compliance",
+			runs: map[string]*github.CheckRun{
+				"compliance-scan": {Status: stringPtr("completed"), Conclusion: stringPtr("skipped")},
+			},
+			statuses:    []*github.RepoStatus{{Context: stringPtr("ci/compliance"), State: stringPtr("success")}},
+			wantSuccess: true,
+		},
+		"is pending until the check appears": {
+			checks:   "compliance-scan",
+			statuses: []*github.RepoStatus{{Context: stringPtr("ci/other"), State: stringPtr("success")}},
+		},
+		"is pending while the check run is in progress": {
+			checks: "compliance-scan",
+			runs: map[string]*github.CheckRun{
+				"compliance-scan": {Status: stringPtr("in_progress")},
+			},
+		},
+		"is pending while the status context is pending": {
+			checks:   "ci/compliance",
+			statuses: []*github.RepoStatus{{Context: stringPtr("ci/compliance"), State: stringPtr("pending")}},
+		},
+		"returns error when the check run failed": {
+			checks: "compliance-scan",
+			runs: map[string]*github.CheckRun{
+				"compliance-scan": {Status: stringPtr("completed"), Conclusion: stringPtr("timed_out")},
+			},
+			wantErr: true,
+		},
+		"returns error when the status context failed": {
+			checks:   "ci/compliance",
+			statuses: []*github.RepoStatus{{Context: stringPtr("ci/compliance"), State: stringPtr("error")}},
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					res := &github.ListCheckRunsResults{}
+					if run, ok := tt.runs[opts.GetCheckName()]; ok {
+						res.CheckRuns = []*github.CheckRun{run}
+					}
+					return res, nil, nil
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("owner", "repo"),
+				WithGitHubRef("sha"),
+				WithChecks(tt.checks),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v: %s", st.IsSuccess(), tt.wantSuccess, st.Detail())
+			}
+		})
+	}
+}